@@ -114,9 +114,13 @@ func main() {
 		log.Error("Failed to create PublishedRepository: " + err.Error())
 		panic("Failed to create PublishedRepository: " + err.Error())
 	}
-	minioStorageCreds := systemInfoService.GetMinioStorageCreds()
-	minioStorageService := service.NewMinioStorageService(buildResultRepository, publishedRepository, minioStorageCreds)
-	dbMigrationService, err := mService.NewDBMigrationService(cp, migrationRunRepository, buildCleanupRepository, transitionRepository, systemInfoService, minioStorageService)
+	blobRepository := repository.NewBlobRepository(cp)
+	blobStorage, err := service.NewBlobStorage(systemInfoService, blobRepository)
+	if err != nil {
+		log.Error("Failed to create blob storage: " + err.Error())
+		panic("Failed to create blob storage: " + err.Error())
+	}
+	dbMigrationService, err := mService.NewDBMigrationService(cp, migrationRunRepository, buildCleanupRepository, transitionRepository, systemInfoService, blobStorage)
 	if err != nil {
 		log.Error("Failed create dbMigrationService: " + err.Error())
 		panic("Failed create dbMigrationService: " + err.Error())
@@ -219,6 +223,7 @@ func main() {
 	roleRepository := repository.NewRoleRepository(cp)
 	operationRepository := repository.NewOperationRepository(cp)
 	agentRepository := repository.NewAgentRepository(cp)
+	builderRepository := repository.NewBuilderRepository(cp)
 	businessMetricRepository := repository.NewBusinessMetricRepository(cp)
 
 	activityTrackingRepository := repository.NewActivityTrackingRepository(cp)
@@ -228,6 +233,15 @@ func main() {
 	personalAccessTokenRepository := repository.NewPersonalAccessTokenRepository(cp)
 
 	packageExportConfigRepository := repository.NewPackageExportConfigRepository(cp)
+	publishGateRepository := repository.NewPublishGateRepository(cp)
+	versionLifecycleRepository := repository.NewVersionLifecycleRepository(cp)
+	versionPromotionRepository := repository.NewVersionPromotionRepository(cp)
+	versionRetentionRepository := repository.NewVersionRetentionRepository(cp)
+	recycleBinRepository := repository.NewRecycleBinRepository(cp)
+	versionLockRepository := repository.NewVersionLockRepository(cp)
+	bulkVersionOperationRepository := repository.NewBulkVersionOperationRepository(cp)
+	referenceGraphRepository := repository.NewReferenceGraphRepository(cp)
+	savedSearchRepository := repository.NewSavedSearchRepository(cp)
 
 	exportRepository := repository.NewExportRepository(cp)
 
@@ -265,20 +279,24 @@ func main() {
 	packageVersionEnrichmentService := service.NewPackageVersionEnrichmentService(publishedRepository)
 	activityTrackingService := service.NewActivityTrackingService(activityTrackingRepository, publishedRepository, userService)
 	operationService := service.NewOperationService(operationRepository, publishedRepository, packageVersionEnrichmentService)
-	roleService := service.NewRoleService(roleRepository, userService, activityTrackingService, publishedRepository)
+	versionLifecycleService := service.NewVersionLifecycleService(versionLifecycleRepository, publishedRepository)
+	roleService := service.NewRoleService(roleRepository, userService, activityTrackingService, publishedRepository, versionLifecycleService)
+	versionLockService := service.NewVersionLockService(versionLockRepository, publishedRepository, activityTrackingService, roleService.IsSysadm)
 	wsBranchService := service.NewWsBranchService(userService, wsLoadBalancer)
 	branchEditorsService := service.NewBranchEditorsService(userService, wsBranchService, branchRepository, olricProvider)
 	branchService := service.NewBranchService(projectService, draftRepository, gitClientProvider, publishedRepository, wsBranchService, branchEditorsService, branchRepository)
 	projectFilesService := service.NewProjectFilesService(gitClientProvider, projectRepository, branchService)
 	ptHandler := service.NewPackageTransitionHandler(transitionRepository)
-	publishedService := service.NewPublishedService(branchService, publishedRepository, projectRepository, buildRepository, gitClientProvider, wsBranchService, favoritesRepository, operationRepository, activityTrackingService, monitoringService, minioStorageService, systemInfoService)
+	buildQueueNotifier := service.NewBuildQueueNotifier(olricProvider)
+	buildStatusNotifier := service.NewBuildStatusNotifier(olricProvider)
+	publishedService := service.NewPublishedService(branchService, publishedRepository, projectRepository, buildRepository, gitClientProvider, wsBranchService, favoritesRepository, operationRepository, activityTrackingService, monitoringService, blobStorage, systemInfoService, buildQueueNotifier, versionLifecycleService)
 	contentService := service.NewContentService(draftRepository, projectService, branchService, gitClientProvider, wsBranchService, templateService, systemInfoService)
 	refService := service.NewRefService(draftRepository, projectService, branchService, publishedRepository, wsBranchService)
 	wsFileEditService := service.NewWsFileEditService(userService, contentService, branchEditorsService, wsLoadBalancer)
 	portalService := service.NewPortalService(basePath, publishedService, publishedRepository, projectRepository)
 
-	operationGroupService := service.NewOperationGroupService(operationRepository, publishedRepository, exportRepository, packageVersionEnrichmentService, activityTrackingService)
-	versionService := service.NewVersionService(gitClientProvider, projectRepository, favoritesRepository, publishedRepository, publishedService, operationRepository, exportRepository, operationService, activityTrackingService, systemInfoService, packageVersionEnrichmentService, portalService, versionCleanupRepository, operationGroupService)
+	operationGroupService := service.NewOperationGroupService(operationRepository, publishedRepository, exportRepository, packageVersionEnrichmentService, activityTrackingService, versionLockService)
+	versionService := service.NewVersionService(gitClientProvider, projectRepository, favoritesRepository, publishedRepository, publishedService, operationRepository, exportRepository, operationService, activityTrackingService, systemInfoService, packageVersionEnrichmentService, portalService, versionCleanupRepository, operationGroupService, versionLifecycleService, versionLockService)
 	packageService := service.NewPackageService(gitClientProvider, projectRepository, favoritesRepository, publishedRepository, versionService, roleService, activityTrackingService, operationGroupService, usersRepository, ptHandler, systemInfoService)
 
 	logsService := service.NewLogsService()
@@ -288,14 +306,21 @@ func main() {
 	apihubApiKeyService := service.NewApihubApiKeyService(apihubApiKeyRepository, publishedRepository, activityTrackingService, userService, roleRepository, roleService.IsSysadm, systemInfoService)
 
 	refResolverService := service.NewRefResolverService(publishedRepository)
-	buildProcessorService := service.NewBuildProcessorService(buildRepository, refResolverService)
-	buildService := service.NewBuildService(buildRepository, buildProcessorService, publishedService, systemInfoService, packageService, refResolverService)
+	buildProcessorService := service.NewBuildProcessorService(buildRepository, refResolverService, buildQueueNotifier, buildStatusNotifier, systemInfoService)
+	builderRegistryService := service.NewBuilderRegistryService(builderRepository, buildRepository, systemInfoService, buildQueueNotifier, buildStatusNotifier)
+	versionInferenceService := service.NewVersionInferenceService(publishedRepository, buildRepository)
+	buildService := service.NewBuildService(buildRepository, buildProcessorService, publishedService, systemInfoService, packageService, refResolverService, buildQueueNotifier, buildStatusNotifier, versionInferenceService, versionLockService, roleService, builderRegistryService)
 
 	packageExportConfigService := service.NewPackageExportConfigService(packageExportConfigRepository, packageService)
+	publishGateService := service.NewPublishGateService(publishGateRepository, packageService, buildService)
+	versionPromotionService := service.NewVersionPromotionService(versionPromotionRepository, roleRepository, publishedRepository, roleService, versionService, packageService, activityTrackingService)
+	bulkVersionOperationService := service.NewBulkVersionOperationService(bulkVersionOperationRepository, publishedRepository, roleService, versionService, versionPromotionService)
+	referenceGraphService := service.NewReferenceGraphService(referenceGraphRepository, publishedRepository, roleService, packageVersionEnrichmentService)
+	savedSearchService := service.NewSavedSearchService(savedSearchRepository, operationRepository, roleService)
 
-	exportService := service.NewExportService(exportRepository, buildService, packageExportConfigService)
+	exportService := service.NewExportService(exportRepository, buildService, packageExportConfigService, blobStorage)
 
-	buildResultService := service.NewBuildResultService(buildResultRepository, buildRepository, publishedRepository, systemInfoService, minioStorageService, publishedService, exportService)
+	buildResultService := service.NewBuildResultService(buildResultRepository, buildRepository, publishedRepository, systemInfoService, blobStorage, publishedService, exportService, buildQueueNotifier, buildStatusNotifier, publishGateService, versionLifecycleService, versionPromotionService, versionInferenceService, versionLockService, savedSearchService)
 	versionService.SetBuildService(buildService)
 	operationGroupService.SetBuildService(buildService)
 
@@ -304,10 +329,19 @@ func main() {
 	comparisonService := service.NewComparisonService(publishedRepository, operationRepository, packageVersionEnrichmentService)
 	businessMetricService := service.NewBusinessMetricService(businessMetricRepository)
 
-	dbCleanupService := service.NewDBCleanupService(buildCleanupRepository, migrationRunRepository, minioStorageService, systemInfoService)
+	dbCleanupService := service.NewDBCleanupService(buildCleanupRepository, migrationRunRepository, blobStorage, systemInfoService)
+	blobStorageMigrationService := service.NewBlobStorageMigrationService(systemInfoService, blobRepository)
 	if err := dbCleanupService.CreateCleanupJob(systemInfoService.GetBuildsCleanupSchedule()); err != nil {
 		log.Error("Failed to start cleaning job" + err.Error())
 	}
+	versionRetentionService := service.NewVersionRetentionService(versionRetentionRepository, publishedRepository, migrationRunRepository, packageService, versionLifecycleService, versionLockService, versionService)
+	if err := versionRetentionService.CreateCleanupJob(systemInfoService.GetVersionRetentionCleanupSchedule()); err != nil {
+		log.Error("Failed to start version retention cleanup job" + err.Error())
+	}
+	recycleBinService := service.NewRecycleBinService(recycleBinRepository, publishedRepository, migrationRunRepository, activityTrackingService, systemInfoService, blobStorage)
+	if err := recycleBinService.CreatePurgeJob(systemInfoService.GetRecycleBinPurgeSchedule()); err != nil {
+		log.Error("Failed to start recycle bin purge job" + err.Error())
+	}
 
 	transitionService := service.NewTransitionService(transitionRepository, publishedRepository)
 	transformationService := service.NewTransformationService(publishedRepository, operationRepository)
@@ -342,7 +376,7 @@ func main() {
 	exportController := controller.NewExportController(publishedService, portalService, searchService, roleService, excelService, versionService, monitoringService, exportService, packageService)
 
 	packageController := controller.NewPackageController(packageService, publishedService, portalService, searchService, roleService, monitoringService, ptHandler)
-	versionController := controller.NewVersionController(versionService, roleService, monitoringService, ptHandler, roleService.IsSysadm)
+	versionController := controller.NewVersionController(versionService, roleService, versionPromotionService, monitoringService, ptHandler, agentService, agentClient, roleService.IsSysadm)
 	roleController := controller.NewRoleController(roleService)
 	samlAuthController := security.NewSamlAuthController(userService, systemInfoService)
 	userController := controller.NewUserController(userService, privateUserPackageService, roleService.IsSysadm)
@@ -353,16 +387,29 @@ func main() {
 	searchController := controller.NewSearchController(operationService, versionService, monitoringService)
 	tempMigrationController := mController.NewTempMigrationController(dbMigrationService, roleService.IsSysadm)
 	activityTrackingController := controller.NewActivityTrackingController(activityTrackingService, roleService, ptHandler)
-	comparisonController := controller.NewComparisonController(operationService, versionService, buildService, roleService, comparisonService, monitoringService, ptHandler)
+	revisionComparisonService := service.NewRevisionComparisonService(publishedRepository, comparisonService, operationService, buildService)
+	comparisonController := controller.NewComparisonController(operationService, versionService, buildService, roleService, comparisonService, revisionComparisonService, monitoringService, ptHandler)
 	buildCleanupController := controller.NewBuildCleanupController(dbCleanupService, roleService.IsSysadm)
+	deadLetterBuildController := controller.NewDeadLetterBuildController(buildService, systemInfoService, roleService.IsSysadm)
+	builderController := controller.NewBuilderController(builderRegistryService, roleService.IsSysadm)
 	transitionController := controller.NewTransitionController(transitionService, roleService.IsSysadm)
 	businessMetricController := controller.NewBusinessMetricController(businessMetricService, excelService, roleService.IsSysadm)
 	apiDocsController := controller.NewApiDocsController(basePath)
 	transformationController := controller.NewTransformationController(roleService, buildService, versionService, transformationService, operationGroupService)
-	minioStorageController := controller.NewMinioStorageController(minioStorageCreds, minioStorageService)
+	blobStorageController := controller.NewBlobStorageController(blobStorageMigrationService, roleService.IsSysadm)
 	gitHookController := controller.NewGitHookController(gitHookService)
 	personalAccessTokenController := controller.NewPersonalAccessTokenController(personalAccessTokenService)
 	packageExportConfigController := controller.NewPackageExportConfigController(roleService, packageExportConfigService, ptHandler)
+	publishGateController := controller.NewPublishGateController(roleService, publishGateService, ptHandler)
+	versionLifecycleController := controller.NewVersionLifecycleController(versionLifecycleService, roleService.IsSysadm)
+	versionPromotionController := controller.NewVersionPromotionController(roleService, versionPromotionService, ptHandler)
+	versionLockController := controller.NewVersionLockController(roleService, versionService, versionLockService, ptHandler, roleService.IsSysadm)
+	bulkVersionOperationController := controller.NewBulkVersionOperationController(roleService, bulkVersionOperationService, ptHandler)
+	referenceGraphController := controller.NewReferenceGraphController(roleService, referenceGraphService, ptHandler)
+	savedSearchController := controller.NewSavedSearchController(savedSearchService)
+	versionInferenceController := controller.NewVersionInferenceController(roleService, versionInferenceService, ptHandler)
+	versionRetentionController := controller.NewVersionRetentionController(roleService, versionRetentionService, ptHandler, roleService.IsSysadm)
+	recycleBinController := controller.NewRecycleBinController(roleService, recycleBinService, roleService.IsSysadm)
 
 	if !systemInfoService.GetEditorDisabled() {
 		r.HandleFunc("/api/v1/integrations/{integrationId}/apikey", security.Secure(integrationsController.GetUserApiKeyStatus)).Methods(http.MethodGet)
@@ -444,7 +491,15 @@ func main() {
 	r.HandleFunc("/api/v2/search/{searchLevel}", security.Secure(searchController.Search_deprecated)).Methods(http.MethodPost) //deprecated
 	r.HandleFunc("/api/v3/search/{searchLevel}", security.Secure(searchController.Search)).Methods(http.MethodPost)
 
+	r.HandleFunc("/api/v2/savedSearches", security.Secure(savedSearchController.CreateSavedSearch)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/savedSearches", security.Secure(savedSearchController.GetSavedSearches)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/savedSearches/{savedSearchId}", security.Secure(savedSearchController.UpdateSavedSearch)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/savedSearches/{savedSearchId}", security.Secure(savedSearchController.DeleteSavedSearch)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/savedSearchNotifications", security.Secure(savedSearchController.GetNotifications)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/savedSearchNotifications/{notificationId}/read", security.Secure(savedSearchController.MarkNotificationRead)).Methods(http.MethodPost)
+
 	r.HandleFunc("/api/v2/builders/{builderId}/tasks", security.Secure(publishV2Controller.GetFreeBuild)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/builders/{builderId}/heartbeat", security.Secure(builderController.ProcessBuilderHeartbeat)).Methods(http.MethodPost)
 
 	r.HandleFunc("/api/v2/packages", security.Secure(packageController.CreatePackage)).Methods(http.MethodPost)
 	r.HandleFunc("/api/v2/packages/{packageId}", security.Secure(packageController.UpdatePackage)).Methods(http.MethodPatch)
@@ -476,14 +531,22 @@ func main() {
 	r.HandleFunc("/api/v2/users/{userId}/availablePackagePromoteStatuses", security.Secure(roleController.GetAvailableUserPackagePromoteStatuses)).Methods(http.MethodPost)
 
 	r.HandleFunc("/api/v2/packages/{packageId}/publish/{publishId}/status", security.Secure(publishV2Controller.GetPublishStatus)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/publish/{publishId}/dryRunResult", security.Secure(publishV2Controller.GetDryRunResult)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v2/packages/{packageId}/publish/statuses", security.Secure(publishV2Controller.GetPublishStatuses)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/packages/{packageId}/publish/statuses/stream", security.Secure(publishV2Controller.StreamPublishStatuses)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/publish/cancel", security.Secure(publishV2Controller.CancelPublishes)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/packages/{packageId}/publish/{publishId}/cancel", security.Secure(publishV2Controller.CancelPublish)).Methods(http.MethodPost)
 	r.HandleFunc("/api/v2/packages/{packageId}/publish", security.Secure(publishV2Controller.Publish)).Methods(http.MethodPost)
 	r.HandleFunc("/api/v2/packages/{packageId}/publish/{publishId}/status", security.Secure(publishV2Controller.SetPublishStatus_deprecated)).Methods(http.MethodPost)
 	r.HandleFunc("/api/v3/packages/{packageId}/publish/{publishId}/status", security.Secure(publishV2Controller.SetPublishStatus)).Methods(http.MethodPost)
 	r.HandleFunc("/api/v1/packages/{packageId}/publish/withOperationsGroup", security.Secure(versionController.PublishFromCSV)).Methods(http.MethodPost)
 	r.HandleFunc("/api/v1/packages/{packageId}/publish/{publishId}/withOperationsGroup/status", security.Secure(versionController.GetCSVDashboardPublishStatus)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/packages/{packageId}/publish/{publishId}/withOperationsGroup/report", security.Secure(versionController.GetCSVDashboardPublishReport)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/publish/fromNamespace", security.Secure(versionController.PublishFromNamespace)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v1/packages/{packageId}/publish/{publishId}/fromNamespace/status", security.Secure(versionController.GetCSVDashboardPublishStatus)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v1/packages/{packageId}/publish/{publishId}/fromNamespace/report", security.Secure(versionController.GetCSVDashboardPublishReport)).Methods(http.MethodGet)
 
+	r.HandleFunc("/api/v2/packages/{packageId}/versions/nextVersion", security.Secure(versionInferenceController.GetNextVersion)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}", security.Secure(versionController.GetPackageVersionContent_deprecated)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v3/packages/{packageId}/versions/{version}", security.Secure(versionController.GetPackageVersionContent)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v2/packages/{packageId}/versions", security.Secure(versionController.GetPackageVersionsList_deprecated)).Methods(http.MethodGet)
@@ -540,6 +603,8 @@ func main() {
 	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/sources", security.Secure(publishedController.GetVersionSources)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/revisions", security.Secure(versionController.GetVersionRevisionsList_deprecated)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v3/packages/{packageId}/versions/{version}/revisions", security.Secure(versionController.GetVersionRevisionsList)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/revisions/{revision}/compare", security.Secure(comparisonController.CompareRevisions)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/revisions/{revision}/{apiType}/changes", security.Secure(comparisonController.GetRevisionChanges)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/sourceData", security.Secure(publishedController.GetPublishedVersionSourceDataConfig)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/config", security.Secure(publishedController.GetPublishedVersionBuildConfig)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/copy", security.Secure(versionController.CopyVersion)).Methods(http.MethodPost)
@@ -595,12 +660,26 @@ func main() {
 	r.HandleFunc("/api/internal/migrate/operations/cancel", security.Secure(tempMigrationController.CancelRunningMigrations)).Methods(http.MethodPost)
 	r.HandleFunc("/api/internal/migrate/operations/cleanup", security.Secure(buildCleanupController.StartMigrationBuildCleanup)).Methods(http.MethodPost)
 	r.HandleFunc("/api/internal/migrate/operations/cleanup/{id}", security.Secure(buildCleanupController.GetMigrationBuildCleanupResult)).Methods(http.MethodGet)
+	r.HandleFunc("/api/internal/migrate/blobs", security.Secure(blobStorageController.StartMigration)).Methods(http.MethodPost)
+	r.HandleFunc("/api/internal/migrate/blobs/{migrationId}", security.Secure(blobStorageController.GetMigration)).Methods(http.MethodGet)
 
 	r.HandleFunc("/api/v2/admin/transition/move", security.Secure(transitionController.MoveOrRenamePackage)).Methods(http.MethodPost)
 	r.HandleFunc("/api/v2/admin/transition/move/{id}", security.Secure(transitionController.GetMoveStatus)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v2/admin/transition/activity", security.Secure(transitionController.ListActivities)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v2/admin/transition", security.Secure(transitionController.ListPackageTransitions)).Methods(http.MethodGet)
 
+	r.HandleFunc("/api/v2/admin/builds/retryPolicies", security.Secure(deadLetterBuildController.GetBuildRetryPolicies)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/builds/deadLetter", security.Secure(deadLetterBuildController.ListDeadLetterBuilds)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/builds/deadLetter/{buildId}", security.Secure(deadLetterBuildController.GetDeadLetterBuild)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/builds/deadLetter/{buildId}/requeue", security.Secure(deadLetterBuildController.RequeueDeadLetterBuild)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/builders", security.Secure(builderController.ListBuilders)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/builders/{builderId}", security.Secure(builderController.GetBuilder)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/versionLifecycle", security.Secure(versionLifecycleController.UpdateVersionLifecycle)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/versionLifecycle", security.Secure(versionLifecycleController.GetVersionLifecycle)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/versionRetention/runs", security.Secure(versionRetentionController.StartCleanup)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/admin/versionRetention/runs", security.Secure(versionRetentionController.ListRuns)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/admin/versionRetention/runs/{runId}", security.Secure(versionRetentionController.GetRun)).Methods(http.MethodGet)
+
 	r.HandleFunc("/api/v2/compare", security.Secure(comparisonController.CompareTwoVersions)).Methods(http.MethodPost)
 
 	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/changes/export", security.Secure(exportController.GenerateApiChangesExcelReport)).Methods(http.MethodGet)
@@ -630,6 +709,54 @@ func main() {
 	r.HandleFunc("/api/v1/packages/{packageId}/exportConfig", security.Secure(packageExportConfigController.GetConfig)).Methods(http.MethodGet)
 	r.HandleFunc("/api/v1/packages/{packageId}/exportConfig", security.Secure(packageExportConfigController.SetConfig)).Methods(http.MethodPatch)
 
+	r.HandleFunc("/api/v2/packages/{packageId}/publishGatePolicy", security.Secure(publishGateController.GetPolicy)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/publishGatePolicy", security.Secure(publishGateController.SetPolicy)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/packages/{packageId}/publishGatePolicy", security.Secure(publishGateController.DeletePolicy)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/packages/{packageId}/publishGateRequests", security.Secure(publishGateController.ListRequests)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/publishGateRequests/{requestId}/approve", security.Secure(publishGateController.ApproveRequest)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/packages/{packageId}/publishGateRequests/{requestId}/reject", security.Secure(publishGateController.RejectRequest)).Methods(http.MethodPost)
+
+	r.HandleFunc("/api/v2/packages/{packageId}/versionPromotionPolicy", security.Secure(versionPromotionController.GetPolicy)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionPromotionPolicy", security.Secure(versionPromotionController.SetPolicy)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionPromotionPolicy", security.Secure(versionPromotionController.DeletePolicy)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionPromotionRequests", security.Secure(versionPromotionController.ListRequests)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionPromotionRequests/{requestId}", security.Secure(versionPromotionController.GetRequest)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionPromotionRequests/{requestId}", security.Secure(versionPromotionController.CancelRequest)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionPromotionRequests/{requestId}/approve", security.Secure(versionPromotionController.ApproveRequest)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionPromotionRequests/{requestId}/reject", security.Secure(versionPromotionController.RejectRequest)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/versionPromotionRequests/awaitingApproval", security.Secure(versionPromotionController.ListRequestsAwaitingApproval)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/versionPromotionNotifications", security.Secure(versionPromotionController.GetNotifications)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/versionPromotionNotifications/{notificationId}/read", security.Secure(versionPromotionController.MarkNotificationRead)).Methods(http.MethodPost)
+
+	r.HandleFunc("/api/v2/packages/{packageId}/versionLockPolicy", security.Secure(versionLockController.GetPolicy)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionLockPolicy", security.Secure(versionLockController.SetPolicy)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionLockPolicy", security.Secure(versionLockController.DeletePolicy)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/lock", security.Secure(versionLockController.GetLock)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/lock", security.Secure(versionLockController.LockVersion)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/lock", security.Secure(versionLockController.UnlockVersion)).Methods(http.MethodDelete)
+
+	r.HandleFunc("/api/v2/packages/{packageId}/bulkVersionOperations", security.Secure(bulkVersionOperationController.StartOperation)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/packages/{packageId}/bulkVersionOperations/{operationId}/status", security.Secure(bulkVersionOperationController.GetOperationStatus)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/bulkVersionOperations/{operationId}/report", security.Secure(bulkVersionOperationController.GetOperationReport)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/dependents", security.Secure(referenceGraphController.GetDependents)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/referenceGraph", security.Secure(referenceGraphController.GetReferenceGraph)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/versions/{version}/impact", security.Secure(referenceGraphController.GetImpact)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v2/packages/{packageId}/versionRetentionPolicy", security.Secure(versionRetentionController.GetPolicy)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionRetentionPolicy", security.Secure(versionRetentionController.SetPolicy)).Methods(http.MethodPut)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionRetentionPolicy", security.Secure(versionRetentionController.DeletePolicy)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionRetentionPolicy/preview", security.Secure(versionRetentionController.PreviewCleanup)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/packages/{packageId}/versionRetentionPolicy/report", security.Secure(versionRetentionController.GetReport)).Methods(http.MethodGet)
+
+	r.HandleFunc("/api/v2/recycleBin", security.Secure(recycleBinController.GetItems)).Methods(http.MethodGet)
+	r.HandleFunc("/api/v2/recycleBin/packages/{packageId}/restore", security.Secure(recycleBinController.RestorePackage)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/recycleBin/packages/{packageId}", security.Secure(recycleBinController.PurgePackage)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/recycleBin/packages/{packageId}/versions/{version}/restore", security.Secure(recycleBinController.RestoreVersion)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/recycleBin/packages/{packageId}/versions/{version}", security.Secure(recycleBinController.PurgeVersion)).Methods(http.MethodDelete)
+	r.HandleFunc("/api/v2/recycleBin/packages/{packageId}/versions/{version}/revisions/{revision}/restore", security.Secure(recycleBinController.RestoreVersion)).Methods(http.MethodPost)
+	r.HandleFunc("/api/v2/recycleBin/packages/{packageId}/versions/{version}/revisions/{revision}", security.Secure(recycleBinController.PurgeVersion)).Methods(http.MethodDelete)
+
 	r.HandleFunc("/api/v1/export", security.Secure(exportController.StartAsyncExport)).Methods(http.MethodPost)
 	r.HandleFunc("/api/v1/export/{exportId}/status", security.Secure(exportController.GetAsyncExportStatus)).Methods(http.MethodGet)
 
@@ -650,8 +777,6 @@ func main() {
 		r.HandleFunc("/api/internal/clear/{testId}", security.Secure(cleanupController.ClearTestData)).Methods(http.MethodDelete)
 
 		r.PathPrefix("/debug/").Handler(http.DefaultServeMux)
-
-		r.HandleFunc("/api/internal/minio/download", security.Secure(minioStorageController.DownloadFilesFromMinioToDatabase)).Methods(http.MethodPost)
 	}
 	debug.SetGCPercent(30)
 
@@ -731,19 +856,16 @@ func main() {
 		})
 	}
 
-	if systemInfoService.IsMinioStorageActive() {
-		utils.SafeAsync(func() {
-			err := minioStorageService.UploadFilesToBucket()
-			if err != nil {
-				log.Errorf("MINIO error - %s", err.Error())
-			}
-		})
-	}
+	blobStorageMigrationService.MigrateFromDatabase()
 
 	utils.SafeAsync(func() {
 		exportService.StartCleanupOldResultsJob()
 	})
 
+	utils.SafeAsync(func() {
+		builderRegistryService.StartStaleBuildersReclaimJob()
+	})
+
 	log.Fatalf("Http server returned error: %v", srv.ListenAndServe())
 }
 
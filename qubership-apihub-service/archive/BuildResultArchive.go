@@ -57,6 +57,9 @@ type BuildResultArchive struct {
 	PackageOperations    view.PackageOperationsFile
 	PackageComparisons   view.PackageComparisonsFile
 	BuilderNotifications view.BuilderNotificationsFile
+
+	builtVersion    string
+	replacedVersion string
 }
 
 func NewBuildResultArchive(zipReader *zip.Reader) *BuildResultArchive {
@@ -72,7 +75,11 @@ func NewBuildResultArchive(zipReader *zip.Reader) *BuildResultArchive {
 }
 
 func (a *BuildResultArchive) ReadPackageInfo() error {
-	return a.readFile(InfoFilePath, a.InfoFile, &a.PackageInfo, true)
+	if err := a.readFile(InfoFilePath, a.InfoFile, &a.PackageInfo, true); err != nil {
+		return err
+	}
+	a.applyReplacedVersion()
+	return nil
 }
 
 func (a *BuildResultArchive) ReadPackageDocuments(required bool) error {
@@ -84,7 +91,35 @@ func (a *BuildResultArchive) ReadPackageOperations(required bool) error {
 }
 
 func (a *BuildResultArchive) ReadPackageComparisons(required bool) error {
-	return a.readFile(ComparisonsFilePath, a.ComparisonsFile, &a.PackageComparisons, required)
+	if err := a.readFile(ComparisonsFilePath, a.ComparisonsFile, &a.PackageComparisons, required); err != nil {
+		return err
+	}
+	a.applyReplacedVersion()
+	return nil
+}
+
+// ReplaceVersion renames the built version (e.g. if the version name was inferred after the build).
+// The new name is applied to already read files and to the files which are read later.
+func (a *BuildResultArchive) ReplaceVersion(version string) {
+	if a.builtVersion == "" {
+		a.builtVersion = a.PackageInfo.Version
+	}
+	a.replacedVersion = version
+	a.applyReplacedVersion()
+}
+
+func (a *BuildResultArchive) applyReplacedVersion() {
+	if a.replacedVersion == "" {
+		return
+	}
+	if a.PackageInfo.Version == a.builtVersion {
+		a.PackageInfo.Version = a.replacedVersion
+	}
+	for i, comparison := range a.PackageComparisons.Comparisons {
+		if comparison.PackageId == a.PackageInfo.PackageId && comparison.Version == a.builtVersion {
+			a.PackageComparisons.Comparisons[i].Version = a.replacedVersion
+		}
+	}
 }
 
 func (a *BuildResultArchive) ReadBuilderNotifications(required bool) error {
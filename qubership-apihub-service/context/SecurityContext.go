@@ -77,6 +77,14 @@ func CreateFromId(userId string) SecurityContext {
 	}
 }
 
+// CreateFromIdAndSystemRole creates context of the user for background processing on behalf of the user
+func CreateFromIdAndSystemRole(userId string, systemRole string) SecurityContext {
+	return &securityContextImpl{
+		userId:     userId,
+		systemRole: systemRole,
+	}
+}
+
 type securityContextImpl struct {
 	userId          string
 	systemRole      string
@@ -168,42 +168,9 @@ func (a agentControllerImpl) GetAgent(w http.ResponseWriter, r *http.Request) {
 func (a agentControllerImpl) GetAgentNamespaces(w http.ResponseWriter, r *http.Request) {
 	agentId := getStringParam(r, "agentId")
 
-	agent, err := a.agentRegistrationService.GetAgent(agentId)
+	agent, err := a.agentRegistrationService.GetActiveAgent(agentId)
 	if err != nil {
-		RespondWithError(w, "Failed to get agent namespaces", err)
-		return
-	}
-	if agent == nil {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusNotFound,
-			Code:    exception.AgentNotFound,
-			Message: exception.AgentNotFoundMsg,
-			Params:  map[string]interface{}{"agentId": agentId},
-		})
-		return
-	}
-	if agent.Status != view.AgentStatusActive {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusFailedDependency,
-			Code:    exception.InactiveAgent,
-			Message: exception.InactiveAgentMsg,
-			Params:  map[string]interface{}{"agentId": agentId}})
-		return
-	}
-	if agent.AgentVersion == "" {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusFailedDependency,
-			Code:    exception.IncompatibleAgentVersion,
-			Message: exception.IncompatibleAgentVersionMsg,
-			Params:  map[string]interface{}{"version": agent.AgentVersion},
-		})
-		return
-	}
-	if agent.CompatibilityError != nil && agent.CompatibilityError.Severity == view.SeverityError {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusFailedDependency,
-			Message: agent.CompatibilityError.Message,
-		})
+		RespondWithError(w, "Failed to get agent", err)
 		return
 	}
 	agentNamespaces, err := a.agentClient.GetNamespaces(context.Create(r), agent.AgentUrl)
@@ -218,42 +185,9 @@ func (a agentControllerImpl) ListServiceNames(w http.ResponseWriter, r *http.Req
 	agentId := getStringParam(r, "agentId")
 	namespace := getStringParam(r, "namespace")
 
-	agent, err := a.agentRegistrationService.GetAgent(agentId)
+	agent, err := a.agentRegistrationService.GetActiveAgent(agentId)
 	if err != nil {
-		RespondWithError(w, "Failed to get agent namespaces", err)
-		return
-	}
-	if agent == nil {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusNotFound,
-			Code:    exception.AgentNotFound,
-			Message: exception.AgentNotFoundMsg,
-			Params:  map[string]interface{}{"agentId": agentId},
-		})
-		return
-	}
-	if agent.Status != view.AgentStatusActive {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusFailedDependency,
-			Code:    exception.InactiveAgent,
-			Message: exception.InactiveAgentMsg,
-			Params:  map[string]interface{}{"agentId": agentId}})
-		return
-	}
-	if agent.AgentVersion == "" {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusFailedDependency,
-			Code:    exception.IncompatibleAgentVersion,
-			Message: exception.IncompatibleAgentVersionMsg,
-			Params:  map[string]interface{}{"version": agent.AgentVersion},
-		})
-		return
-	}
-	if agent.CompatibilityError != nil && agent.CompatibilityError.Severity == view.SeverityError {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusFailedDependency,
-			Message: agent.CompatibilityError.Message,
-		})
+		RespondWithError(w, "Failed to get agent", err)
 		return
 	}
 
@@ -0,0 +1,113 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type BlobStorageController interface {
+	StartMigration(w http.ResponseWriter, r *http.Request)
+	GetMigration(w http.ResponseWriter, r *http.Request)
+}
+
+func NewBlobStorageController(migrationService service.BlobStorageMigrationService, isSysadmFunc func(context.SecurityContext) bool) BlobStorageController {
+	return &blobStorageControllerImpl{
+		migrationService: migrationService,
+		isSysadmFunc:     isSysadmFunc,
+	}
+}
+
+type blobStorageControllerImpl struct {
+	migrationService service.BlobStorageMigrationService
+	isSysadmFunc     func(context.SecurityContext) bool
+}
+
+func (b blobStorageControllerImpl) StartMigration(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !b.isSysadmFunc(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.BlobStorageMigrationReq
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		if customError, ok := validationErr.(*exception.CustomError); ok {
+			RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	id, err := b.migrationService.StartMigration(req)
+	if err != nil {
+		RespondWithError(w, "Failed to start blob storage migration", err)
+		return
+	}
+	result := map[string]interface{}{}
+	result["id"] = id
+	RespondWithJson(w, http.StatusAccepted, result)
+}
+
+func (b blobStorageControllerImpl) GetMigration(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !b.isSysadmFunc(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	migration, err := b.migrationService.GetMigration(getStringParam(r, "migrationId"))
+	if err != nil {
+		RespondWithError(w, "Failed to get blob storage migration", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, migration)
+}
@@ -0,0 +1,151 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type BuilderController interface {
+	ProcessBuilderHeartbeat(w http.ResponseWriter, r *http.Request)
+	ListBuilders(w http.ResponseWriter, r *http.Request)
+	GetBuilder(w http.ResponseWriter, r *http.Request)
+}
+
+func NewBuilderController(builderRegistryService service.BuilderRegistryService, isSysadm func(context.SecurityContext) bool) BuilderController {
+	return &builderControllerImpl{
+		builderRegistryService: builderRegistryService,
+		isSysadm:               isSysadm,
+	}
+}
+
+type builderControllerImpl struct {
+	builderRegistryService service.BuilderRegistryService
+	isSysadm               func(context.SecurityContext) bool
+}
+
+func (b builderControllerImpl) ProcessBuilderHeartbeat(w http.ResponseWriter, r *http.Request) {
+	builderId := getStringParam(r, "builderId")
+	// the same check as for taking builds, heartbeats affect reclamation of running builds
+	ctx := context.Create(r)
+	if !b.isSysadm(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var heartbeat view.BuilderHeartbeat
+	if len(body) > 0 {
+		err = json.Unmarshal(body, &heartbeat)
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.BadRequestBody,
+				Message: exception.BadRequestBodyMsg,
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	err = b.builderRegistryService.ProcessBuilderHeartbeat(builderId, heartbeat)
+	if err != nil {
+		RespondWithError(w, fmt.Sprintf("Failed to process heartbeat of builder %s", builderId), err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b builderControllerImpl) ListBuilders(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !b.isSysadm(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	onlyActive := false
+	if r.URL.Query().Get("onlyActive") != "" {
+		var err error
+		onlyActive, err = strconv.ParseBool(r.URL.Query().Get("onlyActive"))
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "onlyActive", "type": "boolean"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	builders, err := b.builderRegistryService.ListBuilders(onlyActive)
+	if err != nil {
+		RespondWithError(w, "Failed to list builders", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, view.Builders{Builders: builders})
+}
+
+func (b builderControllerImpl) GetBuilder(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !b.isSysadm(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	builderId := getStringParam(r, "builderId")
+	builder, err := b.builderRegistryService.GetBuilder(builderId)
+	if err != nil {
+		RespondWithError(w, "Failed to get builder", err)
+		return
+	}
+	if builder == nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.BuilderNotFound,
+			Message: exception.BuilderNotFoundMsg,
+			Params:  map[string]interface{}{"builderId": builderId},
+		})
+		return
+	}
+	RespondWithJson(w, http.StatusOK, builder)
+}
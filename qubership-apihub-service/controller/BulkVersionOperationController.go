@@ -0,0 +1,157 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type BulkVersionOperationController interface {
+	StartOperation(w http.ResponseWriter, r *http.Request)
+	GetOperationStatus(w http.ResponseWriter, r *http.Request)
+	GetOperationReport(w http.ResponseWriter, r *http.Request)
+}
+
+func NewBulkVersionOperationController(roleService service.RoleService,
+	bulkVersionOperationService service.BulkVersionOperationService,
+	ptHandler service.PackageTransitionHandler) BulkVersionOperationController {
+	return bulkVersionOperationControllerImpl{
+		roleService:                 roleService,
+		bulkVersionOperationService: bulkVersionOperationService,
+		ptHandler:                   ptHandler,
+	}
+}
+
+type bulkVersionOperationControllerImpl struct {
+	roleService                 service.RoleService
+	bulkVersionOperationService service.BulkVersionOperationService
+	ptHandler                   service.PackageTransitionHandler
+}
+
+// StartOperation requires read permission, archive and delete actions additionally require version management permission on the package.
+// Permissions for the action are checked for each version separately.
+func (b bulkVersionOperationControllerImpl) StartOperation(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	if !b.checkReadPermission(w, r, ctx, packageId) {
+		return
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.BulkVersionOperationReq
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		if customError, ok := validationErr.(*exception.CustomError); ok {
+			RespondWithCustomError(w, customError)
+			return
+		}
+	}
+	if req.Action == view.BulkVersionActionArchive || req.Action == view.BulkVersionActionDelete {
+		sufficientPrivileges, err := b.roleService.HasRequiredPermissions(ctx, packageId, view.ManageDraftVersionPermission)
+		if err != nil {
+			RespondWithError(w, "Failed to check user privileges", err)
+			return
+		}
+		if !sufficientPrivileges {
+			respondWithInsufficientPrivileges(w)
+			return
+		}
+	}
+
+	operationId, err := b.bulkVersionOperationService.StartOperation(ctx, packageId, req)
+	if err != nil {
+		RespondWithError(w, "Failed to start bulk version operation", err)
+		return
+	}
+	RespondWithJson(w, http.StatusAccepted, view.BulkVersionOperationResp{OperationId: operationId})
+}
+
+func (b bulkVersionOperationControllerImpl) GetOperationStatus(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	operationId := getStringParam(r, "operationId")
+	ctx := context.Create(r)
+	if !b.checkReadPermission(w, r, ctx, packageId) {
+		return
+	}
+
+	result, err := b.bulkVersionOperationService.GetOperationStatus(packageId, operationId)
+	if err != nil {
+		RespondWithError(w, "Failed to get bulk version operation status", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (b bulkVersionOperationControllerImpl) GetOperationReport(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	operationId := getStringParam(r, "operationId")
+	ctx := context.Create(r)
+	if !b.checkReadPermission(w, r, ctx, packageId) {
+		return
+	}
+
+	report, err := b.bulkVersionOperationService.GetOperationReport(packageId, operationId)
+	if err != nil {
+		RespondWithError(w, "Failed to get bulk version operation report", err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=bulk_operation_report_%v.csv", time.Now().Format("2006-01-02 15-04-05")))
+	w.Header().Set("Expires", "0")
+	w.WriteHeader(http.StatusOK)
+	w.Write(report)
+}
+
+func (b bulkVersionOperationControllerImpl) checkReadPermission(w http.ResponseWriter, r *http.Request, ctx context.SecurityContext, packageId string) bool {
+	sufficientPrivileges, err := b.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, b.ptHandler, packageId, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		respondWithInsufficientPrivileges(w)
+		return false
+	}
+	return true
+}
@@ -32,6 +32,8 @@ import (
 type ComparisonController interface {
 	CompareTwoVersions(w http.ResponseWriter, r *http.Request)
 	GetComparisonChangesSummary(w http.ResponseWriter, r *http.Request)
+	CompareRevisions(w http.ResponseWriter, r *http.Request)
+	GetRevisionChanges(w http.ResponseWriter, r *http.Request)
 }
 
 func NewComparisonController(operationService service.OperationService,
@@ -39,27 +41,30 @@ func NewComparisonController(operationService service.OperationService,
 	buildService service.BuildService,
 	roleService service.RoleService,
 	comparisonService service.ComparisonService,
+	revisionComparisonService service.RevisionComparisonService,
 	monitoringService service.MonitoringService,
 	ptHandler service.PackageTransitionHandler) ComparisonController {
 	return &comparisonControllerImpl{
-		operationService:  operationService,
-		versionService:    versionService,
-		buildService:      buildService,
-		roleService:       roleService,
-		comparisonService: comparisonService,
-		monitoringService: monitoringService,
-		ptHandler:         ptHandler,
+		operationService:          operationService,
+		versionService:            versionService,
+		buildService:              buildService,
+		roleService:               roleService,
+		comparisonService:         comparisonService,
+		revisionComparisonService: revisionComparisonService,
+		monitoringService:         monitoringService,
+		ptHandler:                 ptHandler,
 	}
 }
 
 type comparisonControllerImpl struct {
-	operationService  service.OperationService
-	versionService    service.VersionService
-	buildService      service.BuildService
-	roleService       service.RoleService
-	comparisonService service.ComparisonService
-	monitoringService service.MonitoringService
-	ptHandler         service.PackageTransitionHandler
+	operationService          service.OperationService
+	versionService            service.VersionService
+	buildService              service.BuildService
+	roleService               service.RoleService
+	comparisonService         service.ComparisonService
+	revisionComparisonService service.RevisionComparisonService
+	monitoringService         service.MonitoringService
+	ptHandler                 service.PackageTransitionHandler
 }
 
 func (c comparisonControllerImpl) CompareTwoVersions(w http.ResponseWriter, r *http.Request) {
@@ -251,7 +256,7 @@ func (c comparisonControllerImpl) CompareTwoVersions(w http.ResponseWriter, r *h
 		return
 	}
 	switch buildView.Status {
-	case string(view.StatusError):
+	case string(view.StatusError), string(view.StatusCancelled):
 		calculationProcessStatus = view.CalculationProcessStatus{
 			Status:  string(view.StatusError),
 			Message: buildView.Details,
@@ -363,3 +368,174 @@ func (c comparisonControllerImpl) GetComparisonChangesSummary(w http.ResponseWri
 	}
 	RespondWithJson(w, http.StatusOK, comparisonSummary)
 }
+
+func (c comparisonControllerImpl) CompareRevisions(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := c.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, c.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+	revisionStr := getStringParam(r, "revision")
+	revision, err := strconv.Atoi(revisionStr)
+	if err != nil || revision <= 0 {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "revision", "value": revisionStr},
+		})
+		return
+	}
+	previousRevisionStr := r.URL.Query().Get("previousRevision")
+	if previousRevisionStr == "" {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RequiredParamsMissing,
+			Message: exception.RequiredParamsMissingMsg,
+			Params:  map[string]interface{}{"params": "previousRevision"},
+		})
+		return
+	}
+	previousRevision, err := strconv.Atoi(previousRevisionStr)
+	if err != nil || previousRevision <= 0 {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "previousRevision", "value": previousRevisionStr},
+		})
+		return
+	}
+	reCalculate := false
+	if r.URL.Query().Get("reCalculate") != "" {
+		reCalculate, err = strconv.ParseBool(r.URL.Query().Get("reCalculate"))
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "reCalculate", "type": "boolean"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+	calculationProcessStatus, err := c.revisionComparisonService.CompareRevisions(ctx, packageId, version, revision, previousRevision, reCalculate)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, c.ptHandler, packageId, "Failed to compare revisions", err)
+		return
+	}
+	if calculationProcessStatus != nil {
+		RespondWithJson(w, http.StatusAccepted, calculationProcessStatus)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c comparisonControllerImpl) GetRevisionChanges(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := c.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, c.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+	apiType, err := getUnescapedStringParam(r, "apiType")
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "apiType"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+	revisionStr := getStringParam(r, "revision")
+	revision, err := strconv.Atoi(revisionStr)
+	if err != nil || revision <= 0 {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "revision", "value": revisionStr},
+		})
+		return
+	}
+	previousRevisionStr := r.URL.Query().Get("previousRevision")
+	if previousRevisionStr == "" {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RequiredParamsMissing,
+			Message: exception.RequiredParamsMissingMsg,
+			Params:  map[string]interface{}{"params": "previousRevision"},
+		})
+		return
+	}
+	previousRevision, err := strconv.Atoi(previousRevisionStr)
+	if err != nil || previousRevision <= 0 {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "previousRevision", "value": previousRevisionStr},
+		})
+		return
+	}
+	searchReq, customError := getVersionChangesReq(r)
+	if customError != nil {
+		RespondWithCustomError(w, customError)
+		return
+	}
+
+	changes, calculationProcessStatus, err := c.revisionComparisonService.GetRevisionChanges(packageId, version, revision, previousRevision, apiType, *searchReq)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, c.ptHandler, packageId, "Failed to get revision changes", err)
+		return
+	}
+	if calculationProcessStatus != nil {
+		RespondWithJson(w, http.StatusAccepted, calculationProcessStatus)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, changes)
+}
@@ -0,0 +1,142 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type DeadLetterBuildController interface {
+	ListDeadLetterBuilds(w http.ResponseWriter, r *http.Request)
+	GetDeadLetterBuild(w http.ResponseWriter, r *http.Request)
+	RequeueDeadLetterBuild(w http.ResponseWriter, r *http.Request)
+	GetBuildRetryPolicies(w http.ResponseWriter, r *http.Request)
+}
+
+func NewDeadLetterBuildController(buildService service.BuildService, systemInfoService service.SystemInfoService, isSysadm func(context.SecurityContext) bool) DeadLetterBuildController {
+	return &deadLetterBuildControllerImpl{
+		buildService:      buildService,
+		systemInfoService: systemInfoService,
+		isSysadm:          isSysadm,
+	}
+}
+
+type deadLetterBuildControllerImpl struct {
+	buildService      service.BuildService
+	systemInfoService service.SystemInfoService
+	isSysadm          func(context.SecurityContext) bool
+}
+
+func (d deadLetterBuildControllerImpl) ListDeadLetterBuilds(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !d.isSysadm(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	limit, customErr := getLimitQueryParam(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := d.buildService.GetDeadLetterBuilds(view.DeadLetterBuildsListReq{
+		BuildType: view.BuildType(r.URL.Query().Get("buildType")),
+		PackageId: r.URL.Query().Get("packageId"),
+		Limit:     limit,
+		Page:      page,
+	})
+	if err != nil {
+		RespondWithError(w, "Failed to list dead letter builds", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (d deadLetterBuildControllerImpl) GetDeadLetterBuild(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !d.isSysadm(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := d.buildService.GetDeadLetterBuild(getStringParam(r, "buildId"))
+	if err != nil {
+		RespondWithError(w, "Failed to get dead letter build", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (d deadLetterBuildControllerImpl) RequeueDeadLetterBuild(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !d.isSysadm(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	err := d.buildService.RequeueDeadLetterBuild(ctx, getStringParam(r, "buildId"))
+	if err != nil {
+		RespondWithError(w, "Failed to requeue dead letter build", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (d deadLetterBuildControllerImpl) GetBuildRetryPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !d.isSysadm(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	RespondWithJson(w, http.StatusOK, d.systemInfoService.GetBuildRetryPolicies())
+}
@@ -1235,6 +1235,7 @@ func (e exportControllerImpl) GetAsyncExportStatus(w http.ResponseWriter, r *htt
 		RespondWithJson(w, http.StatusOK, status)
 		return
 	}
+	defer result.Content.Close()
 
 	if packageId != "" { // do permissions check for sensitive data like export content. Export status is considered as non-sensitive.
 		ctx := context.Create(r)
@@ -1256,5 +1257,8 @@ func (e exportControllerImpl) GetAsyncExportStatus(w http.ResponseWriter, r *htt
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%v", result.FileName))
 	w.WriteHeader(http.StatusOK)
-	w.Write(result.Data)
+	_, err = io.Copy(w, result.Content)
+	if err != nil {
+		log.Errorf("Failed to write export %s result: %s", exportId, err.Error())
+	}
 }
@@ -1,63 +0,0 @@
-// Copyright 2024-2025 NetCracker Technology Corporation
-//
-// Licensed under the Apache License, Version 2.0 (the "License");
-// you may not use this file except in compliance with the License.
-// You may obtain a copy of the License at
-//
-//     http://www.apache.org/licenses/LICENSE-2.0
-//
-// Unless required by applicable law or agreed to in writing, software
-// distributed under the License is distributed on an "AS IS" BASIS,
-// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-// See the License for the specific language governing permissions and
-// limitations under the License.
-
-package controller
-
-import (
-	"net/http"
-
-	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
-	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
-	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
-	log "github.com/sirupsen/logrus"
-)
-
-type MinioStorageController interface {
-	DownloadFilesFromMinioToDatabase(w http.ResponseWriter, r *http.Request)
-}
-
-func NewMinioStorageController(minioCreds *view.MinioStorageCreds, minioStorageService service.MinioStorageService) MinioStorageController {
-	return &minioStorageControllerImpl{
-		minioStorageService: minioStorageService,
-		minioCreds:          minioCreds,
-	}
-}
-
-type minioStorageControllerImpl struct {
-	minioStorageService service.MinioStorageService
-	minioCreds          *view.MinioStorageCreds
-}
-
-func (m minioStorageControllerImpl) DownloadFilesFromMinioToDatabase(w http.ResponseWriter, r *http.Request) {
-	if !m.minioCreds.IsActive {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusMethodNotAllowed,
-			Message: "Minio integration is inactive. Please check envs for configuration"})
-		return
-	}
-	err := m.minioStorageService.DownloadFilesFromBucketToDatabase()
-	if err != nil {
-		log.Error("Failed to download data from minio: ", err.Error())
-		if customError, ok := err.(*exception.CustomError); ok {
-			RespondWithCustomError(w, customError)
-		} else {
-			RespondWithCustomError(w, &exception.CustomError{
-				Status:  http.StatusInternalServerError,
-				Message: "Failed to download data from minio",
-				Debug:   err.Error()})
-		}
-		return
-	}
-	w.WriteHeader(http.StatusAccepted)
-}
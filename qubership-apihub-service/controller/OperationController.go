@@ -845,35 +845,48 @@ func (o operationControllerImpl) GetOperationsChanges(w http.ResponseWriter, r *
 		})
 		return
 	}
-	limit, customError := getLimitQueryParam(r)
+	versionChangesSearchReq, customError := getVersionChangesReq(r)
 	if customError != nil {
 		RespondWithCustomError(w, customError)
 		return
 	}
+
+	changelog, err := o.operationService.GetVersionChanges(packageId, versionName, apiType, *versionChangesSearchReq)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to get operations changelog", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, changelog)
+}
+
+func getVersionChangesReq(r *http.Request) (*view.VersionChangesReq, *exception.CustomError) {
+	limit, customError := getLimitQueryParam(r)
+	if customError != nil {
+		return nil, customError
+	}
+	var err error
 	page := 0
 	if r.URL.Query().Get("page") != "" {
 		page, err = strconv.Atoi(r.URL.Query().Get("page"))
 		if err != nil {
-			RespondWithCustomError(w, &exception.CustomError{
+			return nil, &exception.CustomError{
 				Status:  http.StatusBadRequest,
 				Code:    exception.IncorrectParamType,
 				Message: exception.IncorrectParamTypeMsg,
 				Params:  map[string]interface{}{"param": "page", "type": "int"},
 				Debug:   err.Error(),
-			})
-			return
+			}
 		}
 	}
 	textFilter, err := url.QueryUnescape(r.URL.Query().Get("textFilter"))
 	if err != nil {
-		RespondWithCustomError(w, &exception.CustomError{
+		return nil, &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Code:    exception.InvalidURLEscape,
 			Message: exception.InvalidURLEscapeMsg,
 			Params:  map[string]interface{}{"param": "textFilter"},
 			Debug:   err.Error(),
-		})
-		return
+		}
 	}
 	apiKind := r.URL.Query().Get("apiKind")
 	apiAudience := r.URL.Query().Get("apiAudience")
@@ -881,13 +894,12 @@ func (o operationControllerImpl) GetOperationsChanges(w http.ResponseWriter, r *
 		apiAudience = ""
 	}
 	if apiAudience != "" && !view.ValidApiAudience(apiAudience) {
-		RespondWithCustomError(w, &exception.CustomError{
+		return nil, &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Code:    exception.InvalidParameterValue,
 			Message: exception.InvalidParameterValueMsg,
 			Params:  map[string]interface{}{"param": "apiAudience", "value": apiAudience},
-		})
-		return
+		}
 	}
 	documentSlug := r.URL.Query().Get("documentSlug")
 	refPackageId := r.URL.Query().Get("refPackageId")
@@ -899,14 +911,13 @@ func (o operationControllerImpl) GetOperationsChanges(w http.ResponseWriter, r *
 	if emptyTagStr != "" {
 		emptyTag, err = strconv.ParseBool(emptyTagStr)
 		if err != nil {
-			RespondWithCustomError(w, &exception.CustomError{
+			return nil, &exception.CustomError{
 				Status:  http.StatusBadRequest,
 				Code:    exception.IncorrectParamType,
 				Message: exception.IncorrectParamTypeMsg,
 				Params:  map[string]interface{}{"param": "emptyTag", "type": "bool"},
 				Debug:   err.Error(),
-			})
-			return
+			}
 		}
 	}
 	tags := make([]string, 0)
@@ -914,56 +925,51 @@ func (o operationControllerImpl) GetOperationsChanges(w http.ResponseWriter, r *
 	if !emptyTag {
 		tags, customErr = getListFromParam(r, "tag")
 		if customErr != nil {
-			RespondWithCustomError(w, customErr)
-			return
+			return nil, customErr
 		}
 	}
 	emptyGroup := false
 	if r.URL.Query().Get("emptyGroup") != "" {
 		emptyGroup, err = strconv.ParseBool(r.URL.Query().Get("emptyGroup"))
 		if err != nil {
-			RespondWithCustomError(w, &exception.CustomError{
+			return nil, &exception.CustomError{
 				Status:  http.StatusBadRequest,
 				Code:    exception.IncorrectParamType,
 				Message: exception.IncorrectParamTypeMsg,
 				Params:  map[string]interface{}{"param": "emptyGroup", "type": "boolean"},
 				Debug:   err.Error(),
-			})
-			return
+			}
 		}
 	}
 	group := r.URL.Query().Get("group")
 	if emptyGroup && group != "" {
-		RespondWithCustomError(w, &exception.CustomError{
+		return nil, &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Code:    exception.OverlappingQueryParameter,
 			Message: exception.OverlappingQueryParameterMsg,
 			Params:  map[string]interface{}{"param1": "emptyGroup", "param2": "group"},
-		})
-		return
+		}
 	}
 
 	severities := make([]string, 0)
 	severities, customErr = getListFromParam(r, "severity")
 	if customErr != nil {
-		RespondWithCustomError(w, customErr)
-		return
+		return nil, customErr
 	}
 	if len(severities) > 0 {
 		for _, severity := range severities {
 			if !view.ValidSeverity(severity) {
-				RespondWithCustomError(w, &exception.CustomError{
+				return nil, &exception.CustomError{
 					Status:  http.StatusBadRequest,
 					Code:    exception.InvalidParameterValue,
 					Message: exception.InvalidParameterValueMsg,
 					Params:  map[string]interface{}{"param": "severity", "value": severity},
-				})
-				return
+				}
 			}
 		}
 	}
 
-	versionChangesSearchReq := view.VersionChangesReq{
+	return &view.VersionChangesReq{
 		PreviousVersion:          previousVersion,
 		PreviousVersionPackageId: previousVersionPackageId,
 		DocumentSlug:             documentSlug,
@@ -978,14 +984,7 @@ func (o operationControllerImpl) GetOperationsChanges(w http.ResponseWriter, r *
 		Group:                    group,
 		Severities:               severities,
 		ApiAudience:              apiAudience,
-	}
-
-	changelog, err := o.operationService.GetVersionChanges(packageId, versionName, apiType, versionChangesSearchReq)
-	if err != nil {
-		handlePkgRedirectOrRespondWithError(w, r, o.ptHandler, packageId, "Failed to get operations changelog", err)
-		return
-	}
-	RespondWithJson(w, http.StatusOK, changelog)
+	}, nil
 }
 
 func (o operationControllerImpl) GetDeprecatedOperationsList(w http.ResponseWriter, r *http.Request) {
@@ -1254,16 +1254,7 @@ func (o operationGroupControllerImpl) StartOperationGroupPublish(w http.Response
 			return
 		}
 	}
-	_, err = view.ParseVersionStatus(req.Status)
-	if err != nil {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusBadRequest,
-			Code:    exception.InvalidParameter,
-			Message: err.Error(),
-		})
-		return
-	}
-	sufficientPrivileges, err = o.roleService.HasManageVersionPermission(ctx, req.PackageId, req.Status)
+	sufficientPrivileges, err = o.roleService.HasPublishVersionPermission(ctx, req.PackageId, req.Version, req.Status)
 	if err != nil {
 		RespondWithError(w, "Failed to check user privileges", err)
 		return
@@ -15,6 +15,7 @@
 package controller
 
 import (
+	goctx "context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -37,6 +38,10 @@ type PublishV2Controller interface {
 	Publish(w http.ResponseWriter, r *http.Request)
 	GetPublishStatus(w http.ResponseWriter, r *http.Request)
 	GetPublishStatuses(w http.ResponseWriter, r *http.Request)
+	StreamPublishStatuses(w http.ResponseWriter, r *http.Request)
+	GetDryRunResult(w http.ResponseWriter, r *http.Request)
+	CancelPublish(w http.ResponseWriter, r *http.Request)
+	CancelPublishes(w http.ResponseWriter, r *http.Request)
 	GetFreeBuild(w http.ResponseWriter, r *http.Request)
 	SetPublishStatus_deprecated(w http.ResponseWriter, r *http.Request)
 	SetPublishStatus(w http.ResponseWriter, r *http.Request)
@@ -258,17 +263,7 @@ func (p publishV2ControllerImpl) Publish(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	_, err = view.ParseVersionStatus(config.Status)
-	if err != nil {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusBadRequest,
-			Code:    exception.InvalidParameter,
-			Message: err.Error(),
-		})
-		return
-	}
-
-	sufficientPrivileges, err := p.roleService.HasManageVersionPermission(ctx, packageId, config.Status)
+	sufficientPrivileges, err := p.roleService.HasPublishVersionPermission(ctx, packageId, config.Version, config.Status)
 	if err != nil {
 		RespondWithError(w, "Failed to check user privileges", err)
 		return
@@ -336,25 +331,57 @@ func (p publishV2ControllerImpl) GetPublishStatus(w http.ResponseWriter, r *http
 	}
 	publishId := getStringParam(r, "publishId")
 
-	status, details, err := p.buildService.GetStatus(publishId)
+	statuses, err := p.buildService.GetStatuses([]string{publishId})
 	if err != nil {
 		RespondWithError(w, "Failed to get publish status", err)
 		return
 	}
 
-	if status == "" && details == "" {
+	if len(statuses) == 0 {
 		RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Message: "build not found",
 		})
 		return
 	}
+	status := statuses[0]
 
-	RespondWithJson(w, http.StatusOK, view.PublishStatusResponse{
-		PublishId: publishId,
-		Status:    status,
-		Message:   details,
-	})
+	if status.Status == string(view.StatusNotStarted) {
+		position, err := p.buildService.GetQueuePosition(publishId)
+		if err != nil {
+			RespondWithError(w, "Failed to get publish queue position", err)
+			return
+		}
+		status.QueuePosition = &position
+	}
+
+	RespondWithJson(w, http.StatusOK, status)
+}
+
+func (p publishV2ControllerImpl) GetDryRunResult(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := p.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		RespondWithError(w, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	publishId := getStringParam(r, "publishId")
+
+	result, err := p.buildResultService.GetDryRunResult(packageId, publishId)
+	if err != nil {
+		RespondWithError(w, "Failed to get dry run result", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
 }
 
 func (p publishV2ControllerImpl) GetPublishStatuses(w http.ResponseWriter, r *http.Request) {
@@ -414,6 +441,184 @@ func (p publishV2ControllerImpl) GetPublishStatuses(w http.ResponseWriter, r *ht
 	RespondWithJson(w, http.StatusOK, result)
 }
 
+// must be less than http server write timeout, client is expected to reconnect after the stream is closed
+const publishStatusStreamTimeout = 240 * time.Second
+
+// StreamPublishStatuses streams publish status changes as server-sent events
+func (p publishV2ControllerImpl) StreamPublishStatuses(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := p.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		RespondWithError(w, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	publishIds, customErr := getListFromParam(r, "publishIds")
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	if len(publishIds) == 0 {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.EmptyParameter,
+			Message: exception.EmptyParameterMsg,
+			Params:  map[string]interface{}{"param": "publishIds"},
+		})
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		RespondWithError(w, "Failed to stream publish statuses", fmt.Errorf("streaming is not supported by response writer"))
+		return
+	}
+
+	streamCtx, cancel := goctx.WithTimeout(r.Context(), publishStatusStreamTimeout)
+	defer cancel()
+	streamStarted := false
+	err = p.buildService.WatchStatuses(streamCtx, ctx, packageId, publishIds, func(events []view.PublishStatusEvent) error {
+		if !streamStarted {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("X-Accel-Buffering", "no")
+			w.WriteHeader(http.StatusOK)
+			streamStarted = true
+		}
+		if len(events) == 0 {
+			// keep the connection alive for proxies
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return err
+			}
+		}
+		for _, event := range events {
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n\n", data); err != nil {
+				return err
+			}
+		}
+		flusher.Flush()
+		return nil
+	})
+	if err != nil {
+		if !streamStarted {
+			RespondWithError(w, "Failed to stream publish statuses", err)
+			return
+		}
+		log.Debugf("Publish statuses stream for %v is interrupted: %s", publishIds, err.Error())
+		return
+	}
+	if streamCtx.Err() == nil {
+		// all builds are finished, client shouldn't reconnect
+		fmt.Fprint(w, "event: end\ndata: {}\n\n")
+		flusher.Flush()
+	}
+}
+
+func (p publishV2ControllerImpl) CancelPublish(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	publishId := getStringParam(r, "publishId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := p.roleService.HasRequiredPermissions(ctx, packageId, view.ManageDraftVersionPermission)
+	if err != nil {
+		RespondWithError(w, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := p.buildService.CancelBuilds(ctx, packageId, []string{publishId})
+	if err != nil {
+		RespondWithError(w, "Failed to cancel publish", err)
+		return
+	}
+	if len(result.CancelledPublishIds) == 0 {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BuildAlreadyFinished,
+			Message: exception.BuildAlreadyFinishedMsg,
+			Params:  map[string]interface{}{"buildId": publishId},
+		})
+		return
+	}
+
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (p publishV2ControllerImpl) CancelPublishes(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := p.roleService.HasRequiredPermissions(ctx, packageId, view.ManageDraftVersionPermission)
+	if err != nil {
+		RespondWithError(w, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.CancelBuildsRequest
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	if len(req.PublishIds) == 0 {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.RequiredParamsMissing,
+			Message: exception.RequiredParamsMissingMsg,
+			Params:  map[string]interface{}{"params": "publishIds"},
+		})
+		return
+	}
+
+	result, err := p.buildService.CancelBuilds(ctx, packageId, req.PublishIds)
+	if err != nil {
+		RespondWithError(w, "Failed to cancel publishes", err)
+		return
+	}
+
+	RespondWithJson(w, http.StatusOK, result)
+}
+
 func (p publishV2ControllerImpl) SetPublishStatus_deprecated(w http.ResponseWriter, r *http.Request) {
 	packageId := getStringParam(r, "packageId")
 	publishId := getStringParam(r, "publishId") //buildId
@@ -561,7 +766,7 @@ func (p publishV2ControllerImpl) SetPublishStatus_deprecated(w http.ResponseWrit
 			RespondWithError(w, "Failed to publish build package", err)
 			return
 		}
-	case view.StatusNotStarted:
+	case view.StatusNotStarted, view.StatusCancelled:
 		RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Message: fmt.Sprintf("Value '%v' is not acceptable for status", status),
@@ -708,7 +913,7 @@ func (p publishV2ControllerImpl) SetPublishStatus(w http.ResponseWriter, r *http
 			RespondWithError(w, "Failed to publish build package", err)
 			return
 		}
-	case view.StatusNotStarted:
+	case view.StatusNotStarted, view.StatusCancelled:
 		RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Message: fmt.Sprintf("Value '%v' is not acceptable for status", status),
@@ -728,8 +933,66 @@ func (p publishV2ControllerImpl) SetPublishStatus(w http.ResponseWriter, r *http
 func (p publishV2ControllerImpl) GetFreeBuild(w http.ResponseWriter, r *http.Request) {
 	builderId := getStringParam(r, "builderId")
 	start := time.Now()
+	// builders use system api key, so only sysadm is allowed to take builds
+	ctx := context.Create(r)
+	if !p.roleService.IsSysadm(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	longPoll := false
+	if r.URL.Query().Get("longPoll") != "" {
+		var err error
+		longPoll, err = strconv.ParseBool(r.URL.Query().Get("longPoll"))
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "longPoll", "type": "boolean"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
 
-	src, err := p.buildService.GetFreeBuild(builderId)
+	// capabilities are optional, builder without capabilities could take any build
+	var capabilities view.BuilderCapabilities
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	if len(body) > 0 {
+		err = json.Unmarshal(body, &capabilities)
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.BadRequestBody,
+				Message: exception.BadRequestBodyMsg,
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	var src []byte
+	if longPoll {
+		timeout := time.Duration(p.systemInfoService.GetBuildsLongPollTimeoutSec()) * time.Second
+		src, err = p.buildService.AwaitFreeBuild(r.Context(), builderId, capabilities, timeout)
+	} else {
+		src, err = p.buildService.GetFreeBuild(builderId, capabilities)
+	}
 
 	if err != nil {
 		RespondWithError(w, "Failed to get free build", err)
@@ -0,0 +1,252 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type PublishGateController interface {
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+	SetPolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	ListRequests(w http.ResponseWriter, r *http.Request)
+	ApproveRequest(w http.ResponseWriter, r *http.Request)
+	RejectRequest(w http.ResponseWriter, r *http.Request)
+}
+
+func NewPublishGateController(roleService service.RoleService,
+	publishGateService service.PublishGateService,
+	ptHandler service.PackageTransitionHandler) PublishGateController {
+	return publishGateControllerImpl{roleService: roleService, publishGateService: publishGateService, ptHandler: ptHandler}
+}
+
+type publishGateControllerImpl struct {
+	roleService        service.RoleService
+	publishGateService service.PublishGateService
+	ptHandler          service.PackageTransitionHandler
+}
+
+func (p publishGateControllerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := p.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, p.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := p.publishGateService.GetPolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to get publish gate policy", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (p publishGateControllerImpl) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := p.roleService.HasRequiredPermissions(ctx, packageId, view.CreateAndUpdatePackagePermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, p.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.PublishGatePolicyUpdate
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		var customError *exception.CustomError
+		if errors.As(validationErr, &customError) {
+			RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	err = p.publishGateService.SetPolicy(ctx, packageId, req)
+	if err != nil {
+		RespondWithError(w, "Failed to update publish gate policy", err)
+		return
+	}
+
+	result, err := p.publishGateService.GetPolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to get publish gate policy after update", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (p publishGateControllerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := p.roleService.HasRequiredPermissions(ctx, packageId, view.CreateAndUpdatePackagePermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, p.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	err = p.publishGateService.DeletePolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to delete publish gate policy", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p publishGateControllerImpl) ListRequests(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := p.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, p.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	state := r.URL.Query().Get("state")
+	if state != "" && state != string(view.PublishGateRequestPending) &&
+		state != string(view.PublishGateRequestApproved) && state != string(view.PublishGateRequestRejected) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "state", "value": state},
+		})
+		return
+	}
+
+	result, err := p.publishGateService.ListRequests(packageId, state)
+	if err != nil {
+		RespondWithError(w, "Failed to get publish gate requests", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (p publishGateControllerImpl) ApproveRequest(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := p.roleService.HasRequiredPermissions(ctx, packageId, view.ManageReleaseVersionPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, p.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	requestId := getStringParam(r, "requestId")
+
+	result, err := p.publishGateService.ApproveRequest(ctx, packageId, requestId)
+	if err != nil {
+		RespondWithError(w, "Failed to approve publish gate request", err)
+		return
+	}
+	RespondWithJson(w, http.StatusAccepted, result)
+}
+
+func (p publishGateControllerImpl) RejectRequest(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := p.roleService.HasRequiredPermissions(ctx, packageId, view.ManageReleaseVersionPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, p.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	requestId := getStringParam(r, "requestId")
+
+	err = p.publishGateService.RejectRequest(ctx, packageId, requestId)
+	if err != nil {
+		RespondWithError(w, "Failed to reject publish gate request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
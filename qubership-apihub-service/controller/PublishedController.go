@@ -16,6 +16,7 @@ package controller
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -110,9 +111,13 @@ func (v publishControllerImpl) GetVersionSources(w http.ResponseWriter, r *http.
 		return
 	}
 
+	defer srcArchive.Close()
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.WriteHeader(http.StatusOK)
-	w.Write(srcArchive)
+	_, err = io.Copy(w, srcArchive)
+	if err != nil {
+		log.Errorf("Failed to write package version sources: %s", err.Error())
+	}
 }
 
 func (v publishControllerImpl) GetPublishedVersionSourceDataConfig(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,290 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type RecycleBinController interface {
+	GetItems(w http.ResponseWriter, r *http.Request)
+	RestorePackage(w http.ResponseWriter, r *http.Request)
+	PurgePackage(w http.ResponseWriter, r *http.Request)
+	RestoreVersion(w http.ResponseWriter, r *http.Request)
+	PurgeVersion(w http.ResponseWriter, r *http.Request)
+}
+
+func NewRecycleBinController(roleService service.RoleService,
+	recycleBinService service.RecycleBinService,
+	isSysadmFunc func(context.SecurityContext) bool) RecycleBinController {
+	return recycleBinControllerImpl{
+		roleService:       roleService,
+		recycleBinService: recycleBinService,
+		isSysadmFunc:      isSysadmFunc,
+	}
+}
+
+type recycleBinControllerImpl struct {
+	roleService       service.RoleService
+	recycleBinService service.RecycleBinService
+	isSysadmFunc      func(context.SecurityContext) bool
+}
+
+func (c recycleBinControllerImpl) GetItems(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := r.URL.Query().Get("packageId")
+	if packageId == "" {
+		// the whole recycle bin includes deleted workspaces which have no parent to check permissions for
+		if !c.isSysadmFunc(ctx) {
+			respondWithInsufficientPrivileges(w)
+			return
+		}
+	} else if !c.checkPermission(w, ctx, packageId, view.ReadPermission) {
+		return
+	}
+	kind := view.RecycleBinItemKind("")
+	if kindStr := r.URL.Query().Get("kind"); kindStr != "" {
+		var ok bool
+		kind, ok = view.ParseRecycleBinItemKind(kindStr)
+		if !ok {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidParameterValue,
+				Message: exception.InvalidParameterValueMsg,
+				Params:  map[string]interface{}{"param": "kind", "value": kindStr},
+			})
+			return
+		}
+	}
+	limit, customErr := getLimitQueryParam(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := c.recycleBinService.GetItems(view.RecycleBinItemsReq{
+		PackageId: packageId,
+		Kind:      kind,
+		Limit:     limit,
+		Page:      page,
+	})
+	if err != nil {
+		RespondWithError(w, "Failed to get recycle bin items", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c recycleBinControllerImpl) RestorePackage(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	restoreReferences, customErr := getRestoreReferencesParam(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	if !c.checkDeletedPackagePermission(w, ctx, packageId) {
+		return
+	}
+
+	result, err := c.recycleBinService.RestorePackage(ctx, packageId, restoreReferences)
+	if err != nil {
+		RespondWithError(w, "Failed to restore package", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c recycleBinControllerImpl) PurgePackage(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	if !c.checkDeletedPackagePermission(w, ctx, packageId) {
+		return
+	}
+
+	err := c.recycleBinService.PurgePackage(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to purge package", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c recycleBinControllerImpl) RestoreVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	version, revision, customErr := getRecycleBinVersionParams(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	restoreReferences, customErr := getRestoreReferencesParam(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	if !c.checkDeletedVersionPermission(w, ctx, packageId, version, revision) {
+		return
+	}
+
+	result, err := c.recycleBinService.RestoreVersion(ctx, packageId, version, revision, restoreReferences)
+	if err != nil {
+		RespondWithError(w, "Failed to restore version", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c recycleBinControllerImpl) PurgeVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	packageId := getStringParam(r, "packageId")
+	version, revision, customErr := getRecycleBinVersionParams(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	if !c.checkDeletedVersionPermission(w, ctx, packageId, version, revision) {
+		return
+	}
+
+	err := c.recycleBinService.PurgeVersion(packageId, version, revision)
+	if err != nil {
+		RespondWithError(w, "Failed to purge version", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c recycleBinControllerImpl) checkPermission(w http.ResponseWriter, ctx context.SecurityContext, packageId string, permission view.RolePermission) bool {
+	sufficientPrivileges, err := c.roleService.HasRequiredPermissions(ctx, packageId, permission)
+	if err != nil {
+		RespondWithError(w, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		respondWithInsufficientPrivileges(w)
+		return false
+	}
+	return true
+}
+
+// checkDeletedPackagePermission requires the same permission on the parent as the package deletion, deleted workspaces are managed by sysadmin only
+func (c recycleBinControllerImpl) checkDeletedPackagePermission(w http.ResponseWriter, ctx context.SecurityContext, packageId string) bool {
+	if _, err := c.recycleBinService.GetDeletedPackage(packageId); err != nil {
+		RespondWithError(w, "Failed to get deleted package", err)
+		return false
+	}
+	parentIds := utils.GetParentPackageIds(packageId)
+	if len(parentIds) == 0 {
+		if !c.isSysadmFunc(ctx) {
+			respondWithInsufficientPrivileges(w)
+			return false
+		}
+		return true
+	}
+	return c.checkPermission(w, ctx, parentIds[len(parentIds)-1], view.DeletePackagePermission)
+}
+
+func (c recycleBinControllerImpl) checkDeletedVersionPermission(w http.ResponseWriter, ctx context.SecurityContext, packageId string, version string, revision int) bool {
+	item, err := c.recycleBinService.GetDeletedVersion(packageId, version, revision)
+	if err != nil {
+		RespondWithError(w, "Failed to get deleted version", err)
+		return false
+	}
+	sufficientPrivileges, err := c.roleService.HasManageVersionPermission(ctx, packageId, item.Status)
+	if err != nil {
+		RespondWithError(w, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		respondWithInsufficientPrivileges(w)
+		return false
+	}
+	return true
+}
+
+func respondWithInsufficientPrivileges(w http.ResponseWriter) {
+	RespondWithCustomError(w, &exception.CustomError{
+		Status:  http.StatusForbidden,
+		Code:    exception.InsufficientPrivileges,
+		Message: exception.InsufficientPrivilegesMsg,
+	})
+}
+
+func getRecycleBinVersionParams(r *http.Request) (string, int, *exception.CustomError) {
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		return "", 0, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		}
+	}
+	revision := 0
+	if revisionStr := getStringParam(r, "revision"); revisionStr != "" {
+		revision, err = strconv.Atoi(revisionStr)
+		if err != nil || revision <= 0 {
+			return "", 0, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidParameterValue,
+				Message: exception.InvalidParameterValueMsg,
+				Params:  map[string]interface{}{"param": "revision", "value": revisionStr},
+			}
+		}
+	}
+	return version, revision, nil
+}
+
+func getRestoreReferencesParam(r *http.Request) (bool, *exception.CustomError) {
+	restoreReferencesStr := r.URL.Query().Get("restoreReferences")
+	if restoreReferencesStr == "" {
+		return false, nil
+	}
+	restoreReferences, err := strconv.ParseBool(restoreReferencesStr)
+	if err != nil {
+		return false, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.IncorrectParamType,
+			Message: exception.IncorrectParamTypeMsg,
+			Params:  map[string]interface{}{"param": "restoreReferences", "type": "boolean"},
+			Debug:   err.Error(),
+		}
+	}
+	return restoreReferences, nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type ReferenceGraphController interface {
+	GetDependents(w http.ResponseWriter, r *http.Request)
+	GetReferenceGraph(w http.ResponseWriter, r *http.Request)
+	GetImpact(w http.ResponseWriter, r *http.Request)
+}
+
+func NewReferenceGraphController(roleService service.RoleService,
+	referenceGraphService service.ReferenceGraphService,
+	ptHandler service.PackageTransitionHandler) ReferenceGraphController {
+	return referenceGraphControllerImpl{
+		roleService:           roleService,
+		referenceGraphService: referenceGraphService,
+		ptHandler:             ptHandler,
+	}
+}
+
+type referenceGraphControllerImpl struct {
+	roleService           service.RoleService
+	referenceGraphService service.ReferenceGraphService
+	ptHandler             service.PackageTransitionHandler
+}
+
+func (c referenceGraphControllerImpl) GetDependents(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	if !c.checkReadPermission(w, r, ctx, packageId) {
+		return
+	}
+	version, customErr := getReferenceGraphVersionParam(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	req, customErr := getVersionDependentsReq(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := c.referenceGraphService.GetDependents(ctx, packageId, version, *req)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, c.ptHandler, packageId, "Failed to get version dependents", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c referenceGraphControllerImpl) GetReferenceGraph(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	if !c.checkReadPermission(w, r, ctx, packageId) {
+		return
+	}
+	version, customErr := getReferenceGraphVersionParam(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = view.ReferenceGraphFormatJson
+	}
+	if format != view.ReferenceGraphFormatJson && format != view.ReferenceGraphFormatDot {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "format", "value": format},
+		})
+		return
+	}
+
+	result, err := c.referenceGraphService.GetReferenceGraph(packageId, version)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, c.ptHandler, packageId, "Failed to get version reference graph", err)
+		return
+	}
+	if format == view.ReferenceGraphFormatDot {
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		w.WriteHeader(http.StatusOK)
+		w.Write(result.ToDot())
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c referenceGraphControllerImpl) GetImpact(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	if !c.checkReadPermission(w, r, ctx, packageId) {
+		return
+	}
+	version, customErr := getReferenceGraphVersionParam(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := c.referenceGraphService.GetImpact(ctx, packageId, version)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, c.ptHandler, packageId, "Failed to get version impact", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (c referenceGraphControllerImpl) checkReadPermission(w http.ResponseWriter, r *http.Request, ctx context.SecurityContext, packageId string) bool {
+	sufficientPrivileges, err := c.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, c.ptHandler, packageId, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		respondWithInsufficientPrivileges(w)
+		return false
+	}
+	return true
+}
+
+func getReferenceGraphVersionParam(r *http.Request) (string, *exception.CustomError) {
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		return "", &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		}
+	}
+	return version, nil
+}
+
+func getVersionDependentsReq(r *http.Request) (*view.VersionDependentsReq, *exception.CustomError) {
+	limit, customErr := getLimitQueryParam(r)
+	if customErr != nil {
+		return nil, customErr
+	}
+	req := view.VersionDependentsReq{
+		Status: r.URL.Query().Get("status"),
+		Limit:  limit,
+	}
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		req.Page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			}
+		}
+		if req.Page < 0 {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidParameterValue,
+				Message: exception.InvalidParameterValueMsg,
+				Params:  map[string]interface{}{"param": "page", "value": req.Page},
+			}
+		}
+	}
+	if r.URL.Query().Get("onlyLatestRevision") != "" {
+		var err error
+		req.OnlyLatestRevision, err = strconv.ParseBool(r.URL.Query().Get("onlyLatestRevision"))
+		if err != nil {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "onlyLatestRevision", "type": "boolean"},
+				Debug:   err.Error(),
+			}
+		}
+	}
+	return &req, nil
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVersionDependentsReq(t *testing.T) {
+	tests := []struct {
+		name              string
+		query             string
+		expectedReq       *view.VersionDependentsReq
+		expectedErrorCode string
+		expectedParam     string
+	}{
+		{
+			name:        "defaults",
+			expectedReq: &view.VersionDependentsReq{Limit: 100},
+		},
+		{
+			name:        "all params",
+			query:       "?status=release&limit=10&page=2&onlyLatestRevision=true",
+			expectedReq: &view.VersionDependentsReq{Status: "release", Limit: 10, Page: 2, OnlyLatestRevision: true},
+		},
+		{
+			name:              "limit is not a number",
+			query:             "?limit=ten",
+			expectedErrorCode: exception.IncorrectParamType,
+			expectedParam:     "limit",
+		},
+		{
+			name:              "limit out of range",
+			query:             "?limit=101",
+			expectedErrorCode: exception.InvalidParameterValue,
+		},
+		{
+			name:              "page is not a number",
+			query:             "?page=first",
+			expectedErrorCode: exception.IncorrectParamType,
+			expectedParam:     "page",
+		},
+		{
+			name:              "negative page",
+			query:             "?page=-1",
+			expectedErrorCode: exception.InvalidParameterValue,
+			expectedParam:     "page",
+		},
+		{
+			name:              "onlyLatestRevision is not a boolean",
+			query:             "?onlyLatestRevision=yes",
+			expectedErrorCode: exception.IncorrectParamType,
+			expectedParam:     "onlyLatestRevision",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v2/packages/ws.pkg/versions/2024.1/dependents"+tt.query, nil)
+			req, customErr := getVersionDependentsReq(r)
+			if tt.expectedErrorCode == "" {
+				assert.Nil(t, customErr)
+				assert.Equal(t, tt.expectedReq, req)
+				return
+			}
+			if assert.NotNil(t, customErr) {
+				assert.Equal(t, tt.expectedErrorCode, customErr.Code)
+				assert.Equal(t, http.StatusBadRequest, customErr.Status)
+				if tt.expectedParam != "" {
+					assert.Equal(t, tt.expectedParam, customErr.Params["param"])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type SavedSearchController interface {
+	CreateSavedSearch(w http.ResponseWriter, r *http.Request)
+	GetSavedSearches(w http.ResponseWriter, r *http.Request)
+	UpdateSavedSearch(w http.ResponseWriter, r *http.Request)
+	DeleteSavedSearch(w http.ResponseWriter, r *http.Request)
+	GetNotifications(w http.ResponseWriter, r *http.Request)
+	MarkNotificationRead(w http.ResponseWriter, r *http.Request)
+}
+
+func NewSavedSearchController(savedSearchService service.SavedSearchService) SavedSearchController {
+	return &savedSearchControllerImpl{savedSearchService: savedSearchService}
+}
+
+type savedSearchControllerImpl struct {
+	savedSearchService service.SavedSearchService
+}
+
+func (s savedSearchControllerImpl) CreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	req, customErr := getSavedSearchReq(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := s.savedSearchService.CreateSavedSearch(context.Create(r), *req)
+	if err != nil {
+		RespondWithError(w, "Failed to create saved search", err)
+		return
+	}
+	RespondWithJson(w, http.StatusCreated, result)
+}
+
+func (s savedSearchControllerImpl) GetSavedSearches(w http.ResponseWriter, r *http.Request) {
+	result, err := s.savedSearchService.GetSavedSearches(context.Create(r))
+	if err != nil {
+		RespondWithError(w, "Failed to get saved searches", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (s savedSearchControllerImpl) UpdateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	savedSearchId := getStringParam(r, "savedSearchId")
+	req, customErr := getSavedSearchReq(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := s.savedSearchService.UpdateSavedSearch(context.Create(r), savedSearchId, *req)
+	if err != nil {
+		RespondWithError(w, "Failed to update saved search", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (s savedSearchControllerImpl) DeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	savedSearchId := getStringParam(r, "savedSearchId")
+
+	err := s.savedSearchService.DeleteSavedSearch(context.Create(r), savedSearchId)
+	if err != nil {
+		RespondWithError(w, "Failed to delete saved search", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s savedSearchControllerImpl) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	unreadOnly, limit, page, customErr := getNotificationListQueryParams(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := s.savedSearchService.GetNotifications(context.Create(r), view.SavedSearchNotificationListReq{
+		UnreadOnly: unreadOnly,
+		Limit:      limit,
+		Page:       page,
+	})
+	if err != nil {
+		RespondWithError(w, "Failed to get saved search notifications", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (s savedSearchControllerImpl) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	notificationId := getStringParam(r, "notificationId")
+
+	err := s.savedSearchService.MarkNotificationRead(context.Create(r), notificationId)
+	if err != nil {
+		RespondWithError(w, "Failed to mark saved search notification as read", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getNotificationListQueryParams parses unreadOnly, limit and page params of notification inbox endpoints
+func getNotificationListQueryParams(r *http.Request) (bool, int, int, *exception.CustomError) {
+	var err error
+	unreadOnly := false
+	if r.URL.Query().Get("unreadOnly") != "" {
+		unreadOnly, err = strconv.ParseBool(r.URL.Query().Get("unreadOnly"))
+		if err != nil {
+			return false, 0, 0, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "unreadOnly", "type": "boolean"},
+				Debug:   err.Error(),
+			}
+		}
+	}
+	limit, customErr := getLimitQueryParam(r)
+	if customErr != nil {
+		return false, 0, 0, customErr
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			return false, 0, 0, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			}
+		}
+	}
+	return unreadOnly, limit, page, nil
+}
+
+func getSavedSearchReq(r *http.Request) (*view.SavedSearchReq, *exception.CustomError) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		}
+	}
+	var req view.SavedSearchReq
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		}
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		if customError, ok := validationErr.(*exception.CustomError); ok {
+			return nil, customError
+		}
+	}
+	return &req, nil
+}
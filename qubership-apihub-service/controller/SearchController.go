@@ -246,6 +246,15 @@ func (s searchControllerImpl) Search(w http.ResponseWriter, r *http.Request) {
 			}
 			RespondWithJson(w, http.StatusOK, result)
 		}
+	case view.SearchLevelModels:
+		{
+			result, err := s.operationService.SearchForModels(searchQuery)
+			if err != nil {
+				RespondWithError(w, "Failed to perform search for models", err)
+				return
+			}
+			RespondWithJson(w, http.StatusOK, result)
+		}
 	default:
 		RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusBadRequest,
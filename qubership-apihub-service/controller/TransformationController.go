@@ -255,7 +255,7 @@ func (t transformationControllerImpl) TransformDocuments_deprecated(w http.Respo
 		return
 	}
 	switch buildView.Status {
-	case string(view.StatusError):
+	case string(view.StatusError), string(view.StatusCancelled):
 		calculationProcessStatus = view.CalculationProcessStatus{
 			Status:  string(view.StatusError),
 			Message: buildView.Details,
@@ -534,7 +534,7 @@ func (t transformationControllerImpl) TransformDocuments(w http.ResponseWriter,
 		return
 	}
 	switch buildView.Status {
-	case string(view.StatusError):
+	case string(view.StatusError), string(view.StatusCancelled):
 		calculationProcessStatus = view.CalculationProcessStatus{
 			Status:  string(view.StatusError),
 			Message: buildView.Details,
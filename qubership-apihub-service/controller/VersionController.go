@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/client"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/metrics"
@@ -56,27 +57,35 @@ type VersionController interface {
 	CopyVersion(w http.ResponseWriter, r *http.Request)
 	GetPublishedVersionsHistory(w http.ResponseWriter, r *http.Request)
 	PublishFromCSV(w http.ResponseWriter, r *http.Request)
+	PublishFromNamespace(w http.ResponseWriter, r *http.Request)
 	GetCSVDashboardPublishStatus(w http.ResponseWriter, r *http.Request)
 	GetCSVDashboardPublishReport(w http.ResponseWriter, r *http.Request)
 }
 
-func NewVersionController(versionService service.VersionService, roleService service.RoleService, monitoringService service.MonitoringService,
-	ptHandler service.PackageTransitionHandler, isSysadm func(context.SecurityContext) bool) VersionController {
+func NewVersionController(versionService service.VersionService, roleService service.RoleService, versionPromotionService service.VersionPromotionService,
+	monitoringService service.MonitoringService, ptHandler service.PackageTransitionHandler, agentRegistrationService service.AgentRegistrationService,
+	agentClient client.AgentClient, isSysadm func(context.SecurityContext) bool) VersionController {
 	return &versionControllerImpl{
-		versionService:    versionService,
-		roleService:       roleService,
-		monitoringService: monitoringService,
-		ptHandler:         ptHandler,
-		isSysadm:          isSysadm,
+		versionService:           versionService,
+		roleService:              roleService,
+		versionPromotionService:  versionPromotionService,
+		monitoringService:        monitoringService,
+		ptHandler:                ptHandler,
+		agentRegistrationService: agentRegistrationService,
+		agentClient:              agentClient,
+		isSysadm:                 isSysadm,
 	}
 }
 
 type versionControllerImpl struct {
-	versionService    service.VersionService
-	roleService       service.RoleService
-	monitoringService service.MonitoringService
-	ptHandler         service.PackageTransitionHandler
-	isSysadm          func(context.SecurityContext) bool
+	versionService           service.VersionService
+	roleService              service.RoleService
+	versionPromotionService  service.VersionPromotionService
+	monitoringService        service.MonitoringService
+	ptHandler                service.PackageTransitionHandler
+	agentRegistrationService service.AgentRegistrationService
+	agentClient              client.AgentClient
+	isSysadm                 func(context.SecurityContext) bool
 }
 
 func (v versionControllerImpl) SharePublishedFile(w http.ResponseWriter, r *http.Request) {
@@ -416,32 +425,25 @@ func (v versionControllerImpl) PatchVersion(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	statuses := make([]string, 0)
+	versionStatus, err := v.versionService.GetVersionStatus(packageId, versionName)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges (get version status)", err)
+		return
+	}
+	sufficientPrivileges := true
 	if req.Status != nil {
-		_, err := view.ParseVersionStatus(*req.Status)
+		sufficientPrivileges, err = v.roleService.HasVersionStatusTransitionPermission(ctx, packageId, versionStatus, *req.Status)
 		if err != nil {
-			RespondWithCustomError(w, &exception.CustomError{
-				Status:  http.StatusBadRequest,
-				Code:    exception.InvalidParameter,
-				Message: err.Error(),
-			})
+			handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
 			return
 		}
-		statuses = append(statuses, *req.Status)
 	}
-
-	if req.VersionLabels != nil {
-		versionStatus, err := v.versionService.GetVersionStatus(packageId, versionName)
+	if sufficientPrivileges && req.VersionLabels != nil {
+		sufficientPrivileges, err = v.roleService.HasManageVersionPermission(ctx, packageId, versionStatus)
 		if err != nil {
-			handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges (get version status)", err)
+			handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
 			return
 		}
-		statuses = append(statuses, versionStatus)
-	}
-	sufficientPrivileges, err := v.roleService.HasManageVersionPermission(ctx, packageId, statuses...)
-	if err != nil {
-		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
-		return
 	}
 	if !sufficientPrivileges {
 		RespondWithCustomError(w, &exception.CustomError{
@@ -452,6 +454,26 @@ func (v versionControllerImpl) PatchVersion(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if req.Status != nil {
+		promotionRequest, err := v.versionPromotionService.RequestPromotion(ctx, packageId, versionName, *req.Status)
+		if err != nil {
+			handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to create version promotion request", err)
+			return
+		}
+		if promotionRequest != nil {
+			// status is changed after approval, other fields could be changed right away
+			if req.VersionLabels != nil {
+				_, err = v.versionService.PatchVersion(ctx, packageId, versionName, nil, req.VersionLabels)
+				if err != nil {
+					handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to patch version", err)
+					return
+				}
+			}
+			RespondWithJson(w, http.StatusAccepted, promotionRequest)
+			return
+		}
+	}
+
 	content, err := v.versionService.PatchVersion(context.Create(r), packageId, versionName, req.Status, req.VersionLabels)
 	if err != nil {
 		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to patch version", err)
@@ -1332,16 +1354,7 @@ func (v versionControllerImpl) CopyVersion(w http.ResponseWriter, r *http.Reques
 			return
 		}
 	}
-	_, err = view.ParseVersionStatus(req.TargetStatus)
-	if err != nil {
-		RespondWithCustomError(w, &exception.CustomError{
-			Status:  http.StatusBadRequest,
-			Code:    exception.InvalidParameter,
-			Message: err.Error(),
-		})
-		return
-	}
-	sufficientPrivileges, err = v.roleService.HasManageVersionPermission(ctx, req.TargetPackageId, req.TargetStatus)
+	sufficientPrivileges, err = v.roleService.HasPublishVersionPermission(ctx, req.TargetPackageId, req.TargetVersion, req.TargetStatus)
 	if err != nil {
 		RespondWithError(w, "Failed to check user privileges", err)
 		return
@@ -1532,18 +1545,65 @@ func (v versionControllerImpl) PublishFromCSV(w http.ResponseWriter, r *http.Req
 		}
 	}
 
-	_, err = view.ParseVersionStatus(csvPublishReq.Status)
+	sufficientPrivileges, err = v.roleService.HasPublishVersionPermission(ctx, csvPublishReq.PackageId, csvPublishReq.Version, csvPublishReq.Status)
+	if err != nil {
+		RespondWithError(w, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	publishId, err := v.versionService.StartPublishFromCSV(ctx, csvPublishReq)
+	if err != nil {
+		RespondWithError(w, "Failed to start dashboard publish from csv", err)
+		return
+	}
+	RespondWithJson(w, http.StatusAccepted, view.PublishFromCSVResp{PublishId: publishId})
+}
+
+func (v versionControllerImpl) PublishFromNamespace(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
 	if err != nil {
 		RespondWithCustomError(w, &exception.CustomError{
 			Status:  http.StatusBadRequest,
-			Code:    exception.InvalidParameter,
-			Message: err.Error(),
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
 		})
 		return
 	}
-	sufficientPrivileges, err = v.roleService.HasManageVersionPermission(ctx, csvPublishReq.PackageId, csvPublishReq.Status)
+	var req view.PublishFromNamespaceReq
+	err = json.Unmarshal(body, &req)
 	if err != nil {
-		RespondWithError(w, "Failed to check user privileges", err)
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	req.PackageId = packageId
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		if customError, ok := validationErr.(*exception.CustomError); ok {
+			RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	sufficientPrivileges, err := v.roleService.HasPublishVersionPermission(ctx, packageId, req.Version, req.Status)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
 		return
 	}
 	if !sufficientPrivileges {
@@ -1555,9 +1615,24 @@ func (v versionControllerImpl) PublishFromCSV(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	publishId, err := v.versionService.StartPublishFromCSV(ctx, csvPublishReq)
+	agent, err := v.agentRegistrationService.GetActiveAgent(req.AgentId)
 	if err != nil {
-		RespondWithError(w, "Failed to start dashboard publish from csv", err)
+		RespondWithError(w, "Failed to get agent", err)
+		return
+	}
+	serviceNamesResp, err := v.agentClient.ListServiceNames(ctx, agent.AgentUrl, req.Namespace)
+	if err != nil {
+		RespondWithError(w, "Failed to get service names", err)
+		return
+	}
+	serviceNames := make([]string, 0, len(serviceNamesResp.ServiceNames))
+	for _, serviceName := range serviceNamesResp.ServiceNames {
+		serviceNames = append(serviceNames, serviceName.Name)
+	}
+
+	publishId, err := v.versionService.StartPublishFromNamespace(ctx, req, serviceNames)
+	if err != nil {
+		RespondWithError(w, "Failed to start dashboard publish from namespace", err)
 		return
 	}
 	RespondWithJson(w, http.StatusAccepted, view.PublishFromCSVResp{PublishId: publishId})
@@ -0,0 +1,67 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionInferenceController interface {
+	GetNextVersion(w http.ResponseWriter, r *http.Request)
+}
+
+func NewVersionInferenceController(roleService service.RoleService,
+	versionInferenceService service.VersionInferenceService,
+	ptHandler service.PackageTransitionHandler) VersionInferenceController {
+	return versionInferenceControllerImpl{roleService: roleService, versionInferenceService: versionInferenceService, ptHandler: ptHandler}
+}
+
+type versionInferenceControllerImpl struct {
+	roleService             service.RoleService
+	versionInferenceService service.VersionInferenceService
+	ptHandler               service.PackageTransitionHandler
+}
+
+func (v versionInferenceControllerImpl) GetNextVersion(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	version := r.URL.Query().Get("version")
+	previousVersion := r.URL.Query().Get("previousVersion")
+
+	result, err := v.versionInferenceService.GetNextVersion(packageId, version, previousVersion)
+	if err != nil {
+		RespondWithError(w, "Failed to get next version", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
@@ -0,0 +1,101 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionLifecycleController interface {
+	GetVersionLifecycle(w http.ResponseWriter, r *http.Request)
+	UpdateVersionLifecycle(w http.ResponseWriter, r *http.Request)
+}
+
+func NewVersionLifecycleController(versionLifecycleService service.VersionLifecycleService, isSysadmFunc func(context.SecurityContext) bool) VersionLifecycleController {
+	return &versionLifecycleControllerImpl{
+		versionLifecycleService: versionLifecycleService,
+		isSysadmFunc:            isSysadmFunc,
+	}
+}
+
+type versionLifecycleControllerImpl struct {
+	versionLifecycleService service.VersionLifecycleService
+	isSysadmFunc            func(context.SecurityContext) bool
+}
+
+func (v versionLifecycleControllerImpl) GetVersionLifecycle(w http.ResponseWriter, r *http.Request) {
+	lifecycle, err := v.versionLifecycleService.GetLifecycle()
+	if err != nil {
+		RespondWithError(w, "Failed to get version lifecycle", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, lifecycle)
+}
+
+func (v versionLifecycleControllerImpl) UpdateVersionLifecycle(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !v.isSysadmFunc(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.VersionLifecycle
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		if customError, ok := validationErr.(*exception.CustomError); ok {
+			RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	lifecycle, err := v.versionLifecycleService.UpdateLifecycle(ctx, req)
+	if err != nil {
+		RespondWithError(w, "Failed to update version lifecycle", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, lifecycle)
+}
@@ -0,0 +1,232 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionLockController interface {
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+	SetPolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	GetLock(w http.ResponseWriter, r *http.Request)
+	LockVersion(w http.ResponseWriter, r *http.Request)
+	UnlockVersion(w http.ResponseWriter, r *http.Request)
+}
+
+func NewVersionLockController(roleService service.RoleService,
+	versionService service.VersionService,
+	versionLockService service.VersionLockService,
+	ptHandler service.PackageTransitionHandler,
+	isSysadm func(context.SecurityContext) bool) VersionLockController {
+	return versionLockControllerImpl{
+		roleService:        roleService,
+		versionService:     versionService,
+		versionLockService: versionLockService,
+		ptHandler:          ptHandler,
+		isSysadm:           isSysadm,
+	}
+}
+
+type versionLockControllerImpl struct {
+	roleService        service.RoleService
+	versionService     service.VersionService
+	versionLockService service.VersionLockService
+	ptHandler          service.PackageTransitionHandler
+	isSysadm           func(context.SecurityContext) bool
+}
+
+func (v versionLockControllerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	if !v.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+
+	result, err := v.versionLockService.GetPolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to get version lock policy", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionLockControllerImpl) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	if !v.checkPermission(w, r, ctx, packageId, view.CreateAndUpdatePackagePermission) {
+		return
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.VersionLockPolicyUpdate
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+
+	err = v.versionLockService.SetPolicy(ctx, packageId, req)
+	if err != nil {
+		RespondWithError(w, "Failed to update version lock policy", err)
+		return
+	}
+
+	result, err := v.versionLockService.GetPolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to get version lock policy after update", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+// DeletePolicy unlocks all versions which were locked by the policy, so it is available for sysadmin only
+func (v versionLockControllerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !v.isSysadm(ctx) {
+		respondWithInsufficientPrivileges(w)
+		return
+	}
+	packageId := getStringParam(r, "packageId")
+
+	err := v.versionLockService.DeletePolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to delete version lock policy", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (v versionLockControllerImpl) GetLock(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	if !v.checkPermission(w, r, ctx, packageId, view.ReadPermission) {
+		return
+	}
+	version, customError := getVersionLockVersionParam(r)
+	if customError != nil {
+		RespondWithCustomError(w, customError)
+		return
+	}
+
+	result, err := v.versionLockService.GetLock(packageId, version)
+	if err != nil {
+		RespondWithError(w, "Failed to get version lock", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionLockControllerImpl) LockVersion(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	version, customError := getVersionLockVersionParam(r)
+	if customError != nil {
+		RespondWithCustomError(w, customError)
+		return
+	}
+	versionStatus, err := v.versionService.GetVersionStatus(packageId, version)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges(get version status)", err)
+		return
+	}
+	sufficientPrivileges, err := v.roleService.HasManageVersionPermission(ctx, packageId, versionStatus)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		respondWithInsufficientPrivileges(w)
+		return
+	}
+
+	result, err := v.versionLockService.LockVersion(ctx, packageId, version)
+	if err != nil {
+		RespondWithError(w, "Failed to lock version", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionLockControllerImpl) UnlockVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !v.isSysadm(ctx) {
+		respondWithInsufficientPrivileges(w)
+		return
+	}
+	packageId := getStringParam(r, "packageId")
+	version, customError := getVersionLockVersionParam(r)
+	if customError != nil {
+		RespondWithCustomError(w, customError)
+		return
+	}
+
+	result, err := v.versionLockService.UnlockVersion(ctx, packageId, version)
+	if err != nil {
+		RespondWithError(w, "Failed to unlock version", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionLockControllerImpl) checkPermission(w http.ResponseWriter, r *http.Request, ctx context.SecurityContext, packageId string, permission view.RolePermission) bool {
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(ctx, packageId, permission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		respondWithInsufficientPrivileges(w)
+		return false
+	}
+	return true
+}
+
+func getVersionLockVersionParam(r *http.Request) (string, *exception.CustomError) {
+	version, err := getUnescapedStringParam(r, "version")
+	if err != nil {
+		return "", &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidURLEscape,
+			Message: exception.InvalidURLEscapeMsg,
+			Params:  map[string]interface{}{"param": "version"},
+			Debug:   err.Error(),
+		}
+	}
+	return version, nil
+}
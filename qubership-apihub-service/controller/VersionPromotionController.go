@@ -0,0 +1,392 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionPromotionController interface {
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+	SetPolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	ListRequests(w http.ResponseWriter, r *http.Request)
+	GetRequest(w http.ResponseWriter, r *http.Request)
+	ApproveRequest(w http.ResponseWriter, r *http.Request)
+	RejectRequest(w http.ResponseWriter, r *http.Request)
+	CancelRequest(w http.ResponseWriter, r *http.Request)
+	ListRequestsAwaitingApproval(w http.ResponseWriter, r *http.Request)
+	GetNotifications(w http.ResponseWriter, r *http.Request)
+	MarkNotificationRead(w http.ResponseWriter, r *http.Request)
+}
+
+func NewVersionPromotionController(roleService service.RoleService,
+	versionPromotionService service.VersionPromotionService,
+	ptHandler service.PackageTransitionHandler) VersionPromotionController {
+	return versionPromotionControllerImpl{roleService: roleService, versionPromotionService: versionPromotionService, ptHandler: ptHandler}
+}
+
+type versionPromotionControllerImpl struct {
+	roleService             service.RoleService
+	versionPromotionService service.VersionPromotionService
+	ptHandler               service.PackageTransitionHandler
+}
+
+func (v versionPromotionControllerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := v.versionPromotionService.GetPolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to get version promotion policy", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionPromotionControllerImpl) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(ctx, packageId, view.CreateAndUpdatePackagePermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.VersionPromotionPolicyUpdate
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		var customError *exception.CustomError
+		if errors.As(validationErr, &customError) {
+			RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	err = v.versionPromotionService.SetPolicy(ctx, packageId, req)
+	if err != nil {
+		RespondWithError(w, "Failed to update version promotion policy", err)
+		return
+	}
+
+	result, err := v.versionPromotionService.GetPolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to get version promotion policy after update", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionPromotionControllerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(ctx, packageId, view.CreateAndUpdatePackagePermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	err = v.versionPromotionService.DeletePolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to delete version promotion policy", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (v versionPromotionControllerImpl) ListRequests(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	state := r.URL.Query().Get("state")
+	if state != "" && !view.ValidVersionPromotionRequestState(state) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidParameterValue,
+			Message: exception.InvalidParameterValueMsg,
+			Params:  map[string]interface{}{"param": "state", "value": state},
+		})
+		return
+	}
+
+	result, err := v.versionPromotionService.ListRequests(packageId, state)
+	if err != nil {
+		RespondWithError(w, "Failed to get version promotion requests", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionPromotionControllerImpl) GetRequest(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	result, err := v.versionPromotionService.GetRequest(packageId, getStringParam(r, "requestId"))
+	if err != nil {
+		RespondWithError(w, "Failed to get version promotion request", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionPromotionControllerImpl) ApproveRequest(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.VersionPromotionDecisionReq
+	if len(body) > 0 {
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.BadRequestBody,
+				Message: exception.BadRequestBodyMsg,
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := v.versionPromotionService.ApproveRequest(ctx, packageId, getStringParam(r, "requestId"), req.Comment)
+	if err != nil {
+		RespondWithError(w, "Failed to approve version promotion request", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionPromotionControllerImpl) RejectRequest(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.VersionPromotionDecisionReq
+	if len(body) > 0 {
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			RespondWithCustomError(w, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.BadRequestBody,
+				Message: exception.BadRequestBodyMsg,
+				Debug:   err.Error(),
+			})
+			return
+		}
+	}
+
+	result, err := v.versionPromotionService.RejectRequest(ctx, packageId, getStringParam(r, "requestId"), req.Comment)
+	if err != nil {
+		RespondWithError(w, "Failed to reject version promotion request", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionPromotionControllerImpl) CancelRequest(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	ctx := context.Create(r)
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(ctx, packageId, view.ReadPermission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	err = v.versionPromotionService.CancelRequest(ctx, packageId, getStringParam(r, "requestId"))
+	if err != nil {
+		RespondWithError(w, "Failed to cancel version promotion request", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (v versionPromotionControllerImpl) ListRequestsAwaitingApproval(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	result, err := v.versionPromotionService.ListRequestsAwaitingApproval(ctx)
+	if err != nil {
+		RespondWithError(w, "Failed to get version promotion requests awaiting approval", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionPromotionControllerImpl) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	unreadOnly, limit, page, customErr := getNotificationListQueryParams(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := v.versionPromotionService.GetNotifications(context.Create(r), view.VersionPromotionNotificationListReq{
+		UnreadOnly: unreadOnly,
+		Limit:      limit,
+		Page:       page,
+	})
+	if err != nil {
+		RespondWithError(w, "Failed to get version promotion notifications", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionPromotionControllerImpl) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	notificationId := getStringParam(r, "notificationId")
+
+	err := v.versionPromotionService.MarkNotificationRead(context.Create(r), notificationId)
+	if err != nil {
+		RespondWithError(w, "Failed to mark version promotion notification as read", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,286 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionRetentionController interface {
+	GetPolicy(w http.ResponseWriter, r *http.Request)
+	SetPolicy(w http.ResponseWriter, r *http.Request)
+	DeletePolicy(w http.ResponseWriter, r *http.Request)
+	PreviewCleanup(w http.ResponseWriter, r *http.Request)
+	GetReport(w http.ResponseWriter, r *http.Request)
+	StartCleanup(w http.ResponseWriter, r *http.Request)
+	ListRuns(w http.ResponseWriter, r *http.Request)
+	GetRun(w http.ResponseWriter, r *http.Request)
+}
+
+func NewVersionRetentionController(roleService service.RoleService,
+	versionRetentionService service.VersionRetentionService,
+	ptHandler service.PackageTransitionHandler,
+	isSysadmFunc func(context.SecurityContext) bool) VersionRetentionController {
+	return versionRetentionControllerImpl{
+		roleService:             roleService,
+		versionRetentionService: versionRetentionService,
+		ptHandler:               ptHandler,
+		isSysadmFunc:            isSysadmFunc,
+	}
+}
+
+type versionRetentionControllerImpl struct {
+	roleService             service.RoleService
+	versionRetentionService service.VersionRetentionService
+	ptHandler               service.PackageTransitionHandler
+	isSysadmFunc            func(context.SecurityContext) bool
+}
+
+func (v versionRetentionControllerImpl) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	if !v.checkPackagePermission(w, r, packageId, view.ReadPermission) {
+		return
+	}
+
+	result, err := v.versionRetentionService.GetPolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to get version retention policy", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionRetentionControllerImpl) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	if !v.checkPackagePermission(w, r, packageId, view.CreateAndUpdatePackagePermission) {
+		return
+	}
+
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	var req view.VersionRetentionPolicyUpdate
+	err = json.Unmarshal(body, &req)
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BadRequestBody,
+			Message: exception.BadRequestBodyMsg,
+			Debug:   err.Error(),
+		})
+		return
+	}
+	validationErr := utils.ValidateObject(req)
+	if validationErr != nil {
+		var customError *exception.CustomError
+		if errors.As(validationErr, &customError) {
+			RespondWithCustomError(w, customError)
+			return
+		}
+	}
+
+	err = v.versionRetentionService.SetPolicy(context.Create(r), packageId, req)
+	if err != nil {
+		RespondWithError(w, "Failed to update version retention policy", err)
+		return
+	}
+
+	result, err := v.versionRetentionService.GetPolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to get version retention policy after update", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionRetentionControllerImpl) DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	if !v.checkPackagePermission(w, r, packageId, view.CreateAndUpdatePackagePermission) {
+		return
+	}
+
+	err := v.versionRetentionService.DeletePolicy(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to delete version retention policy", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (v versionRetentionControllerImpl) PreviewCleanup(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	if !v.checkPackagePermission(w, r, packageId, view.ReadPermission) {
+		return
+	}
+
+	result, err := v.versionRetentionService.PreviewCleanup(packageId)
+	if err != nil {
+		RespondWithError(w, "Failed to preview version retention cleanup", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionRetentionControllerImpl) GetReport(w http.ResponseWriter, r *http.Request) {
+	packageId := getStringParam(r, "packageId")
+	if !v.checkPackagePermission(w, r, packageId, view.ReadPermission) {
+		return
+	}
+	req, customErr := getVersionRetentionReportReq(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := v.versionRetentionService.GetReport(packageId, *req)
+	if err != nil {
+		RespondWithError(w, "Failed to get version retention report", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionRetentionControllerImpl) StartCleanup(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Create(r)
+	if !v.isSysadmFunc(ctx) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+
+	runId, err := v.versionRetentionService.StartCleanup(ctx)
+	if err != nil {
+		RespondWithError(w, "Failed to start version retention cleanup", err)
+		return
+	}
+	RespondWithJson(w, http.StatusAccepted, map[string]int{"runId": runId})
+}
+
+func (v versionRetentionControllerImpl) ListRuns(w http.ResponseWriter, r *http.Request) {
+	if !v.isSysadmFunc(context.Create(r)) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	req, customErr := getVersionRetentionReportReq(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := v.versionRetentionService.ListRuns(*req)
+	if err != nil {
+		RespondWithError(w, "Failed to list version retention cleanup runs", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionRetentionControllerImpl) GetRun(w http.ResponseWriter, r *http.Request) {
+	if !v.isSysadmFunc(context.Create(r)) {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return
+	}
+	runId, err := strconv.Atoi(getStringParam(r, "runId"))
+	if err != nil {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.IncorrectParamType,
+			Message: exception.IncorrectParamTypeMsg,
+			Params:  map[string]interface{}{"param": "runId", "type": "int"},
+			Debug:   err.Error(),
+		})
+		return
+	}
+	req, customErr := getVersionRetentionReportReq(r)
+	if customErr != nil {
+		RespondWithCustomError(w, customErr)
+		return
+	}
+
+	result, err := v.versionRetentionService.GetRun(runId, *req)
+	if err != nil {
+		RespondWithError(w, "Failed to get version retention cleanup run", err)
+		return
+	}
+	RespondWithJson(w, http.StatusOK, result)
+}
+
+func (v versionRetentionControllerImpl) checkPackagePermission(w http.ResponseWriter, r *http.Request, packageId string, permission view.RolePermission) bool {
+	sufficientPrivileges, err := v.roleService.HasRequiredPermissions(context.Create(r), packageId, permission)
+	if err != nil {
+		handlePkgRedirectOrRespondWithError(w, r, v.ptHandler, packageId, "Failed to check user privileges", err)
+		return false
+	}
+	if !sufficientPrivileges {
+		RespondWithCustomError(w, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		})
+		return false
+	}
+	return true
+}
+
+func getVersionRetentionReportReq(r *http.Request) (*view.VersionRetentionReportReq, *exception.CustomError) {
+	limit, customErr := getLimitQueryParam(r)
+	if customErr != nil {
+		return nil, customErr
+	}
+	page := 0
+	if r.URL.Query().Get("page") != "" {
+		var err error
+		page, err = strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.IncorrectParamType,
+				Message: exception.IncorrectParamTypeMsg,
+				Params:  map[string]interface{}{"param": "page", "type": "int"},
+				Debug:   err.Error(),
+			}
+		}
+	}
+	return &view.VersionRetentionReportReq{Limit: limit, Page: page}, nil
+}
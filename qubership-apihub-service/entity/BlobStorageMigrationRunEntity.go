@@ -0,0 +1,27 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import "time"
+
+// BlobStorageMigrationRunEntity is used as a lock, so only one blob storage migration is executed at a time across all instances
+type BlobStorageMigrationRunEntity struct {
+	tableName struct{} `pg:"blob_storage_migration_run"`
+
+	RunId      int        `pg:"run_id, pk, type:integer"`
+	Status     string     `pg:"status, type:varchar"`
+	StartedAt  time.Time  `pg:"started_at, type:timestamp without time zone"`
+	FinishedAt *time.Time `pg:"finished_at, type:timestamp without time zone"`
+}
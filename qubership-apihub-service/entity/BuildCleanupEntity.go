@@ -23,12 +23,13 @@ type BuildCleanupEntity struct {
 	DeletedRows int       `pg:"deleted_rows, type:integer"`
 	ScheduledAt time.Time `pg:"scheduled_at, type:timestamp without time zone"`
 
-	BuildResult         int `pg:"build_result, type:integer"`
-	BuildSrc            int `pg:"build_src, type:integer"`
-	OperationData       int `pg:"operation_data, type:integer"`
-	TsOperationData     int `pg:"ts_operation_data, type:integer"`
-	TsRestOperationData int `pg:"ts_rest_operation_data, type:integer"`
-	TsGQLOperationData  int `pg:"ts_gql_operation_data, type:integer"`
+	BuildResult             int `pg:"build_result, type:integer"`
+	BuildSrc                int `pg:"build_src, type:integer"`
+	OperationData           int `pg:"operation_data, type:integer"`
+	TsOperationData         int `pg:"ts_operation_data, type:integer"`
+	TsRestOperationData     int `pg:"ts_rest_operation_data, type:integer"`
+	TsGQLOperationData      int `pg:"ts_gql_operation_data, type:integer"`
+	TsProtobufOperationData int `pg:"ts_protobuf_operation_data, type:integer"`
 }
 
 type BuildIdEntity struct {
@@ -42,6 +42,17 @@ type BuildEntity struct {
 	BuilderId string                 `pg:"builder_id, type:varchar"`
 	Priority  int                    `pg:"priority, type:integer, use_zero"`
 	Metadata  map[string]interface{} `pg:"metadata, type:jsonb"`
+
+	DeadLetter    bool       `pg:"dead_letter, type:boolean, use_zero"`
+	NextAttemptAt *time.Time `pg:"next_attempt_at, type:timestamp without time zone"`
+
+	// build requirements, only builder with matching capabilities can take the build
+	BuildType  string `pg:"build_type, type:varchar"`
+	ApiType    string `pg:"api_type, type:varchar"`
+	SourceSize int64  `pg:"source_size, type:bigint, use_zero"`
+
+	// build which triggered this one without a dependency, e.g. changelog recalculation created on publish
+	ParentBuildId string `pg:"parent_build_id, type:varchar"`
 }
 
 type BuildSourceEntity struct {
@@ -59,6 +70,15 @@ type BuildDependencyEntity struct {
 	DependId string `pg:"depend_id, type:varchar"`
 }
 
+// BuildToCancelEntity is a not finished build which is cancelled together with the requested ones
+type BuildToCancelEntity struct {
+	BuildId   string `pg:"build_id, type:varchar"`
+	PackageId string `pg:"package_id, type:varchar"`
+	Version   string `pg:"version, type:varchar"`
+	// status of the version which is going to be published by the build, empty for builds which don't publish a version
+	VersionStatus string `pg:"version_status, type:varchar"`
+}
+
 type ChangelogBuildSearchQueryEntity struct {
 	PackageId                string         `pg:"package_id, type:varchar, use_zero"`
 	Version                  string         `pg:"version, type:varchar, use_zero"`
@@ -91,3 +111,32 @@ func MakeBuildView(buildEnt *BuildEntity) *view.BuildView {
 		RestartCount: buildEnt.RestartCount,
 	}
 }
+
+func MakeDeadLetterBuildView(ent BuildEntity) view.DeadLetterBuild {
+	return view.DeadLetterBuild{
+		BuildId:      ent.BuildId,
+		BuildType:    view.BuildType(ent.BuildType),
+		PackageId:    ent.PackageId,
+		Version:      ent.Version,
+		Details:      ent.Details,
+		CreatedAt:    ent.CreatedAt,
+		CreatedBy:    ent.CreatedBy,
+		LastActive:   ent.LastActive,
+		RestartCount: ent.RestartCount,
+		BuilderId:    ent.BuilderId,
+	}
+}
+
+func (b BuildEntity) GetRequirements() view.BuildRequirements {
+	return view.BuildRequirements{
+		BuildType:  view.BuildType(b.BuildType),
+		ApiType:    b.ApiType,
+		SourceSize: b.SourceSize,
+	}
+}
+
+func (b *BuildEntity) SetRequirements(requirements view.BuildRequirements) {
+	b.BuildType = string(requirements.BuildType)
+	b.ApiType = requirements.ApiType
+	b.SourceSize = requirements.SourceSize
+}
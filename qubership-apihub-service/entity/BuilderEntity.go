@@ -0,0 +1,77 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type BuilderEntity struct {
+	tableName struct{} `pg:"builder"`
+
+	BuilderId     string                   `pg:"builder_id, pk, type:varchar"`
+	Version       string                   `pg:"version, type:varchar"`
+	Capacity      int                      `pg:"capacity, type:integer, use_zero"`
+	Capabilities  view.BuilderCapabilities `pg:"capabilities, type:jsonb"`
+	RegisteredAt  time.Time                `pg:"registered_at, type:timestamp without time zone"`
+	LastHeartbeat time.Time                `pg:"last_heartbeat, type:timestamp without time zone"`
+}
+
+// BuilderReclaimRunEntity is used as a lock, so stale builds are reclaimed by one instance at a time
+type BuilderReclaimRunEntity struct {
+	tableName struct{} `pg:"builder_reclaim_run"`
+
+	RunId       int       `pg:"run_id, pk, type:integer"`
+	ScheduledAt time.Time `pg:"scheduled_at, type:timestamp without time zone"`
+}
+
+type BuilderStatsEntity struct {
+	BuilderId       string   `pg:"builder_id, type:varchar"`
+	CurrentBuilds   []string `pg:"current_builds, type:varchar[], array"`
+	CompletedBuilds int      `pg:"completed_builds, type:integer"`
+	FailedBuilds    int      `pg:"failed_builds, type:integer"`
+}
+
+func MakeBuilderView(ent BuilderEntity, stats *BuilderStatsEntity, heartbeatTimeout time.Duration) view.BuilderInstance {
+	status := view.BuilderStatusActive
+	if time.Since(ent.LastHeartbeat) > heartbeatTimeout {
+		status = view.BuilderStatusInactive
+	}
+	result := view.BuilderInstance{
+		BuilderId:     ent.BuilderId,
+		Version:       ent.Version,
+		Capacity:      ent.Capacity,
+		Capabilities:  ent.Capabilities,
+		Status:        status,
+		RegisteredAt:  ent.RegisteredAt,
+		LastHeartbeat: ent.LastHeartbeat,
+		CurrentBuilds: make([]string, 0),
+	}
+	if stats != nil {
+		if stats.CurrentBuilds != nil {
+			result.CurrentBuilds = stats.CurrentBuilds
+		}
+		result.Stats = view.BuilderStats{
+			CompletedBuilds: stats.CompletedBuilds,
+			FailedBuilds:    stats.FailedBuilds,
+		}
+		if finished := stats.CompletedBuilds + stats.FailedBuilds; finished > 0 {
+			result.Stats.ErrorRate = float64(stats.FailedBuilds) / float64(finished)
+		}
+	}
+	return result
+}
@@ -0,0 +1,31 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type DryRunResultEntity struct {
+	tableName struct{} `pg:"dry_run_result"`
+
+	BuildId   string                   `pg:"build_id, pk, type:varchar"`
+	PackageId string                   `pg:"package_id, type:varchar"`
+	Version   string                   `pg:"version, type:varchar"`
+	CreatedAt time.Time                `pg:"created_at, type:timestamp without time zone"`
+	Result    view.DryRunPublishResult `pg:"result, type:jsonb"`
+}
@@ -27,5 +27,4 @@ type ExportResultEntity struct {
 	CreatedAt time.Time        `pg:"created_at, type:timestamp without time zone"`
 	CreatedBy string           `pg:"created_by, type:varchar"`
 	Filename  string           `pg:"filename, type:varchar"`
-	Data      []byte           `pg:"data, type:bytea"`
 }
@@ -0,0 +1,86 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type PublishGatePolicyEntity struct {
+	tableName struct{} `pg:"publish_gate_policy"`
+
+	PackageId  string    `pg:"package_id, pk, type:varchar"`
+	Action     string    `pg:"action, type:varchar"`
+	Severities []string  `pg:"severities, type:varchar array, array"`
+	UpdatedBy  string    `pg:"updated_by, type:varchar"`
+	UpdatedAt  time.Time `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type PublishGatePolicyExtEntity struct {
+	tableName struct{} `pg:"publish_gate_policy, alias:publish_gate_policy"`
+
+	PublishGatePolicyEntity
+	PackageName string `pg:"package_name, type:varchar"`
+	PackageKind string `pg:"package_kind, type:varchar"`
+}
+
+type PublishGateRequestEntity struct {
+	tableName struct{} `pg:"publish_gate_request, alias:publish_gate_request"`
+
+	RequestId           string     `pg:"request_id, pk, type:varchar"`
+	PackageId           string     `pg:"package_id, type:varchar"`
+	Version             string     `pg:"version, type:varchar"`
+	BreakingChanges     int        `pg:"breaking_changes, type:integer, use_zero"`
+	SemiBreakingChanges int        `pg:"semi_breaking_changes, type:integer, use_zero"`
+	State               string     `pg:"state, type:varchar"`
+	CreatedBy           string     `pg:"created_by, type:varchar"`
+	CreatedAt           time.Time  `pg:"created_at, type:timestamp without time zone"`
+	ResolvedBy          string     `pg:"resolved_by, type:varchar"`
+	ResolvedAt          *time.Time `pg:"resolved_at, type:timestamp without time zone"`
+	ApprovedBuildId     string     `pg:"approved_build_id, type:varchar"`
+}
+
+func MakePublishGatePolicyView(ent PublishGatePolicyExtEntity, packageId string) *view.PublishGatePolicy {
+	severities := make([]view.Severity, 0, len(ent.Severities))
+	for _, s := range ent.Severities {
+		severities = append(severities, view.Severity(s))
+	}
+	return &view.PublishGatePolicy{
+		Action:      view.PublishGateAction(ent.Action),
+		Severities:  severities,
+		PackageId:   ent.PackageId,
+		PackageName: ent.PackageName,
+		PackageKind: ent.PackageKind,
+		Inherited:   ent.PackageId != packageId,
+	}
+}
+
+func MakePublishGateRequestView(ent PublishGateRequestEntity) view.PublishGateRequest {
+	return view.PublishGateRequest{
+		RequestId:           ent.RequestId,
+		PackageId:           ent.PackageId,
+		Version:             ent.Version,
+		BreakingChanges:     ent.BreakingChanges,
+		SemiBreakingChanges: ent.SemiBreakingChanges,
+		State:               view.PublishGateRequestState(ent.State),
+		CreatedBy:           ent.CreatedBy,
+		CreatedAt:           ent.CreatedAt,
+		ResolvedBy:          ent.ResolvedBy,
+		ResolvedAt:          ent.ResolvedAt,
+		ApprovedPublishId:   ent.ApprovedBuildId,
+	}
+}
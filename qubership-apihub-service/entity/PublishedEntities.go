@@ -327,7 +327,7 @@ func MakePublishedVersionView(versionEnt *PublishedVersionEntity, contentEnts []
 		contents = append(contents, *MakePublishedContentView(&ent))
 	}
 
-	status, _ := view.ParseVersionStatus(versionEnt.Status)
+	status := view.VersionStatus(versionEnt.Status)
 	var labels []string
 	if versionEnt.Labels != nil {
 		labels = versionEnt.Labels
@@ -392,7 +392,7 @@ func MakePublishedVersionHistoryView(ent PackageVersionHistoryEntity) view.Publi
 }
 
 func MakePublishedVersionListView(versionEnt *PublishedVersionEntity) *view.PublishedVersionListView_deprecated {
-	status, _ := view.ParseVersionStatus(versionEnt.Status)
+	status := view.VersionStatus(versionEnt.Status)
 	return &view.PublishedVersionListView_deprecated{
 		Version:                  versionEnt.Version,
 		Revision:                 versionEnt.Revision,
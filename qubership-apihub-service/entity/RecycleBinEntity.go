@@ -0,0 +1,81 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type RecycleBinItemEntity struct {
+	Kind        string    `pg:"kind, type:varchar"`
+	PackageId   string    `pg:"package_id, type:varchar"`
+	PackageName string    `pg:"package_name, type:varchar"`
+	PackageKind string    `pg:"package_kind, type:varchar"`
+	Version     string    `pg:"version, type:varchar"`
+	Revision    int       `pg:"revision, type:integer"`
+	Status      string    `pg:"status, type:varchar"`
+	DeletedAt   time.Time `pg:"deleted_at, type:timestamp without time zone"`
+	DeletedBy   string    `pg:"deleted_by, type:varchar"`
+}
+
+type RecycleBinRevisionEntity struct {
+	PackageId string `pg:"package_id, type:varchar"`
+	Version   string `pg:"version, type:varchar"`
+	Revision  int    `pg:"revision, type:integer"`
+}
+
+type RecycleBinRestoredEntity struct {
+	PackageIds []string
+	Revisions  []RecycleBinRevisionEntity
+}
+
+// RecycleBinPurgedEntity contains keys of the blobs which are not needed anymore after the purge
+type RecycleBinPurgedEntity struct {
+	BuildIds         []string
+	ArchiveChecksums []string
+}
+
+func MakeRecycleBinItemView(ent RecycleBinItemEntity, gracePeriodDays int) view.RecycleBinItem {
+	return view.RecycleBinItem{
+		Kind:        view.RecycleBinItemKind(ent.Kind),
+		PackageId:   ent.PackageId,
+		PackageName: ent.PackageName,
+		PackageKind: ent.PackageKind,
+		Version:     ent.Version,
+		Revision:    ent.Revision,
+		Status:      ent.Status,
+		DeletedAt:   ent.DeletedAt,
+		DeletedBy:   ent.DeletedBy,
+		PurgeAt:     ent.DeletedAt.AddDate(0, 0, gracePeriodDays),
+	}
+}
+
+func MakeRecycleBinRestoreResultView(ent RecycleBinRestoredEntity) *view.RecycleBinRestoreResult {
+	result := &view.RecycleBinRestoreResult{
+		Packages:  make([]string, 0, len(ent.PackageIds)),
+		Revisions: make([]view.RecycleBinRevisionRef, 0, len(ent.Revisions)),
+	}
+	result.Packages = append(result.Packages, ent.PackageIds...)
+	for _, rev := range ent.Revisions {
+		result.Revisions = append(result.Revisions, view.RecycleBinRevisionRef{
+			PackageId: rev.PackageId,
+			Version:   rev.Version,
+			Revision:  rev.Revision,
+		})
+	}
+	return result
+}
@@ -0,0 +1,46 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionDependentEntity struct {
+	PackageId      string    `pg:"package_id, type:varchar"`
+	Version        string    `pg:"version, type:varchar"`
+	Revision       int       `pg:"revision, type:integer"`
+	Status         string    `pg:"status, type:varchar"`
+	PublishedAt    time.Time `pg:"published_at, type:timestamp without time zone"`
+	PackageName    string    `pg:"package_name, type:varchar"`
+	PackageKind    string    `pg:"package_kind, type:varchar"`
+	Direct         bool      `pg:"direct, type:boolean"`
+	LatestRevision bool      `pg:"latest_revision, type:boolean"`
+}
+
+func MakeVersionDependentView(ent VersionDependentEntity) view.VersionDependent {
+	return view.VersionDependent{
+		PackageId:      ent.PackageId,
+		PackageName:    ent.PackageName,
+		Kind:           ent.PackageKind,
+		Version:        view.MakeVersionRefKey(ent.Version, ent.Revision),
+		Status:         ent.Status,
+		PublishedAt:    ent.PublishedAt,
+		Direct:         ent.Direct,
+		LatestRevision: ent.LatestRevision,
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type SavedSearchEntity struct {
+	tableName struct{} `pg:"saved_search, alias:saved_search"`
+
+	SavedSearchId string              `pg:"saved_search_id, pk, type:varchar"`
+	UserId        string              `pg:"user_id, type:varchar"`
+	Name          string              `pg:"name, type:varchar"`
+	Query         view.SearchQueryReq `pg:"query, type:jsonb"`
+	Subscribed    bool                `pg:"subscribed, type:boolean, use_zero"`
+	WebhookUrl    string              `pg:"webhook_url, type:varchar"`
+	CreatedAt     time.Time           `pg:"created_at, type:timestamp without time zone"`
+	UpdatedAt     *time.Time          `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type SavedSearchNotificationEntity struct {
+	tableName struct{} `pg:"saved_search_notification, alias:saved_search_notification"`
+
+	NotificationId string                             `pg:"notification_id, pk, type:varchar"`
+	SavedSearchId  string                             `pg:"saved_search_id, type:varchar"`
+	UserId         string                             `pg:"user_id, type:varchar"`
+	PackageId      string                             `pg:"package_id, type:varchar"`
+	Version        string                             `pg:"version, type:varchar"`
+	Revision       int                                `pg:"revision, type:integer"`
+	Operations     []view.SavedSearchMatchedOperation `pg:"operations, type:jsonb"`
+	Read           bool                               `pg:"read, type:boolean, use_zero"`
+	CreatedAt      time.Time                          `pg:"created_at, type:timestamp without time zone"`
+}
+
+type SavedSearchNotificationRichEntity struct {
+	tableName struct{} `pg:"saved_search_notification, alias:saved_search_notification"`
+
+	SavedSearchNotificationEntity
+	SavedSearchName string `pg:"saved_search_name, type:varchar"`
+}
+
+type SavedSearchMatchedOperationEntity struct {
+	OperationId string `pg:"operation_id, type:varchar"`
+	Title       string `pg:"title, type:varchar"`
+	ApiType     string `pg:"type, type:varchar"`
+}
+
+func MakeSavedSearchView(ent SavedSearchEntity) view.SavedSearch {
+	return view.SavedSearch{
+		SavedSearchId: ent.SavedSearchId,
+		Name:          ent.Name,
+		Query:         ent.Query,
+		Subscribed:    ent.Subscribed,
+		WebhookUrl:    ent.WebhookUrl,
+		CreatedAt:     ent.CreatedAt,
+		UpdatedAt:     ent.UpdatedAt,
+	}
+}
+
+func MakeSavedSearchNotificationView(ent SavedSearchNotificationEntity, savedSearchName string) view.SavedSearchNotification {
+	return view.SavedSearchNotification{
+		NotificationId:  ent.NotificationId,
+		SavedSearchId:   ent.SavedSearchId,
+		SavedSearchName: savedSearchName,
+		PackageId:       ent.PackageId,
+		Version:         view.MakeVersionRefKey(ent.Version, ent.Revision),
+		Operations:      ent.Operations,
+		Read:            ent.Read,
+		CreatedAt:       ent.CreatedAt,
+	}
+}
@@ -15,9 +15,11 @@
 package entity
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 )
 
@@ -46,6 +48,7 @@ type OperationSearchScopeFilter struct {
 	FilterProperties bool `pg:"filter_properties, type:boolean, use_zero"`
 	FilterProperty   bool `pg:"filter_property, type:boolean, use_zero"`
 	FilterArgument   bool `pg:"filter_argument, type:boolean, use_zero"`
+	FilterField      bool `pg:"filter_field, type:boolean, use_zero"`
 }
 
 type OperationSearchQuery struct {
@@ -53,7 +56,7 @@ type OperationSearchQuery struct {
 	OperationSearchWeight
 	VersionStatusSearchWeight
 	SearchString   string    `pg:"search_filter, type:varchar, use_zero"` //for postgres FTS
-	TextFilter     string    `pg:"text_filter, type:varchar, use_zero"`   //for varchar
+	TextFilter     string    `pg:"text_filter, type:varchar, use_zero"`   //ilike pattern
 	ApiType        string    `pg:"api_type, type:varchar, use_zero"`
 	Packages       []string  `pg:"packages, type:varchar[], use_zero"`
 	Versions       []string  `pg:"versions, type:varchar[], use_zero"`
@@ -65,8 +68,12 @@ type OperationSearchQuery struct {
 	Limit          int       `pg:"limit, type:integer, use_zero"`
 	Offset         int       `pg:"offset, type:integer, use_zero"`
 
-	RestApiType    string `pg:"rest_api_type, type:varchar, use_zero"`
-	GraphqlApiType string `pg:"graphql_api_type, type:varchar, use_zero"`
+	RestApiType     string `pg:"rest_api_type, type:varchar, use_zero"`
+	GraphqlApiType  string `pg:"graphql_api_type, type:varchar, use_zero"`
+	ProtobufApiType string `pg:"protobuf_api_type, type:varchar, use_zero"`
+
+	StructuredQuery bool                  `pg:"structured_query, type:boolean, use_zero"`
+	Query           *view.SearchQueryNode `pg:"-"`
 }
 
 // deprecated
@@ -126,19 +133,31 @@ func MakeOperationSearchQueryEntity(searchQuery *view.SearchQueryReq) (*Operatio
 	ftsSearchString = strings.TrimSpace(ftsSearchString) + ":*" //starts with
 
 	searchQueryEntity := &OperationSearchQuery{
-		SearchString:   ftsSearchString,
-		TextFilter:     searchQuery.SearchString,
-		Packages:       searchQuery.PackageIds,
-		Versions:       searchQuery.Versions,
-		Statuses:       searchQuery.Statuses,
-		StartDate:      searchQuery.PublicationDateInterval.StartDate,
-		EndDate:        searchQuery.PublicationDateInterval.EndDate,
-		Methods:        make([]string, 0),
-		OperationTypes: make([]string, 0),
-		Limit:          searchQuery.Limit,
-		Offset:         searchQuery.Limit * searchQuery.Page,
-		RestApiType:    string(view.RestApiType),
-		GraphqlApiType: string(view.GraphqlApiType),
+		SearchString:    ftsSearchString,
+		TextFilter:      "%" + utils.LikeEscaped(searchQuery.SearchString) + "%",
+		Packages:        searchQuery.PackageIds,
+		Versions:        searchQuery.Versions,
+		Statuses:        searchQuery.Statuses,
+		StartDate:       searchQuery.PublicationDateInterval.StartDate,
+		EndDate:         searchQuery.PublicationDateInterval.EndDate,
+		Methods:         make([]string, 0),
+		OperationTypes:  make([]string, 0),
+		Limit:           searchQuery.Limit,
+		Offset:          searchQuery.Limit * searchQuery.Page,
+		RestApiType:     string(view.RestApiType),
+		GraphqlApiType:  string(view.GraphqlApiType),
+		ProtobufApiType: string(view.ProtobufApiType),
+	}
+	if view.IsStructuredSearchQuery(searchQuery.SearchString) {
+		queryNode, err := view.ParseSearchQuery(searchQuery.SearchString)
+		if err != nil {
+			return nil, err
+		}
+		//structured query is applied as a filter, free text terms are only used to rank matching operations by title
+		searchQueryEntity.Query = queryNode
+		searchQueryEntity.StructuredQuery = true
+		searchQueryEntity.SearchString = ""
+		searchQueryEntity.TextFilter = makeFreeTextTermsPattern(queryNode.FreeTextTerms())
 	}
 	if searchQueryEntity.Packages == nil {
 		searchQueryEntity.Packages = make([]string, 0)
@@ -158,6 +177,15 @@ func MakeOperationSearchQueryEntity(searchQuery *view.SearchQueryReq) (*Operatio
 	return searchQueryEntity, nil
 }
 
+// makeFreeTextTermsPattern makes ilike pattern which matches the title containing all terms in the query order
+func makeFreeTextTermsPattern(terms []string) string {
+	escapedTerms := make([]string, 0, len(terms))
+	for _, term := range terms {
+		escapedTerms = append(escapedTerms, utils.LikeEscaped(term))
+	}
+	return "%" + strings.Join(escapedTerms, "%") + "%"
+}
+
 // depreacted
 func MakeOperationSearchResultView_deprecated(ent OperationSearchResult_deprecated) view.OperationSearchResult_deprecated {
 	operationSearchResult := view.OperationSearchResult_deprecated{
@@ -230,6 +258,11 @@ func MakeOperationSearchResultView(ent OperationSearchResult) interface{} {
 			CommonOperationSearchResult: operationSearchResult,
 			GraphQLOperationView:        MakeGraphQLOperationView(&ent.OperationEntity),
 		}
+	case string(view.ProtobufApiType):
+		return view.ProtobufOperationSearchResult{
+			CommonOperationSearchResult: operationSearchResult,
+			ProtobufOperationView:       MakeProtobufOperationView(&ent.OperationEntity),
+		}
 	}
 	return operationSearchResult
 }
@@ -443,3 +476,122 @@ func MakeDocumentSearchResultView(ent DocumentSearchResult, content string) *vie
 		},
 	}
 }
+
+type ModelSearchQuery struct {
+	ModelName string    `pg:"model_name, type:varchar, use_zero"` //lower case like pattern
+	ApiType   string    `pg:"api_type, type:varchar, use_zero"`
+	Packages  []string  `pg:"packages, type:varchar[], use_zero"`
+	Versions  []string  `pg:"versions, type:varchar[], use_zero"`
+	Statuses  []string  `pg:"statuses, type:varchar[], use_zero"`
+	StartDate time.Time `pg:"start_date, type:timestamp without time zone, use_zero"`
+	EndDate   time.Time `pg:"end_date, type:timestamp without time zone, use_zero"`
+	Limit     int       `pg:"limit, type:integer, use_zero"`  //number of package versions
+	Offset    int       `pg:"offset, type:integer, use_zero"` //number of package versions
+
+	PropertyPath []string `pg:"-"`
+}
+
+type ModelSearchResult struct {
+	tableName struct{} `pg:",discard_unknown_columns"`
+
+	PackageId     string            `pg:"package_id, type:varchar"`
+	PackageName   string            `pg:"name, type:varchar"`
+	ParentNames   []string          `pg:"parent_names, type:varchar[]"`
+	Version       string            `pg:"version, type:varchar"`
+	Revision      int               `pg:"revision, type:integer"`
+	VersionStatus string            `pg:"status, type:varchar"`
+	OperationId   string            `pg:"operation_id, type:varchar"`
+	Title         string            `pg:"title, type:varchar"`
+	ApiType       string            `pg:"type, type:varchar"`
+	DataHash      string            `pg:"data_hash, type:varchar"`
+	Models        map[string]string `pg:"models, type:jsonb"` //matched model names with model hashes
+}
+
+// MakeModelSearchQueryEntity splits search string into model name and property path, e.g. "Customer.address.zip"
+func MakeModelSearchQueryEntity(searchQuery *view.SearchQueryReq) (*ModelSearchQuery, error) {
+	searchPath := strings.Split(strings.TrimSpace(searchQuery.SearchString), ".")
+	for _, pathItem := range searchPath {
+		if strings.TrimSpace(pathItem) == "" {
+			return nil, fmt.Errorf("search string '%v' is not a valid model name or property path", searchQuery.SearchString)
+		}
+	}
+	if strings.Trim(searchPath[0], "*") == "" {
+		return nil, fmt.Errorf("model name '%v' shall contain at least one character except '*'", searchPath[0])
+	}
+	searchQueryEntity := &ModelSearchQuery{
+		ModelName:    strings.ReplaceAll(utils.LikeEscaped(strings.ToLower(searchPath[0])), "*", "%"),
+		Packages:     searchQuery.PackageIds,
+		Versions:     searchQuery.Versions,
+		Statuses:     searchQuery.Statuses,
+		StartDate:    searchQuery.PublicationDateInterval.StartDate,
+		EndDate:      searchQuery.PublicationDateInterval.EndDate,
+		Limit:        searchQuery.Limit,
+		Offset:       searchQuery.Limit * searchQuery.Page,
+		PropertyPath: searchPath[1:],
+	}
+	if searchQuery.OperationSearchParams != nil {
+		searchQueryEntity.ApiType = searchQuery.OperationSearchParams.ApiType
+	}
+	if searchQueryEntity.Packages == nil {
+		searchQueryEntity.Packages = make([]string, 0)
+	}
+	if searchQueryEntity.Versions == nil {
+		searchQueryEntity.Versions = make([]string, 0)
+	}
+	if searchQueryEntity.Statuses == nil {
+		searchQueryEntity.Statuses = make([]string, 0)
+	}
+	if searchQueryEntity.StartDate.IsZero() {
+		searchQueryEntity.StartDate = time.Unix(0, 0) //January 1, 1970
+	}
+	if searchQueryEntity.EndDate.IsZero() {
+		searchQueryEntity.EndDate = time.Unix(2556057600, 0) //December 31, 2050
+	}
+	return searchQueryEntity, nil
+}
+
+type SearchFacetEntity struct {
+	Facet string `pg:"facet, type:varchar"`
+	Value string `pg:"value, type:varchar"`
+	Name  string `pg:"name, type:varchar"`
+	Count int    `pg:"count, type:integer"`
+}
+
+func MakeSearchFacetsView(ents []SearchFacetEntity) *view.SearchFacets {
+	facets := &view.SearchFacets{
+		Packages:          make([]view.SearchFacetValue, 0),
+		ParentGroups:      make([]view.SearchFacetValue, 0),
+		Statuses:          make([]view.SearchFacetValue, 0),
+		ApiTypes:          make([]view.SearchFacetValue, 0),
+		Methods:           make([]view.SearchFacetValue, 0),
+		ApiKinds:          make([]view.SearchFacetValue, 0),
+		Tags:              make([]view.SearchFacetValue, 0),
+		PublicationMonths: make([]view.SearchFacetValue, 0),
+	}
+	for _, ent := range ents {
+		facetValue := view.SearchFacetValue{Value: ent.Value, Name: ent.Name, Count: ent.Count}
+		switch ent.Facet {
+		case "total":
+			facets.TotalCount = ent.Count
+		case "packagesCount":
+			facets.PackagesCount = ent.Count
+		case "package":
+			facets.Packages = append(facets.Packages, facetValue)
+		case "parentGroup":
+			facets.ParentGroups = append(facets.ParentGroups, facetValue)
+		case "status":
+			facets.Statuses = append(facets.Statuses, facetValue)
+		case "apiType":
+			facets.ApiTypes = append(facets.ApiTypes, facetValue)
+		case "method":
+			facets.Methods = append(facets.Methods, facetValue)
+		case "apiKind":
+			facets.ApiKinds = append(facets.ApiKinds, facetValue)
+		case "tag":
+			facets.Tags = append(facets.Tags, facetValue)
+		case "publicationMonth":
+			facets.PublicationMonths = append(facets.PublicationMonths, facetValue)
+		}
+	}
+	return facets
+}
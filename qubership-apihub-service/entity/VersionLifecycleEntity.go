@@ -0,0 +1,92 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionStatusEntity struct {
+	tableName struct{} `pg:"version_status"`
+
+	Status      string    `pg:"status, pk, type:varchar"`
+	Description string    `pg:"description, type:varchar"`
+	Permission  string    `pg:"permission, type:varchar"`
+	Initial     bool      `pg:"initial, type:boolean, use_zero"`
+	SortOrder   int       `pg:"sort_order, type:integer, use_zero"`
+	UpdatedBy   string    `pg:"updated_by, type:varchar"`
+	UpdatedAt   time.Time `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type VersionStatusTransitionEntity struct {
+	tableName struct{} `pg:"version_status_transition"`
+
+	FromStatus string `pg:"from_status, pk, type:varchar"`
+	ToStatus   string `pg:"to_status, pk, type:varchar"`
+	Permission string `pg:"permission, type:varchar"`
+}
+
+func MakeVersionLifecycleView(statusEnts []VersionStatusEntity, transitionEnts []VersionStatusTransitionEntity) *view.VersionLifecycle {
+	lifecycle := &view.VersionLifecycle{
+		Statuses:    make([]view.VersionLifecycleStatus, 0, len(statusEnts)),
+		Transitions: make([]view.VersionLifecycleTransition, 0, len(transitionEnts)),
+	}
+	for _, ent := range statusEnts {
+		lifecycle.Statuses = append(lifecycle.Statuses, view.VersionLifecycleStatus{
+			Status:      ent.Status,
+			Description: ent.Description,
+			Permission:  view.RolePermission(ent.Permission),
+			Initial:     ent.Initial,
+		})
+	}
+	for _, ent := range transitionEnts {
+		lifecycle.Transitions = append(lifecycle.Transitions, view.VersionLifecycleTransition{
+			From:       ent.FromStatus,
+			To:         ent.ToStatus,
+			Permission: view.RolePermission(ent.Permission),
+		})
+	}
+	return lifecycle
+}
+
+func MakeVersionStatusEntities(lifecycle view.VersionLifecycle, userId string, updatedAt time.Time) []VersionStatusEntity {
+	ents := make([]VersionStatusEntity, 0, len(lifecycle.Statuses))
+	for i, status := range lifecycle.Statuses {
+		ents = append(ents, VersionStatusEntity{
+			Status:      status.Status,
+			Description: status.Description,
+			Permission:  string(status.Permission),
+			Initial:     status.Initial,
+			SortOrder:   i,
+			UpdatedBy:   userId,
+			UpdatedAt:   updatedAt,
+		})
+	}
+	return ents
+}
+
+func MakeVersionStatusTransitionEntities(lifecycle view.VersionLifecycle) []VersionStatusTransitionEntity {
+	ents := make([]VersionStatusTransitionEntity, 0, len(lifecycle.Transitions))
+	for _, transition := range lifecycle.Transitions {
+		ents = append(ents, VersionStatusTransitionEntity{
+			FromStatus: transition.From,
+			ToStatus:   transition.To,
+			Permission: string(transition.Permission),
+		})
+	}
+	return ents
+}
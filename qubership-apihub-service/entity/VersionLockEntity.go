@@ -0,0 +1,58 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionLockEntity struct {
+	tableName struct{} `pg:"version_lock"`
+
+	PackageId string    `pg:"package_id, pk, type:varchar"`
+	Version   string    `pg:"version, pk, type:varchar"`
+	Locked    bool      `pg:"locked, type:boolean, use_zero"`
+	UpdatedBy string    `pg:"updated_by, type:varchar"`
+	UpdatedAt time.Time `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type VersionLockPolicyEntity struct {
+	tableName struct{} `pg:"version_lock_policy"`
+
+	PackageId string    `pg:"package_id, pk, type:varchar"`
+	Statuses  []string  `pg:"statuses, type:varchar array, array"`
+	UpdatedBy string    `pg:"updated_by, type:varchar"`
+	UpdatedAt time.Time `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type VersionLockPolicyExtEntity struct {
+	tableName struct{} `pg:"version_lock_policy, alias:version_lock_policy"`
+
+	VersionLockPolicyEntity
+	PackageName string `pg:"package_name, type:varchar"`
+	PackageKind string `pg:"package_kind, type:varchar"`
+}
+
+func MakeVersionLockPolicyView(ent VersionLockPolicyExtEntity, packageId string) *view.VersionLockPolicy {
+	return &view.VersionLockPolicy{
+		Statuses:    ent.Statuses,
+		PackageId:   ent.PackageId,
+		PackageName: ent.PackageName,
+		PackageKind: ent.PackageKind,
+		Inherited:   ent.PackageId != packageId,
+	}
+}
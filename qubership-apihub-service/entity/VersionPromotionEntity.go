@@ -0,0 +1,143 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionPromotionPolicyEntity struct {
+	tableName struct{} `pg:"version_promotion_policy"`
+
+	PackageId         string    `pg:"package_id, pk, type:varchar"`
+	RequiredApprovals int       `pg:"required_approvals, type:integer, use_zero"`
+	ApproverRoles     []string  `pg:"approver_roles, type:varchar array, array"`
+	Statuses          []string  `pg:"statuses, type:varchar array, array"`
+	UpdatedBy         string    `pg:"updated_by, type:varchar"`
+	UpdatedAt         time.Time `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type VersionPromotionPolicyExtEntity struct {
+	tableName struct{} `pg:"version_promotion_policy, alias:version_promotion_policy"`
+
+	VersionPromotionPolicyEntity
+	PackageName string `pg:"package_name, type:varchar"`
+	PackageKind string `pg:"package_kind, type:varchar"`
+}
+
+type VersionPromotionRequestEntity struct {
+	tableName struct{} `pg:"version_promotion_request, alias:version_promotion_request"`
+
+	RequestId         string     `pg:"request_id, pk, type:varchar"`
+	PackageId         string     `pg:"package_id, type:varchar"`
+	Version           string     `pg:"version, type:varchar"`
+	FromStatus        string     `pg:"from_status, type:varchar"`
+	ToStatus          string     `pg:"to_status, type:varchar"`
+	State             string     `pg:"state, type:varchar"`
+	RequiredApprovals int        `pg:"required_approvals, type:integer, use_zero"`
+	ApproverRoles     []string   `pg:"approver_roles, type:varchar array, array"`
+	CreatedBy         string     `pg:"created_by, type:varchar"`
+	CreatedAt         time.Time  `pg:"created_at, type:timestamp without time zone"`
+	ResolvedBy        string     `pg:"resolved_by, type:varchar"`
+	ResolvedAt        *time.Time `pg:"resolved_at, type:timestamp without time zone"`
+}
+
+type VersionPromotionApprovalEntity struct {
+	tableName struct{} `pg:"version_promotion_approval"`
+
+	RequestId string    `pg:"request_id, pk, type:varchar"`
+	UserId    string    `pg:"user_id, pk, type:varchar"`
+	Decision  string    `pg:"decision, type:varchar"`
+	Comment   string    `pg:"comment, type:varchar"`
+	CreatedAt time.Time `pg:"created_at, type:timestamp without time zone"`
+}
+
+type VersionPromotionNotificationEntity struct {
+	tableName struct{} `pg:"version_promotion_notification, alias:version_promotion_notification"`
+
+	NotificationId string    `pg:"notification_id, pk, type:varchar"`
+	RequestId      string    `pg:"request_id, type:varchar"`
+	UserId         string    `pg:"user_id, type:varchar"`
+	Read           bool      `pg:"read, type:boolean, use_zero"`
+	CreatedAt      time.Time `pg:"created_at, type:timestamp without time zone"`
+}
+
+type VersionPromotionNotificationRichEntity struct {
+	tableName struct{} `pg:"version_promotion_notification, alias:version_promotion_notification"`
+
+	VersionPromotionNotificationEntity
+	PackageId  string `pg:"package_id, type:varchar"`
+	Version    string `pg:"version, type:varchar"`
+	FromStatus string `pg:"from_status, type:varchar"`
+	ToStatus   string `pg:"to_status, type:varchar"`
+	State      string `pg:"state, type:varchar"`
+	CreatedBy  string `pg:"created_by, type:varchar"`
+}
+
+func MakeVersionPromotionPolicyView(ent VersionPromotionPolicyExtEntity, packageId string) *view.VersionPromotionPolicy {
+	return &view.VersionPromotionPolicy{
+		RequiredApprovals: ent.RequiredApprovals,
+		ApproverRoles:     ent.ApproverRoles,
+		Statuses:          ent.Statuses,
+		PackageId:         ent.PackageId,
+		PackageName:       ent.PackageName,
+		PackageKind:       ent.PackageKind,
+		Inherited:         ent.PackageId != packageId,
+	}
+}
+
+func MakeVersionPromotionRequestView(ent VersionPromotionRequestEntity, approvalEnts []VersionPromotionApprovalEntity) view.VersionPromotionRequest {
+	approvals := make([]view.VersionPromotionApproval, 0, len(approvalEnts))
+	for _, approvalEnt := range approvalEnts {
+		approvals = append(approvals, view.VersionPromotionApproval{
+			UserId:    approvalEnt.UserId,
+			Decision:  view.VersionPromotionDecision(approvalEnt.Decision),
+			Comment:   approvalEnt.Comment,
+			CreatedAt: approvalEnt.CreatedAt,
+		})
+	}
+	return view.VersionPromotionRequest{
+		RequestId:         ent.RequestId,
+		PackageId:         ent.PackageId,
+		Version:           ent.Version,
+		FromStatus:        ent.FromStatus,
+		ToStatus:          ent.ToStatus,
+		State:             view.VersionPromotionRequestState(ent.State),
+		RequiredApprovals: ent.RequiredApprovals,
+		ApproverRoles:     ent.ApproverRoles,
+		Approvals:         approvals,
+		CreatedBy:         ent.CreatedBy,
+		CreatedAt:         ent.CreatedAt,
+		ResolvedBy:        ent.ResolvedBy,
+		ResolvedAt:        ent.ResolvedAt,
+	}
+}
+
+func MakeVersionPromotionNotificationView(ent VersionPromotionNotificationRichEntity) view.VersionPromotionNotification {
+	return view.VersionPromotionNotification{
+		NotificationId: ent.NotificationId,
+		RequestId:      ent.RequestId,
+		PackageId:      ent.PackageId,
+		Version:        ent.Version,
+		FromStatus:     ent.FromStatus,
+		ToStatus:       ent.ToStatus,
+		State:          view.VersionPromotionRequestState(ent.State),
+		RequestedBy:    ent.CreatedBy,
+		Read:           ent.Read,
+		CreatedAt:      ent.CreatedAt,
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionRetentionPolicyEntity struct {
+	tableName struct{} `pg:"version_retention_policy"`
+
+	PackageId string                      `pg:"package_id, pk, type:varchar"`
+	Rules     []view.VersionRetentionRule `pg:"rules, type:jsonb"`
+	UpdatedBy string                      `pg:"updated_by, type:varchar"`
+	UpdatedAt time.Time                   `pg:"updated_at, type:timestamp without time zone"`
+}
+
+type VersionRetentionPolicyExtEntity struct {
+	tableName struct{} `pg:"version_retention_policy, alias:version_retention_policy"`
+
+	VersionRetentionPolicyEntity
+	PackageName string `pg:"package_name, type:varchar"`
+	PackageKind string `pg:"package_kind, type:varchar"`
+}
+
+type VersionRetentionRunEntity struct {
+	tableName struct{} `pg:"version_retention_run"`
+
+	RunId        int        `pg:"run_id, pk, type:integer"`
+	ScheduledAt  time.Time  `pg:"scheduled_at, type:timestamp without time zone"`
+	FinishedAt   *time.Time `pg:"finished_at, type:timestamp without time zone"`
+	TriggeredBy  string     `pg:"triggered_by, type:varchar"`
+	Status       string     `pg:"status, type:varchar"`
+	Details      string     `pg:"details, type:varchar"`
+	DeletedItems int        `pg:"deleted_items, type:integer, use_zero"`
+}
+
+type VersionRetentionDeletedVersionEntity struct {
+	tableName struct{} `pg:"version_retention_deleted_version"`
+
+	RunId           int       `pg:"run_id, pk, type:integer"`
+	PackageId       string    `pg:"package_id, pk, type:varchar"`
+	Version         string    `pg:"version, pk, type:varchar"`
+	Status          string    `pg:"status, type:varchar"`
+	PublishedAt     time.Time `pg:"published_at, type:timestamp without time zone"`
+	PolicyPackageId string    `pg:"policy_package_id, type:varchar"`
+	Reason          string    `pg:"reason, type:varchar"`
+	DeletedAt       time.Time `pg:"deleted_at, type:timestamp without time zone"`
+}
+
+func MakeVersionRetentionPolicyView(ent VersionRetentionPolicyExtEntity, packageId string) *view.VersionRetentionPolicy {
+	return &view.VersionRetentionPolicy{
+		Rules:       ent.Rules,
+		PackageId:   ent.PackageId,
+		PackageName: ent.PackageName,
+		PackageKind: ent.PackageKind,
+		Inherited:   ent.PackageId != packageId,
+	}
+}
+
+func MakeVersionRetentionRunView(ent VersionRetentionRunEntity) view.VersionRetentionRun {
+	return view.VersionRetentionRun{
+		RunId:        ent.RunId,
+		ScheduledAt:  ent.ScheduledAt,
+		FinishedAt:   ent.FinishedAt,
+		TriggeredBy:  ent.TriggeredBy,
+		Status:       ent.Status,
+		Details:      ent.Details,
+		DeletedItems: ent.DeletedItems,
+	}
+}
+
+func MakeVersionRetentionDeletedVersionView(ent VersionRetentionDeletedVersionEntity) view.VersionRetentionDeletedVersion {
+	return view.VersionRetentionDeletedVersion{
+		VersionRetentionCandidate: view.VersionRetentionCandidate{
+			PackageId:       ent.PackageId,
+			Version:         ent.Version,
+			Status:          ent.Status,
+			PublishedAt:     ent.PublishedAt,
+			PolicyPackageId: ent.PolicyPackageId,
+			Reason:          view.VersionRetentionReason(ent.Reason),
+		},
+		RunId:     ent.RunId,
+		DeletedAt: ent.DeletedAt,
+	}
+}
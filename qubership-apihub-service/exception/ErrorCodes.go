@@ -530,12 +530,27 @@ const BuildNotFoundMsg = "Build '$buildId' doesn't exist"
 const BuildAlreadyFinished = "4302"
 const BuildAlreadyFinishedMsg = "Build '$buildId' already finished"
 
+const BuildCancelled = "4303"
+const BuildCancelledMsg = "Build '$buildId' was cancelled"
+
+const BuildNotInDeadLetter = "4304"
+const BuildNotInDeadLetterMsg = "Build '$buildId' is not in dead letter state"
+
+const BuilderNotFound = "4305"
+const BuilderNotFoundMsg = "Builder '$builderId' not found"
+
+const DryRunResultNotFound = "4306"
+const DryRunResultNotFoundMsg = "Dry run result for publish '$publishId' not found"
+
 const ForbiddenDefaultMigrationBuildParameters = "4401"
 const ForbiddenDefaultMigrationBuildParametersMsg = "Config contains forbidden migration build parameters - '$parameters'"
 
 const ChangesAreNotEmpty = "4402"
 const ChangesAreNotEmptyMsg = "Changes are not empty when noChangelog is true"
 
+const DryRunNotSupportedForBuildType = "4403"
+const DryRunNotSupportedForBuildTypeMsg = "Dry run is not supported for '$buildType' buildType"
+
 const AgentNotFound = "4500"
 const AgentNotFoundMsg = "Agent '$agentId' not found"
 
@@ -764,3 +779,159 @@ const HeadersLimitExceededMsg = "HTTP headers limit exceeded. Maximum allowed nu
 
 const HeaderValuesLimitExceeded = "7402"
 const HeaderValuesLimitExceededMsg = "HTTP header values limit exceeded for key '$key'. Maximum allowed number of values is $maxValues"
+
+const InvalidPublishGateAction = "7500"
+const InvalidPublishGateActionMsg = "Publish gate action '$action' is invalid"
+
+const InvalidPublishGateSeverity = "7501"
+const InvalidPublishGateSeverityMsg = "Publish gate severity '$severity' is invalid. Allowed values: breaking, semi-breaking"
+
+const PublishGateRejected = "7502"
+const PublishGateRejectedMsg = "Release version cannot be published: changelog contains $breaking breaking and $semiBreaking semi-breaking changes (publish gate policy of '$policyPackageId')"
+
+const PublishGateOverrideRequired = "7503"
+const PublishGateOverrideRequiredMsg = "Changelog contains $breaking breaking and $semiBreaking semi-breaking changes. Set 'allowBreakingChanges' in the publish config to publish the release version (publish gate policy of '$policyPackageId')"
+
+const PublishGateApprovalRequired = "7504"
+const PublishGateApprovalRequiredMsg = "Changelog contains $breaking breaking and $semiBreaking semi-breaking changes. Release version publication requires approval, approval request '$requestId' is created (publish gate policy of '$policyPackageId')"
+
+const PublishGateRequestNotFound = "7505"
+const PublishGateRequestNotFoundMsg = "Publish gate request '$requestId' not found"
+
+const PublishGateRequestAlreadyResolved = "7506"
+const PublishGateRequestAlreadyResolvedMsg = "Publish gate request '$requestId' is already $state"
+
+const UnknownBlobStorageType = "7600"
+const UnknownBlobStorageTypeMsg = "Blob storage type '$type' is unknown. Allowed values: postgres, filesystem, s3"
+
+const UnknownBlobKind = "7601"
+const UnknownBlobKindMsg = "Blob kind '$kind' is unknown. Allowed values: build_result, published_sources_archives, export_result"
+
+const SameBlobStorageMigration = "7602"
+const SameBlobStorageMigrationMsg = "Source and target blob storages must be different"
+
+const BlobStorageMigrationNotFound = "7603"
+const BlobStorageMigrationNotFoundMsg = "Blob storage migration with id $migrationId not found"
+
+const BlobStorageMigrationInProgress = "7604"
+const BlobStorageMigrationInProgressMsg = "Another blob storage migration is already running"
+
+const UnknownVersionStatus = "7700"
+const UnknownVersionStatusMsg = "Version status '$status' is not defined in the version lifecycle"
+
+const VersionStatusTransitionNotAllowed = "7701"
+const VersionStatusTransitionNotAllowedMsg = "Version status transition from '$from' to '$to' is not allowed"
+
+const VersionStatusNotInitial = "7702"
+const VersionStatusNotInitialMsg = "New version could not be published in status '$status'"
+
+const InvalidVersionLifecycle = "7703"
+const InvalidVersionLifecycleMsg = "Version lifecycle is invalid: $details"
+
+const VersionStatusInUse = "7704"
+const VersionStatusInUseMsg = "Version status '$status' could not be removed since there are versions in this status"
+
+const VersionPromotionRequestNotFound = "7800"
+const VersionPromotionRequestNotFoundMsg = "Version promotion request '$requestId' not found"
+
+const VersionPromotionRequestAlreadyResolved = "7801"
+const VersionPromotionRequestAlreadyResolvedMsg = "Version promotion request '$requestId' is already $state"
+
+const VersionPromotionRequestAlreadyExists = "7802"
+const VersionPromotionRequestAlreadyExistsMsg = "Version '$version' already has pending promotion request '$requestId'"
+
+const NotVersionPromotionApprover = "7803"
+const NotVersionPromotionApproverMsg = "Only users with one of the roles $roles could approve or reject the version promotion request"
+
+const VersionPromotionSelfApproval = "7804"
+const VersionPromotionSelfApprovalMsg = "Version promotion request could not be approved by its author"
+
+const VersionPromotionAlreadyDecided = "7805"
+const VersionPromotionAlreadyDecidedMsg = "User has already approved or rejected version promotion request '$requestId'"
+
+const VersionPromotionRequestOutdated = "7806"
+const VersionPromotionRequestOutdatedMsg = "Version promotion request '$requestId' is cancelled since the version status was changed to '$status'"
+
+const VersionPromotionApprovalRequired = "7807"
+const VersionPromotionApprovalRequiredMsg = "Changing status of version '$version' to '$status' requires approval, patch the version status to create a promotion request"
+
+const InvalidVersionPromotionPolicy = "7808"
+const InvalidVersionPromotionPolicyMsg = "Version promotion policy is invalid: $details"
+
+const NewVersionPromotionApprovalRequired = "7809"
+const NewVersionPromotionApprovalRequiredMsg = "Publishing new version '$version' in status '$status' requires approval, publish it in another status and patch the version status to create a promotion request"
+
+const VersionPromotionNotificationNotFound = "7810"
+const VersionPromotionNotificationNotFoundMsg = "Version promotion notification '$notificationId' not found"
+
+const InvalidVersionMode = "7900"
+const InvalidVersionModeMsg = "Version mode '$mode' is not supported for build type '$buildType'"
+
+const VersionNotAllowedInAutoMode = "7901"
+const VersionNotAllowedInAutoModeMsg = "Version '$version' could not be set with 'auto' version mode, it is inferred from the changelog"
+
+const NotSemanticVersion = "7902"
+const NotSemanticVersionMsg = "Version '$version' is not a semantic version (major.minor.patch)"
+
+const InferredVersionAlreadyExists = "7903"
+const InferredVersionAlreadyExistsMsg = "Inferred version '$version' already exists in package '$packageId'"
+
+const InferredVersionReserved = "7904"
+const InferredVersionReservedMsg = "Inferred version '$version' of package '$packageId' is being published by another build"
+
+const InvalidVersionRetentionPolicy = "8000"
+const InvalidVersionRetentionPolicyMsg = "Version retention policy is invalid: $details"
+
+const VersionRetentionRunInProgress = "8001"
+const VersionRetentionRunInProgressMsg = "Version retention cleanup is already running"
+
+const VersionRetentionRunNotFound = "8002"
+const VersionRetentionRunNotFoundMsg = "Version retention cleanup run '$runId' not found"
+
+const RecycleBinItemNotFound = "8100"
+const RecycleBinItemNotFoundMsg = "$item is not found in the recycle bin"
+
+const RecycleBinParentPackageDeleted = "8101"
+const RecycleBinParentPackageDeletedMsg = "Package '$packageId' is deleted, restore it first"
+
+const RecycleBinVersionNotDeleted = "8102"
+const RecycleBinVersionNotDeletedMsg = "Version '$version' of package '$packageId' has not deleted revisions, restore particular revisions instead"
+
+const RecycleBinDeletedReferences = "8103"
+const RecycleBinDeletedReferencesMsg = "Restored versions reference deleted versions: $references. Use restoreReferences=true to restore them as well"
+
+const SameRevisionComparison = "8200"
+const SameRevisionComparisonMsg = "Revision $revision of version '$version' cannot be compared with itself"
+
+const RevisionComparisonNotCalculated = "8201"
+const RevisionComparisonNotCalculatedMsg = "Comparison of revisions $revision and $previousRevision of version '$version' is not calculated yet"
+
+const VersionLocked = "8300"
+const VersionLockedMsg = "Version '$version' of package '$packageId' is locked, only sysadmin could unlock it"
+
+const VersionLockPolicyWeakened = "8301"
+const VersionLockPolicyWeakenedMsg = "Only sysadmin could remove statuses from the version lock policy"
+
+const InvalidBulkVersionOperation = "8400"
+const InvalidBulkVersionOperationMsg = "Bulk version operation is invalid: $details"
+
+const BulkVersionOperationNotFound = "8401"
+const BulkVersionOperationNotFoundMsg = "Bulk version operation '$operationId' not found"
+
+const SavedSearchNotFound = "8500"
+const SavedSearchNotFoundMsg = "Saved search '$savedSearchId' not found"
+
+const SavedSearchNameIsUsed = "8501"
+const SavedSearchNameIsUsedMsg = "Saved search with name '$name' already exists"
+
+const InvalidSavedSearchWebhookUrl = "8502"
+const InvalidSavedSearchWebhookUrlMsg = "Webhook url '$url' is invalid, only http and https urls are supported"
+
+const SavedSearchWebhookUrlNotAllowed = "8505"
+const SavedSearchWebhookUrlNotAllowedMsg = "Webhook url '$url' is not allowed: $reason"
+
+const SavedSearchLimitExceeded = "8503"
+const SavedSearchLimitExceededMsg = "Saved searches limit exceeded, user could have up to $limit saved searches"
+
+const SavedSearchNotificationNotFound = "8504"
+const SavedSearchNotificationNotFoundMsg = "Saved search notification '$notificationId' not found"
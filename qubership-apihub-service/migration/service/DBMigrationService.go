@@ -48,7 +48,7 @@ type DBMigrationService interface {
 
 func NewDBMigrationService(cp db.ConnectionProvider, mRRepo mRepository.MigrationRunRepository,
 	bCRepo repository.BuildCleanupRepository, transitionRepository repository.TransitionRepository,
-	systemInfoService service.SystemInfoService, minioStorageService service.MinioStorageService) (DBMigrationService, error) {
+	systemInfoService service.SystemInfoService, blobStorage service.BlobStorage) (DBMigrationService, error) {
 	service := &dbMigrationServiceImpl{
 		cp:                     cp,
 		systemInfoService:      systemInfoService,
@@ -56,7 +56,7 @@ func NewDBMigrationService(cp db.ConnectionProvider, mRRepo mRepository.Migratio
 		buildCleanupRepository: bCRepo,
 		transitionRepository:   transitionRepository,
 		migrationsFolder:       filepath.Join(systemInfoService.GetBasePath(), "resources", "migrations"),
-		minioStorageService:    minioStorageService,
+		blobStorage:            blobStorage,
 	}
 	upMigrations, downMigrations, err := service.getMigrationFilenamesMap()
 	if err != nil {
@@ -76,7 +76,7 @@ type dbMigrationServiceImpl struct {
 	migrationsFolder       string
 	upMigrations           map[int]string
 	downMigrations         map[int]string
-	minioStorageService    service.MinioStorageService
+	blobStorage            service.BlobStorage
 }
 
 const storedMigrationsTableMigrationVersion = 1 // migration table will be created at first migration
@@ -114,13 +114,13 @@ func (d dbMigrationServiceImpl) MigrateOperations(migrationId string, req mView.
 			// this action will generate a lot of data and may cause DB disk overflow
 			// Try to avoid too much space usage by cleaning up all old migration build data
 			log.Infof("Starting cleanup before full migration")
-			if d.systemInfoService.IsMinioStorageActive() {
+			if d.blobStorage.Type() != view.BlobStoragePostgres {
 				ctx := context.Background()
 				ids, err := d.buildCleanupRepository.GetRemoveMigrationBuildIds()
 				if err != nil {
 					return err
 				}
-				err = d.minioStorageService.RemoveFiles(ctx, view.BUILD_RESULT_TABLE, ids)
+				err = d.blobStorage.Delete(ctx, view.BUILD_RESULT_TABLE, ids)
 				if err != nil {
 					return err
 				}
@@ -414,7 +414,7 @@ MigrationProcess:
 						}
 						continue
 					}
-					if buildEnt.Status == string(view.StatusError) {
+					if buildEnt.Status == string(view.StatusError) || buildEnt.Status == string(view.StatusCancelled) {
 						if buildEnt.Details == CancelledMigrationError {
 							migrationCancelled = true
 							break MigrationProcess
@@ -556,7 +556,7 @@ MigrationProcess:
 					}
 					continue
 				}
-				if buildEnt.Status == string(view.StatusError) {
+				if buildEnt.Status == string(view.StatusError) || buildEnt.Status == string(view.StatusCancelled) {
 					if buildEnt.Details == CancelledMigrationError {
 						migrationCancelled = true
 						break MigrationProcess
@@ -754,7 +754,7 @@ MigrationProcess:
 				}
 				continue
 			}
-			if buildEnt.Status == string(view.StatusError) {
+			if buildEnt.Status == string(view.StatusError) || buildEnt.Status == string(view.StatusCancelled) {
 				if buildEnt.Details == CancelledMigrationError {
 					migrationCancelled = true
 					break MigrationProcess
@@ -859,6 +859,38 @@ func (d dbMigrationServiceImpl) rebuildTextSearchTables(migrationId string) erro
 		return fmt.Errorf("failed to calculate ts_grahpql_operation_data: %w", err)
 	}
 
+	log.Info("Calculating ts_protobuf_operation_data")
+	calculateProtobufTextSearchDataQuery := fmt.Sprintf(`
+	insert into ts_protobuf_operation_data
+		select data_hash,
+		to_tsvector(jsonb_extract_path_text(search_scope, ?)) scope_request,
+		to_tsvector(jsonb_extract_path_text(search_scope, ?)) scope_response,
+		to_tsvector(jsonb_extract_path_text(search_scope, ?)) scope_annotation,
+		to_tsvector(jsonb_extract_path_text(search_scope, ?)) scope_field
+		from operation_data
+		where data_hash in (
+			select distinct o.data_hash
+			from operation o
+			inner join migration."expired_ts_operation_data_%s"  exp
+			on exp.package_id = o.package_id
+			and exp.version = o.version
+			and exp.revision = o.revision
+			where o.type = ?
+		)
+        order by 1
+        for update skip locked
+	on conflict (data_hash) do update
+	set scope_request = EXCLUDED.scope_request,
+	scope_response = EXCLUDED.scope_response,
+	scope_annotation = EXCLUDED.scope_annotation,
+	scope_field = EXCLUDED.scope_field;`, migrationId)
+	_, err = d.cp.GetConnection().Exec(calculateProtobufTextSearchDataQuery,
+		view.ProtobufScopeRequest, view.ProtobufScopeResponse, view.ProtobufScopeAnnotation, view.ProtobufScopeField,
+		view.ProtobufApiType)
+	if err != nil {
+		return fmt.Errorf("failed to calculate ts_protobuf_operation_data: %w", err)
+	}
+
 	log.Info("Calculating ts_operation_data")
 	calculateAllTextSearchDataQuery := fmt.Sprintf(`
 	insert into ts_operation_data
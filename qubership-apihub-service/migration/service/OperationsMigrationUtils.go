@@ -40,7 +40,6 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-const MigrationBuildPriority = -100
 const CancelledMigrationError = "cancelled"
 
 func (d dbMigrationServiceImpl) validateMinRequiredVersion(minRequiredMigrationVersion int) error {
@@ -108,7 +107,7 @@ func (d dbMigrationServiceImpl) addTaskToRebuild(migrationId string, versionEnt
 
 		CreatedBy:    "db migration",
 		RestartCount: 0,
-		Priority:     MigrationBuildPriority,
+		Priority:     view.MigrationBuildPriority,
 		Metadata: map[string]interface{}{
 			"build_type":                  view.PublishType,
 			"previous_version":            versionEnt.PreviousVersion,
@@ -117,9 +116,7 @@ func (d dbMigrationServiceImpl) addTaskToRebuild(migrationId string, versionEnt
 	}
 
 	var config, data []byte
-	var err error
-	if d.systemInfoService.IsMinioStorageActive() && !d.systemInfoService.IsMinioStoreOnlyBuildResult() {
-		savedSourcesQuery := `
+	savedSourcesQuery := `
 		select config, archive_checksum
 		from published_sources
 		where package_id = ?
@@ -127,33 +124,15 @@ func (d dbMigrationServiceImpl) addTaskToRebuild(migrationId string, versionEnt
 		and revision = ?
 		limit 1
 	`
-		configEntity, err := d.getPublishedSrcDataConfigEntity(savedSourcesQuery, versionEnt.PackageId, versionEnt.Version, versionEnt.Revision)
-		if err != nil {
-			return "", err
-		}
-		if configEntity.ArchiveChecksum != "" {
-			file, err := d.minioStorageService.GetFile(context.Background(), view.PUBLISHED_SOURCES_ARCHIVES_TABLE, configEntity.ArchiveChecksum)
-			if err != nil {
-				return "", err
-			}
-			config = configEntity.Config
-			data = file
-		}
-	} else {
-		savedSourcesQuery := `
-		select psa.checksum as archive_checksum, psa.data, ps.config, ps.package_id
-		from published_sources_archives psa, published_sources ps
-		where ps.package_id = ?
-		and ps.version = ?
-		and ps.revision = ?
-		and ps.archive_checksum = psa.checksum
-		limit 1
-	`
-		configEntity, err := d.getPublishedSrcDataConfigEntity(savedSourcesQuery, versionEnt.PackageId, versionEnt.Version, versionEnt.Revision)
+	configEntity, err := d.getPublishedSrcDataConfigEntity(savedSourcesQuery, versionEnt.PackageId, versionEnt.Version, versionEnt.Revision)
+	if err != nil {
+		return "", err
+	}
+	if configEntity.ArchiveChecksum != "" {
+		data, err = service.GetBlob(context.Background(), d.blobStorage, view.PUBLISHED_SOURCES_ARCHIVES_TABLE, configEntity.ArchiveChecksum)
 		if err != nil {
 			return "", err
 		}
-		data = configEntity.Data
 		config = configEntity.Config
 	}
 	var buildSourceEnt *entity.BuildSourceEntity
@@ -165,6 +144,10 @@ func (d dbMigrationServiceImpl) addTaskToRebuild(migrationId string, versionEnt
 	if err != nil {
 		return "", err
 	}
+	buildEnt.SetRequirements(view.BuildRequirements{
+		BuildType:  view.PublishType,
+		SourceSize: int64(len(buildSourceEnt.Source)),
+	})
 
 	err = d.storeVersionBuildTask(buildEnt, *buildSourceEnt)
 	if err != nil {
@@ -211,13 +194,14 @@ func (d dbMigrationServiceImpl) addChangelogTaskToRebuild(migrationId string, ch
 
 		CreatedBy:    config.CreatedBy,
 		RestartCount: 0,
-		Priority:     MigrationBuildPriority,
+		Priority:     view.MigrationBuildPriority,
 		Metadata: map[string]interface{}{
 			"build_type":                  config.BuildType,
 			"previous_version":            config.PreviousVersion,
 			"previous_version_package_id": config.PreviousVersionPackageId,
 		},
 	}
+	buildEnt.SetRequirements(view.MakeBuildRequirements(config, nil))
 
 	confAsMap, err := view.BuildConfigToMap(config)
 	if err != nil {
@@ -0,0 +1,170 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+	"github.com/pkg/errors"
+)
+
+// BlobRepository stores blobs of different kinds in the dedicated key->data tables
+type BlobRepository interface {
+	SaveBlob(kind string, key string, data []byte) error
+	GetBlob(kind string, key string) ([]byte, error)
+	DeleteBlobs(kind string, keys []string) (int, error)
+	GetBlobKeys(kind string, afterKey string, limit int) ([]string, error)
+	VacuumBlobs(kind string) error
+
+	GetLastMigrationRun() (*entity.BlobStorageMigrationRunEntity, error)
+	StoreMigrationRun(ent entity.BlobStorageMigrationRunEntity) error
+	UpdateMigrationRun(ent entity.BlobStorageMigrationRunEntity) error
+}
+
+func NewBlobRepository(cp db.ConnectionProvider) BlobRepository {
+	return &blobRepositoryImpl{cp: cp}
+}
+
+type blobRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+type blobTable struct {
+	name      string
+	keyColumn string
+	// content addressed blobs are never overwritten
+	immutable bool
+}
+
+var blobTables = map[string]blobTable{
+	view.BUILD_RESULT_TABLE:               {name: "build_result", keyColumn: "build_id"},
+	view.PUBLISHED_SOURCES_ARCHIVES_TABLE: {name: "published_sources_archives", keyColumn: "checksum", immutable: true},
+	view.EXPORT_RESULT_TABLE:              {name: "export_result_data", keyColumn: "export_id"},
+}
+
+func getBlobTable(kind string) (*blobTable, error) {
+	table, exists := blobTables[kind]
+	if !exists {
+		return nil, fmt.Errorf("unsupported blob kind: %s", kind)
+	}
+	return &table, nil
+}
+
+func (b blobRepositoryImpl) SaveBlob(kind string, key string, data []byte) error {
+	table, err := getBlobTable(kind)
+	if err != nil {
+		return err
+	}
+	onConflict := "do update set data = excluded.data"
+	if table.immutable {
+		onConflict = "do nothing"
+	}
+	query := fmt.Sprintf(`insert into ?0 (?1, data) values (?2, ?3) on conflict (?1) %s`, onConflict)
+	_, err = b.cp.GetConnection().Exec(query, pg.Ident(table.name), pg.Ident(table.keyColumn), key, data)
+	if err != nil {
+		return fmt.Errorf("failed to save %s blob %s: %w", kind, key, err)
+	}
+	return nil
+}
+
+func (b blobRepositoryImpl) GetBlob(kind string, key string) ([]byte, error) {
+	table, err := getBlobTable(kind)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	_, err = b.cp.GetConnection().QueryOne(pg.Scan(&data), `select data from ?0 where ?1 = ?2`,
+		pg.Ident(table.name), pg.Ident(table.keyColumn), key)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (b blobRepositoryImpl) DeleteBlobs(kind string, keys []string) (int, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	table, err := getBlobTable(kind)
+	if err != nil {
+		return 0, err
+	}
+	result, err := b.cp.GetConnection().Exec(`delete from ?0 where ?1 in (?2)`,
+		pg.Ident(table.name), pg.Ident(table.keyColumn), pg.In(keys))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+func (b blobRepositoryImpl) GetBlobKeys(kind string, afterKey string, limit int) ([]string, error) {
+	table, err := getBlobTable(kind)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	_, err = b.cp.GetConnection().Query(&keys, `select ?1 from ?0 where ?1 > ?2 order by ?1 limit ?3`,
+		pg.Ident(table.name), pg.Ident(table.keyColumn), afterKey, limit)
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (b blobRepositoryImpl) VacuumBlobs(kind string) error {
+	table, err := getBlobTable(kind)
+	if err != nil {
+		return err
+	}
+	_, err = b.cp.GetConnection().Exec(`vacuum full ?`, pg.Ident(table.name))
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to run vacuum for table %s", table.name))
+	}
+	return nil
+}
+
+func (b blobRepositoryImpl) GetLastMigrationRun() (*entity.BlobStorageMigrationRunEntity, error) {
+	result := new(entity.BlobStorageMigrationRunEntity)
+	err := b.cp.GetConnection().Model(result).
+		OrderExpr("run_id DESC").Limit(1).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b blobRepositoryImpl) StoreMigrationRun(ent entity.BlobStorageMigrationRunEntity) error {
+	_, err := b.cp.GetConnection().Model(&ent).Insert()
+	return err
+}
+
+func (b blobRepositoryImpl) UpdateMigrationRun(ent entity.BlobStorageMigrationRunEntity) error {
+	_, err := b.cp.GetConnection().Model(&ent).
+		Column("status", "finished_at").
+		WherePK().
+		Update()
+	return err
+}
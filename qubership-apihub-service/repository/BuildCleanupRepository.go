@@ -188,11 +188,11 @@ func (b buildCleanUpRepositoryImpl) getCleanupTx(tx *pg.Tx, runId int) (*entity.
 func (b buildCleanUpRepositoryImpl) removeOldBuildResults(tx *pg.Tx, successBuildsRetention, failedBuildsRetention time.Time) (deletedRows int, err error) {
 	query := `with builds as 
 		(select build_id from build where 
-		(status = ? and last_active <= ?) or
+		(status in (?) and last_active <= ?) or
 		(status = ? and last_active <= ?))
 		delete from build_result 
 		where build_result.build_id in (select builds.build_id from builds)`
-	result, err := tx.Exec(query, view.StatusError, failedBuildsRetention, view.StatusComplete, successBuildsRetention)
+	result, err := tx.Exec(query, pg.In([]view.BuildStatusEnum{view.StatusError, view.StatusCancelled}), failedBuildsRetention, view.StatusComplete, successBuildsRetention)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete builds from table build_result: %w", err)
 	}
@@ -204,11 +204,11 @@ func (b buildCleanUpRepositoryImpl) removeOldBuildResults(tx *pg.Tx, successBuil
 func (b buildCleanUpRepositoryImpl) removeOldBuildSources(tx *pg.Tx, successBuildsRetention, failedBuildsRetention time.Time) (deletedRows int, err error) {
 	query := `with builds as 
 		(select build_id from build where 
-		(status = ? and last_active <= ?) or
+		(status in (?) and last_active <= ?) or
 		(status = ? and last_active <= ?))
 		delete from build_src 
 		where build_src.build_id in (select builds.build_id from builds)`
-	result, err := tx.Exec(query, view.StatusError, failedBuildsRetention, view.StatusComplete, successBuildsRetention)
+	result, err := tx.Exec(query, pg.In([]view.BuildStatusEnum{view.StatusError, view.StatusCancelled}), failedBuildsRetention, view.StatusComplete, successBuildsRetention)
 	if err != nil {
 		return 0, fmt.Errorf("failed to delete builds from table build_src: %w", err)
 	}
@@ -222,9 +222,9 @@ func (b buildCleanUpRepositoryImpl) getRemoveCandidateOldBuildEntities(successBu
 	var ents []entity.BuildIdEntity
 
 	query := `select build_id from build where 
-		(status = ? and last_active <= ?) or
+		(status in (?) and last_active <= ?) or
 		(status = ? and last_active <= ?)`
-	_, err := b.cp.GetConnection().Query(&ents, query, view.StatusError, failedBuildsRetention, view.StatusComplete, successBuildsRetention)
+	_, err := b.cp.GetConnection().Query(&ents, query, pg.In([]view.BuildStatusEnum{view.StatusError, view.StatusCancelled}), failedBuildsRetention, view.StatusComplete, successBuildsRetention)
 	if err != nil {
 		return nil, err
 	}
@@ -405,6 +405,13 @@ func (b buildCleanUpRepositoryImpl) RemoveUnreferencedOperationData(runId int) e
 		cleanupEnt.TsGQLOperationData += res.RowsAffected()
 		cleanupEnt.DeletedRows += res.RowsAffected()
 
+		res, err = conn.Exec("delete from ts_protobuf_operation_data od where od.data_hash = any (select data_hash from tmp_data_hash order by data_hash limit ? offset ?)", limit, page*limit)
+		if err != nil {
+			return err
+		}
+		cleanupEnt.TsProtobufOperationData += res.RowsAffected()
+		cleanupEnt.DeletedRows += res.RowsAffected()
+
 		err = b.updateCleanup(*cleanupEnt)
 		if err != nil {
 			return err
@@ -437,5 +444,10 @@ func (b buildCleanUpRepositoryImpl) RemoveUnreferencedOperationData(runId int) e
 		return errors.Wrap(err, "failed to run vacuum for table ts_graphql_operation_data")
 	}
 
+	_, err = b.cp.GetConnection().Exec("vacuum full ts_protobuf_operation_data")
+	if err != nil {
+		return errors.Wrap(err, "failed to run vacuum for table ts_protobuf_operation_data")
+	}
+
 	return nil
 }
@@ -16,8 +16,10 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
 
@@ -30,16 +32,33 @@ import (
 type BuildRepository interface {
 	StoreBuild(buildEntity entity.BuildEntity, sourceEntity entity.BuildSourceEntity, depends []entity.BuildDependencyEntity) error
 	UpdateBuildStatus(buildId string, status view.BuildStatusEnum, details string) error
+	FailBuild(buildId string, details string, retryPolicies view.BuildRetryPolicies) (bool, error)
 	GetBuild(buildId string) (*entity.BuildEntity, error)
 	GetBuilds(buildIds []string) ([]entity.BuildEntity, error)
 	GetBuildSrc(buildId string) (*entity.BuildSourceEntity, error)
 
-	FindAndTakeFreeBuild(builderId string) (*entity.BuildEntity, error)
+	GetBuildQueue(fairSharePolicy view.BuildFairSharePolicy) ([]entity.BuildEntity, error)
+	RequeueBuildsOfBuilders(builderIds []string, retryPolicies view.BuildRetryPolicies) ([]string, error)
+	FindAndTakeFreeBuild(builderId string, capabilities view.BuilderCapabilities, retryPolicies view.BuildRetryPolicies, fairSharePolicy view.BuildFairSharePolicy) (*entity.BuildEntity, error)
 
 	GetBuildByChangelogSearchQuery(searchQuery entity.ChangelogBuildSearchQueryEntity) (*entity.BuildEntity, error)
 	GetBuildByDocumentGroupSearchQuery(searchQuery entity.DocumentGroupBuildSearchQueryEntity) (*entity.BuildEntity, error)
 
 	UpdateBuildSourceConfig(buildId string, config map[string]interface{}) error
+	UpdateBuildVersion(buildId string, version string, config map[string]interface{}) error
+	ReserveInferredVersion(packageId string, version string, buildId string) (bool, error)
+
+	CancelBuilds(buildIds []string, details string, checkBuildsToCancel func(builds []entity.BuildToCancelEntity) error) ([]string, error)
+
+	GetWaitingBuilds(waitingSince time.Time) ([]entity.BuildEntity, error)
+	MoveBuildsToDeadLetter(buildIds []string, details string) ([]string, error)
+
+	GetDeadLetterBuilds(req view.DeadLetterBuildsListReq) ([]entity.BuildEntity, error)
+	GetDeadLetterBuild(buildId string) (*entity.BuildEntity, error)
+	GetBuildDependencies(buildId string) ([]string, error)
+	GetBuildDependencyLinks(buildIds []string) ([]entity.BuildDependencyEntity, error)
+	GetChildBuilds(parentBuildIds []string) ([]entity.BuildEntity, error)
+	RequeueDeadLetterBuild(buildId string) error
 }
 
 func NewBuildRepositoryPG(cp db.ConnectionProvider) (BuildRepository, error) {
@@ -123,65 +142,179 @@ const getBuildWithLock = "select * from build where build_id = ? limit 1 for no
 func (b buildRepositoryImpl) UpdateBuildStatus(buildId string, status view.BuildStatusEnum, details string) error {
 	ctx := context.Background()
 	err := b.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		var ents []entity.BuildEntity
-		_, err := tx.Query(&ents, getBuildWithLock, buildId)
+		ent, err := getBuildForStatusUpdate(tx, buildId, status)
 		if err != nil {
-			return fmt.Errorf("failed to get build %s for status update: %w", buildId, err)
-		}
-		if len(ents) == 0 {
-			return fmt.Errorf("build with id = %s is not found for status update", buildId)
+			return err
 		}
-		ent := &ents[0]
+		return setBuildStatus(tx, ent, status, details)
+	})
+	return err
+}
 
-		buildStatus, err := view.BuildStatusFromString(ent.Status)
+// FailBuild applies the retry policy to the build failed by the builder: the build is returned to the queue with backoff
+// until it runs out of attempts, then it is moved to dead letter. Returns true if the build is returned to the queue.
+func (b buildRepositoryImpl) FailBuild(buildId string, details string, retryPolicies view.BuildRetryPolicies) (bool, error) {
+	requeued := false
+	ctx := context.Background()
+	err := b.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		ent, err := getBuildForStatusUpdate(tx, buildId, view.StatusError)
 		if err != nil {
-			return fmt.Errorf("invalid status for buildId %s: %s", ent.BuildId, err)
+			return err
 		}
-		if buildStatus == view.StatusComplete ||
-			(buildStatus == view.StatusError && status != view.StatusError) {
-			return &exception.CustomError{
-				Status:  http.StatusBadRequest,
-				Code:    exception.BuildAlreadyFinished,
-				Message: exception.BuildAlreadyFinishedMsg,
-				Params:  map[string]interface{}{"buildId": buildId},
-			}
+		if ent.Status == string(view.StatusError) {
+			return setBuildStatus(tx, ent, view.StatusError, details)
 		}
-		//Append new error to existing one
-		if buildStatus == view.StatusError && status == view.StatusError &&
-			ent.RestartCount >= 2 && ent.Details != "" {
-			details = fmt.Sprintf("%v: %v", ent.Details, details)
+		ent.Details = details
+		retryPolicy := retryPolicies.Get(view.BuildType(ent.BuildType))
+		if ent.RestartCount+1 >= retryPolicy.MaxAttempts {
+			return moveBuildToDeadLetter(tx, ent)
 		}
+		requeued = true
+		return returnBuildToQueue(tx, ent, retryPolicy)
+	})
+	if err != nil {
+		return false, err
+	}
+	return requeued, nil
+}
 
-		query := tx.Model(ent).
-			Where("build_id = ?", buildId).
-			Set("status = ?", status).
-			Set("details = ?", details).
-			Set("last_active = now()")
-		_, err = query.Update()
-		if err != nil {
-			return err
+func getBuildForStatusUpdate(tx *pg.Tx, buildId string, status view.BuildStatusEnum) (*entity.BuildEntity, error) {
+	var ents []entity.BuildEntity
+	_, err := tx.Query(&ents, getBuildWithLock, buildId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build %s for status update: %w", buildId, err)
+	}
+	if len(ents) == 0 {
+		return nil, fmt.Errorf("build with id = %s is not found for status update", buildId)
+	}
+	ent := &ents[0]
+
+	buildStatus, err := view.BuildStatusFromString(ent.Status)
+	if err != nil {
+		return nil, fmt.Errorf("invalid status for buildId %s: %s", ent.BuildId, err)
+	}
+	if buildStatus == view.StatusCancelled {
+		return nil, buildCancelledError(buildId)
+	}
+	if buildStatus == view.StatusComplete ||
+		(buildStatus == view.StatusError && status != view.StatusError) {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.BuildAlreadyFinished,
+			Message: exception.BuildAlreadyFinishedMsg,
+			Params:  map[string]interface{}{"buildId": buildId},
 		}
-		return nil
-	})
+	}
+	return ent, nil
+}
+
+func setBuildStatus(tx *pg.Tx, ent *entity.BuildEntity, status view.BuildStatusEnum, details string) error {
+	//Append new error to existing one
+	if ent.Status == string(view.StatusError) && status == view.StatusError &&
+		ent.DeadLetter && ent.Details != "" {
+		details = fmt.Sprintf("%v: %v", ent.Details, details)
+	}
+
+	_, err := tx.Model(ent).
+		Where("build_id = ?", ent.BuildId).
+		Set("status = ?", status).
+		Set("details = ?", details).
+		Set("last_active = now()").
+		Update()
 	return err
 }
 
+// builder receives this error on any status update of the cancelled build and should stop processing it
+func buildCancelledError(buildId string) error {
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.BuildCancelled,
+		Message: exception.BuildCancelledMsg,
+		Params:  map[string]interface{}{"buildId": buildId},
+	}
+}
+
 const buildKeepaliveTimeoutSec = 600
 
-var queryItemToBuild = fmt.Sprintf("select * from build b where "+
-	"(b.status='none' or (b.status='%s' and b.last_active < (now() - interval '%d seconds'))) and "+
-	"(b.build_id not in (select distinct build_id from build_depends where depend_id in (select build.build_id from build where status='%s' or status='%s'))) "+
-	"order by b.priority DESC, b.created_at ASC limit 1 for no key update skip locked", view.StatusRunning, buildKeepaliveTimeoutSec, view.StatusNotStarted, view.StatusRunning)
+// running_builds contains alive running builds, used to calculate the current load of workspaces and authors for the fair share scheduling
+var queryItemToBuild = fmt.Sprintf("with running_builds as ("+
+	"select split_part(package_id, '.', 1) as workspace_id, created_by from build where status='%s' and last_active >= (now() - interval '%d seconds')), "+
+	"workspace_load as (select workspace_id, count(*) as cnt from running_builds group by workspace_id), "+
+	"user_load as (select created_by, count(*) as cnt from running_builds group by created_by) "+
+	"select b.* from build b "+
+	"left join workspace_load wl on wl.workspace_id = split_part(b.package_id, '.', 1) "+
+	"left join user_load ul on ul.created_by = b.created_by "+
+	"where ((b.status='%s' and (b.next_attempt_at is null or b.next_attempt_at <= now())) or (b.status='%s' and b.last_active < (now() - interval '%d seconds'))) and "+
+	"(b.build_id not in (select distinct build_id from build_depends where depend_id in (select build.build_id from build where status in ('%s', '%s', '%s')))) ",
+	view.StatusRunning, buildKeepaliveTimeoutSec,
+	view.StatusNotStarted, view.StatusRunning, buildKeepaliveTimeoutSec, view.StatusNotStarted, view.StatusRunning, view.StatusCancelled)
+
+// within the same priority the build of the least loaded (relative to its weight) workspace and author goes first
+const queryItemToBuildOrder = "order by b.priority DESC, " +
+	"coalesce(wl.cnt, 0) / coalesce((?::jsonb->>split_part(b.package_id, '.', 1))::numeric, 1) + " +
+	"coalesce(ul.cnt, 0) / coalesce((?::jsonb->>b.created_by)::numeric, 1) ASC, " +
+	"b.created_at ASC "
+
+const queryItemToBuildLock = "limit 1 for no key update of b skip locked"
+
+// builds without requirements (e.g. created before requirements were introduced) could be taken by any builder
+func makeQueryItemToBuild(capabilities view.BuilderCapabilities, fairSharePolicy view.BuildFairSharePolicy) (string, []interface{}, error) {
+	query := queryItemToBuild
+	var params []interface{}
+	if len(capabilities.BuildTypes) > 0 {
+		query += "and (b.build_type is null or b.build_type in (?)) "
+		params = append(params, pg.In(capabilities.BuildTypes))
+	}
+	if len(capabilities.ApiTypes) > 0 {
+		query += "and (coalesce(b.api_type, '') = '' or b.api_type in (?)) "
+		params = append(params, pg.In(capabilities.ApiTypes))
+	}
+	if capabilities.MaxSourceSizeMB > 0 {
+		query += "and (b.source_size is null or b.source_size <= ?) "
+		params = append(params, capabilities.MaxSourceSizeMB*1024*1024)
+	}
+	workspaceWeights, err := json.Marshal(fairSharePolicy.WorkspaceWeights)
+	if err != nil {
+		return "", nil, err
+	}
+	createdByWeights, err := json.Marshal(fairSharePolicy.CreatedByWeights)
+	if err != nil {
+		return "", nil, err
+	}
+	params = append(params, string(workspaceWeights), string(createdByWeights))
+	return query + queryItemToBuildOrder, params, nil
+}
+
+// GetBuildQueue returns builds which could be taken right now in the same order as builders take them (for a builder without restrictions)
+func (b buildRepositoryImpl) GetBuildQueue(fairSharePolicy view.BuildFairSharePolicy) ([]entity.BuildEntity, error) {
+	query, queryParams, err := makeQueryItemToBuild(view.BuilderCapabilities{}, fairSharePolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build free build query: %w", err)
+	}
+	var result []entity.BuildEntity
+	_, err = b.cp.GetConnection().Query(&result, query, queryParams...)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
 
-func (b buildRepositoryImpl) FindAndTakeFreeBuild(builderId string) (*entity.BuildEntity, error) {
+func (b buildRepositoryImpl) FindAndTakeFreeBuild(builderId string, capabilities view.BuilderCapabilities, retryPolicies view.BuildRetryPolicies, fairSharePolicy view.BuildFairSharePolicy) (*entity.BuildEntity, error) {
 	var result *entity.BuildEntity
-	var err error
+	query, queryParams, err := makeQueryItemToBuild(capabilities, fairSharePolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build free build query: %w", err)
+	}
+	query += queryItemToBuildLock
 	for {
-		buildFailed := false
+		buildSkipped := false
 		err = b.cp.GetConnection().RunInTransaction(context.Background(), func(tx *pg.Tx) error {
 			var ents []entity.BuildEntity
 
-			_, err := tx.Query(&ents, queryItemToBuild)
+			_, err := tx.Query(&ents, query, queryParams...)
 			if err != nil {
 				if err == pg.ErrNoRows {
 					return nil
@@ -192,27 +325,31 @@ func (b buildRepositoryImpl) FindAndTakeFreeBuild(builderId string) (*entity.Bui
 				result = &ents[0]
 
 				// we got build candidate
-				if result.RestartCount >= 2 {
-					query := tx.Model(result).
-						Where("build_id = ?", result.BuildId).
-						Set("status = ?", view.StatusError).
-						Set("details = ?", fmt.Sprintf("Restart count exceeded limit. Details: %v", result.Details)).
-						Set("last_active = now()")
-					_, err := query.Update()
-					if err != nil {
-						return err
+				if result.Status == string(view.StatusRunning) {
+					// builder stopped sending keepalives (crash, timeout), so retry policy is applied
+					retryPolicy := retryPolicies.Get(view.BuildType(result.BuildType))
+
+					if result.RestartCount+1 >= retryPolicy.MaxAttempts {
+						err := moveBuildToDeadLetter(tx, result)
+						if err != nil {
+							return err
+						}
+						buildSkipped = true
+						return nil
 					}
-					buildFailed = true
-					return nil
-				}
-
-				// take free build
-				isFirstRun := result.Status == string(view.StatusNotStarted)
 
-				if !isFirstRun {
+					if retryPolicy.GetBackoff(result.RestartCount+1) > 0 {
+						// return the build to the queue, it will be available for builders after backoff
+						if err := returnBuildToQueue(tx, result, retryPolicy); err != nil {
+							return fmt.Errorf("unable to schedule build retry: %w", err)
+						}
+						buildSkipped = true
+						return nil
+					}
 					result.RestartCount += 1
 				}
 
+				// take free build
 				result.Status = string(view.StatusRunning)
 				result.BuilderId = builderId
 				// TODO: add optimistic lock as well?
@@ -221,6 +358,7 @@ func (b buildRepositoryImpl) FindAndTakeFreeBuild(builderId string) (*entity.Bui
 					Set("status = ?status").
 					Set("builder_id = ?builder_id").
 					Set("restart_count = ?restart_count").
+					Set("next_attempt_at = null").
 					Set("last_active = now()").
 					Where("build_id = ?", result.BuildId).
 					Update()
@@ -232,7 +370,8 @@ func (b buildRepositoryImpl) FindAndTakeFreeBuild(builderId string) (*entity.Bui
 			}
 			return nil
 		})
-		if buildFailed {
+		if buildSkipped {
+			result = nil
 			continue
 		}
 		break
@@ -243,6 +382,69 @@ func (b buildRepositoryImpl) FindAndTakeFreeBuild(builderId string) (*entity.Bui
 	return result, nil
 }
 
+// returnBuildToQueue increments restart count of the build and makes it available for builders after the retry backoff
+func returnBuildToQueue(tx *pg.Tx, build *entity.BuildEntity, retryPolicy view.BuildRetryPolicy) error {
+	build.RestartCount += 1
+	_, err := tx.Model(build).
+		Where("build_id = ?", build.BuildId).
+		Set("status = ?", view.StatusNotStarted).
+		Set("builder_id = ''").
+		Set("restart_count = ?restart_count").
+		Set("next_attempt_at = ?", time.Now().Add(retryPolicy.GetBackoff(build.RestartCount))).
+		Set("last_active = now()").
+		Update()
+	return err
+}
+
+func moveBuildToDeadLetter(tx *pg.Tx, build *entity.BuildEntity) error {
+	_, err := tx.Model(build).
+		Where("build_id = ?", build.BuildId).
+		Set("status = ?", view.StatusError).
+		Set("dead_letter = true").
+		Set("details = ?", fmt.Sprintf("Restart count exceeded limit. Details: %v", build.Details)).
+		Set("last_active = now()").
+		Update()
+	return err
+}
+
+// RequeueBuildsOfBuilders returns running builds of the given builders to the queue according to the retry policy
+func (b buildRepositoryImpl) RequeueBuildsOfBuilders(builderIds []string, retryPolicies view.BuildRetryPolicies) ([]string, error) {
+	requeuedBuildIds := make([]string, 0)
+	if len(builderIds) == 0 {
+		return requeuedBuildIds, nil
+	}
+	err := b.cp.GetConnection().RunInTransaction(context.Background(), func(tx *pg.Tx) error {
+		var ents []entity.BuildEntity
+		err := tx.Model(&ents).
+			Where("builder_id in (?)", pg.In(builderIds)).
+			Where("status = ?", view.StatusRunning).
+			For("no key update skip locked").
+			Select()
+		if err != nil {
+			return err
+		}
+		for i := range ents {
+			build := &ents[i]
+			retryPolicy := retryPolicies.Get(view.BuildType(build.BuildType))
+			if build.RestartCount+1 >= retryPolicy.MaxAttempts {
+				if err = moveBuildToDeadLetter(tx, build); err != nil {
+					return err
+				}
+				continue
+			}
+			if err = returnBuildToQueue(tx, build, retryPolicy); err != nil {
+				return err
+			}
+			requeuedBuildIds = append(requeuedBuildIds, build.BuildId)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to requeue builds of builders %v: %w", builderIds, err)
+	}
+	return requeuedBuildIds, nil
+}
+
 func (b buildRepositoryImpl) GetBuildByChangelogSearchQuery(searchQuery entity.ChangelogBuildSearchQueryEntity) (*entity.BuildEntity, error) {
 	var ent entity.BuildEntity
 	query := `
@@ -307,3 +509,290 @@ func (b buildRepositoryImpl) UpdateBuildSourceConfig(buildId string, config map[
 	}
 	return nil
 }
+
+// UpdateBuildVersion sets version name which was inferred during the build
+func (b buildRepositoryImpl) UpdateBuildVersion(buildId string, version string, config map[string]interface{}) error {
+	ctx := context.Background()
+	return b.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		_, err := tx.Model(&entity.BuildEntity{}).
+			Where("build_id = ?", buildId).
+			Set("version = ?", version).
+			Update()
+		if err != nil {
+			return fmt.Errorf("failed to update version of build %s: %w", buildId, err)
+		}
+		_, err = tx.Model(&entity.BuildSourceEntity{}).
+			Where("build_id = ?", buildId).
+			Set("config = ?", config).
+			Update()
+		if err != nil {
+			return fmt.Errorf("failed to update config of build %s: %w", buildId, err)
+		}
+		return nil
+	})
+}
+
+// the reservation of another build could be taken over only if that build is not in progress anymore,
+// its version is either stored or the build has failed
+const reserveInferredVersionQuery = `
+insert into inferred_version_reservation as r (package_id, version, build_id, reserved_at)
+values (?, ?, ?, now())
+on conflict (package_id, version) do update
+set build_id = excluded.build_id, reserved_at = excluded.reserved_at
+where r.build_id = excluded.build_id
+   or not exists(select 1 from build b where b.build_id = r.build_id and b.status in (?, ?))`
+
+// ReserveInferredVersion reserves the version name for the build, returns false if the version is reserved by another build in progress
+func (b buildRepositoryImpl) ReserveInferredVersion(packageId string, version string, buildId string) (bool, error) {
+	result, err := b.cp.GetConnection().Exec(reserveInferredVersionQuery,
+		packageId, version, buildId, view.StatusNotStarted, view.StatusRunning)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// selects and locks not finished builds and all not finished builds which depend on them (directly or transitively)
+const buildsToCancelQuery = `with recursive builds_to_cancel as (
+		select build_id from build where build_id in (?0) and status in (?1)
+		union
+		select d.build_id from build_depends d
+		inner join builds_to_cancel c on d.depend_id = c.build_id
+	)
+	select b.build_id, b.package_id, b.version, coalesce(s.config->>'status', '') as version_status from build b
+	inner join builds_to_cancel c on b.build_id = c.build_id
+	left join build_src s on s.build_id = b.build_id
+	where b.status in (?1)
+	for update of b`
+
+// CancelBuilds cancels given builds which are not finished yet together with all not finished builds which depend on them.
+// Builds to cancel are locked until the end of the transaction, so checkBuildsToCancel could reject the cancellation
+// without a risk that some build is started, finished or gets a new dependent build in the meantime.
+func (b buildRepositoryImpl) CancelBuilds(buildIds []string, details string, checkBuildsToCancel func(builds []entity.BuildToCancelEntity) error) ([]string, error) {
+	if len(buildIds) == 0 {
+		return nil, nil
+	}
+	result := make([]string, 0)
+	notFinishedStatuses := pg.In([]view.BuildStatusEnum{view.StatusNotStarted, view.StatusRunning})
+	ctx := context.Background()
+	err := b.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		var buildsToCancel []entity.BuildToCancelEntity
+		_, err := tx.Query(&buildsToCancel, buildsToCancelQuery, pg.In(buildIds), notFinishedStatuses)
+		if err != nil {
+			return fmt.Errorf("failed to get builds to cancel: %w", err)
+		}
+		if len(buildsToCancel) == 0 {
+			return nil
+		}
+		err = checkBuildsToCancel(buildsToCancel)
+		if err != nil {
+			return err
+		}
+		buildIdsToCancel := make([]string, 0, len(buildsToCancel))
+		for _, build := range buildsToCancel {
+			buildIdsToCancel = append(buildIdsToCancel, build.BuildId)
+		}
+		var ents []entity.BuildIdEntity
+		_, err = tx.Query(&ents, `update build set status = ?, details = ?, last_active = now()
+			where build_id in (?)
+			returning build_id`,
+			view.StatusCancelled, details, pg.In(buildIdsToCancel))
+		if err != nil {
+			return fmt.Errorf("failed to cancel builds: %w", err)
+		}
+		for _, ent := range ents {
+			result = append(result, ent.Id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetWaitingBuilds returns not started builds which are available for builders, but nobody took them since waitingSince.
+// The build becomes available when it is created or requeued, when its next attempt time comes or when its last dependency is finished.
+func (b buildRepositoryImpl) GetWaitingBuilds(waitingSince time.Time) ([]entity.BuildEntity, error) {
+	var result []entity.BuildEntity
+	_, err := b.cp.GetConnection().Query(&result,
+		`select b.* from build b
+		where b.status = ?
+		and (b.next_attempt_at is null or b.next_attempt_at <= now())
+		and not exists(
+			select 1 from build_depends d
+			inner join build db on db.build_id = d.depend_id
+			where d.build_id = b.build_id and db.status in (?))
+		and greatest(b.last_active, b.next_attempt_at, (
+			select max(db.last_active) from build_depends d
+			inner join build db on db.build_id = d.depend_id
+			where d.build_id = b.build_id)) < ?`,
+		view.StatusNotStarted,
+		pg.In([]view.BuildStatusEnum{view.StatusNotStarted, view.StatusRunning, view.StatusCancelled}),
+		waitingSince)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b buildRepositoryImpl) MoveBuildsToDeadLetter(buildIds []string, details string) ([]string, error) {
+	var ents []entity.BuildIdEntity
+	if len(buildIds) == 0 {
+		return nil, nil
+	}
+	_, err := b.cp.GetConnection().Query(&ents,
+		`update build set status = ?, dead_letter = true, details = ?, last_active = now()
+		where build_id in (?) and status = ?
+		returning build_id`,
+		view.StatusError, details, pg.In(buildIds), view.StatusNotStarted)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(ents))
+	for _, ent := range ents {
+		result = append(result, ent.Id)
+	}
+	return result, nil
+}
+
+func (b buildRepositoryImpl) GetDeadLetterBuilds(req view.DeadLetterBuildsListReq) ([]entity.BuildEntity, error) {
+	var result []entity.BuildEntity
+	query := b.cp.GetConnection().Model(&result).
+		Where("status = ?", view.StatusError).
+		Where("dead_letter = true")
+	if req.PackageId != "" {
+		query.Where("package_id = ?", req.PackageId)
+	}
+	if req.BuildType != "" {
+		query.Where("build_type = ?", req.BuildType)
+	}
+	err := query.Order("last_active DESC").
+		Limit(req.Limit).
+		Offset(req.Limit * req.Page).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b buildRepositoryImpl) GetDeadLetterBuild(buildId string) (*entity.BuildEntity, error) {
+	result := new(entity.BuildEntity)
+	err := b.cp.GetConnection().Model(result).
+		Where("build_id = ?", buildId).
+		Where("dead_letter = true").
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBuildDependencyLinks returns links where the given builds are either dependent or dependency builds
+func (b buildRepositoryImpl) GetBuildDependencyLinks(buildIds []string) ([]entity.BuildDependencyEntity, error) {
+	var ents []entity.BuildDependencyEntity
+	if len(buildIds) == 0 {
+		return ents, nil
+	}
+	err := b.cp.GetConnection().Model(&ents).
+		Where("build_id in (?)", pg.In(buildIds)).
+		WhereOr("depend_id in (?)", pg.In(buildIds)).
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return ents, nil
+}
+
+func (b buildRepositoryImpl) GetChildBuilds(parentBuildIds []string) ([]entity.BuildEntity, error) {
+	var ents []entity.BuildEntity
+	if len(parentBuildIds) == 0 {
+		return ents, nil
+	}
+	err := b.cp.GetConnection().Model(&ents).
+		Where("parent_build_id in (?)", pg.In(parentBuildIds)).
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return ents, nil
+}
+
+func (b buildRepositoryImpl) GetBuildDependencies(buildId string) ([]string, error) {
+	var ents []entity.BuildDependencyEntity
+	err := b.cp.GetConnection().Model(&ents).
+		Where("build_id = ?", buildId).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	result := make([]string, 0, len(ents))
+	for _, ent := range ents {
+		result = append(result, ent.DependId)
+	}
+	return result, nil
+}
+
+func (b buildRepositoryImpl) RequeueDeadLetterBuild(buildId string) error {
+	ctx := context.Background()
+	return b.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		var ents []entity.BuildEntity
+		_, err := tx.Query(&ents, getBuildWithLock, buildId)
+		if err != nil {
+			return fmt.Errorf("failed to get build %s for requeue: %w", buildId, err)
+		}
+		if len(ents) == 0 {
+			return &exception.CustomError{
+				Status:  http.StatusNotFound,
+				Code:    exception.BuildNotFound,
+				Message: exception.BuildNotFoundMsg,
+				Params:  map[string]interface{}{"buildId": buildId},
+			}
+		}
+		ent := &ents[0]
+		if ent.Status != string(view.StatusError) || !ent.DeadLetter {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.BuildNotInDeadLetter,
+				Message: exception.BuildNotInDeadLetterMsg,
+				Params:  map[string]interface{}{"buildId": buildId},
+			}
+		}
+		srcExists, err := tx.Model(&entity.BuildSourceEntity{}).Where("build_id = ?", buildId).Exists()
+		if err != nil {
+			return fmt.Errorf("failed to check sources of build %s: %w", buildId, err)
+		}
+		if !srcExists {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.BuildSourcesNotFound,
+				Message: exception.BuildSourcesNotFoundMsg,
+				Params:  map[string]interface{}{"publishId": buildId},
+			}
+		}
+		_, err = tx.Model(ent).
+			Where("build_id = ?", buildId).
+			Set("status = ?", view.StatusNotStarted).
+			Set("details = ''").
+			Set("builder_id = ''").
+			Set("restart_count = 0").
+			Set("dead_letter = false").
+			Set("next_attempt_at = null").
+			Set("last_active = now()").
+			Update()
+		if err != nil {
+			return fmt.Errorf("failed to requeue build %s: %w", buildId, err)
+		}
+		return nil
+	})
+}
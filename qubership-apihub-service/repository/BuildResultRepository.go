@@ -16,18 +16,17 @@ package repository
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 	"github.com/go-pg/pg/v10"
-	"github.com/pkg/errors"
 )
 
 type BuildResultRepository interface {
-	StoreBuildResult(ent entity.BuildResultEntity) error
-	GetBuildResult(buildId string) (*entity.BuildResultEntity, error)
-	GetBuildResultWithOffset(offset int) (*entity.BuildResultEntity, error)
-	DeleteBuildResults(buildIds []string) error
+	SaveDryRunResult(ent entity.DryRunResultEntity) error
+	GetDryRunResult(buildId string) (*entity.DryRunResultEntity, error)
 }
 
 func NewBuildResultRepository(cp db.ConnectionProvider) BuildResultRepository {
@@ -38,32 +37,51 @@ type buildResultRepositoryImpl struct {
 	cp db.ConnectionProvider
 }
 
-func (b buildResultRepositoryImpl) StoreBuildResult(ent entity.BuildResultEntity) error {
-	_, err := b.cp.GetConnection().Model(&ent).Insert()
-	if err != nil {
-		return err
-	}
-	return nil
+func (b buildResultRepositoryImpl) SaveDryRunResult(ent entity.DryRunResultEntity) error {
+	ctx := context.Background()
+	return b.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		var ents []entity.BuildEntity
+		_, err := tx.Query(&ents, getBuildWithLock, ent.BuildId)
+		if err != nil {
+			return fmt.Errorf("SaveDryRunResult: failed to get build %s: %w", ent.BuildId, err)
+		}
+		if len(ents) == 0 {
+			return fmt.Errorf("SaveDryRunResult: failed to save dry run result. Build with buildId='%s' is not found", ent.BuildId)
+		}
+		build := &ents[0]
 
-}
+		if build.Status == string(view.StatusCancelled) {
+			return buildCancelledError(build.BuildId)
+		}
+		//do not allow publish for "complete" builds and failed builds which are not in dead letter state (i.e. not failed with "Restart count exceeded limit")
+		if build.Status == string(view.StatusComplete) ||
+			(build.Status == string(view.StatusError) && !build.DeadLetter) {
+			return fmt.Errorf("failed to save dry run result. Build with buildId='%v' is already published or failed", ent.BuildId)
+		}
 
-func (b buildResultRepositoryImpl) GetBuildResult(buildId string) (*entity.BuildResultEntity, error) {
-	result := new(entity.BuildResultEntity)
-	err := b.cp.GetConnection().Model(result).
-		Where("build_id = ?", buildId).
-		First()
-	if err != nil {
-		if err == pg.ErrNoRows {
-			return nil, nil
+		_, err = tx.Model(&ent).Insert()
+		if err != nil {
+			return err
 		}
-		return nil, err
-	}
-	return result, nil
+
+		var buildEntity entity.BuildEntity
+		_, err = tx.Model(&buildEntity).
+			Where("build_id = ?", ent.BuildId).
+			Set("status = ?", view.StatusComplete).
+			Set("details = ?", "").
+			Set("last_active = now()").
+			Update()
+		if err != nil {
+			return fmt.Errorf("failed to update build entity: %w", err)
+		}
+		return nil
+	})
 }
 
-func (b buildResultRepositoryImpl) GetBuildResultWithOffset(offset int) (*entity.BuildResultEntity, error) {
-	result := new(entity.BuildResultEntity)
-	err := b.cp.GetConnection().Model(result).Offset(offset).Limit(1).
+func (b buildResultRepositoryImpl) GetDryRunResult(buildId string) (*entity.DryRunResultEntity, error) {
+	result := new(entity.DryRunResultEntity)
+	err := b.cp.GetConnection().Model(result).
+		Where("build_id = ?", buildId).
 		First()
 	if err != nil {
 		if err == pg.ErrNoRows {
@@ -73,26 +91,3 @@ func (b buildResultRepositoryImpl) GetBuildResultWithOffset(offset int) (*entity
 	}
 	return result, nil
 }
-
-func (b buildResultRepositoryImpl) DeleteBuildResults(buildIds []string) error {
-	ctx := context.Background()
-	var deletedRows int
-	err := b.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		query := `delete from build_result 
-		where build_id in (?)`
-		result, err := tx.Exec(query, pg.In(buildIds))
-		if err != nil {
-			return err
-		}
-		deletedRows += result.RowsAffected()
-		return nil
-	})
-
-	if deletedRows > 0 {
-		_, err = b.cp.GetConnection().Exec("vacuum full build_result")
-		if err != nil {
-			return errors.Wrap(err, "failed to run vacuum for table build_result")
-		}
-	}
-	return nil
-}
@@ -0,0 +1,178 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type BuilderRepository interface {
+	SaveBuilderHeartbeat(ent entity.BuilderEntity) error
+	ListBuilders(activeSince *time.Time) ([]entity.BuilderEntity, error)
+	GetBuilder(builderId string) (*entity.BuilderEntity, error)
+	GetBuildersStats(builderIds []string, finishedSince time.Time) ([]entity.BuilderStatsEntity, error)
+	GetStaleBuilderIds(inactiveSince time.Time) ([]string, error)
+	HasActiveUnregisteredBuilders(activeSince time.Time) (bool, error)
+	DeleteInactiveBuilders(inactiveSince time.Time) (int, error)
+	GetLastReclaimRun() (*entity.BuilderReclaimRunEntity, error)
+	StoreReclaimRun(ent entity.BuilderReclaimRunEntity) error
+	DeleteReclaimRuns(scheduledBefore time.Time) error
+}
+
+func NewBuilderRepository(cp db.ConnectionProvider) BuilderRepository {
+	return builderRepositoryImpl{cp: cp}
+}
+
+type builderRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+// registered_at is kept from the first heartbeat
+func (b builderRepositoryImpl) SaveBuilderHeartbeat(ent entity.BuilderEntity) error {
+	_, err := b.cp.GetConnection().Model(&ent).
+		OnConflict("(builder_id) DO UPDATE").
+		Set("version = EXCLUDED.version").
+		Set("capacity = EXCLUDED.capacity").
+		Set("capabilities = EXCLUDED.capabilities").
+		Set("last_heartbeat = EXCLUDED.last_heartbeat").
+		Insert()
+	return err
+}
+
+func (b builderRepositoryImpl) ListBuilders(activeSince *time.Time) ([]entity.BuilderEntity, error) {
+	var result []entity.BuilderEntity
+	query := b.cp.GetConnection().Model(&result)
+	if activeSince != nil {
+		query.Where("last_heartbeat >= ?", *activeSince)
+	}
+	query.Order("builder_id ASC")
+
+	err := query.Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b builderRepositoryImpl) GetBuilder(builderId string) (*entity.BuilderEntity, error) {
+	result := new(entity.BuilderEntity)
+	err := b.cp.GetConnection().Model(result).
+		Where("builder_id = ?", builderId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b builderRepositoryImpl) GetBuildersStats(builderIds []string, finishedSince time.Time) ([]entity.BuilderStatsEntity, error) {
+	var result []entity.BuilderStatsEntity
+	if len(builderIds) == 0 {
+		return result, nil
+	}
+	query := `
+		select builder_id,
+			array_agg(build_id) filter (where status = ?) as current_builds,
+			count(*) filter (where status = ? and last_active >= ?) as completed_builds,
+			count(*) filter (where status = ? and last_active >= ?) as failed_builds
+		from build
+		where builder_id in (?)
+		and (status = ? or last_active >= ?)
+		group by builder_id`
+	_, err := b.cp.GetConnection().Query(&result, query,
+		view.StatusRunning,
+		view.StatusComplete, finishedSince,
+		view.StatusError, finishedSince,
+		pg.In(builderIds),
+		view.StatusRunning, finishedSince)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStaleBuilderIds returns builders which stopped sending heartbeats but still have running builds
+func (b builderRepositoryImpl) GetStaleBuilderIds(inactiveSince time.Time) ([]string, error) {
+	var result []string
+	_, err := b.cp.GetConnection().Query(&result,
+		`select br.builder_id from builder br
+		where br.last_heartbeat < ?
+		and exists(select 1 from build b where b.builder_id = br.builder_id and b.status = ?)`,
+		inactiveSince, view.StatusRunning)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// HasActiveUnregisteredBuilders checks if builds were recently taken or updated by builders which don't send heartbeats
+// (e.g. builders of old versions), such builders don't report capabilities and could take any build
+func (b builderRepositoryImpl) HasActiveUnregisteredBuilders(activeSince time.Time) (bool, error) {
+	var result bool
+	_, err := b.cp.GetConnection().QueryOne(pg.Scan(&result),
+		`select exists(
+			select 1 from build b
+			where coalesce(b.builder_id, '') != '' and b.last_active >= ?
+			and not exists(select 1 from builder br where br.builder_id = b.builder_id))`,
+		activeSince)
+	if err != nil {
+		return false, err
+	}
+	return result, nil
+}
+
+func (b builderRepositoryImpl) DeleteInactiveBuilders(inactiveSince time.Time) (int, error) {
+	res, err := b.cp.GetConnection().Model(&entity.BuilderEntity{}).
+		Where("last_heartbeat < ?", inactiveSince).
+		Delete()
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+func (b builderRepositoryImpl) GetLastReclaimRun() (*entity.BuilderReclaimRunEntity, error) {
+	result := new(entity.BuilderReclaimRunEntity)
+	err := b.cp.GetConnection().Model(result).
+		OrderExpr("run_id DESC").Limit(1).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b builderRepositoryImpl) StoreReclaimRun(ent entity.BuilderReclaimRunEntity) error {
+	_, err := b.cp.GetConnection().Model(&ent).Insert()
+	return err
+}
+
+func (b builderRepositoryImpl) DeleteReclaimRuns(scheduledBefore time.Time) error {
+	_, err := b.cp.GetConnection().Model(&entity.BuilderReclaimRunEntity{}).
+		Where("scheduled_at < ?", scheduledBefore).
+		Delete()
+	return err
+}
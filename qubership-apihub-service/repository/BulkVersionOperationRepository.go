@@ -0,0 +1,128 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type BulkVersionOperationRepository interface {
+	StoreOperation(ent *entity.BulkVersionOperationEntity) error
+	UpdateOperation(ent *entity.BulkVersionOperationEntity) error
+	GetOperation(packageId string, operationId string) (*entity.BulkVersionOperationEntity, error)
+	GetOperationReport(packageId string, operationId string) (*entity.BulkVersionOperationEntity, error)
+	GetVersions(rootPackageId string, filter view.BulkVersionFilter) ([]entity.PublishedVersionEntity, error)
+}
+
+func NewBulkVersionOperationRepository(cp db.ConnectionProvider) BulkVersionOperationRepository {
+	return &bulkVersionOperationRepositoryImpl{cp: cp}
+}
+
+type bulkVersionOperationRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (b bulkVersionOperationRepositoryImpl) StoreOperation(ent *entity.BulkVersionOperationEntity) error {
+	_, err := b.cp.GetConnection().Model(ent).Insert()
+	return err
+}
+
+func (b bulkVersionOperationRepositoryImpl) UpdateOperation(ent *entity.BulkVersionOperationEntity) error {
+	_, err := b.cp.GetConnection().Model(ent).
+		WherePK().
+		Set("message = ?message").
+		Set("status = ?status").
+		Set("report = ?report").
+		Update()
+	return err
+}
+
+func (b bulkVersionOperationRepositoryImpl) GetOperation(packageId string, operationId string) (*entity.BulkVersionOperationEntity, error) {
+	result := new(entity.BulkVersionOperationEntity)
+	err := b.cp.GetConnection().Model(result).
+		ExcludeColumn("report").
+		Where("operation_id = ?", operationId).
+		Where("package_id = ?", packageId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b bulkVersionOperationRepositoryImpl) GetOperationReport(packageId string, operationId string) (*entity.BulkVersionOperationEntity, error) {
+	result := new(entity.BulkVersionOperationEntity)
+	err := b.cp.GetConnection().Model(result).
+		Where("operation_id = ?", operationId).
+		Where("package_id = ?", packageId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetVersions returns the latest revisions of not deleted versions of the package and its descendants which match the filter
+func (b bulkVersionOperationRepositoryImpl) GetVersions(rootPackageId string, filter view.BulkVersionFilter) ([]entity.PublishedVersionEntity, error) {
+	var result []entity.PublishedVersionEntity
+	query := `with maxrev as
+		(
+			select package_id, version, max(revision) as revision
+			from published_version
+			where package_id = ? or package_id like ? || '.%'
+			group by package_id, version
+		)
+		select pv.* from published_version pv
+		inner join maxrev
+			on maxrev.package_id = pv.package_id
+			and maxrev.version = pv.version
+			and maxrev.revision = pv.revision
+		inner join package_group pg
+			on pg.id = pv.package_id
+			and pg.deleted_at is null
+		where pv.deleted_at is null`
+	params := []interface{}{rootPackageId, rootPackageId}
+	if filter.Status != "" {
+		query += ` and pv.status = ?`
+		params = append(params, filter.Status)
+	}
+	if filter.Label != "" {
+		query += ` and ? = any(pv.labels)`
+		params = append(params, filter.Label)
+	}
+	if filter.PublishedFrom != nil {
+		query += ` and pv.published_at >= ?`
+		params = append(params, *filter.PublishedFrom)
+	}
+	if filter.PublishedTo != nil {
+		query += ` and pv.published_at <= ?`
+		params = append(params, *filter.PublishedTo)
+	}
+	query += ` order by pv.package_id, pv.published_at`
+	_, err := b.cp.GetConnection().Query(&result, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -28,7 +28,8 @@ import (
 type ExportResultRepository interface {
 	SaveExportResult(ent entity.ExportResultEntity) error
 	GetExportResult(exportId string) (*entity.ExportResultEntity, error)
-	CleanupExportResults(ttl time.Duration) error
+	// CleanupExportResults deletes outdated export results and returns their ids
+	CleanupExportResults(ttl time.Duration) ([]string, error)
 
 	// deprecated??
 	SaveTransformedDocument(data *entity.TransformedContentDataEntity, publishId string) error
@@ -44,12 +45,11 @@ type exportRepositoryImpl struct {
 	cp db.ConnectionProvider
 }
 
-func (p exportRepositoryImpl) CleanupExportResults(ttl time.Duration) error {
-	var ent entity.ExportResultEntity
-	_, err := p.cp.GetConnection().Model(&ent).
-		Where("created_at < (now() - interval '? seconds')", int(ttl.Seconds())).
-		Delete()
-	return err
+func (p exportRepositoryImpl) CleanupExportResults(ttl time.Duration) ([]string, error) {
+	var exportIds []string
+	_, err := p.cp.GetConnection().Query(&exportIds,
+		`delete from export_result where created_at < (now() - interval '? seconds') returning export_id`, int(ttl.Seconds()))
+	return exportIds, err
 }
 
 func (p exportRepositoryImpl) SaveExportResult(exportResEnt entity.ExportResultEntity) error {
@@ -65,9 +65,12 @@ func (p exportRepositoryImpl) SaveExportResult(exportResEnt entity.ExportResultE
 		}
 		build := &ents[0]
 
-		//do not allow publish for "complete" builds and builds that are not failed with "Restart count exceeded limit"
+		if build.Status == string(view.StatusCancelled) {
+			return buildCancelledError(build.BuildId)
+		}
+		//do not allow publish for "complete" builds and failed builds which are not in dead letter state (i.e. not failed with "Restart count exceeded limit")
 		if build.Status == string(view.StatusComplete) ||
-			(build.Status == string(view.StatusError) && build.RestartCount < 2) {
+			(build.Status == string(view.StatusError) && !build.DeadLetter) {
 			return fmt.Errorf("failed to save export. Build with buildId='%v' is already published or failed", exportResEnt.ExportId)
 		}
 
@@ -119,9 +122,12 @@ func (p exportRepositoryImpl) SaveTransformedDocument(data *entity.TransformedCo
 		}
 		build := &ents[0]
 
-		//do not allow publish for "complete" builds and builds that are not failed with "Restart count exceeded limit"
+		if build.Status == string(view.StatusCancelled) {
+			return buildCancelledError(build.BuildId)
+		}
+		//do not allow publish for "complete" builds and failed builds which are not in dead letter state (i.e. not failed with "Restart count exceeded limit")
 		if build.Status == string(view.StatusComplete) ||
-			(build.Status == string(view.StatusError) && build.RestartCount < 2) {
+			(build.Status == string(view.StatusError) && !build.DeadLetter) {
 			return fmt.Errorf("failed to start document transformation. Build with buildId='%v' is already published or failed", publishId)
 		}
 
@@ -37,6 +37,10 @@ type OperationRepository interface {
 	GetChangelog(searchQuery entity.ChangelogSearchQueryEntity) ([]entity.OperationComparisonChangelogEntity, error)
 	SearchForOperations_deprecated(searchQuery *entity.OperationSearchQuery) ([]entity.OperationSearchResult_deprecated, error)
 	SearchForOperations(searchQuery *entity.OperationSearchQuery) ([]entity.OperationSearchResult, error)
+	GetOperationSearchFacets(searchQuery *entity.OperationSearchQuery, facetLimit int) ([]entity.SearchFacetEntity, error)
+	SearchForModels(searchQuery *entity.ModelSearchQuery) ([]entity.ModelSearchResult, error)
+	GetOperationsData(dataHashes []string) ([]entity.OperationDataEntity, error)
+	GetVersionOperationsMatchingSearch(searchQuery *entity.OperationSearchQuery, packageId string, version string, revision int) ([]entity.SavedSearchMatchedOperationEntity, error)
 	GetOperationsTypeCount(packageId string, version string, revision int) ([]entity.OperationsTypeCountEntity, error)
 	GetOperationsTypeDataHashes(packageId string, version string, revision int) ([]entity.OperationsTypeDataHashEntity, error)
 	GetOperationDeprecatedItems(packageId string, version string, revision int, operationType string, operationId string) (*entity.OperationRichEntity, error)
@@ -785,7 +789,6 @@ func (o operationRepositoryImpl) SearchForOperations_deprecated(searchQuery *ent
 	if err != nil {
 		return nil, fmt.Errorf("invalid search string: %v", err.Error())
 	}
-	searchQuery.TextFilter = "%" + utils.LikeEscaped(searchQuery.TextFilter) + "%"
 	var result []entity.OperationSearchResult_deprecated
 	operationsSearchQuery := `
 	with	maxrev as
@@ -830,6 +833,7 @@ func (o operationRepositoryImpl) SearchForOperations_deprecated(searchQuery *ent
 							and v.revision = o.revision
 							and (?api_type = '' or o.type = ?api_type)
 							and (?methods = '{}' or o.metadata->>'method' = ANY(?methods))
+					where ?0
 			)
 			select
 			o.package_id,
@@ -844,7 +848,7 @@ func (o operationRepositoryImpl) SearchForOperations_deprecated(searchQuery *ent
 			o.metadata,
 			parent_package_names(o.package_id) parent_names,
 			case
-				when init_rank > 0 then init_rank + version_status_tf + operation_open_count
+				when init_rank > 0 or ?structured_query then init_rank + version_status_tf + operation_open_count
 				else 0
 			end rank,
 
@@ -895,8 +899,7 @@ func (o operationRepositoryImpl) SearchForOperations_deprecated(searchQuery *ent
 					) ts
 					group by ts.data_hash
 					order by max(rank) desc
-					limit ?limit
-					offset ?offset
+					?1
 			) rest_ts
 				on rest_ts.data_hash = o.data_hash
 				and o.type = ?rest_api_type
@@ -927,12 +930,44 @@ func (o operationRepositoryImpl) SearchForOperations_deprecated(searchQuery *ent
 					) ts
 					group by ts.data_hash
 					order by max(rank) desc
-					limit ?limit
-					offset ?offset
+					?1
 			) graphql_ts
 				on graphql_ts.data_hash = o.data_hash
 				and o.type = ?graphql_api_type
 				and ?filter_all = false
+			left join (
+					select ts.data_hash, max(rank) as rank from (
+							with filtered as (select data_hash from operations)
+							select
+							ts.data_hash,
+							scope_rank rank
+							from
+							ts_protobuf_operation_data ts,
+							filtered f,
+							to_tsquery(?search_filter) search_query,
+							--using coalesce to skip ts_rank evaluation for scopes that are not requested
+							coalesce(case when ?filter_request then null else 0 end, ts_rank(scope_request, search_query)) req_rank,
+							coalesce(case when ?filter_response then null else 0 end, ts_rank(scope_response, search_query)) resp_rank,
+							coalesce(case when ?filter_annotation then null else 0 end, ts_rank(scope_annotation, search_query)) annotation_rank,
+							coalesce(case when ?filter_field then null else 0 end, ts_rank(scope_field, search_query)) field_rank,
+							coalesce(req_rank + resp_rank + annotation_rank + field_rank) scope_rank
+							where ts.data_hash = f.data_hash
+							and
+							(
+								(?filter_request = false and ?filter_response = false and ?filter_annotation = false and ?filter_field = false) or
+								(?filter_request and search_query @@ scope_request) or
+								(?filter_response and search_query @@ scope_response) or
+								(?filter_annotation and search_query @@ scope_annotation) or
+								(?filter_field and search_query @@ scope_field)
+							)
+					) ts
+					group by ts.data_hash
+					order by max(rank) desc
+					?1
+			) protobuf_ts
+				on protobuf_ts.data_hash = o.data_hash
+				and o.type = ?protobuf_api_type
+				and ?filter_all = false
 			left join (
 					select ts.data_hash, max(rank) as rank from (
 							with filtered as (select data_hash from operations)
@@ -950,8 +985,7 @@ func (o operationRepositoryImpl) SearchForOperations_deprecated(searchQuery *ent
 					) ts
 					group by ts.data_hash
 					order by max(rank) desc
-					limit ?limit
-					offset ?offset
+					?1
 			) all_ts
 				on all_ts.data_hash = o.data_hash
 				and ?filter_all = true
@@ -960,18 +994,19 @@ func (o operationRepositoryImpl) SearchForOperations_deprecated(searchQuery *ent
                 and oc.version = o.version
                 and oc.operation_id = o.operation_id,
 			coalesce(?title_weight * (o.title ilike ?text_filter)::int, 0) title_tf,
-			coalesce(?scope_weight * (coalesce(rest_ts.rank, 0) + coalesce(graphql_ts.rank, 0) + coalesce(all_ts.rank, 0)), 0) scope_tf,
+			coalesce(?scope_weight * (coalesce(rest_ts.rank, 0) + coalesce(graphql_ts.rank, 0) + coalesce(protobuf_ts.rank, 0) + coalesce(all_ts.rank, 0)), 0) scope_tf,
 			coalesce(title_tf + scope_tf, 0) init_rank,
 			coalesce(
 				?version_status_release_weight * (o.version_status = ?version_status_release)::int +
 				?version_status_draft_weight * (o.version_status = ?version_status_draft)::int +
 				?version_status_archived_weight * (o.version_status = ?version_status_archived)::int) version_status_tf,
 			coalesce(?open_count_weight * coalesce(oc.open_count), 0) operation_open_count
-			where init_rank > 0
+			where init_rank > 0 or ?structured_query
 			order by rank desc, o.version_published_at desc, o.operation_id
-			limit ?limit;
+			limit ?limit
+			offset (case when ?structured_query then ?offset else 0 end);
 	`
-	_, err = o.cp.GetConnection().Model(searchQuery).Query(&result, operationsSearchQuery)
+	_, err = o.cp.GetConnection().Model(searchQuery).Query(&result, operationsSearchQuery, makeOperationSearchQueryParams(searchQuery)...)
 	if err != nil {
 		if err == pg.ErrNoRows {
 			return nil, nil
@@ -987,9 +1022,20 @@ func (o operationRepositoryImpl) SearchForOperations(searchQuery *entity.Operati
 	if err != nil {
 		return nil, fmt.Errorf("invalid search string: %v", err.Error())
 	}
-	searchQuery.TextFilter = "%" + utils.LikeEscaped(searchQuery.TextFilter) + "%"
 	var result []entity.OperationSearchResult
-	operationsSearchQuery := `
+	_, err = o.cp.GetConnection().Model(searchQuery).Query(&result, operationsSearchQuery, makeOperationSearchQueryParams(searchQuery)...)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// operationsSearchQuery ranks operations matching the search query, ?0 is the structured query filter, ?1 is the page of the scope ranks
+const operationsSearchQuery = `
 	with	maxrev as
 			(
 					select package_id, version, pg.name as package_name, max(revision) as revision
@@ -1033,6 +1079,7 @@ func (o operationRepositoryImpl) SearchForOperations(searchQuery *entity.Operati
 							and (?api_type = '' or o.type = ?api_type)
 							and (?methods = '{}' or o.metadata->>'method' = ANY(?methods))
 							and (?operation_types = '{}' or o.metadata->>'type' = ANY(?operation_types))
+					where ?0
 			)
 			select
 			o.package_id,
@@ -1049,7 +1096,7 @@ func (o operationRepositoryImpl) SearchForOperations(searchQuery *entity.Operati
 			o.metadata,
 			parent_package_names(o.package_id) parent_names,
 			case
-				when init_rank > 0 then init_rank + version_status_tf + operation_open_count
+				when init_rank > 0 or ?structured_query then init_rank + version_status_tf + operation_open_count
 				else 0
 			end rank,
 
@@ -1100,8 +1147,7 @@ func (o operationRepositoryImpl) SearchForOperations(searchQuery *entity.Operati
 					) ts
 					group by ts.data_hash
 					order by max(rank) desc
-					limit ?limit
-					offset ?offset
+					?1
 			) rest_ts
 				on rest_ts.data_hash = o.data_hash
 				and o.type = ?rest_api_type
@@ -1132,12 +1178,44 @@ func (o operationRepositoryImpl) SearchForOperations(searchQuery *entity.Operati
 					) ts
 					group by ts.data_hash
 					order by max(rank) desc
-					limit ?limit
-					offset ?offset
+					?1
 			) graphql_ts
 				on graphql_ts.data_hash = o.data_hash
 				and o.type = ?graphql_api_type
 				and ?filter_all = false
+			left join (
+					select ts.data_hash, max(rank) as rank from (
+							with filtered as (select data_hash from operations)
+							select
+							ts.data_hash,
+							scope_rank rank
+							from
+							ts_protobuf_operation_data ts,
+							filtered f,
+							to_tsquery(?search_filter) search_query,
+							--using coalesce to skip ts_rank evaluation for scopes that are not requested
+							coalesce(case when ?filter_request then null else 0 end, ts_rank(scope_request, search_query)) req_rank,
+							coalesce(case when ?filter_response then null else 0 end, ts_rank(scope_response, search_query)) resp_rank,
+							coalesce(case when ?filter_annotation then null else 0 end, ts_rank(scope_annotation, search_query)) annotation_rank,
+							coalesce(case when ?filter_field then null else 0 end, ts_rank(scope_field, search_query)) field_rank,
+							coalesce(req_rank + resp_rank + annotation_rank + field_rank) scope_rank
+							where ts.data_hash = f.data_hash
+							and
+							(
+								(?filter_request = false and ?filter_response = false and ?filter_annotation = false and ?filter_field = false) or
+								(?filter_request and search_query @@ scope_request) or
+								(?filter_response and search_query @@ scope_response) or
+								(?filter_annotation and search_query @@ scope_annotation) or
+								(?filter_field and search_query @@ scope_field)
+							)
+					) ts
+					group by ts.data_hash
+					order by max(rank) desc
+					?1
+			) protobuf_ts
+				on protobuf_ts.data_hash = o.data_hash
+				and o.type = ?protobuf_api_type
+				and ?filter_all = false
 			left join (
 					select ts.data_hash, max(rank) as rank from (
 							with filtered as (select data_hash from operations)
@@ -1155,8 +1233,7 @@ func (o operationRepositoryImpl) SearchForOperations(searchQuery *entity.Operati
 					) ts
 					group by ts.data_hash
 					order by max(rank) desc
-					limit ?limit
-					offset ?offset
+					?1
 			) all_ts
 				on all_ts.data_hash = o.data_hash
 				and ?filter_all = true
@@ -1165,25 +1242,318 @@ func (o operationRepositoryImpl) SearchForOperations(searchQuery *entity.Operati
                 and oc.version = o.version
                 and oc.operation_id = o.operation_id,
 			coalesce(?title_weight * (o.title ilike ?text_filter)::int, 0) title_tf,
-			coalesce(?scope_weight * (coalesce(rest_ts.rank, 0) + coalesce(graphql_ts.rank, 0) + coalesce(all_ts.rank, 0)), 0) scope_tf,
+			coalesce(?scope_weight * (coalesce(rest_ts.rank, 0) + coalesce(graphql_ts.rank, 0) + coalesce(protobuf_ts.rank, 0) + coalesce(all_ts.rank, 0)), 0) scope_tf,
 			coalesce(title_tf + scope_tf, 0) init_rank,
 			coalesce(
 				?version_status_release_weight * (o.version_status = ?version_status_release)::int +
 				?version_status_draft_weight * (o.version_status = ?version_status_draft)::int +
 				?version_status_archived_weight * (o.version_status = ?version_status_archived)::int) version_status_tf,
 			coalesce(?open_count_weight * coalesce(oc.open_count), 0) operation_open_count
-			where init_rank > 0
+			where init_rank > 0 or ?structured_query
 			order by rank desc, o.version_published_at desc, o.operation_id
-			limit ?limit;
+			limit ?limit
+			offset (case when ?structured_query then ?offset else 0 end);
+`
+
+// operationSearchMatchCondition matches operations selected with "o" alias against the search query
+// in the same way as SearchForOperations, "search_query" shall be defined as to_tsquery(?search_filter)
+const operationSearchMatchCondition = `
+	(
+		?structured_query
+		or o.title ilike ?text_filter
+		or (?filter_all and exists(
+			select 1 from ts_operation_data ts
+			where ts.data_hash = o.data_hash
+			and search_query @@ ts.scope_all))
+		or (?filter_all = false and o.type = ?rest_api_type and exists(
+			select 1 from ts_rest_operation_data ts
+			where ts.data_hash = o.data_hash
+			and (
+				(?filter_request = false and ?filter_response = false) or
+				(?filter_request and search_query @@ ts.scope_request) or
+				(?filter_response and search_query @@ ts.scope_response)
+			)
+			and (
+				(?filter_annotation = false and ?filter_examples = false and ?filter_properties = false) or
+				(?filter_annotation and search_query @@ ts.scope_annotation) or
+				(?filter_examples and search_query @@ ts.scope_examples) or
+				(?filter_properties and search_query @@ ts.scope_properties)
+			)))
+		or (?filter_all = false and o.type = ?graphql_api_type and exists(
+			select 1 from ts_graphql_operation_data ts
+			where ts.data_hash = o.data_hash
+			and (
+				(?filter_annotation = false and ?filter_property = false and ?filter_argument = false) or
+				(?filter_annotation and search_query @@ ts.scope_annotation) or
+				(?filter_property and search_query @@ ts.scope_property) or
+				(?filter_argument and search_query @@ ts.scope_argument)
+			)))
+		or (?filter_all = false and o.type = ?protobuf_api_type and exists(
+			select 1 from ts_protobuf_operation_data ts
+			where ts.data_hash = o.data_hash
+			and (
+				(?filter_request = false and ?filter_response = false and ?filter_annotation = false and ?filter_field = false) or
+				(?filter_request and search_query @@ ts.scope_request) or
+				(?filter_response and search_query @@ ts.scope_response) or
+				(?filter_annotation and search_query @@ ts.scope_annotation) or
+				(?filter_field and search_query @@ ts.scope_field)
+			)))
+	)`
+
+// GetOperationSearchFacets calculates facet counts for all operations matching the search query, not only for the requested page.
+// Values of each facet are limited by facetLimit values with the highest counts.
+func (o operationRepositoryImpl) GetOperationSearchFacets(searchQuery *entity.OperationSearchQuery, facetLimit int) ([]entity.SearchFacetEntity, error) {
+	_, err := o.cp.GetConnection().Exec("select to_tsquery(?)", searchQuery.SearchString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search string: %v", err.Error())
+	}
+	var result []entity.SearchFacetEntity
+	facetsQuery := `
+	with	maxrev as
+			(
+					select package_id, version, pg.name as package_name, max(revision) as revision
+					from published_version pv
+						inner join package_group pg
+							on pg.id = pv.package_id
+							and pg.exclude_from_search = false
+					where (?packages = '{}' or package_id like ANY(
+						select id from unnest(?packages::text[]) id
+						union
+						select id||'.%' from unnest(?packages::text[]) id))
+					and (?versions = '{}' or version = ANY(?versions))
+					group by package_id, version, pg.name
+			),
+			versions as
+			(
+					select pv.package_id, pv.version, pv.revision, pv.published_at, pv.status, maxrev.package_name
+					from published_version pv
+					inner join maxrev
+							on pv.package_id = maxrev.package_id
+							and pv.version = maxrev.version
+							and pv.revision = maxrev.revision
+					where pv.deleted_at is null
+							and (?statuses = '{}' or pv.status = ANY(?statuses))
+							and pv.published_at >= ?start_date
+							and pv.published_at <= ?end_date
+			),
+			operations as
+			(
+					select o.*, v.status version_status, v.package_name, v.published_at version_published_at
+					from operation o
+					inner join versions v
+							on v.package_id = o.package_id
+							and v.version = o.version
+							and v.revision = o.revision
+							and (?api_type = '' or o.type = ?api_type)
+							and (?methods = '{}' or o.metadata->>'method' = ANY(?methods))
+							and (?operation_types = '{}' or o.metadata->>'type' = ANY(?operation_types))
+					where ?0
+			),
+			matched as
+			(
+					select o.* from operations o, to_tsquery(?search_filter) search_query
+					where ` + operationSearchMatchCondition + `
+			),
+			facets as
+			(
+					select 'total' facet, '' as value, '' as name, count(*) count from matched
+					union all
+					select 'packagesCount', '', '', count(distinct package_id) from matched
+					union all
+					select 'package', package_id, max(package_name), count(*) from matched group by package_id
+					union all
+					select 'parentGroup', parent.id, max(parent.name), count(*)
+					from matched m
+					inner join package_group pg on pg.id = m.package_id
+					inner join package_group parent on parent.id = pg.parent_id
+					group by parent.id
+					union all
+					select 'status', version_status, '', count(*) from matched group by version_status
+					union all
+					select 'apiType', type, '', count(*) from matched group by type
+					union all
+					select 'method', metadata->>'method', '', count(*) from matched
+					where type = ?rest_api_type and metadata->>'method' is not null
+					group by metadata->>'method'
+					union all
+					select 'apiKind', kind, '', count(*) from matched where kind is not null group by kind
+					union all
+					select 'tag', tag, '', count(*)
+					from matched m, jsonb_array_elements_text(coalesce(m.metadata -> 'tags', '[]')) tag
+					group by tag
+					union all
+					select 'publicationMonth', to_char(version_published_at, 'YYYY-MM'), '', count(*)
+					from matched group by to_char(version_published_at, 'YYYY-MM')
+			)
+			select facet, value, name, count from (
+				select f.*, row_number() over (partition by facet order by count desc, value) rn from facets f
+			) f
+			where rn <= ?1
+			order by facet, count desc, value;
+	`
+	_, err = o.cp.GetConnection().Model(searchQuery).Query(&result, facetsQuery, makeOperationSearchQueryFilter(searchQuery.Query, searchQuery.OperationSearchScopeFilter), facetLimit)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetVersionOperationsMatchingSearch returns operations of the specific package version revision matching the search query.
+// Version status and publication date filters are applied to the revision itself.
+func (o operationRepositoryImpl) GetVersionOperationsMatchingSearch(searchQuery *entity.OperationSearchQuery, packageId string, version string, revision int) ([]entity.SavedSearchMatchedOperationEntity, error) {
+	_, err := o.cp.GetConnection().Exec("select to_tsquery(?)", searchQuery.SearchString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search string: %v", err.Error())
+	}
+	var result []entity.SavedSearchMatchedOperationEntity
+	versionMatchQuery := `
+	with	operations as
+			(
+					select o.*
+					from operation o
+					inner join published_version pv
+							on pv.package_id = o.package_id
+							and pv.version = o.version
+							and pv.revision = o.revision
+							and pv.deleted_at is null
+							and (?statuses = '{}' or pv.status = ANY(?statuses))
+							and pv.published_at >= ?start_date
+							and pv.published_at <= ?end_date
+					inner join package_group pg
+							on pg.id = o.package_id
+							and pg.exclude_from_search = false
+					where o.package_id = ?1
+							and o.version = ?2
+							and o.revision = ?3
+							and (?packages = '{}' or o.package_id like ANY(
+								select id from unnest(?packages::text[]) id
+								union
+								select id||'.%' from unnest(?packages::text[]) id))
+							and (?versions = '{}' or o.version = ANY(?versions))
+							and (?api_type = '' or o.type = ?api_type)
+							and (?methods = '{}' or o.metadata->>'method' = ANY(?methods))
+							and (?operation_types = '{}' or o.metadata->>'type' = ANY(?operation_types))
+							and ?0
+			)
+			select o.operation_id, o.title, o.type
+			from operations o, to_tsquery(?search_filter) search_query
+			where ` + operationSearchMatchCondition + `
+			order by o.operation_id;
+	`
+	_, err = o.cp.GetConnection().Model(searchQuery).Query(&result, versionMatchQuery, makeOperationSearchQueryFilter(searchQuery.Query, searchQuery.OperationSearchScopeFilter), packageId, version, revision)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (o operationRepositoryImpl) SearchForModels(searchQuery *entity.ModelSearchQuery) ([]entity.ModelSearchResult, error) {
+	var result []entity.ModelSearchResult
+	modelsSearchQuery := `
+	with	maxrev as
+			(
+					select package_id, version, pg.name as package_name, max(revision) as revision
+					from published_version pv
+						inner join package_group pg
+							on pg.id = pv.package_id
+							and pg.exclude_from_search = false
+					where (?packages = '{}' or package_id like ANY(
+						select id from unnest(?packages::text[]) id
+						union
+						select id||'.%' from unnest(?packages::text[]) id))
+					and (?versions = '{}' or version = ANY(?versions))
+					group by package_id, version, pg.name
+			),
+			versions as
+			(
+					select pv.package_id, pv.version, pv.revision, pv.published_at, pv.status, maxrev.package_name
+					from published_version pv
+					inner join maxrev
+							on pv.package_id = maxrev.package_id
+							and pv.version = maxrev.version
+							and pv.revision = maxrev.revision
+					where pv.deleted_at is null
+							and (?statuses = '{}' or pv.status = ANY(?statuses))
+							and pv.published_at >= ?start_date
+							and pv.published_at <= ?end_date
+			),
+			--results are grouped by package version, so paging is applied to the versions which have matching models
+			matched_versions as
+			(
+					select v.* from versions v
+					where exists(
+						select 1 from operation o, jsonb_object_keys(o.models) model_name
+						where o.package_id = v.package_id
+						and o.version = v.version
+						and o.revision = v.revision
+						and (?api_type = '' or o.type = ?api_type)
+						and lower(model_name) like ?model_name)
+					order by v.published_at desc, v.package_id, v.version
+					limit ?limit
+					offset ?offset
+			),
+			operation_models as
+			(
+					select o.package_id, o.version, o.revision, o.operation_id, o.title, o.type, o.data_hash,
+					v.package_name, v.status, v.published_at,
+					jsonb_object_agg(m.key, m.value) models
+					from operation o
+					inner join matched_versions v
+							on v.package_id = o.package_id
+							and v.version = o.version
+							and v.revision = o.revision
+							and (?api_type = '' or o.type = ?api_type),
+					jsonb_each_text(o.models) m
+					where lower(m.key) like ?model_name
+					group by o.package_id, o.version, o.revision, o.operation_id, o.title, o.type, o.data_hash,
+					v.package_name, v.status, v.published_at
+			)
+			select
+			om.package_id,
+			om.package_name name,
+			parent_package_names(om.package_id) parent_names,
+			om.version,
+			om.revision,
+			om.status,
+			om.operation_id,
+			om.title,
+			om.type,
+			om.data_hash,
+			om.models
+			from operation_models om
+			order by om.published_at desc, om.package_id, om.version, om.operation_id;
 	`
-	_, err = o.cp.GetConnection().Model(searchQuery).Query(&result, operationsSearchQuery)
+	_, err := o.cp.GetConnection().Model(searchQuery).Query(&result, modelsSearchQuery)
 	if err != nil {
 		if err == pg.ErrNoRows {
 			return nil, nil
 		}
 		return nil, err
 	}
+	return result, nil
+}
 
+func (o operationRepositoryImpl) GetOperationsData(dataHashes []string) ([]entity.OperationDataEntity, error) {
+	if len(dataHashes) == 0 {
+		return nil, nil
+	}
+	var result []entity.OperationDataEntity
+	err := o.cp.GetConnection().Model(&result).
+		Column("data_hash", "data").
+		Where("data_hash in (?)", pg.In(dataHashes)).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -0,0 +1,164 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+// makeOperationSearchQueryFilter compiles parsed search query into sql condition for operations selected with "o" alias.
+// Free text terms are matched against the search scopes requested in operationParams.
+func makeOperationSearchQueryFilter(queryNode *view.SearchQueryNode, scopes entity.OperationSearchScopeFilter) *orm.SafeQueryAppender {
+	if queryNode == nil {
+		return pg.SafeQuery("true")
+	}
+	params := make([]interface{}, 0)
+	condition := makeSearchQueryCondition(*queryNode, scopes, &params)
+	return pg.SafeQuery(condition, params...)
+}
+
+// makeOperationSearchQueryParams returns positional params of the operation search queries:
+// the structured query filter and the page of the operations ranked by the search scopes.
+// Structured query is applied as a filter, so all matching operations are ranked and the page is taken from the final result.
+func makeOperationSearchQueryParams(searchQuery *entity.OperationSearchQuery) []interface{} {
+	rankPage := pg.SafeQuery("limit ? offset ?", searchQuery.Limit, searchQuery.Offset)
+	if searchQuery.StructuredQuery {
+		rankPage = pg.SafeQuery("")
+	}
+	return []interface{}{makeOperationSearchQueryFilter(searchQuery.Query, searchQuery.OperationSearchScopeFilter), rankPage}
+}
+
+func makeSearchQueryCondition(queryNode view.SearchQueryNode, scopes entity.OperationSearchScopeFilter, params *[]interface{}) string {
+	switch queryNode.Type {
+	case view.SearchQueryAnd, view.SearchQueryOr:
+		conditions := make([]string, 0, len(queryNode.Children))
+		for _, child := range queryNode.Children {
+			conditions = append(conditions, makeSearchQueryCondition(child, scopes, params))
+		}
+		return "(" + strings.Join(conditions, " "+string(queryNode.Type)+" ") + ")"
+	case view.SearchQueryNot:
+		return "not " + makeSearchQueryCondition(queryNode.Children[0], scopes, params)
+	}
+	switch queryNode.Field {
+	case view.SearchQueryFieldPath:
+		*params = append(*params, makeSearchQueryPattern(queryNode.Value))
+		return "(coalesce(o.metadata->>'path', '') ilike ?)"
+	case view.SearchQueryFieldMethod:
+		*params = append(*params, makeSearchQueryPattern(queryNode.Value))
+		return "(coalesce(o.metadata->>'method', '') ilike ?)"
+	case view.SearchQueryFieldTag:
+		*params = append(*params, makeSearchQueryPattern(queryNode.Value))
+		return "exists(select 1 from jsonb_array_elements_text(coalesce(o.metadata -> 'tags', '[]')) tag where tag ilike ?)"
+	case view.SearchQueryFieldKind:
+		*params = append(*params, makeSearchQueryPattern(queryNode.Value))
+		return "(o.kind ilike ?)"
+	case view.SearchQueryFieldAudience:
+		*params = append(*params, makeSearchQueryPattern(queryNode.Value))
+		return "(o.api_audience ilike ?)"
+	case view.SearchQueryFieldDeprecated:
+		*params = append(*params, queryNode.Value == "true")
+		return "(o.deprecated = ?)"
+	case view.SearchQueryFieldPackage:
+		*params = append(*params, makeSearchQueryPattern(queryNode.Value))
+		return "(o.package_id like ?)"
+	}
+	tsQuery := "plainto_tsquery(?)"
+	if queryNode.Phrase {
+		tsQuery = "phraseto_tsquery(?)"
+	}
+	*params = append(*params, "%"+utils.LikeEscaped(queryNode.Value)+"%")
+	return "(o.title ilike ? or " + makeSearchScopesCondition(queryNode.Value, tsQuery, scopes, params) + ")"
+}
+
+type searchScopeColumn struct {
+	requested bool
+	column    string
+}
+
+type apiTypeSearchScopes struct {
+	apiType view.ApiType
+	table   string
+	// columns of each group are joined with OR, groups are joined with AND
+	groups [][]searchScopeColumn
+}
+
+// makeSearchScopesCondition matches free text term against full text search data of the requested scopes the same way as plain search does.
+// Operations of api types without requested scopes are matched against all their data.
+func makeSearchScopesCondition(value string, tsQuery string, scopes entity.OperationSearchScopeFilter, params *[]interface{}) string {
+	if scopes.FilterAll {
+		*params = append(*params, value)
+		return "exists(select 1 from ts_operation_data ts where ts.data_hash = o.data_hash and ts.scope_all @@ " + tsQuery + ")"
+	}
+	apiTypesScopes := []apiTypeSearchScopes{
+		{
+			apiType: view.RestApiType,
+			table:   "ts_rest_operation_data",
+			groups: [][]searchScopeColumn{
+				{{scopes.FilterRequest, "scope_request"}, {scopes.FilterResponse, "scope_response"}},
+				{{scopes.FilterAnnotation, "scope_annotation"}, {scopes.FilterExamples, "scope_examples"}, {scopes.FilterProperties, "scope_properties"}},
+			},
+		},
+		{
+			apiType: view.GraphqlApiType,
+			table:   "ts_graphql_operation_data",
+			groups: [][]searchScopeColumn{
+				{{scopes.FilterAnnotation, "scope_annotation"}, {scopes.FilterProperty, "scope_property"}, {scopes.FilterArgument, "scope_argument"}},
+			},
+		},
+		{
+			apiType: view.ProtobufApiType,
+			table:   "ts_protobuf_operation_data",
+			groups: [][]searchScopeColumn{
+				{{scopes.FilterRequest, "scope_request"}, {scopes.FilterResponse, "scope_response"}, {scopes.FilterAnnotation, "scope_annotation"}, {scopes.FilterField, "scope_field"}},
+			},
+		},
+	}
+	conditions := make([]string, 0, len(apiTypesScopes))
+	for _, apiTypeScopes := range apiTypesScopes {
+		groupConditions := make([]string, 0, len(apiTypeScopes.groups))
+		for _, group := range apiTypeScopes.groups {
+			matches := make([]string, 0, len(group))
+			for _, scope := range group {
+				if scope.requested {
+					matches = append(matches, "search_query @@ ts."+scope.column)
+				}
+			}
+			if len(matches) != 0 {
+				groupConditions = append(groupConditions, "("+strings.Join(matches, " or ")+")")
+			}
+		}
+		table := apiTypeScopes.table
+		if len(groupConditions) == 0 {
+			table = "ts_operation_data"
+			groupConditions = append(groupConditions, "search_query @@ ts.scope_all")
+		}
+		*params = append(*params, string(apiTypeScopes.apiType), value)
+		conditions = append(conditions, fmt.Sprintf("(o.type = ? and exists(select 1 from %v ts, %v search_query where ts.data_hash = o.data_hash and %v))",
+			table, tsQuery, strings.Join(groupConditions, " and ")))
+	}
+	return "(" + strings.Join(conditions, " or ") + ")"
+}
+
+// makeSearchQueryPattern converts query value with '*' wildcards into like pattern
+func makeSearchQueryPattern(value string) string {
+	return strings.ReplaceAll(utils.LikeEscaped(value), "*", "%")
+}
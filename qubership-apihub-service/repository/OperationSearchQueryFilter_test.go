@@ -0,0 +1,135 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10/orm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeSearchQueryCondition(t *testing.T) {
+	allScopes := entity.OperationSearchScopeFilter{FilterAll: true}
+	tests := []struct {
+		query             string
+		expectedCondition string
+		expectedParams    []interface{}
+	}{
+		{
+			query:             "path:/users/*",
+			expectedCondition: "(coalesce(o.metadata->>'path', '') ilike ?)",
+			expectedParams:    []interface{}{"/users/%"},
+		},
+		{
+			query:             "path:/a_b%c*",
+			expectedCondition: "(coalesce(o.metadata->>'path', '') ilike ?)",
+			expectedParams:    []interface{}{`/a\_b\%c%`},
+		},
+		{
+			query:             "method:get OR NOT deprecated:TRUE",
+			expectedCondition: "((coalesce(o.metadata->>'method', '') ilike ?) or not (o.deprecated = ?))",
+			expectedParams:    []interface{}{"get", true},
+		},
+		{
+			query:             "deprecated:false kind:no-bwc audience:internal",
+			expectedCondition: "((o.deprecated = ?) and (o.kind ilike ?) and (o.api_audience ilike ?))",
+			expectedParams:    []interface{}{false, "no-bwc", "internal"},
+		},
+		{
+			query:             "tag:billing package:QS.*",
+			expectedCondition: "(exists(select 1 from jsonb_array_elements_text(coalesce(o.metadata -> 'tags', '[]')) tag where tag ilike ?) and (o.package_id like ?))",
+			expectedParams:    []interface{}{"billing", "QS.%"},
+		},
+		{
+			query:             "user",
+			expectedCondition: "(o.title ilike ? or exists(select 1 from ts_operation_data ts where ts.data_hash = o.data_hash and ts.scope_all @@ plainto_tsquery(?)))",
+			expectedParams:    []interface{}{"%user%", "user"},
+		},
+		{
+			query:             `"user id"`,
+			expectedCondition: "(o.title ilike ? or exists(select 1 from ts_operation_data ts where ts.data_hash = o.data_hash and ts.scope_all @@ phraseto_tsquery(?)))",
+			expectedParams:    []interface{}{"%user id%", "user id"},
+		},
+	}
+	for _, test := range tests {
+		queryNode, err := view.ParseSearchQuery(test.query)
+		if !assert.NoError(t, err, test.query) {
+			continue
+		}
+		params := make([]interface{}, 0)
+		condition := makeSearchQueryCondition(*queryNode, allScopes, &params)
+		assert.Equal(t, test.expectedCondition, condition, test.query)
+		assert.Equal(t, test.expectedParams, params, test.query)
+	}
+}
+
+func TestMakeSearchQueryConditionWithScopes(t *testing.T) {
+	queryNode, err := view.ParseSearchQuery(`"user id"`)
+	if !assert.NoError(t, err) {
+		return
+	}
+	params := make([]interface{}, 0)
+	condition := makeSearchQueryCondition(*queryNode, entity.OperationSearchScopeFilter{FilterRequest: true, FilterProperties: true, FilterExamples: true}, &params)
+	assert.Equal(t, "(o.title ilike ? or ("+
+		"(o.type = ? and exists(select 1 from ts_rest_operation_data ts, phraseto_tsquery(?) search_query where ts.data_hash = o.data_hash and "+
+		"(search_query @@ ts.scope_request) and (search_query @@ ts.scope_examples or search_query @@ ts.scope_properties))) or "+
+		"(o.type = ? and exists(select 1 from ts_operation_data ts, phraseto_tsquery(?) search_query where ts.data_hash = o.data_hash and search_query @@ ts.scope_all)) or "+
+		"(o.type = ? and exists(select 1 from ts_protobuf_operation_data ts, phraseto_tsquery(?) search_query where ts.data_hash = o.data_hash and (search_query @@ ts.scope_request)))))",
+		condition)
+	assert.Equal(t, []interface{}{"%user id%", "rest", "user id", "graphql", "user id", "protobuf", "user id"}, params)
+
+	params = make([]interface{}, 0)
+	condition = makeSearchQueryCondition(*queryNode, entity.OperationSearchScopeFilter{FilterArgument: true}, &params)
+	assert.Contains(t, condition, "from ts_graphql_operation_data ts, phraseto_tsquery(?) search_query where ts.data_hash = o.data_hash and (search_query @@ ts.scope_argument)")
+	assert.Equal(t, 7, len(params))
+}
+
+func TestOperationsSearchQueryPaging(t *testing.T) {
+	for page := 0; page < 3; page++ {
+		searchQuery, err := entity.MakeOperationSearchQueryEntity(&view.SearchQueryReq{
+			SearchString: "path:/users/* get user",
+			Limit:        20,
+			Page:         page,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		searchQuery.FilterAll = true
+		query := formatOperationsSearchQuery(searchQuery)
+		assert.Equal(t, 1, strings.Count(query, "limit "), "scope ranks must not be paged for structured query")
+		assert.Contains(t, query, "limit 20\n")
+		assert.Contains(t, query, fmt.Sprintf("offset (case when TRUE then %d else 0 end)", page*20))
+		assert.Contains(t, query, "(coalesce(o.metadata->>'path', '') ilike '/users/%')")
+		assert.Contains(t, query, "o.title ilike '%get%user%'")
+	}
+
+	searchQuery, err := entity.MakeOperationSearchQueryEntity(&view.SearchQueryReq{SearchString: "get user", Limit: 20, Page: 2})
+	if !assert.NoError(t, err) {
+		return
+	}
+	query := formatOperationsSearchQuery(searchQuery)
+	assert.Equal(t, 4, strings.Count(query, "limit 20 offset 40"), "scope ranks must be paged for plain search")
+	assert.Contains(t, query, "offset (case when FALSE then 40 else 0 end)")
+	assert.Contains(t, query, "o.title ilike '%get user%'")
+}
+
+func formatOperationsSearchQuery(searchQuery *entity.OperationSearchQuery) string {
+	return string(orm.NewFormatter().WithModel(orm.NewQuery(nil, searchQuery)).FormatQuery(nil, operationsSearchQuery, makeOperationSearchQueryParams(searchQuery)...))
+}
@@ -0,0 +1,163 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type PublishGateRepository interface {
+	GetPolicyForHierarchy(packageId string) (*entity.PublishGatePolicyExtEntity, error)
+	SetPolicy(ent entity.PublishGatePolicyEntity) error
+	DeletePolicy(packageId string) error
+
+	CreateRequest(ent entity.PublishGateRequestEntity) error
+	GetRequest(requestId string) (*entity.PublishGateRequestEntity, error)
+	GetRequestByApprovedBuildId(buildId string) (*entity.PublishGateRequestEntity, error)
+	ListRequests(packageId string, state string) ([]entity.PublishGateRequestEntity, error)
+	ResolveRequest(requestId string, state string, resolvedBy string, approvedBuildId string) (bool, error)
+	ReopenRequest(requestId string, approvedBuildId string) error
+}
+
+func NewPublishGateRepository(cp db.ConnectionProvider) PublishGateRepository {
+	return &publishGateRepositoryImpl{cp: cp}
+}
+
+type publishGateRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+// GetPolicyForHierarchy returns the policy of the package itself or of the closest parent which has it
+func (p publishGateRepositoryImpl) GetPolicyForHierarchy(packageId string) (*entity.PublishGatePolicyExtEntity, error) {
+	packageIds := utils.GetPackageHierarchy(packageId)
+	result := new(entity.PublishGatePolicyExtEntity)
+	err := p.cp.GetConnection().Model(result).
+		ColumnExpr("publish_gate_policy.*").
+		ColumnExpr("p.name as package_name").
+		ColumnExpr("p.kind as package_kind").
+		Join("inner join package_group p").
+		JoinOn("publish_gate_policy.package_id = p.id").
+		Where("publish_gate_policy.package_id in (?)", pg.In(packageIds)).
+		OrderExpr("length(publish_gate_policy.package_id) desc").
+		Limit(1).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p publishGateRepositoryImpl) SetPolicy(ent entity.PublishGatePolicyEntity) error {
+	_, err := p.cp.GetConnection().Model(&ent).
+		OnConflict("(package_id) DO UPDATE").
+		Insert()
+	return err
+}
+
+func (p publishGateRepositoryImpl) DeletePolicy(packageId string) error {
+	_, err := p.cp.GetConnection().Model(&entity.PublishGatePolicyEntity{}).
+		Where("package_id = ?", packageId).
+		Delete()
+	return err
+}
+
+func (p publishGateRepositoryImpl) CreateRequest(ent entity.PublishGateRequestEntity) error {
+	// the same build result could be sent more than once
+	_, err := p.cp.GetConnection().Model(&ent).
+		OnConflict("(request_id) DO NOTHING").
+		Insert()
+	return err
+}
+
+func (p publishGateRepositoryImpl) GetRequest(requestId string) (*entity.PublishGateRequestEntity, error) {
+	result := new(entity.PublishGateRequestEntity)
+	err := p.cp.GetConnection().Model(result).
+		Where("request_id = ?", requestId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p publishGateRepositoryImpl) GetRequestByApprovedBuildId(buildId string) (*entity.PublishGateRequestEntity, error) {
+	result := new(entity.PublishGateRequestEntity)
+	err := p.cp.GetConnection().Model(result).
+		Where("approved_build_id = ?", buildId).
+		Where("state = ?", view.PublishGateRequestApproved).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p publishGateRepositoryImpl) ListRequests(packageId string, state string) ([]entity.PublishGateRequestEntity, error) {
+	var result []entity.PublishGateRequestEntity
+	query := p.cp.GetConnection().Model(&result).
+		Where("package_id = ?", packageId)
+	if state != "" {
+		query.Where("state = ?", state)
+	}
+	err := query.Order("created_at DESC").Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ResolveRequest changes state of the pending request, returns false if the request is not pending anymore
+func (p publishGateRepositoryImpl) ResolveRequest(requestId string, state string, resolvedBy string, approvedBuildId string) (bool, error) {
+	result, err := p.cp.GetConnection().Model(&entity.PublishGateRequestEntity{}).
+		Set("state = ?", state).
+		Set("resolved_by = ?", resolvedBy).
+		Set("resolved_at = ?", time.Now()).
+		Set("approved_build_id = nullif(?, '')", approvedBuildId).
+		Where("request_id = ?", requestId).
+		Where("state = ?", view.PublishGateRequestPending).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ReopenRequest returns the request approved with the given build back to pending state
+func (p publishGateRepositoryImpl) ReopenRequest(requestId string, approvedBuildId string) error {
+	_, err := p.cp.GetConnection().Model(&entity.PublishGateRequestEntity{}).
+		Set("state = ?", view.PublishGateRequestPending).
+		Set("resolved_by = null").
+		Set("resolved_at = null").
+		Set("approved_build_id = null").
+		Where("request_id = ?", requestId).
+		Where("approved_build_id = ?", approvedBuildId).
+		Update()
+	return err
+}
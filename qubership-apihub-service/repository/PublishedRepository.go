@@ -41,12 +41,10 @@ type PublishedRepository interface {
 	GetRevisionContentBySlug(packageId string, versionName string, slug string, revision int) (*entity.PublishedContentEntity, error)
 	GetLatestContentByVersion(packageId string, versionName string) ([]entity.PublishedContentEntity, error)
 
-	GetVersionSources(packageId string, versionName string, revision int) (*entity.PublishedSrcArchiveEntity, error)
-	GetPublishedVersionSourceDataConfig(packageId string, versionName string, revision int) (*entity.PublishedSrcDataConfigEntity, error)
 	GetPublishedSources(packageId string, versionName string, revision int) (*entity.PublishedSrcEntity, error)
 
 	CreateVersionWithData(packageInfo view.PackageInfoFile, publishId string, version *entity.PublishedVersionEntity, content []*entity.PublishedContentEntity,
-		data []*entity.PublishedContentDataEntity, refs []*entity.PublishedReferenceEntity, src *entity.PublishedSrcEntity, srcArchive *entity.PublishedSrcArchiveEntity,
+		data []*entity.PublishedContentDataEntity, refs []*entity.PublishedReferenceEntity, src *entity.PublishedSrcEntity,
 		operations []*entity.OperationEntity, operationsData []*entity.OperationDataEntity,
 		operationComparisons []*entity.OperationComparisonEntity, builderNotifications []*entity.BuilderNotificationsEntity,
 		versionComparisonEntities []*entity.VersionComparisonEntity, serviceName string, pkg *entity.PackageEntity, versionComparisonsFromCache []string) error
@@ -63,6 +61,8 @@ type PublishedRepository interface {
 	GetLastVersions(ids []string) ([]entity.PublishedVersionEntity, error)
 	GetLastVersion(id string) (*entity.PublishedVersionEntity, error)
 	GetDefaultVersion(packageId string, status string) (*entity.PublishedVersionEntity, error)
+	GetLatestVersionWithLabels(packageId string, labels []string) (*entity.PublishedVersionEntity, error)
+	GetVersionsByStatus(packageId string, status string) ([]entity.PublishedVersionEntity, error)
 	CleanupDeleted() error
 	DeleteDraftVersionsBeforeDate(packageId string, date time.Time, userId string) (int, error)
 
@@ -103,9 +103,6 @@ type PublishedRepository interface {
 	GetLatestRevision(packageId, version string) (int, error)
 
 	GetVersionRevisionContentForDocumentsTransformation(packageId string, version string, revision int, searchQuery entity.ContentForDocumentsTransformationSearchQueryEntity) ([]entity.PublishedContentWithDataEntity, error)
-	GetPublishedSourcesArchives(offset int) (*entity.PublishedSrcArchiveEntity, error)
-	DeletePublishedSourcesArchives(checksums []string) error
-	SavePublishedSourcesArchive(ent *entity.PublishedSrcArchiveEntity) error
 	GetPublishedVersionsHistory(filter view.PublishedVersionHistoryFilter) ([]entity.PackageVersionHistoryEntity, error)
 
 	StoreOperationGroupPublishProcess(ent *entity.OperationGroupPublishEntity) error
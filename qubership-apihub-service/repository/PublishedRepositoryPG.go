@@ -23,7 +23,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/pkg/errors"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
 	mEntity "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/entity"
@@ -79,6 +78,18 @@ func (p publishedRepositoryImpl) MarkVersionDeleted(packageId string, versionNam
 			}
 		}
 
+		// links kept from the previous deletion of the version with the same name are outdated
+		_, err = tx.Exec(`delete from deleted_default_release_version where package_id = ? and version = ?`, packageId, versionName)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`insert into deleted_default_release_version
+			select id, default_released_version from package_group
+			where default_released_version = ? and id = ?
+			on conflict (package_id) do update set version = excluded.version`, versionName, packageId)
+		if err != nil {
+			return err
+		}
 		clearDefaultReleaseVersionForProjectQuery := `
 			UPDATE package_group
 			SET default_released_version = null
@@ -87,16 +98,16 @@ func (p publishedRepositoryImpl) MarkVersionDeleted(packageId string, versionNam
 		if err != nil {
 			return err
 		}
+		_, err = tx.Exec(`insert into deleted_grouped_operation select * from grouped_operation where package_id = ? and version = ?`, packageId, versionName)
+		if err != nil {
+			return err
+		}
 		_, err = tx.Exec(`delete from grouped_operation where package_id = ? and version = ?`, packageId, versionName)
 		if err != nil {
 			return err
 		}
 
-		clearPreviousVersionQuery := `
-			UPDATE published_version
-			SET previous_version = null, previous_version_package_id = null
-			WHERE previous_version = ? AND (previous_version_package_id = ? OR ((previous_version_package_id = '' or previous_version_package_id is null) and package_id = ?))`
-		_, err = tx.Exec(clearPreviousVersionQuery, versionName, packageId, packageId)
+		err = clearPreviousVersionLinks(tx, packageId, versionName)
 		if err != nil {
 			return err
 		}
@@ -150,7 +161,7 @@ func (p publishedRepositoryImpl) PatchVersion(packageId string, versionName stri
 	return ent, nil
 }
 
-func (p publishedRepositoryImpl) markAllVersionsDeletedByPackageId(tx *pg.Tx, packageId string, userId string) error {
+func (p publishedRepositoryImpl) markAllVersionsDeletedByPackageId(tx *pg.Tx, packageId string, userId string, deletedAt time.Time) error {
 	var ents []entity.PublishedVersionEntity
 	err := tx.Model(&ents).
 		Where("package_id = ?", packageId).
@@ -163,24 +174,23 @@ func (p publishedRepositoryImpl) markAllVersionsDeletedByPackageId(tx *pg.Tx, pa
 		return err
 	}
 
-	timeNow := time.Now()
 	for _, ent := range ents {
 		tmpEnt := &ent
-		tmpEnt.DeletedAt = &timeNow
+		tmpEnt.DeletedAt = &deletedAt
 		tmpEnt.DeletedBy = userId
 		err := p.updateVersion(tx, tmpEnt)
 		if err != nil {
 			return err
 		}
-		clearPreviousVersionQuery := `
-			UPDATE published_version
-			SET previous_version = null, previous_version_package_id = null
-			WHERE previous_version = ? AND (previous_version_package_id = ? OR ((previous_version_package_id = '' or previous_version_package_id is null) and package_id = ?))`
-		_, err = tx.Exec(clearPreviousVersionQuery, ent.Version, packageId, packageId)
+		err = clearPreviousVersionLinks(tx, packageId, ent.Version)
 		if err != nil {
 			return err
 		}
 	}
+	_, err = tx.Exec(`insert into deleted_grouped_operation select * from grouped_operation where package_id = ?`, packageId)
+	if err != nil {
+		return err
+	}
 	_, err = tx.Exec(`delete from grouped_operation where package_id = ?`, packageId)
 	if err != nil {
 		return err
@@ -188,6 +198,31 @@ func (p publishedRepositoryImpl) markAllVersionsDeletedByPackageId(tx *pg.Tx, pa
 	return nil
 }
 
+// clearPreviousVersionLinks clears previous version of the versions which point to the deleted version,
+// original values are kept in deleted_previous_version_link to be restored together with the version
+func clearPreviousVersionLinks(tx *pg.Tx, packageId string, versionName string) error {
+	// links kept from the previous deletion of the version with the same name are outdated
+	_, err := tx.Exec(`delete from deleted_previous_version_link where deleted_package_id = ? and previous_version = ?`, packageId, versionName)
+	if err != nil {
+		return err
+	}
+	linksCondition := `previous_version = ? AND (previous_version_package_id = ? OR ((previous_version_package_id = '' or previous_version_package_id is null) and package_id = ?))`
+	_, err = tx.Exec(`
+		INSERT INTO deleted_previous_version_link
+		SELECT package_id, version, revision, previous_version, previous_version_package_id, ?
+		FROM published_version
+		WHERE `+linksCondition, packageId, versionName, packageId, packageId)
+	if err != nil {
+		return err
+	}
+	clearPreviousVersionQuery := `
+		UPDATE published_version
+		SET previous_version = null, previous_version_package_id = null
+		WHERE ` + linksCondition
+	_, err = tx.Exec(clearPreviousVersionQuery, versionName, packageId, packageId)
+	return err
+}
+
 func (p publishedRepositoryImpl) GetVersion(packageId string, versionName string) (*entity.PublishedVersionEntity, error) {
 	getPackage, errGetPackage := p.GetPackage(packageId)
 	if errGetPackage != nil {
@@ -953,7 +988,7 @@ func (p publishedRepositoryImpl) getOperationComparisonsChanges(tx *pg.Tx, packa
 }
 
 func (p publishedRepositoryImpl) CreateVersionWithData(packageInfo view.PackageInfoFile, buildId string, version *entity.PublishedVersionEntity, content []*entity.PublishedContentEntity,
-	data []*entity.PublishedContentDataEntity, refs []*entity.PublishedReferenceEntity, src *entity.PublishedSrcEntity, srcArchive *entity.PublishedSrcArchiveEntity,
+	data []*entity.PublishedContentDataEntity, refs []*entity.PublishedReferenceEntity, src *entity.PublishedSrcEntity,
 	operations []*entity.OperationEntity, operationsData []*entity.OperationDataEntity,
 	operationComparisons []*entity.OperationComparisonEntity, builderNotifications []*entity.BuilderNotificationsEntity,
 	versionComparisons []*entity.VersionComparisonEntity, serviceName string, pkg *entity.PackageEntity, versionComparisonsFromCache []string) error {
@@ -976,9 +1011,12 @@ func (p publishedRepositoryImpl) CreateVersionWithData(packageInfo view.PackageI
 		}
 		build := &ents[0]
 
-		//do not allow publish for "complete" builds and builds that are not failed with "Restart count exceeded limit"
+		if build.Status == string(view.StatusCancelled) {
+			return buildCancelledError(build.BuildId)
+		}
+		//do not allow publish for "complete" builds and failed builds which are not in dead letter state (i.e. not failed with "Restart count exceeded limit")
 		if build.Status == string(view.StatusComplete) ||
-			(build.Status == string(view.StatusError) && build.RestartCount < 2) {
+			(build.Status == string(view.StatusError) && !build.DeadLetter) {
 			return fmt.Errorf("failed to start version publish. Version with buildId='%v' is already published or failed", buildId)
 		}
 
@@ -1031,22 +1069,6 @@ func (p publishedRepositoryImpl) CreateVersionWithData(packageInfo view.PackageI
 			}
 			utils.PerfLog(time.Since(start).Milliseconds(), 50, "CreateVersionWithData: refs insert")
 		}
-		if srcArchive != nil {
-			start = time.Now()
-			count, err := tx.Model(srcArchive).
-				Where("checksum = ?", srcArchive.Checksum).
-				Count()
-			if err != nil {
-				return err
-			}
-			if count == 0 {
-				_, err := tx.Model(srcArchive).OnConflict("(checksum) DO NOTHING").Insert()
-				if err != nil {
-					return fmt.Errorf("failed to insert published_sources_archive %+v: %w", srcArchive, err)
-				}
-			}
-			utils.PerfLog(time.Since(start).Milliseconds(), 50, "CreateVersionWithData: srcArchive insert")
-		}
 		if src != nil {
 			start = time.Now()
 			_, err := tx.Model(src).OnConflict("(package_id, version, revision) DO UPDATE").Insert()
@@ -1193,6 +1215,26 @@ func (p publishedRepositoryImpl) CreateVersionWithData(packageInfo view.PackageI
 				if err != nil {
 					return fmt.Errorf("failed to insert ts_grahpql_operation_data: %w", err)
 				}
+				calculateProtobufTextSearchDataQuery := `
+				insert into ts_protobuf_operation_data
+					select data_hash,
+					to_tsvector(jsonb_extract_path_text(search_scope, ?)) scope_request,
+					to_tsvector(jsonb_extract_path_text(search_scope, ?)) scope_response,
+					to_tsvector(jsonb_extract_path_text(search_scope, ?)) scope_annotation,
+					to_tsvector(jsonb_extract_path_text(search_scope, ?)) scope_field
+					from operation_data
+					where data_hash in (select distinct data_hash from operation where package_id = ? and version = ? and revision = ? and type = ?)
+				on conflict (data_hash) do update
+				set scope_request = EXCLUDED.scope_request,
+				scope_response = EXCLUDED.scope_response,
+				scope_annotation = EXCLUDED.scope_annotation,
+				scope_field = EXCLUDED.scope_field;`
+				_, err = tx.Exec(calculateProtobufTextSearchDataQuery,
+					view.ProtobufScopeRequest, view.ProtobufScopeResponse, view.ProtobufScopeAnnotation, view.ProtobufScopeField,
+					version.PackageId, version.Version, version.Revision, view.ProtobufApiType)
+				if err != nil {
+					return fmt.Errorf("failed to insert ts_protobuf_operation_data: %w", err)
+				}
 				calculateAllTextSearchDataQuery := `
 				insert into ts_operation_data
 					select data_hash,
@@ -1449,9 +1491,12 @@ func (p publishedRepositoryImpl) SaveVersionChanges(packageInfo view.PackageInfo
 		}
 		build := &ents[0]
 
-		//do not allow publish for "complete" builds and builds that are not failed with "Restart count exceeded limit"
+		if build.Status == string(view.StatusCancelled) {
+			return buildCancelledError(build.BuildId)
+		}
+		//do not allow publish for "complete" builds and failed builds which are not in dead letter state (i.e. not failed with "Restart count exceeded limit")
 		if build.Status == string(view.StatusComplete) ||
-			(build.Status == string(view.StatusError) && build.RestartCount < 2) {
+			(build.Status == string(view.StatusError) && !build.DeadLetter) {
 			return fmt.Errorf("failed to start version publish. Version with buildId='%v' is already published or failed", publishId)
 		}
 		if packageInfo.MigrationBuild && !packageInfo.NoChangelog {
@@ -1647,48 +1692,6 @@ func (p publishedRepositoryImpl) GetLatestContentByVersion(packageId string, ver
 	return p.GetRevisionContent(packageId, version, latestVersionRev.Revision)
 }
 
-func (p publishedRepositoryImpl) GetVersionSources(packageId string, versionName string, revision int) (*entity.PublishedSrcArchiveEntity, error) {
-	query := `
-		select psa.*
-		from published_sources_archives psa, published_sources ps
-		where ps.package_id = ?
-		and ps.version = ?
-		and ps.revision = ?
-		and ps.archive_checksum = psa.checksum
-		limit 1
-	`
-	savedSources := new(entity.PublishedSrcArchiveEntity)
-	_, err := p.cp.GetConnection().QueryOne(savedSources, query, packageId, versionName, revision)
-	if err != nil {
-		if err == pg.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
-	}
-	return savedSources, nil
-}
-
-func (p publishedRepositoryImpl) GetPublishedVersionSourceDataConfig(packageId string, versionName string, revision int) (*entity.PublishedSrcDataConfigEntity, error) {
-	query := `
-		select psa.checksum as archive_checksum, psa.data, ps.config, ps.package_id
-		from published_sources_archives psa, published_sources ps
-		where ps.package_id = ?
-		and ps.version = ?
-		and ps.revision = ?
-		and ps.archive_checksum = psa.checksum
-		limit 1
-	`
-	savedSources := new(entity.PublishedSrcDataConfigEntity)
-	_, err := p.cp.GetConnection().QueryOne(savedSources, query, packageId, versionName, revision)
-	if err != nil {
-		if err == pg.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
-	}
-	return savedSources, nil
-}
-
 func (p publishedRepositoryImpl) GetPublishedSources(packageId string, versionName string, revision int) (*entity.PublishedSrcEntity, error) {
 	src := new(entity.PublishedSrcEntity)
 	err := p.cp.GetConnection().Model(src).
@@ -2373,6 +2376,60 @@ func (p publishedRepositoryImpl) GetDefaultVersion(packageId string, status stri
 	return result, nil
 }
 
+// GetLatestVersionWithLabels returns the latest published not deleted version whose latest revision has all the labels
+func (p publishedRepositoryImpl) GetLatestVersionWithLabels(packageId string, labels []string) (*entity.PublishedVersionEntity, error) {
+	result := new(entity.PublishedVersionEntity)
+	query := `with maxrev as
+		(
+			select package_id, version, max(revision) as revision
+			from published_version
+			where package_id = ?
+			group by package_id, version
+		)
+		select pv.* from published_version pv
+		inner join maxrev
+			on maxrev.package_id = pv.package_id
+			and maxrev.version = pv.version
+			and maxrev.revision = pv.revision
+		where pv.labels @> ?::varchar[] and pv.deleted_at is null
+		order by pv.published_at desc
+		limit 1;`
+	_, err := p.cp.GetConnection().QueryOne(result, query, packageId, pg.Array(labels))
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetVersionsByStatus returns not deleted versions whose latest revision has the status
+func (p publishedRepositoryImpl) GetVersionsByStatus(packageId string, status string) ([]entity.PublishedVersionEntity, error) {
+	var result []entity.PublishedVersionEntity
+	query := `with maxrev as
+		(
+			select package_id, version, max(revision) as revision
+			from published_version
+			where package_id = ?
+			group by package_id, version
+		)
+		select pv.* from published_version pv
+		inner join maxrev
+			on maxrev.package_id = pv.package_id
+			and maxrev.version = pv.version
+			and maxrev.revision = pv.revision
+		where pv.status = ? and pv.deleted_at is null`
+	_, err := p.cp.GetConnection().Query(&result, query, packageId, status)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
 func (p publishedRepositoryImpl) CleanupDeleted() error {
 	var ents []entity.PublishedVersionEntity
 	_, err := p.cp.GetConnection().Model(&ents).
@@ -2739,7 +2796,7 @@ func (p publishedRepositoryImpl) updatePackage(tx *pg.Tx, ent *entity.PackageEnt
 	return ent, nil
 }
 
-func (p publishedRepositoryImpl) deletePackage(tx *pg.Tx, packageId string, userId string) error {
+func (p publishedRepositoryImpl) deletePackage(tx *pg.Tx, packageId string, userId string, deletedAt time.Time) error {
 	ent := new(entity.PackageEntity)
 	err := tx.Model(ent).
 		Where("id = ?", packageId).
@@ -2753,13 +2810,12 @@ func (p publishedRepositoryImpl) deletePackage(tx *pg.Tx, packageId string, user
 		return err
 	}
 
-	err = p.markAllVersionsDeletedByPackageId(tx, packageId, userId)
+	err = p.markAllVersionsDeletedByPackageId(tx, packageId, userId, deletedAt)
 	if err != nil {
 		return err
 	}
 
-	timeNow := time.Now()
-	ent.DeletedAt = &timeNow
+	ent.DeletedAt = &deletedAt
 	ent.DeletedBy = userId
 	ent.ServiceName = ""
 
@@ -2786,11 +2842,61 @@ func (p publishedRepositoryImpl) deletePackageServiceOwnership(tx *pg.Tx, packag
 func (p publishedRepositoryImpl) DeletePackage(id string, userId string) error {
 	ctx := context.Background()
 	return p.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		return p.deleteGroup(tx, id, userId)
+		lockedVersion, err := p.getLockedVersion(tx, id)
+		if err != nil {
+			return err
+		}
+		if lockedVersion != nil {
+			return &exception.CustomError{
+				Status:  http.StatusLocked,
+				Code:    exception.VersionLocked,
+				Message: exception.VersionLockedMsg,
+				Params:  map[string]interface{}{"version": lockedVersion.Version, "packageId": lockedVersion.PackageId},
+			}
+		}
+		// the whole hierarchy is marked with the same deletion time, so it could be restored from the recycle bin at once
+		return p.deleteGroup(tx, id, userId, time.Now())
 	})
 }
 
-func (p publishedRepositoryImpl) deleteGroup(tx *pg.Tx, packageId string, userId string) error {
+// getLockedVersion returns any not deleted version of the package or its descendants which is locked explicitly or by the closest lock policy
+func (p publishedRepositoryImpl) getLockedVersion(tx *pg.Tx, packageId string) (*entity.PublishedVersionEntity, error) {
+	result := new(entity.PublishedVersionEntity)
+	query := `with maxrev as
+		(
+			select package_id, version, max(revision) as revision
+			from published_version
+			where (package_id = ? or package_id like ? || '.%')
+				and deleted_at is null
+			group by package_id, version
+		)
+		select pv.* from published_version pv
+		inner join maxrev
+			on maxrev.package_id = pv.package_id
+			and maxrev.version = pv.version
+			and maxrev.revision = pv.revision
+		left join version_lock vl
+			on vl.package_id = pv.package_id
+			and vl.version = pv.version
+		where coalesce(vl.locked, (
+				select pv.status = any(vlp.statuses)
+				from version_lock_policy vlp
+				where pv.package_id = vlp.package_id or pv.package_id like vlp.package_id || '.%'
+				order by length(vlp.package_id) desc
+				limit 1
+			), false)
+		limit 1`
+	_, err := tx.QueryOne(result, query, packageId, packageId)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (p publishedRepositoryImpl) deleteGroup(tx *pg.Tx, packageId string, userId string, deletedAt time.Time) error {
 	ent := new(entity.PackageEntity)
 	err := tx.Model(ent).
 		Where("id = ?", packageId).
@@ -2816,25 +2922,24 @@ func (p publishedRepositoryImpl) deleteGroup(tx *pg.Tx, packageId string, userId
 	}
 	for _, child := range children {
 		if child.Kind == entity.KIND_GROUP || child.Kind == entity.KIND_WORKSPACE {
-			err := p.deleteGroup(tx, child.Id, userId)
+			err := p.deleteGroup(tx, child.Id, userId, deletedAt)
 			if err != nil {
 				return err
 			}
 		} else if child.Kind == entity.KIND_PACKAGE || child.Kind == entity.KIND_DASHBOARD {
-			err := p.deletePackage(tx, child.Id, userId)
+			err := p.deletePackage(tx, child.Id, userId, deletedAt)
 			if err != nil {
 				return err
 			}
 		}
 	}
 
-	err = p.markAllVersionsDeletedByPackageId(tx, packageId, userId)
+	err = p.markAllVersionsDeletedByPackageId(tx, packageId, userId, deletedAt)
 	if err != nil {
 		return err
 	}
 
-	timeNow := time.Now()
-	ent.DeletedAt = &timeNow
+	ent.DeletedAt = &deletedAt
 	ent.DeletedBy = userId
 	ent.ServiceName = ""
 
@@ -3482,54 +3587,6 @@ func (p publishedRepositoryImpl) GetVersionRevisionContentForDocumentsTransforma
 	return ents, err
 }
 
-func (p publishedRepositoryImpl) GetPublishedSourcesArchives(offset int) (*entity.PublishedSrcArchiveEntity, error) {
-	result := new(entity.PublishedSrcArchiveEntity)
-	err := p.cp.GetConnection().Model(result).Offset(offset).Limit(1).
-		First()
-	if err != nil {
-		if err == pg.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
-	}
-	return result, nil
-}
-
-func (p publishedRepositoryImpl) DeletePublishedSourcesArchives(checksums []string) error {
-	ctx := context.Background()
-	var deletedRows int
-	err := p.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		query := `delete from published_sources_archives
-		where checksum in (?)`
-		result, err := tx.Exec(query, pg.In(checksums))
-		if err != nil {
-			return err
-		}
-		deletedRows += result.RowsAffected()
-		return nil
-	})
-
-	if deletedRows > 0 {
-		_, err = p.cp.GetConnection().Exec("vacuum full published_sources_archives")
-		if err != nil {
-			return errors.Wrap(err, "failed to run vacuum for table published_sources_archives")
-		}
-	}
-	return nil
-}
-
-func (p publishedRepositoryImpl) SavePublishedSourcesArchive(ent *entity.PublishedSrcArchiveEntity) error {
-	ctx := context.Background()
-	err := p.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
-		_, err := tx.Model(ent).OnConflict("(checksum) DO NOTHING").Insert()
-		if err != nil {
-			return fmt.Errorf("failed to insert published_sources_archive %+v: %w", ent, err)
-		}
-		return nil
-	})
-	return err
-}
-
 func (p publishedRepositoryImpl) DeleteDraftVersionsBeforeDate_deprecated(packageId string, date time.Time, userId string) (int, error) {
 	limit, page, deletedItems := 100, 0, 0
 	for {
@@ -0,0 +1,472 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/go-pg/pg/v10"
+)
+
+type RecycleBinRepository interface {
+	GetItems(rootPackageId string, kind string, limit int, page int) ([]entity.RecycleBinItemEntity, error)
+	GetVersionRevisions(packageId string, version string) ([]entity.PublishedVersionEntity, error)
+
+	RestorePackage(packageId string, restoreReferences bool) (*entity.RecycleBinRestoredEntity, error)
+	RestoreVersion(packageId string, version string, restoreReferences bool) (*entity.RecycleBinRestoredEntity, error)
+	RestoreRevision(packageId string, version string, revision int, restoreReferences bool) (*entity.RecycleBinRestoredEntity, error)
+
+	PurgePackage(packageId string) (*entity.RecycleBinPurgedEntity, error)
+	PurgeVersion(packageId string, version string) (*entity.RecycleBinPurgedEntity, error)
+	PurgeRevision(packageId string, version string, revision int) (*entity.RecycleBinPurgedEntity, error)
+
+	GetExpiredPackages(deletedBefore time.Time) ([]string, error)
+	GetExpiredVersions(deletedBefore time.Time) ([]entity.RecycleBinRevisionEntity, error)
+	PurgeExpiredVersion(packageId string, version string, deletedBefore time.Time) (*entity.RecycleBinPurgedEntity, error)
+}
+
+func NewRecycleBinRepository(cp db.ConnectionProvider) RecycleBinRepository {
+	return &recycleBinRepositoryImpl{cp: cp}
+}
+
+type recycleBinRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+// Packages/revisions deleted together share the same deleted_at, so an item is listed only if it was deleted separately from its parent.
+// Revisions deleted together with the latest revision of a fully deleted version are listed as a single version item.
+const recycleBinItemsQuery = `
+	with deleted_version as (
+		select package_id, version, max(revision) as revision
+		from published_version
+		where (package_id, version) in (select package_id, version from published_version where deleted_at is not null %[1]s)
+		group by package_id, version
+		having bool_and(deleted_at is not null)
+	)
+	select * from (
+		select 'package' as kind, p.id as package_id, p.name as package_name, p.kind as package_kind,
+			'' as version, 0 as revision, '' as status, p.deleted_at, p.deleted_by
+		from package_group p
+		left join package_group parent on parent.id = p.parent_id
+		where p.deleted_at is not null
+		and parent.deleted_at is distinct from p.deleted_at
+		%[2]s
+		union all
+		select 'version' as kind, pv.package_id, p.name, p.kind, pv.version, pv.revision, pv.status, pv.deleted_at, pv.deleted_by
+		from published_version pv
+		inner join deleted_version dv on dv.package_id = pv.package_id and dv.version = pv.version and dv.revision = pv.revision
+		inner join package_group p on p.id = pv.package_id
+		where p.deleted_at is distinct from pv.deleted_at
+		%[2]s
+		union all
+		select 'revision' as kind, pv.package_id, p.name, p.kind, pv.version, pv.revision, pv.status, pv.deleted_at, pv.deleted_by
+		from published_version pv
+		inner join package_group p on p.id = pv.package_id
+		where pv.deleted_at is not null
+		and p.deleted_at is distinct from pv.deleted_at
+		and not exists (
+			select 1 from deleted_version dv
+			inner join published_version lr on lr.package_id = dv.package_id and lr.version = dv.version and lr.revision = dv.revision
+			where dv.package_id = pv.package_id and dv.version = pv.version and lr.deleted_at = pv.deleted_at
+		)
+		%[2]s
+	) items
+	where true %[3]s
+	order by deleted_at desc, package_id, version, revision desc
+	limit ? offset ?`
+
+// GetItems returns the recycle bin content, rootPackageId and kind filters are optional
+func (r recycleBinRepositoryImpl) GetItems(rootPackageId string, kind string, limit int, page int) ([]entity.RecycleBinItemEntity, error) {
+	var result []entity.RecycleBinItemEntity
+	versionFilter, packageFilter, kindFilter := "", "", ""
+	var versionParams, packageParams, kindParams []interface{}
+	if rootPackageId != "" {
+		versionFilter = ` and (package_id = ? or package_id like ? || '.%')`
+		versionParams = []interface{}{rootPackageId, rootPackageId}
+		packageFilter = ` and (p.id = ? or p.id like ? || '.%')`
+		packageParams = []interface{}{rootPackageId, rootPackageId}
+	}
+	if kind != "" {
+		kindFilter = ` and kind = ?`
+		kindParams = []interface{}{kind}
+	}
+	params := make([]interface{}, 0)
+	params = append(params, versionParams...)
+	for i := 0; i < 3; i++ {
+		params = append(params, packageParams...)
+	}
+	params = append(params, kindParams...)
+	params = append(params, limit, limit*page)
+
+	query := fmt.Sprintf(recycleBinItemsQuery, versionFilter, packageFilter, kindFilter)
+	_, err := r.cp.GetConnection().Query(&result, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetVersionRevisions returns all revisions of the version including deleted ones, the latest first
+func (r recycleBinRepositoryImpl) GetVersionRevisions(packageId string, version string) ([]entity.PublishedVersionEntity, error) {
+	var result []entity.PublishedVersionEntity
+	err := r.cp.GetConnection().Model(&result).
+		Where("package_id = ?", packageId).
+		Where("version = ?", version).
+		Order("revision DESC").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RestorePackage restores the package with all descendants and versions which were deleted together with it
+func (r recycleBinRepositoryImpl) RestorePackage(packageId string, restoreReferences bool) (*entity.RecycleBinRestoredEntity, error) {
+	result := &entity.RecycleBinRestoredEntity{}
+	err := r.cp.GetConnection().RunInTransaction(context.Background(), func(tx *pg.Tx) error {
+		_, err := tx.Query(&result.Revisions, `
+			update published_version pv set deleted_at = null, deleted_by = null
+			from package_group p, package_group root
+			where root.id = ?
+			and (p.id = root.id or p.id like root.id || '.%')
+			and p.deleted_at = root.deleted_at
+			and pv.package_id = p.id
+			and pv.deleted_at = root.deleted_at
+			returning pv.package_id, pv.version, pv.revision`, packageId)
+		if err != nil {
+			return fmt.Errorf("failed to restore versions: %w", err)
+		}
+		_, err = tx.Query(&result.PackageIds, `
+			update package_group p set deleted_at = null, deleted_by = null
+			from package_group root
+			where root.id = ?
+			and (p.id = root.id or p.id like root.id || '.%')
+			and p.deleted_at = root.deleted_at
+			returning p.id`, packageId)
+		if err != nil {
+			return fmt.Errorf("failed to restore packages: %w", err)
+		}
+		return r.completeRestore(tx, result, restoreReferences)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RestoreVersion restores revisions which were deleted together with the latest revision of the version
+func (r recycleBinRepositoryImpl) RestoreVersion(packageId string, version string, restoreReferences bool) (*entity.RecycleBinRestoredEntity, error) {
+	result := &entity.RecycleBinRestoredEntity{}
+	err := r.cp.GetConnection().RunInTransaction(context.Background(), func(tx *pg.Tx) error {
+		_, err := tx.Query(&result.Revisions, `
+			update published_version pv set deleted_at = null, deleted_by = null
+			from published_version latest
+			where latest.package_id = ? and latest.version = ?
+			and latest.revision = (select max(revision) from published_version where package_id = latest.package_id and version = latest.version)
+			and pv.package_id = latest.package_id
+			and pv.version = latest.version
+			and pv.deleted_at = latest.deleted_at
+			returning pv.package_id, pv.version, pv.revision`, packageId, version)
+		if err != nil {
+			return fmt.Errorf("failed to restore version: %w", err)
+		}
+		return r.completeRestore(tx, result, restoreReferences)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r recycleBinRepositoryImpl) RestoreRevision(packageId string, version string, revision int, restoreReferences bool) (*entity.RecycleBinRestoredEntity, error) {
+	result := &entity.RecycleBinRestoredEntity{}
+	err := r.cp.GetConnection().RunInTransaction(context.Background(), func(tx *pg.Tx) error {
+		_, err := tx.Query(&result.Revisions, `
+			update published_version set deleted_at = null, deleted_by = null
+			where package_id = ? and version = ? and revision = ?
+			and deleted_at is not null
+			returning package_id, version, revision`, packageId, version, revision)
+		if err != nil {
+			return fmt.Errorf("failed to restore revision: %w", err)
+		}
+		return r.completeRestore(tx, result, restoreReferences)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// completeRestore returns grouped operations and links to the restored revisions and checks that the revisions don't reference deleted versions.
+// If restoreReferences is set, referenced versions are restored as well (together with the revisions which were deleted at the same time).
+func (r recycleBinRepositoryImpl) completeRestore(tx *pg.Tx, result *entity.RecycleBinRestoredEntity, restoreReferences bool) error {
+	restored := result.Revisions
+	for len(restored) != 0 {
+		if err := r.restoreGroupedOperations(tx, restored); err != nil {
+			return err
+		}
+		if err := r.restoreVersionLinks(tx, restored); err != nil {
+			return err
+		}
+		var deletedRefs []struct {
+			entity.RecycleBinRevisionEntity
+			PackageDeleted bool `pg:"package_deleted"`
+		}
+		packageIds, versions, revisions := revisionArrays(restored)
+		_, err := tx.Query(&deletedRefs, `
+			select distinct ref.package_id, ref.version, ref.revision, p.deleted_at is not null as package_deleted
+			from published_version_reference r
+			inner join published_version ref on ref.package_id = r.reference_id and ref.version = r.reference_version and ref.revision = r.reference_revision
+			inner join package_group p on p.id = ref.package_id
+			where (r.package_id, r.version, r.revision) in (select * from unnest(?::varchar[], ?::varchar[], ?::integer[]))
+			and ref.deleted_at is not null`,
+			pg.Array(packageIds), pg.Array(versions), pg.Array(revisions))
+		if err != nil {
+			return fmt.Errorf("failed to get deleted references: %w", err)
+		}
+		if len(deletedRefs) == 0 {
+			return nil
+		}
+		refNames := make([]string, 0, len(deletedRefs))
+		for _, ref := range deletedRefs {
+			if ref.PackageDeleted {
+				return &exception.CustomError{
+					Status:  http.StatusConflict,
+					Code:    exception.RecycleBinParentPackageDeleted,
+					Message: exception.RecycleBinParentPackageDeletedMsg,
+					Params:  map[string]interface{}{"packageId": ref.PackageId},
+				}
+			}
+			refNames = append(refNames, fmt.Sprintf("%s@%s@%d", ref.PackageId, ref.Version, ref.Revision))
+		}
+		if !restoreReferences {
+			return &exception.CustomError{
+				Status:  http.StatusConflict,
+				Code:    exception.RecycleBinDeletedReferences,
+				Message: exception.RecycleBinDeletedReferencesMsg,
+				Params:  map[string]interface{}{"references": strings.Join(refNames, ", ")},
+			}
+		}
+		restored = make([]entity.RecycleBinRevisionEntity, 0)
+		for _, ref := range deletedRefs {
+			var refRevisions []entity.RecycleBinRevisionEntity
+			_, err = tx.Query(&refRevisions, `
+				update published_version pv set deleted_at = null, deleted_by = null
+				from published_version ref
+				where ref.package_id = ? and ref.version = ? and ref.revision = ?
+				and pv.package_id = ref.package_id
+				and pv.version = ref.version
+				and pv.deleted_at = ref.deleted_at
+				returning pv.package_id, pv.version, pv.revision`, ref.PackageId, ref.Version, ref.Revision)
+			if err != nil {
+				return fmt.Errorf("failed to restore referenced version: %w", err)
+			}
+			restored = append(restored, refRevisions...)
+		}
+		result.Revisions = append(result.Revisions, restored...)
+	}
+	return nil
+}
+
+func (r recycleBinRepositoryImpl) restoreGroupedOperations(tx *pg.Tx, revisionEnts []entity.RecycleBinRevisionEntity) error {
+	packageIds, versions, revisions := revisionArrays(revisionEnts)
+	_, err := tx.Exec(`
+		with restored as (
+			delete from deleted_grouped_operation
+			where (package_id, version, revision) in (select * from unnest(?::varchar[], ?::varchar[], ?::integer[]))
+			returning *
+		)
+		insert into grouped_operation select * from restored`,
+		pg.Array(packageIds), pg.Array(versions), pg.Array(revisions))
+	if err != nil {
+		return fmt.Errorf("failed to restore grouped operations: %w", err)
+	}
+	return nil
+}
+
+// restoreVersionLinks returns previous version and default release version links which were cleared on delete of the restored versions.
+// Links which were changed after the delete are not overwritten.
+func (r recycleBinRepositoryImpl) restoreVersionLinks(tx *pg.Tx, revisionEnts []entity.RecycleBinRevisionEntity) error {
+	packageIds, versions, _ := revisionArrays(revisionEnts)
+	_, err := tx.Exec(`
+		with restored as (
+			delete from deleted_previous_version_link
+			where (deleted_package_id, previous_version) in (select * from unnest(?::varchar[], ?::varchar[]))
+			returning *
+		)
+		update published_version pv
+		set previous_version = r.previous_version, previous_version_package_id = r.previous_version_package_id
+		from restored r
+		where pv.package_id = r.package_id and pv.version = r.version and pv.revision = r.revision
+		and pv.previous_version is null`,
+		pg.Array(packageIds), pg.Array(versions))
+	if err != nil {
+		return fmt.Errorf("failed to restore previous version links: %w", err)
+	}
+	_, err = tx.Exec(`
+		with restored as (
+			delete from deleted_default_release_version
+			where (package_id, version) in (select * from unnest(?::varchar[], ?::varchar[]))
+			returning *
+		)
+		update package_group p
+		set default_released_version = r.version
+		from restored r
+		where p.id = r.package_id
+		and p.default_released_version is null`,
+		pg.Array(packageIds), pg.Array(versions))
+	if err != nil {
+		return fmt.Errorf("failed to restore default release version: %w", err)
+	}
+	return nil
+}
+
+func revisionArrays(revisionEnts []entity.RecycleBinRevisionEntity) ([]string, []string, []int) {
+	packageIds := make([]string, 0, len(revisionEnts))
+	versions := make([]string, 0, len(revisionEnts))
+	revisions := make([]int, 0, len(revisionEnts))
+	for _, ent := range revisionEnts {
+		packageIds = append(packageIds, ent.PackageId)
+		versions = append(versions, ent.Version)
+		revisions = append(revisions, ent.Revision)
+	}
+	return packageIds, versions, revisions
+}
+
+// PurgePackage permanently deletes the package, all related data is deleted by cascade including child packages and their builds
+func (r recycleBinRepositoryImpl) PurgePackage(packageId string) (*entity.RecycleBinPurgedEntity, error) {
+	return r.purge(purgeQuery{
+		deleteQuery:        `delete from package_group where id = ? and deleted_at is not null`,
+		deleteParams:       []interface{}{packageId},
+		revisionsCondition: `pv.package_id = ? or pv.package_id like ? || '.%'`,
+		revisionsParams:    []interface{}{packageId, packageId},
+		buildsCondition:    `b.package_id = ? or b.package_id like ? || '.%'`,
+		buildsParams:       []interface{}{packageId, packageId},
+	})
+}
+
+// PurgeVersion permanently deletes the deleted revisions of the version, builds are kept since they are removed by the build cleanup
+func (r recycleBinRepositoryImpl) PurgeVersion(packageId string, version string) (*entity.RecycleBinPurgedEntity, error) {
+	return r.purge(purgeQuery{
+		deleteQuery:        `delete from published_version where package_id = ? and version = ? and deleted_at is not null`,
+		deleteParams:       []interface{}{packageId, version},
+		revisionsCondition: `pv.package_id = ? and pv.version = ? and pv.deleted_at is not null`,
+		revisionsParams:    []interface{}{packageId, version},
+	})
+}
+
+func (r recycleBinRepositoryImpl) PurgeRevision(packageId string, version string, revision int) (*entity.RecycleBinPurgedEntity, error) {
+	return r.purge(purgeQuery{
+		deleteQuery:        `delete from published_version where package_id = ? and version = ? and revision = ? and deleted_at is not null`,
+		deleteParams:       []interface{}{packageId, version, revision},
+		revisionsCondition: `pv.package_id = ? and pv.version = ? and pv.revision = ? and pv.deleted_at is not null`,
+		revisionsParams:    []interface{}{packageId, version, revision},
+	})
+}
+
+type purgeQuery struct {
+	deleteQuery  string
+	deleteParams []interface{}
+	// selects purged revisions from published_version pv
+	revisionsCondition string
+	revisionsParams    []interface{}
+	// selects builds from build b which are deleted by cascade, optional
+	buildsCondition string
+	buildsParams    []interface{}
+}
+
+// purge executes the delete query in a transaction and returns keys of the blobs which are not needed anymore:
+// results of the builds deleted by cascade and sources archives which are not referenced by other revisions
+func (r recycleBinRepositoryImpl) purge(query purgeQuery) (*entity.RecycleBinPurgedEntity, error) {
+	result := &entity.RecycleBinPurgedEntity{BuildIds: make([]string, 0), ArchiveChecksums: make([]string, 0)}
+	err := r.cp.GetConnection().RunInTransaction(context.Background(), func(tx *pg.Tx) error {
+		var buildIds, checksums []string
+		if query.buildsCondition != "" {
+			_, err := tx.Query(&buildIds, `select b.build_id from build b where `+query.buildsCondition, query.buildsParams...)
+			if err != nil {
+				return fmt.Errorf("failed to get builds: %w", err)
+			}
+		}
+		// published sources are deleted by cascade
+		_, err := tx.Query(&checksums, `
+			select distinct ps.archive_checksum
+			from published_sources ps
+			inner join published_version pv on pv.package_id = ps.package_id and pv.version = ps.version and pv.revision = ps.revision
+			where ps.archive_checksum is not null and (`+query.revisionsCondition+`)`, query.revisionsParams...)
+		if err != nil {
+			return fmt.Errorf("failed to get sources archive checksums: %w", err)
+		}
+		deleted, err := tx.Exec(query.deleteQuery, query.deleteParams...)
+		if err != nil {
+			return err
+		}
+		if deleted.RowsAffected() == 0 {
+			return nil
+		}
+		result.BuildIds = append(result.BuildIds, buildIds...)
+		if len(checksums) == 0 {
+			return nil
+		}
+		_, err = tx.Query(&result.ArchiveChecksums, `
+			select c from unnest(?::varchar[]) c
+			where not exists(select 1 from published_sources where archive_checksum = c)`, pg.Array(checksums))
+		if err != nil {
+			return fmt.Errorf("failed to get unreferenced sources archive checksums: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetExpiredPackages returns ids of packages deleted before the date, parents go first
+func (r recycleBinRepositoryImpl) GetExpiredPackages(deletedBefore time.Time) ([]string, error) {
+	var result []string
+	_, err := r.cp.GetConnection().Query(&result,
+		`select id from package_group where deleted_at < ? order by length(id), id`, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetExpiredVersions returns versions which have revisions deleted before the date, revision field is not set
+func (r recycleBinRepositoryImpl) GetExpiredVersions(deletedBefore time.Time) ([]entity.RecycleBinRevisionEntity, error) {
+	var result []entity.RecycleBinRevisionEntity
+	_, err := r.cp.GetConnection().Query(&result,
+		`select distinct package_id, version from published_version where deleted_at < ? order by package_id, version`, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r recycleBinRepositoryImpl) PurgeExpiredVersion(packageId string, version string, deletedBefore time.Time) (*entity.RecycleBinPurgedEntity, error) {
+	return r.purge(purgeQuery{
+		deleteQuery:        `delete from published_version where package_id = ? and version = ? and deleted_at < ?`,
+		deleteParams:       []interface{}{packageId, version, deletedBefore},
+		revisionsCondition: `pv.package_id = ? and pv.version = ? and pv.deleted_at < ?`,
+		revisionsParams:    []interface{}{packageId, version, deletedBefore},
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+)
+
+type ReferenceGraphRepository interface {
+	GetDependents(packageId string, version string, revision int, status string, onlyLatestRevision bool) ([]entity.VersionDependentEntity, error)
+}
+
+func NewReferenceGraphRepository(cp db.ConnectionProvider) ReferenceGraphRepository {
+	return &referenceGraphRepositoryImpl{cp: cp}
+}
+
+type referenceGraphRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+// GetDependents returns not deleted versions which reference the package version.
+// References are stored flattened for each dashboard version, so dependents which reference the version through other dashboards are returned as well.
+// Any revision of the version is matched if revision is 0.
+func (r referenceGraphRepositoryImpl) GetDependents(packageId string, version string, revision int, status string, onlyLatestRevision bool) ([]entity.VersionDependentEntity, error) {
+	var result []entity.VersionDependentEntity
+	query := `select d.* from (
+			select pv.package_id, pv.version, pv.revision, pv.status, pv.published_at,
+				p.name as package_name, p.kind as package_kind,
+				bool_or(r.parent_reference_id = '') as direct,
+				not exists(
+					select 1 from published_version n
+					where n.package_id = pv.package_id
+					and n.version = pv.version
+					and n.revision > pv.revision
+					and n.deleted_at is null
+				) as latest_revision
+			from published_version_reference r
+			inner join published_version pv
+				on pv.package_id = r.package_id
+				and pv.version = r.version
+				and pv.revision = r.revision
+				and pv.deleted_at is null
+			inner join package_group p
+				on p.id = pv.package_id
+				and p.deleted_at is null
+			where r.reference_id = ?
+			and r.reference_version = ?
+			and (? = 0 or r.reference_revision = ?)
+			and r.excluded = false
+			and (? = '' or pv.status = ?)
+			group by pv.package_id, pv.version, pv.revision, pv.status, pv.published_at, p.name, p.kind
+		) d
+		where (? = false or d.latest_revision)
+		order by d.package_id, d.published_at desc, d.revision desc`
+	_, err := r.cp.GetConnection().Query(&result, query,
+		packageId, version, revision, revision, status, status, onlyLatestRevision)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+)
+
+type SavedSearchRepository interface {
+	CreateSavedSearch(ent *entity.SavedSearchEntity) error
+	UpdateSavedSearch(ent *entity.SavedSearchEntity) error
+	DeleteSavedSearch(userId string, savedSearchId string) (bool, error)
+	GetSavedSearch(userId string, savedSearchId string) (*entity.SavedSearchEntity, error)
+	GetSavedSearchByName(userId string, name string) (*entity.SavedSearchEntity, error)
+	GetSavedSearches(userId string) ([]entity.SavedSearchEntity, error)
+	CountSavedSearches(userId string) (int, error)
+	GetSubscribedSavedSearches(packageId string) ([]entity.SavedSearchEntity, error)
+
+	StoreMatches(savedSearchId string, packageId string, operationIds []string) ([]string, error)
+
+	StoreNotification(ent *entity.SavedSearchNotificationEntity) error
+	GetNotifications(userId string, req view.SavedSearchNotificationListReq) ([]entity.SavedSearchNotificationRichEntity, error)
+	MarkNotificationRead(userId string, notificationId string) (bool, error)
+}
+
+func NewSavedSearchRepository(cp db.ConnectionProvider) SavedSearchRepository {
+	return &savedSearchRepositoryImpl{cp: cp}
+}
+
+type savedSearchRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (s savedSearchRepositoryImpl) CreateSavedSearch(ent *entity.SavedSearchEntity) error {
+	_, err := s.cp.GetConnection().Model(ent).Insert()
+	return err
+}
+
+func (s savedSearchRepositoryImpl) UpdateSavedSearch(ent *entity.SavedSearchEntity) error {
+	_, err := s.cp.GetConnection().Model(ent).
+		Column("name", "query", "subscribed", "webhook_url", "updated_at").
+		WherePK().
+		Update()
+	return err
+}
+
+func (s savedSearchRepositoryImpl) DeleteSavedSearch(userId string, savedSearchId string) (bool, error) {
+	res, err := s.cp.GetConnection().Model(&entity.SavedSearchEntity{}).
+		Where("saved_search_id = ?", savedSearchId).
+		Where("user_id = ?", userId).
+		Delete()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
+
+func (s savedSearchRepositoryImpl) GetSavedSearch(userId string, savedSearchId string) (*entity.SavedSearchEntity, error) {
+	result := new(entity.SavedSearchEntity)
+	err := s.cp.GetConnection().Model(result).
+		Where("saved_search_id = ?", savedSearchId).
+		Where("user_id = ?", userId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s savedSearchRepositoryImpl) GetSavedSearchByName(userId string, name string) (*entity.SavedSearchEntity, error) {
+	result := new(entity.SavedSearchEntity)
+	err := s.cp.GetConnection().Model(result).
+		Where("user_id = ?", userId).
+		Where("name = ?", name).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s savedSearchRepositoryImpl) GetSavedSearches(userId string) ([]entity.SavedSearchEntity, error) {
+	var result []entity.SavedSearchEntity
+	err := s.cp.GetConnection().Model(&result).
+		Where("user_id = ?", userId).
+		Order("name ASC").
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s savedSearchRepositoryImpl) CountSavedSearches(userId string) (int, error) {
+	return s.cp.GetConnection().Model(&entity.SavedSearchEntity{}).
+		Where("user_id = ?", userId).
+		Count()
+}
+
+// GetSubscribedSavedSearches returns subscribed saved searches which could match operations of the package:
+// searches without packages filter and searches filtered by the package itself or by its parent
+func (s savedSearchRepositoryImpl) GetSubscribedSavedSearches(packageId string) ([]entity.SavedSearchEntity, error) {
+	var result []entity.SavedSearchEntity
+	packageIds := `jsonb_array_elements_text(case when jsonb_typeof(saved_search.query->'packageIds') = 'array' then saved_search.query->'packageIds' else '[]' end)`
+	err := s.cp.GetConnection().Model(&result).
+		Where("subscribed = true").
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			return q.WhereOr("not exists(select 1 from "+packageIds+")").
+				WhereOr("exists(select 1 from "+packageIds+" scope where scope = ?0 or left(?0, length(scope) + 1) = scope || '.')", packageId), nil
+		}).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// StoreMatches remembers operations matched by the saved search and returns ids of the operations which were not matched before
+func (s savedSearchRepositoryImpl) StoreMatches(savedSearchId string, packageId string, operationIds []string) ([]string, error) {
+	if len(operationIds) == 0 {
+		return nil, nil
+	}
+	var newOperationIds []string
+	insertQuery := `
+	insert into saved_search_match (saved_search_id, package_id, operation_id, matched_at)
+	select ?, ?, operation_id, now()
+	from unnest(?::varchar[]) operation_id
+	on conflict (saved_search_id, package_id, operation_id) do nothing
+	returning operation_id`
+	_, err := s.cp.GetConnection().Query(&newOperationIds, insertQuery, savedSearchId, packageId, pg.Array(operationIds))
+	return newOperationIds, err
+}
+
+func (s savedSearchRepositoryImpl) StoreNotification(ent *entity.SavedSearchNotificationEntity) error {
+	_, err := s.cp.GetConnection().Model(ent).Insert()
+	return err
+}
+
+func (s savedSearchRepositoryImpl) GetNotifications(userId string, req view.SavedSearchNotificationListReq) ([]entity.SavedSearchNotificationRichEntity, error) {
+	var result []entity.SavedSearchNotificationRichEntity
+	query := s.cp.GetConnection().Model(&result).
+		ColumnExpr("saved_search_notification.*").
+		ColumnExpr("ss.name as saved_search_name").
+		Join("inner join saved_search ss").
+		JoinOn("ss.saved_search_id = saved_search_notification.saved_search_id").
+		Where("saved_search_notification.user_id = ?", userId)
+	if req.UnreadOnly {
+		query.Where("saved_search_notification.read = false")
+	}
+	err := query.
+		Order("saved_search_notification.created_at DESC").
+		Limit(req.Limit).
+		Offset(req.Limit * req.Page).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s savedSearchRepositoryImpl) MarkNotificationRead(userId string, notificationId string) (bool, error) {
+	res, err := s.cp.GetConnection().Model(&entity.SavedSearchNotificationEntity{}).
+		Set("read = true").
+		Where("notification_id = ?", notificationId).
+		Where("user_id = ?", userId).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
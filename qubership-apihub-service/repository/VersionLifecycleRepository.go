@@ -0,0 +1,93 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/go-pg/pg/v10"
+)
+
+type VersionLifecycleRepository interface {
+	GetStatuses() ([]entity.VersionStatusEntity, error)
+	GetTransitions() ([]entity.VersionStatusTransitionEntity, error)
+	ReplaceLifecycle(statuses []entity.VersionStatusEntity, transitions []entity.VersionStatusTransitionEntity) error
+	GetUsedVersionStatuses() ([]string, error)
+}
+
+func NewVersionLifecycleRepository(cp db.ConnectionProvider) VersionLifecycleRepository {
+	return &versionLifecycleRepositoryImpl{cp: cp}
+}
+
+type versionLifecycleRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (v versionLifecycleRepositoryImpl) GetStatuses() ([]entity.VersionStatusEntity, error) {
+	var ents []entity.VersionStatusEntity
+	err := v.cp.GetConnection().Model(&ents).
+		Order("sort_order ASC").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return ents, nil
+}
+
+func (v versionLifecycleRepositoryImpl) GetTransitions() ([]entity.VersionStatusTransitionEntity, error) {
+	var ents []entity.VersionStatusTransitionEntity
+	err := v.cp.GetConnection().Model(&ents).
+		Order("from_status ASC", "to_status ASC").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return ents, nil
+}
+
+func (v versionLifecycleRepositoryImpl) ReplaceLifecycle(statuses []entity.VersionStatusEntity, transitions []entity.VersionStatusTransitionEntity) error {
+	ctx := context.Background()
+	return v.cp.GetConnection().RunInTransaction(ctx, func(tx *pg.Tx) error {
+		// transitions are removed by cascade
+		_, err := tx.Exec(`delete from version_status`)
+		if err != nil {
+			return err
+		}
+		if len(statuses) != 0 {
+			_, err = tx.Model(&statuses).Insert()
+			if err != nil {
+				return err
+			}
+		}
+		if len(transitions) != 0 {
+			_, err = tx.Model(&transitions).Insert()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (v versionLifecycleRepositoryImpl) GetUsedVersionStatuses() ([]string, error) {
+	var statuses []string
+	_, err := v.cp.GetConnection().Query(&statuses, `select distinct status from published_version`)
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
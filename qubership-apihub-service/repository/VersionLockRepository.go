@@ -0,0 +1,98 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/go-pg/pg/v10"
+)
+
+type VersionLockRepository interface {
+	GetLock(packageId string, version string) (*entity.VersionLockEntity, error)
+	SetLock(ent entity.VersionLockEntity) error
+
+	GetPolicyForHierarchy(packageId string) (*entity.VersionLockPolicyExtEntity, error)
+	SetPolicy(ent entity.VersionLockPolicyEntity) error
+	DeletePolicy(packageId string) error
+}
+
+func NewVersionLockRepository(cp db.ConnectionProvider) VersionLockRepository {
+	return &versionLockRepositoryImpl{cp: cp}
+}
+
+type versionLockRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+func (v versionLockRepositoryImpl) GetLock(packageId string, version string) (*entity.VersionLockEntity, error) {
+	result := new(entity.VersionLockEntity)
+	err := v.cp.GetConnection().Model(result).
+		Where("package_id = ?", packageId).
+		Where("version = ?", version).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionLockRepositoryImpl) SetLock(ent entity.VersionLockEntity) error {
+	_, err := v.cp.GetConnection().Model(&ent).
+		OnConflict("(package_id, version) DO UPDATE").
+		Insert()
+	return err
+}
+
+// GetPolicyForHierarchy returns the policy of the package itself or of the closest parent which has it
+func (v versionLockRepositoryImpl) GetPolicyForHierarchy(packageId string) (*entity.VersionLockPolicyExtEntity, error) {
+	packageIds := utils.GetPackageHierarchy(packageId)
+	result := new(entity.VersionLockPolicyExtEntity)
+	err := v.cp.GetConnection().Model(result).
+		ColumnExpr("version_lock_policy.*").
+		ColumnExpr("p.name as package_name").
+		ColumnExpr("p.kind as package_kind").
+		Join("inner join package_group p").
+		JoinOn("version_lock_policy.package_id = p.id").
+		Where("version_lock_policy.package_id in (?)", pg.In(packageIds)).
+		OrderExpr("length(version_lock_policy.package_id) desc").
+		Limit(1).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionLockRepositoryImpl) SetPolicy(ent entity.VersionLockPolicyEntity) error {
+	_, err := v.cp.GetConnection().Model(&ent).
+		OnConflict("(package_id) DO UPDATE").
+		Insert()
+	return err
+}
+
+func (v versionLockRepositoryImpl) DeletePolicy(packageId string) error {
+	_, err := v.cp.GetConnection().Model(&entity.VersionLockPolicyEntity{}).
+		Where("package_id = ?", packageId).
+		Delete()
+	return err
+}
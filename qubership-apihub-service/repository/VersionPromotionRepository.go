@@ -0,0 +1,280 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/go-pg/pg/v10"
+)
+
+type VersionPromotionRepository interface {
+	GetPolicyForHierarchy(packageId string) (*entity.VersionPromotionPolicyExtEntity, error)
+	SetPolicy(ent entity.VersionPromotionPolicyEntity) error
+	DeletePolicy(packageId string) error
+
+	CreateRequest(ent entity.VersionPromotionRequestEntity) error
+	GetRequest(requestId string) (*entity.VersionPromotionRequestEntity, error)
+	GetPendingRequest(packageId string, version string) (*entity.VersionPromotionRequestEntity, error)
+	ListRequests(packageId string, state string) ([]entity.VersionPromotionRequestEntity, error)
+	ListPendingRequests() ([]entity.VersionPromotionRequestEntity, error)
+	ResolveRequest(requestId string, state string, resolvedBy string) (bool, error)
+	ApproveRequest(ent entity.VersionPromotionRequestEntity, resolvedBy string) (bool, error)
+
+	AddApproval(ent entity.VersionPromotionApprovalEntity) (bool, error)
+	GetApprovals(requestIds []string) ([]entity.VersionPromotionApprovalEntity, error)
+
+	StoreNotifications(ents []entity.VersionPromotionNotificationEntity) error
+	GetNotifications(userId string, req view.VersionPromotionNotificationListReq) ([]entity.VersionPromotionNotificationRichEntity, error)
+	MarkNotificationRead(userId string, notificationId string) (bool, error)
+}
+
+func NewVersionPromotionRepository(cp db.ConnectionProvider) VersionPromotionRepository {
+	return &versionPromotionRepositoryImpl{cp: cp}
+}
+
+type versionPromotionRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+// GetPolicyForHierarchy returns the policy of the package itself or of the closest parent which has it
+func (v versionPromotionRepositoryImpl) GetPolicyForHierarchy(packageId string) (*entity.VersionPromotionPolicyExtEntity, error) {
+	packageIds := utils.GetPackageHierarchy(packageId)
+	result := new(entity.VersionPromotionPolicyExtEntity)
+	err := v.cp.GetConnection().Model(result).
+		ColumnExpr("version_promotion_policy.*").
+		ColumnExpr("p.name as package_name").
+		ColumnExpr("p.kind as package_kind").
+		Join("inner join package_group p").
+		JoinOn("version_promotion_policy.package_id = p.id").
+		Where("version_promotion_policy.package_id in (?)", pg.In(packageIds)).
+		OrderExpr("length(version_promotion_policy.package_id) desc").
+		Limit(1).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionPromotionRepositoryImpl) SetPolicy(ent entity.VersionPromotionPolicyEntity) error {
+	_, err := v.cp.GetConnection().Model(&ent).
+		OnConflict("(package_id) DO UPDATE").
+		Insert()
+	return err
+}
+
+func (v versionPromotionRepositoryImpl) DeletePolicy(packageId string) error {
+	_, err := v.cp.GetConnection().Model(&entity.VersionPromotionPolicyEntity{}).
+		Where("package_id = ?", packageId).
+		Delete()
+	return err
+}
+
+func (v versionPromotionRepositoryImpl) CreateRequest(ent entity.VersionPromotionRequestEntity) error {
+	_, err := v.cp.GetConnection().Model(&ent).Insert()
+	return err
+}
+
+func (v versionPromotionRepositoryImpl) GetRequest(requestId string) (*entity.VersionPromotionRequestEntity, error) {
+	result := new(entity.VersionPromotionRequestEntity)
+	err := v.cp.GetConnection().Model(result).
+		Where("request_id = ?", requestId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionPromotionRepositoryImpl) GetPendingRequest(packageId string, version string) (*entity.VersionPromotionRequestEntity, error) {
+	result := new(entity.VersionPromotionRequestEntity)
+	err := v.cp.GetConnection().Model(result).
+		Where("package_id = ?", packageId).
+		Where("version = ?", version).
+		Where("state = ?", view.VersionPromotionRequestPending).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionPromotionRepositoryImpl) ListRequests(packageId string, state string) ([]entity.VersionPromotionRequestEntity, error) {
+	var result []entity.VersionPromotionRequestEntity
+	query := v.cp.GetConnection().Model(&result).
+		Where("package_id = ?", packageId)
+	if state != "" {
+		query.Where("state = ?", state)
+	}
+	err := query.Order("created_at DESC").Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionPromotionRepositoryImpl) ListPendingRequests() ([]entity.VersionPromotionRequestEntity, error) {
+	var result []entity.VersionPromotionRequestEntity
+	err := v.cp.GetConnection().Model(&result).
+		Where("state = ?", view.VersionPromotionRequestPending).
+		Order("created_at DESC").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ResolveRequest changes state of the pending request, returns false if the request is not pending anymore
+func (v versionPromotionRepositoryImpl) ResolveRequest(requestId string, state string, resolvedBy string) (bool, error) {
+	result, err := v.cp.GetConnection().Model(&entity.VersionPromotionRequestEntity{}).
+		Set("state = ?", state).
+		Set("resolved_by = ?", resolvedBy).
+		Set("resolved_at = ?", time.Now()).
+		Where("request_id = ?", requestId).
+		Where("state = ?", view.VersionPromotionRequestPending).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+var errPromotionNotApplied = errors.New("promotion request is not pending or version status was changed")
+
+// ApproveRequest resolves the pending request as approved and changes status of the latest version revision in one transaction,
+// returns false if the request is not pending anymore or the version is not in the request from status
+func (v versionPromotionRepositoryImpl) ApproveRequest(ent entity.VersionPromotionRequestEntity, resolvedBy string) (bool, error) {
+	err := v.cp.GetConnection().RunInTransaction(context.Background(), func(tx *pg.Tx) error {
+		result, err := tx.Model(&entity.VersionPromotionRequestEntity{}).
+			Set("state = ?", view.VersionPromotionRequestApproved).
+			Set("resolved_by = ?", resolvedBy).
+			Set("resolved_at = ?", time.Now()).
+			Where("request_id = ?", ent.RequestId).
+			Where("state = ?", view.VersionPromotionRequestPending).
+			Update()
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return errPromotionNotApplied
+		}
+		updateStatusQuery := `update published_version set status = ?
+			where package_id = ? and version = ? and status = ? and deleted_at is null
+			and revision = (select max(revision) from published_version where package_id = ? and version = ?)`
+		result, err = tx.Exec(updateStatusQuery, ent.ToStatus, ent.PackageId, ent.Version, ent.FromStatus, ent.PackageId, ent.Version)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return errPromotionNotApplied
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errPromotionNotApplied) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// AddApproval stores the user decision, returns false if the user has already made a decision for the request
+func (v versionPromotionRepositoryImpl) AddApproval(ent entity.VersionPromotionApprovalEntity) (bool, error) {
+	result, err := v.cp.GetConnection().Model(&ent).
+		OnConflict("(request_id, user_id) DO NOTHING").
+		Insert()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (v versionPromotionRepositoryImpl) GetApprovals(requestIds []string) ([]entity.VersionPromotionApprovalEntity, error) {
+	var result []entity.VersionPromotionApprovalEntity
+	if len(requestIds) == 0 {
+		return result, nil
+	}
+	err := v.cp.GetConnection().Model(&result).
+		Where("request_id in (?)", pg.In(requestIds)).
+		Order("created_at ASC").
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionPromotionRepositoryImpl) StoreNotifications(ents []entity.VersionPromotionNotificationEntity) error {
+	if len(ents) == 0 {
+		return nil
+	}
+	_, err := v.cp.GetConnection().Model(&ents).Insert()
+	return err
+}
+
+func (v versionPromotionRepositoryImpl) GetNotifications(userId string, req view.VersionPromotionNotificationListReq) ([]entity.VersionPromotionNotificationRichEntity, error) {
+	var result []entity.VersionPromotionNotificationRichEntity
+	query := v.cp.GetConnection().Model(&result).
+		ColumnExpr("version_promotion_notification.*").
+		ColumnExpr("r.package_id, r.version, r.from_status, r.to_status, r.state, r.created_by").
+		Join("inner join version_promotion_request r").
+		JoinOn("r.request_id = version_promotion_notification.request_id").
+		Where("version_promotion_notification.user_id = ?", userId)
+	if req.UnreadOnly {
+		query.Where("version_promotion_notification.read = false")
+	}
+	err := query.
+		Order("version_promotion_notification.created_at DESC").
+		Limit(req.Limit).
+		Offset(req.Limit * req.Page).
+		Select()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionPromotionRepositoryImpl) MarkNotificationRead(userId string, notificationId string) (bool, error) {
+	res, err := v.cp.GetConnection().Model(&entity.VersionPromotionNotificationEntity{}).
+		Set("read = true").
+		Where("notification_id = ?", notificationId).
+		Where("user_id = ?", userId).
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return res.RowsAffected() > 0, nil
+}
@@ -0,0 +1,185 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/db"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/go-pg/pg/v10"
+)
+
+type VersionRetentionRepository interface {
+	GetPolicyForHierarchy(packageId string) (*entity.VersionRetentionPolicyExtEntity, error)
+	SetPolicy(ent entity.VersionRetentionPolicyEntity) error
+	DeletePolicy(packageId string) error
+	GetPackagesUnderPolicy(rootPackageId string) ([]string, error)
+
+	GetLastRun() (*entity.VersionRetentionRunEntity, error)
+	StoreRun(ent entity.VersionRetentionRunEntity) error
+	UpdateRun(ent entity.VersionRetentionRunEntity) error
+	GetRun(runId int) (*entity.VersionRetentionRunEntity, error)
+	ListRuns(limit int, page int) ([]entity.VersionRetentionRunEntity, error)
+
+	StoreDeletedVersion(ent entity.VersionRetentionDeletedVersionEntity) error
+	GetDeletedVersions(rootPackageId string, runId int, limit int, page int) ([]entity.VersionRetentionDeletedVersionEntity, error)
+}
+
+func NewVersionRetentionRepository(cp db.ConnectionProvider) VersionRetentionRepository {
+	return &versionRetentionRepositoryImpl{cp: cp}
+}
+
+type versionRetentionRepositoryImpl struct {
+	cp db.ConnectionProvider
+}
+
+// GetPolicyForHierarchy returns the policy of the package itself or of the closest parent which has it
+func (v versionRetentionRepositoryImpl) GetPolicyForHierarchy(packageId string) (*entity.VersionRetentionPolicyExtEntity, error) {
+	packageIds := utils.GetPackageHierarchy(packageId)
+	result := new(entity.VersionRetentionPolicyExtEntity)
+	err := v.cp.GetConnection().Model(result).
+		ColumnExpr("version_retention_policy.*").
+		ColumnExpr("p.name as package_name").
+		ColumnExpr("p.kind as package_kind").
+		Join("inner join package_group p").
+		JoinOn("version_retention_policy.package_id = p.id").
+		Where("version_retention_policy.package_id in (?)", pg.In(packageIds)).
+		OrderExpr("length(version_retention_policy.package_id) desc").
+		Limit(1).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionRetentionRepositoryImpl) SetPolicy(ent entity.VersionRetentionPolicyEntity) error {
+	_, err := v.cp.GetConnection().Model(&ent).
+		OnConflict("(package_id) DO UPDATE").
+		Insert()
+	return err
+}
+
+func (v versionRetentionRepositoryImpl) DeletePolicy(packageId string) error {
+	_, err := v.cp.GetConnection().Model(&entity.VersionRetentionPolicyEntity{}).
+		Where("package_id = ?", packageId).
+		Delete()
+	return err
+}
+
+// GetPackagesUnderPolicy returns ids of packages and dashboards which have retention policy in their hierarchy.
+// If rootPackageId is set, only the package itself and its descendants are returned.
+func (v versionRetentionRepositoryImpl) GetPackagesUnderPolicy(rootPackageId string) ([]string, error) {
+	var result []string
+	query := `select p.id from package_group p
+		where p.kind in (?, ?)
+		and p.deleted_at is null
+		and exists (
+			select 1 from version_retention_policy vp
+			where p.id = vp.package_id or p.id like vp.package_id || '.%'
+		)`
+	params := []interface{}{entity.KIND_PACKAGE, entity.KIND_DASHBOARD}
+	if rootPackageId != "" {
+		query += ` and (p.id = ? or p.id like ? || '.%')`
+		params = append(params, rootPackageId, rootPackageId)
+	}
+	query += ` order by p.id`
+	_, err := v.cp.GetConnection().Query(&result, query, params...)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionRetentionRepositoryImpl) GetLastRun() (*entity.VersionRetentionRunEntity, error) {
+	result := new(entity.VersionRetentionRunEntity)
+	err := v.cp.GetConnection().Model(result).
+		Order("run_id DESC").
+		Limit(1).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionRetentionRepositoryImpl) StoreRun(ent entity.VersionRetentionRunEntity) error {
+	_, err := v.cp.GetConnection().Model(&ent).Insert()
+	return err
+}
+
+func (v versionRetentionRepositoryImpl) UpdateRun(ent entity.VersionRetentionRunEntity) error {
+	_, err := v.cp.GetConnection().Model(&ent).WherePK().Update()
+	return err
+}
+
+func (v versionRetentionRepositoryImpl) GetRun(runId int) (*entity.VersionRetentionRunEntity, error) {
+	result := new(entity.VersionRetentionRunEntity)
+	err := v.cp.GetConnection().Model(result).
+		Where("run_id = ?", runId).
+		First()
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionRetentionRepositoryImpl) ListRuns(limit int, page int) ([]entity.VersionRetentionRunEntity, error) {
+	var result []entity.VersionRetentionRunEntity
+	err := v.cp.GetConnection().Model(&result).
+		Order("run_id DESC").
+		Limit(limit).
+		Offset(limit * page).
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (v versionRetentionRepositoryImpl) StoreDeletedVersion(ent entity.VersionRetentionDeletedVersionEntity) error {
+	_, err := v.cp.GetConnection().Model(&ent).Insert()
+	return err
+}
+
+// GetDeletedVersions returns versions deleted by retention policies, rootPackageId and runId filters are optional
+func (v versionRetentionRepositoryImpl) GetDeletedVersions(rootPackageId string, runId int, limit int, page int) ([]entity.VersionRetentionDeletedVersionEntity, error) {
+	var result []entity.VersionRetentionDeletedVersionEntity
+	query := v.cp.GetConnection().Model(&result)
+	if rootPackageId != "" {
+		query.Where("package_id = ? or package_id like ? || '.%'", rootPackageId, rootPackageId)
+	}
+	if runId != 0 {
+		query.Where("run_id = ?", runId)
+	}
+	err := query.
+		Order("deleted_at DESC", "package_id ASC", "version ASC").
+		Limit(limit).
+		Offset(limit * page).
+		Select()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
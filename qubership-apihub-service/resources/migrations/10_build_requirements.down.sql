@@ -0,0 +1,3 @@
+alter table build drop column build_type;
+alter table build drop column api_type;
+alter table build drop column source_size;
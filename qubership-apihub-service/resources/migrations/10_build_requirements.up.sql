@@ -0,0 +1,11 @@
+alter table build add column build_type varchar;
+alter table build add column api_type varchar;
+alter table build add column source_size bigint;
+
+update build b set build_type = s.config->>'buildType', api_type = s.config->>'apiType'
+from build_src s
+where s.build_id = b.build_id;
+
+update build b set source_size = coalesce(octet_length(s.source), 0)
+from build_src s
+where s.build_id = b.build_id and b.status in ('none', 'running');
@@ -0,0 +1,2 @@
+drop index if exists build_builder_id_index;
+drop table builder;
@@ -0,0 +1,13 @@
+create table builder
+(
+    builder_id character varying
+        constraint builder_pk
+            primary key,
+    version character varying,
+    capacity integer not null default 1,
+    capabilities jsonb,
+    registered_at timestamp without time zone not null,
+    last_heartbeat timestamp without time zone not null
+);
+
+create index if not exists build_builder_id_index on build (builder_id) where status = 'running';
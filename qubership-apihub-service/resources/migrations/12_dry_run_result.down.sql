@@ -0,0 +1 @@
+drop table dry_run_result;
@@ -0,0 +1,12 @@
+create table dry_run_result
+(
+    build_id character varying
+        constraint dry_run_result_pk
+            primary key
+        constraint dry_run_result_build_id_fk
+            references build (build_id) on delete cascade,
+    package_id character varying not null,
+    version character varying not null,
+    created_at timestamp without time zone not null,
+    result jsonb not null
+);
@@ -0,0 +1,2 @@
+drop table publish_gate_request;
+drop table publish_gate_policy;
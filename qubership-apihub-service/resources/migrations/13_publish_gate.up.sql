@@ -0,0 +1,34 @@
+create table publish_gate_policy
+(
+    package_id character varying
+        constraint publish_gate_policy_pk
+            primary key
+        constraint publish_gate_policy_package_group_id_fk
+            references package_group (id) on update cascade on delete cascade,
+    action character varying not null,
+    severities character varying ARRAY not null,
+    updated_by character varying,
+    updated_at timestamp without time zone not null
+);
+
+create table publish_gate_request
+(
+    request_id character varying
+        constraint publish_gate_request_pk
+            primary key,
+    package_id character varying not null
+        constraint publish_gate_request_package_group_id_fk
+            references package_group (id) on update cascade on delete cascade,
+    version character varying not null,
+    breaking_changes integer not null default 0,
+    semi_breaking_changes integer not null default 0,
+    state character varying not null,
+    created_by character varying,
+    created_at timestamp without time zone not null,
+    resolved_by character varying,
+    resolved_at timestamp without time zone,
+    approved_build_id character varying
+);
+
+create index publish_gate_request_package_id_state_index on publish_gate_request (package_id, state);
+create unique index publish_gate_request_approved_build_id_uindex on publish_gate_request (approved_build_id);
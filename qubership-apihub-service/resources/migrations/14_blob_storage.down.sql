@@ -0,0 +1,28 @@
+-- blobs could be stored outside of the database depending on the blob storage configuration,
+-- they must be moved back to postgres via blob storage migration API before the rollback, otherwise the rollback fails to prevent data loss
+do $$
+begin
+    if exists(select 1 from export_result er
+              where not exists(select 1 from export_result_data erd where erd.export_id = er.export_id)) then
+        raise exception 'Some export results are stored outside of the database, migrate them to postgres blob storage before the rollback';
+    end if;
+    if exists(select 1 from published_sources ps
+              where ps.archive_checksum is not null
+                and not exists(select 1 from published_sources_archives psa where psa.checksum = ps.archive_checksum)) then
+        raise exception 'Some published sources archives are stored outside of the database, migrate them to postgres blob storage before the rollback';
+    end if;
+end
+$$;
+
+alter table export_result add column data bytea;
+
+update export_result er
+set data = erd.data
+from export_result_data erd
+where er.export_id = erd.export_id;
+
+alter table export_result alter column data set not null;
+
+drop table export_result_data;
+
+alter table published_sources add constraint published_sources_published_sources_archives_checksum_fk foreign key (archive_checksum) references published_sources_archives (checksum);
@@ -0,0 +1,15 @@
+create table export_result_data
+(
+    export_id character varying
+        constraint export_result_data_pk
+            primary key,
+    data      bytea not null
+);
+
+insert into export_result_data (export_id, data)
+select export_id, data from export_result;
+
+alter table export_result drop column data;
+
+-- sources archives could be stored outside of the database depending on the blob storage configuration
+alter table published_sources drop constraint if exists published_sources_published_sources_archives_checksum_fk;
@@ -0,0 +1,2 @@
+drop table version_status_transition;
+drop table version_status;
@@ -0,0 +1,36 @@
+create table version_status
+(
+    status character varying
+        constraint version_status_pk
+            primary key,
+    description character varying,
+    permission character varying not null,
+    initial boolean not null default false,
+    sort_order integer not null default 0,
+    updated_by character varying,
+    updated_at timestamp without time zone
+);
+
+create table version_status_transition
+(
+    from_status character varying not null
+        constraint version_status_transition_from_status_fk
+            references version_status (status) on delete cascade,
+    to_status character varying not null
+        constraint version_status_transition_to_status_fk
+            references version_status (status) on delete cascade,
+    permission character varying,
+    constraint version_status_transition_pk
+        primary key (from_status, to_status)
+);
+
+-- default lifecycle keeps the behavior which was hardcoded before
+insert into version_status (status, permission, initial, sort_order)
+values ('draft', 'manage_draft_version', true, 0),
+       ('release', 'manage_release_version', true, 1),
+       ('archived', 'manage_archived_version', true, 2);
+
+insert into version_status_transition (from_status, to_status)
+select f.status, t.status
+from version_status f, version_status t
+where f.status != t.status;
@@ -0,0 +1,3 @@
+drop table version_promotion_approval;
+drop table version_promotion_request;
+drop table version_promotion_policy;
@@ -0,0 +1,49 @@
+create table version_promotion_policy
+(
+    package_id character varying
+        constraint version_promotion_policy_pk
+            primary key
+        constraint version_promotion_policy_package_group_id_fk
+            references package_group (id) on update cascade on delete cascade,
+    required_approvals integer not null,
+    approver_roles character varying ARRAY not null,
+    statuses character varying ARRAY not null,
+    updated_by character varying,
+    updated_at timestamp without time zone not null
+);
+
+create table version_promotion_request
+(
+    request_id character varying
+        constraint version_promotion_request_pk
+            primary key,
+    package_id character varying not null
+        constraint version_promotion_request_package_group_id_fk
+            references package_group (id) on update cascade on delete cascade,
+    version character varying not null,
+    from_status character varying not null,
+    to_status character varying not null,
+    state character varying not null,
+    required_approvals integer not null,
+    approver_roles character varying ARRAY not null,
+    created_by character varying,
+    created_at timestamp without time zone not null,
+    resolved_by character varying,
+    resolved_at timestamp without time zone
+);
+
+create index version_promotion_request_package_id_state_index on version_promotion_request (package_id, state);
+create unique index version_promotion_request_pending_uindex on version_promotion_request (package_id, version) where state = 'pending';
+
+create table version_promotion_approval
+(
+    request_id character varying not null
+        constraint version_promotion_approval_request_id_fk
+            references version_promotion_request (request_id) on delete cascade,
+    user_id character varying not null,
+    decision character varying not null,
+    comment character varying,
+    created_at timestamp without time zone not null,
+    constraint version_promotion_approval_pk
+        primary key (request_id, user_id)
+);
@@ -0,0 +1,3 @@
+drop table version_retention_deleted_version;
+drop table version_retention_run;
+drop table version_retention_policy;
@@ -0,0 +1,42 @@
+create table version_retention_policy
+(
+    package_id character varying
+        constraint version_retention_policy_pk
+            primary key
+        constraint version_retention_policy_package_group_id_fk
+            references package_group (id) on update cascade on delete cascade,
+    rules jsonb not null,
+    updated_by character varying,
+    updated_at timestamp without time zone not null
+);
+
+create table version_retention_run
+(
+    run_id integer
+        constraint version_retention_run_pk
+            primary key,
+    scheduled_at timestamp without time zone not null,
+    finished_at timestamp without time zone,
+    triggered_by character varying,
+    status character varying not null,
+    details character varying,
+    deleted_items integer not null default 0
+);
+
+create table version_retention_deleted_version
+(
+    run_id integer not null
+        constraint version_retention_deleted_version_run_id_fk
+            references version_retention_run (run_id) on delete cascade,
+    package_id character varying not null,
+    version character varying not null,
+    status character varying not null,
+    published_at timestamp without time zone not null,
+    policy_package_id character varying not null,
+    reason character varying not null,
+    deleted_at timestamp without time zone not null,
+    constraint version_retention_deleted_version_pk
+        primary key (run_id, package_id, version)
+);
+
+create index version_retention_deleted_version_package_id_index on version_retention_deleted_version (package_id);
@@ -0,0 +1,3 @@
+drop index package_group_deleted_at_index;
+drop index published_version_deleted_at_index;
+drop table deleted_grouped_operation;
@@ -0,0 +1,18 @@
+-- grouped operations of deleted versions are kept here to be restored together with the version
+create table deleted_grouped_operation
+(
+    group_id character varying not null,
+    package_id character varying not null,
+    version character varying not null,
+    revision integer not null,
+    operation_id character varying not null,
+    constraint deleted_grouped_operation_operation_fk
+        foreign key (package_id, version, revision, operation_id) references operation (package_id, version, revision, operation_id) on update cascade on delete cascade,
+    constraint deleted_grouped_operation_operation_group_fk
+        foreign key (group_id) references operation_group (group_id) on update cascade on delete cascade
+);
+
+create index deleted_grouped_operation_version_index on deleted_grouped_operation (package_id, version, revision);
+
+create index published_version_deleted_at_index on published_version (deleted_at) where deleted_at is not null;
+create index package_group_deleted_at_index on package_group (deleted_at) where deleted_at is not null;
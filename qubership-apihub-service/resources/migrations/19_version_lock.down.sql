@@ -0,0 +1,2 @@
+drop table version_lock_policy;
+drop table version_lock;
@@ -0,0 +1,25 @@
+create table version_lock
+(
+    package_id character varying not null
+        constraint version_lock_package_group_id_fk
+            references package_group (id) on update cascade on delete cascade,
+    version character varying not null,
+    -- false means that the version was unlocked by sysadmin and the lock policy is not applied to it
+    locked boolean not null,
+    updated_by character varying,
+    updated_at timestamp without time zone not null,
+    constraint version_lock_pk
+        primary key (package_id, version)
+);
+
+create table version_lock_policy
+(
+    package_id character varying
+        constraint version_lock_policy_pk
+            primary key
+        constraint version_lock_policy_package_group_id_fk
+            references package_group (id) on update cascade on delete cascade,
+    statuses character varying ARRAY not null,
+    updated_by character varying,
+    updated_at timestamp without time zone not null
+);
@@ -0,0 +1 @@
+drop table bulk_version_operation;
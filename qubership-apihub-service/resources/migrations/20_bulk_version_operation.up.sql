@@ -0,0 +1,15 @@
+create table bulk_version_operation
+(
+    operation_id character varying not null
+        constraint bulk_version_operation_pk
+            primary key,
+    package_id character varying not null
+        constraint bulk_version_operation_package_group_id_fk
+            references package_group (id) on update cascade on delete cascade,
+    action character varying not null,
+    status character varying not null,
+    message character varying,
+    report bytea,
+    created_by character varying,
+    created_at timestamp without time zone not null
+);
@@ -0,0 +1,3 @@
+alter table build_cleanup_run drop column if exists ts_protobuf_operation_data;
+
+drop table if exists ts_protobuf_operation_data;
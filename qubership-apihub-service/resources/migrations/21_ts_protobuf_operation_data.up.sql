@@ -0,0 +1,24 @@
+create table if not exists ts_protobuf_operation_data
+(
+    data_hash character varying not null
+        constraint pk_ts_protobuf_operation_data
+            primary key,
+    scope_request tsvector,
+    scope_response tsvector,
+    scope_annotation tsvector,
+    scope_field tsvector
+);
+
+alter table build_cleanup_run add column if not exists ts_protobuf_operation_data integer default 0;
+
+create index if not exists ts_protobuf_operation_data_idx on ts_protobuf_operation_data using gin (scope_request, scope_response, scope_annotation, scope_field) with (fastupdate='true');
+
+insert into ts_protobuf_operation_data
+    select data_hash,
+    to_tsvector(jsonb_extract_path_text(search_scope, 'request')) scope_request,
+    to_tsvector(jsonb_extract_path_text(search_scope, 'response')) scope_response,
+    to_tsvector(jsonb_extract_path_text(search_scope, 'annotation')) scope_annotation,
+    to_tsvector(jsonb_extract_path_text(search_scope, 'field')) scope_field
+    from operation_data
+    where data_hash in (select distinct data_hash from operation where type = 'protobuf')
+on conflict (data_hash) do nothing;
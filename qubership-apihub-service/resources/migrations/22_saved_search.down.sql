@@ -0,0 +1,3 @@
+drop table saved_search_notification;
+drop table saved_search_match;
+drop table saved_search;
@@ -0,0 +1,53 @@
+create table saved_search
+(
+    saved_search_id character varying not null
+        constraint saved_search_pk
+            primary key,
+    user_id character varying not null
+        constraint saved_search_user_data_user_id_fk
+            references user_data (user_id) on update cascade on delete cascade,
+    name character varying not null,
+    query jsonb not null,
+    subscribed boolean not null default false,
+    webhook_url character varying,
+    created_at timestamp without time zone not null,
+    updated_at timestamp without time zone,
+    constraint saved_search_user_id_name_uindex
+        unique (user_id, name)
+);
+
+create index saved_search_subscribed_index
+    on saved_search (subscribed)
+    where subscribed = true;
+
+create table saved_search_match
+(
+    saved_search_id character varying not null
+        constraint saved_search_match_saved_search_id_fk
+            references saved_search (saved_search_id) on delete cascade,
+    package_id character varying not null,
+    operation_id character varying not null,
+    matched_at timestamp without time zone not null,
+    constraint saved_search_match_pk
+        primary key (saved_search_id, package_id, operation_id)
+);
+
+create table saved_search_notification
+(
+    notification_id character varying not null
+        constraint saved_search_notification_pk
+            primary key,
+    saved_search_id character varying not null
+        constraint saved_search_notification_saved_search_id_fk
+            references saved_search (saved_search_id) on delete cascade,
+    user_id character varying not null,
+    package_id character varying not null,
+    version character varying not null,
+    revision integer not null,
+    operations jsonb not null,
+    read boolean not null default false,
+    created_at timestamp without time zone not null
+);
+
+create index saved_search_notification_user_id_created_at_index
+    on saved_search_notification (user_id, created_at desc);
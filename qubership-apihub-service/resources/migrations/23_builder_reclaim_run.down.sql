@@ -0,0 +1 @@
+drop table builder_reclaim_run;
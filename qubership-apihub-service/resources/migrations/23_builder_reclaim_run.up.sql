@@ -0,0 +1,7 @@
+create table builder_reclaim_run
+(
+    run_id integer
+        constraint builder_reclaim_run_pk
+            primary key,
+    scheduled_at timestamp without time zone not null
+);
@@ -0,0 +1,3 @@
+drop index if exists build_parent_build_id_index;
+
+alter table build drop column if exists parent_build_id;
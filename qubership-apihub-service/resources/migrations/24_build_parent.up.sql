@@ -0,0 +1,3 @@
+alter table build add column if not exists parent_build_id varchar;
+
+create index if not exists build_parent_build_id_index on build (parent_build_id);
@@ -0,0 +1 @@
+drop table blob_storage_migration_run;
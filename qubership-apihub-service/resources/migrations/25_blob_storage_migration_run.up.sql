@@ -0,0 +1,9 @@
+create table blob_storage_migration_run
+(
+    run_id integer
+        constraint blob_storage_migration_run_pk
+            primary key,
+    status character varying not null,
+    started_at timestamp without time zone not null,
+    finished_at timestamp without time zone
+);
@@ -0,0 +1 @@
+drop table version_promotion_notification;
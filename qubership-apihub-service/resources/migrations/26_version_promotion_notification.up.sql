@@ -0,0 +1,15 @@
+create table version_promotion_notification
+(
+    notification_id character varying not null
+        constraint version_promotion_notification_pk
+            primary key,
+    request_id character varying not null
+        constraint version_promotion_notification_request_id_fk
+            references version_promotion_request (request_id) on delete cascade,
+    user_id character varying not null,
+    read boolean not null default false,
+    created_at timestamp without time zone not null
+);
+
+create index version_promotion_notification_user_id_created_at_index
+    on version_promotion_notification (user_id, created_at desc);
@@ -0,0 +1 @@
+drop table inferred_version_reservation;
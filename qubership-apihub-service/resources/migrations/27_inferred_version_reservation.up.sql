@@ -0,0 +1,11 @@
+create table inferred_version_reservation
+(
+    package_id character varying not null
+        constraint inferred_version_reservation_package_group_id_fk
+            references package_group (id) on update cascade on delete cascade,
+    version character varying not null,
+    build_id character varying not null,
+    reserved_at timestamp without time zone not null,
+    constraint inferred_version_reservation_pk
+        primary key (package_id, version)
+);
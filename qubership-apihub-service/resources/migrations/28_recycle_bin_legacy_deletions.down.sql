@@ -0,0 +1,16 @@
+-- items restored or purged after the migration are left as is
+update published_version pv set deleted_at = b.deleted_at
+from recycle_bin_legacy_deletion_backup b
+where b.version is not null
+and pv.package_id = b.package_id
+and pv.version = b.version
+and pv.revision = b.revision
+and pv.deleted_at is not null;
+
+update package_group p set deleted_at = b.deleted_at
+from recycle_bin_legacy_deletion_backup b
+where b.version is null
+and p.id = b.package_id
+and p.deleted_at is not null;
+
+drop table recycle_bin_legacy_deletion_backup;
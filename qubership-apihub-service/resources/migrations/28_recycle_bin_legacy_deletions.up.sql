@@ -0,0 +1,62 @@
+-- Before the recycle bin, every package of the deleted hierarchy and its versions got own deleted_at value
+-- (children were deleted right before the parent in the same transaction).
+-- Align them to deleted_at of the hierarchy root, so legacy deletions could be listed and restored as a single item.
+-- Descendants deleted by the same user less than a minute before the parent are considered deleted together with it.
+-- Original values are kept in recycle_bin_legacy_deletion_backup, so the down migration could revert the alignment.
+create table recycle_bin_legacy_deletion_backup
+(
+    package_id character varying not null,
+    -- version and revision are null for the package itself
+    version character varying,
+    revision integer,
+    deleted_at timestamp without time zone not null
+);
+
+create temporary table legacy_deletion on commit drop as
+with recursive deletion as (
+    select p.id, p.deleted_at, p.deleted_by, p.deleted_at as root_deleted_at
+    from package_group p
+    left join package_group parent on parent.id = p.parent_id
+    where p.deleted_at is not null
+    and not coalesce(parent.deleted_at is not null
+        and parent.deleted_by is not distinct from p.deleted_by
+        and parent.deleted_at >= p.deleted_at
+        and parent.deleted_at - p.deleted_at < interval '1 minute', false)
+    union all
+    select c.id, c.deleted_at, c.deleted_by, d.root_deleted_at
+    from package_group c
+    inner join deletion d on c.parent_id = d.id
+    where c.deleted_at is not null
+    and c.deleted_by is not distinct from d.deleted_by
+    and d.deleted_at >= c.deleted_at
+    and d.deleted_at - c.deleted_at < interval '1 minute'
+)
+select id, deleted_at, deleted_by, root_deleted_at from deletion;
+
+create temporary table legacy_deletion_version on commit drop as
+select pv.package_id, pv.version, pv.revision, pv.deleted_at, d.root_deleted_at
+from published_version pv
+inner join legacy_deletion d on pv.package_id = d.id
+where pv.deleted_at is not null
+and pv.deleted_by is not distinct from d.deleted_by
+and d.deleted_at >= pv.deleted_at
+and d.deleted_at - pv.deleted_at < interval '1 minute'
+and pv.deleted_at <> d.root_deleted_at;
+
+insert into recycle_bin_legacy_deletion_backup (package_id, version, revision, deleted_at)
+select package_id, version, revision, deleted_at from legacy_deletion_version;
+
+insert into recycle_bin_legacy_deletion_backup (package_id, deleted_at)
+select id, deleted_at from legacy_deletion
+where deleted_at <> root_deleted_at;
+
+update published_version pv set deleted_at = v.root_deleted_at
+from legacy_deletion_version v
+where pv.package_id = v.package_id
+and pv.version = v.version
+and pv.revision = v.revision;
+
+update package_group p set deleted_at = d.root_deleted_at
+from legacy_deletion d
+where p.id = d.id
+and p.deleted_at <> d.root_deleted_at;
@@ -0,0 +1,2 @@
+drop table deleted_default_release_version;
+drop table deleted_previous_version_link;
@@ -0,0 +1,29 @@
+-- links to the deleted version are cleared on delete and are kept here to be restored together with the version
+
+-- versions which had the deleted version as the previous one
+create table deleted_previous_version_link
+(
+    package_id character varying not null,
+    version character varying not null,
+    revision integer not null,
+    -- original values of the cleared columns
+    previous_version character varying not null,
+    previous_version_package_id character varying,
+    -- package of the deleted version, previous_version_package_id could be empty for the version of the same package
+    deleted_package_id character varying not null,
+    constraint deleted_previous_version_link_published_version_fk
+        foreign key (package_id, version, revision) references published_version (package_id, version, revision) on update cascade on delete cascade
+);
+
+create index deleted_previous_version_link_deleted_version_index on deleted_previous_version_link (deleted_package_id, previous_version);
+
+-- packages which had the deleted version as the default release version
+create table deleted_default_release_version
+(
+    package_id character varying not null
+        constraint deleted_default_release_version_package_group_id_fk
+            references package_group (id) on update cascade on delete cascade,
+    version character varying not null,
+    constraint deleted_default_release_version_pk
+        primary key (package_id)
+);
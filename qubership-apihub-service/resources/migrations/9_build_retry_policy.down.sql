@@ -0,0 +1,2 @@
+alter table build drop column dead_letter;
+alter table build drop column next_attempt_at;
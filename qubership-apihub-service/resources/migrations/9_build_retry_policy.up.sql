@@ -0,0 +1,5 @@
+alter table build add column dead_letter boolean not null default false;
+alter table build add column next_attempt_at timestamp without time zone;
+
+update build set dead_letter = true
+where status = 'error' and restart_count >= 2 and details like 'Restart count exceeded limit%';
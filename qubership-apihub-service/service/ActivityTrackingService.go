@@ -259,6 +259,8 @@ func (a activityTrackingServiceImpl) makePkgActivityResponse(ents []entity.Enric
 			ent.Type == string(view.ATETPublishNewVersion) ||
 			ent.Type == string(view.ATETPatchVersionMeta) ||
 			ent.Type == string(view.ATETDeleteVersion) ||
+			ent.Type == string(view.ATETLockVersion) ||
+			ent.Type == string(view.ATETUnlockVersion) ||
 			ent.Type == string(view.ATETCreateManualGroup) ||
 			ent.Type == string(view.ATETDeleteManualGroup) ||
 			ent.Type == string(view.ATETOperationsGroupParameters) {
@@ -16,10 +16,12 @@ package service
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
 )
@@ -28,6 +30,8 @@ type AgentRegistrationService interface {
 	ProcessAgentSignal(view.AgentKeepaliveMessage) (*view.AgentVersion, error)
 	ListAgents(onlyActive bool, showIncompatible bool) ([]view.AgentInstance, error)
 	GetAgent(id string) (*view.AgentInstance, error)
+	// GetActiveAgent returns an error if the agent doesn't exist, is inactive or incompatible
+	GetActiveAgent(id string) (*view.AgentInstance, error)
 }
 
 func NewAgentRegistrationService(repository repository.AgentRepository) AgentRegistrationService {
@@ -94,6 +98,44 @@ func (a agentRegistrationServiceImpl) GetAgent(id string) (*view.AgentInstance,
 	return &res, nil
 }
 
+func (a agentRegistrationServiceImpl) GetActiveAgent(id string) (*view.AgentInstance, error) {
+	agent, err := a.GetAgent(id)
+	if err != nil {
+		return nil, err
+	}
+	if agent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.AgentNotFound,
+			Message: exception.AgentNotFoundMsg,
+			Params:  map[string]interface{}{"agentId": id},
+		}
+	}
+	if agent.Status != view.AgentStatusActive {
+		return nil, &exception.CustomError{
+			Status:  http.StatusFailedDependency,
+			Code:    exception.InactiveAgent,
+			Message: exception.InactiveAgentMsg,
+			Params:  map[string]interface{}{"agentId": id},
+		}
+	}
+	if agent.AgentVersion == "" {
+		return nil, &exception.CustomError{
+			Status:  http.StatusFailedDependency,
+			Code:    exception.IncompatibleAgentVersion,
+			Message: exception.IncompatibleAgentVersionMsg,
+			Params:  map[string]interface{}{"version": agent.AgentVersion},
+		}
+	}
+	if agent.CompatibilityError != nil && agent.CompatibilityError.Severity == view.SeverityError {
+		return nil, &exception.CustomError{
+			Status:  http.StatusFailedDependency,
+			Message: agent.CompatibilityError.Message,
+		}
+	}
+	return agent, nil
+}
+
 func CheckAgentCompatibility(actualAgentVersion string) *view.AgentCompatibilityError {
 	if EXPECTED_AGENT_VERSION == actualAgentVersion {
 		return nil
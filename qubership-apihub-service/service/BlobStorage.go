@@ -0,0 +1,159 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+// BlobStorage keeps relatively big binary files (build results, published sources archives, export results).
+// Blobs are addressed by kind (see view.BlobKinds) and key which is unique within the kind.
+type BlobStorage interface {
+	Type() view.BlobStorageType
+	// Put stores the blob, size could be -1 if it's unknown
+	Put(ctx context.Context, kind string, key string, content io.Reader, size int64) error
+	// Get returns ErrBlobNotFound if there's no blob with the key. Returned reader must be closed by the caller.
+	Get(ctx context.Context, kind string, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, kind string, keys []string) error
+	ListKeys(ctx context.Context, kind string, handler func(key string) error) error
+}
+
+// blobStorageCompactor is implemented by the backends which do not release the space right after the blob deletion
+type blobStorageCompactor interface {
+	Compact(ctx context.Context, kind string) error
+}
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// PutBlob is used when the blob content is already in memory (e.g. it has been processed before storing)
+func PutBlob(ctx context.Context, storage BlobStorage, kind string, key string, data []byte) error {
+	return storage.Put(ctx, kind, key, bytes.NewReader(data), int64(len(data)))
+}
+
+// GetBlob reads the whole blob into memory, returns nil if there's no blob with the key.
+// It should be used only when the content is processed in memory anyway (e.g. unzipped), otherwise use BlobStorage.Get
+func GetBlob(ctx context.Context, storage BlobStorage, kind string, key string) ([]byte, error) {
+	content, err := storage.Get(ctx, kind, key)
+	if err != nil {
+		if errors.Is(err, ErrBlobNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s blob %s: %w", kind, key, err)
+	}
+	return data, nil
+}
+
+// NewBlobStorage creates the storage configured via BLOB_STORAGE_TYPE env
+func NewBlobStorage(systemInfoService SystemInfoService, blobRepository repository.BlobRepository) (BlobStorage, error) {
+	defaultStorage, err := NewBlobStorageBackend(systemInfoService.GetBlobStorageType(), systemInfoService, blobRepository)
+	if err != nil {
+		return nil, err
+	}
+	storages := make(map[string]BlobStorage)
+	for _, kind := range view.BlobKinds {
+		storageType := getBlobStorageTypeForKind(systemInfoService, kind)
+		if storageType == defaultStorage.Type() {
+			storages[kind] = defaultStorage
+			continue
+		}
+		storages[kind], err = NewBlobStorageBackend(storageType, systemInfoService, blobRepository)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &blobStorageImpl{defaultStorage: defaultStorage, storages: storages}, nil
+}
+
+func NewBlobStorageBackend(storageType view.BlobStorageType, systemInfoService SystemInfoService, blobRepository repository.BlobRepository) (BlobStorage, error) {
+	switch storageType {
+	case view.BlobStoragePostgres:
+		return NewPostgresBlobStorage(blobRepository), nil
+	case view.BlobStorageFilesystem:
+		return NewFilesystemBlobStorage(systemInfoService.GetBlobStorageFsPath())
+	case view.BlobStorageS3:
+		return NewS3BlobStorage(systemInfoService.GetMinioStorageCreds())
+	}
+	return nil, fmt.Errorf("unknown blob storage type: %s", storageType)
+}
+
+// getBlobStorageTypeForKind keeps STORAGE_SERVER_STORE_ONLY_BUILD_RESULT working: only build results are stored in minio in this case
+func getBlobStorageTypeForKind(systemInfoService SystemInfoService, kind string) view.BlobStorageType {
+	storageType := systemInfoService.GetBlobStorageType()
+	if storageType == view.BlobStorageS3 && systemInfoService.IsMinioStoreOnlyBuildResult() && kind != view.BUILD_RESULT_TABLE {
+		return view.BlobStoragePostgres
+	}
+	return storageType
+}
+
+type blobStorageImpl struct {
+	defaultStorage BlobStorage
+	storages       map[string]BlobStorage
+}
+
+func (b blobStorageImpl) getStorage(kind string) (BlobStorage, error) {
+	storage, exists := b.storages[kind]
+	if !exists {
+		return nil, fmt.Errorf("unsupported blob kind: %s", kind)
+	}
+	return storage, nil
+}
+
+func (b blobStorageImpl) Type() view.BlobStorageType {
+	return b.defaultStorage.Type()
+}
+
+func (b blobStorageImpl) Put(ctx context.Context, kind string, key string, content io.Reader, size int64) error {
+	storage, err := b.getStorage(kind)
+	if err != nil {
+		return err
+	}
+	return storage.Put(ctx, kind, key, content, size)
+}
+
+func (b blobStorageImpl) Get(ctx context.Context, kind string, key string) (io.ReadCloser, error) {
+	storage, err := b.getStorage(kind)
+	if err != nil {
+		return nil, err
+	}
+	return storage.Get(ctx, kind, key)
+}
+
+func (b blobStorageImpl) Delete(ctx context.Context, kind string, keys []string) error {
+	storage, err := b.getStorage(kind)
+	if err != nil {
+		return err
+	}
+	return storage.Delete(ctx, kind, keys)
+}
+
+func (b blobStorageImpl) ListKeys(ctx context.Context, kind string, handler func(key string) error) error {
+	storage, err := b.getStorage(kind)
+	if err != nil {
+		return err
+	}
+	return storage.ListKeys(ctx, kind, handler)
+}
@@ -0,0 +1,137 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+// tmp files are created in the same directory to make the final rename atomic
+const filesystemBlobTmpPrefix = ".tmp-"
+
+// NewFilesystemBlobStorage creates the storage which keeps blobs as files in <rootPath>/<kind>/<key>.
+// The directory must be shared between all backend replicas.
+func NewFilesystemBlobStorage(rootPath string) (BlobStorage, error) {
+	if err := os.MkdirAll(rootPath, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create blob storage directory %s: %w", rootPath, err)
+	}
+	return &filesystemBlobStorageImpl{rootPath: rootPath}, nil
+}
+
+type filesystemBlobStorageImpl struct {
+	rootPath string
+}
+
+func (f filesystemBlobStorageImpl) Type() view.BlobStorageType {
+	return view.BlobStorageFilesystem
+}
+
+func (f filesystemBlobStorageImpl) blobPath(kind string, key string) (string, error) {
+	if !view.ValidBlobKind(kind) {
+		return "", fmt.Errorf("unsupported blob kind: %s", kind)
+	}
+	if key == "" || strings.HasPrefix(key, ".") || strings.ContainsAny(key, `/\`) {
+		return "", fmt.Errorf("invalid blob key: %s", key)
+	}
+	return filepath.Join(f.rootPath, kind, key), nil
+}
+
+func (f filesystemBlobStorageImpl) Put(ctx context.Context, kind string, key string, content io.Reader, size int64) error {
+	path, err := f.blobPath(kind, key)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err = os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create blob directory %s: %w", dir, err)
+	}
+	tmpFile, err := os.CreateTemp(dir, filesystemBlobTmpPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("failed to create tmp file for %s blob %s: %w", kind, key, err)
+	}
+	defer os.Remove(tmpFile.Name()) // no-op after successful rename
+	_, err = io.Copy(tmpFile, content)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s blob %s: %w", kind, key, err)
+	}
+	if err = os.Rename(tmpFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to store %s blob %s: %w", kind, key, err)
+	}
+	return nil
+}
+
+func (f filesystemBlobStorageImpl) Get(ctx context.Context, kind string, key string) (io.ReadCloser, error) {
+	path, err := f.blobPath(kind, key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrBlobNotFound
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+func (f filesystemBlobStorageImpl) Delete(ctx context.Context, kind string, keys []string) error {
+	for _, key := range keys {
+		path, err := f.blobPath(kind, key)
+		if err != nil {
+			return err
+		}
+		if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete %s blob %s: %w", kind, key, err)
+		}
+	}
+	return nil
+}
+
+func (f filesystemBlobStorageImpl) ListKeys(ctx context.Context, kind string, handler func(key string) error) error {
+	if !view.ValidBlobKind(kind) {
+		return fmt.Errorf("unsupported blob kind: %s", kind)
+	}
+	entries, err := os.ReadDir(filepath.Join(f.rootPath, kind))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		if err = handler(entry.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// BlobStorageMigrationService copies blobs between the storage backends.
+// Migrations are executed asynchronously and their statuses are kept in memory of the replica which started them.
+// Only one migration run is executed at a time across all replicas.
+type BlobStorageMigrationService interface {
+	StartMigration(req view.BlobStorageMigrationReq) (string, error)
+	GetMigration(migrationId string) (*view.BlobStorageMigration, error)
+	// MigrateFromDatabase moves blobs which are still stored in the database to the configured storage
+	MigrateFromDatabase()
+}
+
+func NewBlobStorageMigrationService(systemInfoService SystemInfoService, blobRepository repository.BlobRepository) BlobStorageMigrationService {
+	return &blobStorageMigrationServiceImpl{
+		systemInfoService: systemInfoService,
+		blobRepository:    blobRepository,
+		storages:          map[view.BlobStorageType]BlobStorage{},
+		migrations:        map[string]*view.BlobStorageMigration{},
+	}
+}
+
+type blobStorageMigrationServiceImpl struct {
+	systemInfoService SystemInfoService
+	blobRepository    repository.BlobRepository
+
+	storages      map[view.BlobStorageType]BlobStorage
+	storagesMutex sync.Mutex
+
+	migrations      map[string]*view.BlobStorageMigration
+	migrationsMutex sync.RWMutex
+}
+
+const blobStorageMigrationDeleteBatchSize = 100
+
+func (b *blobStorageMigrationServiceImpl) StartMigration(req view.BlobStorageMigrationReq) (string, error) {
+	if _, err := view.ParseBlobStorageType(string(req.From)); err != nil {
+		return "", unknownBlobStorageTypeError(req.From)
+	}
+	if _, err := view.ParseBlobStorageType(string(req.To)); err != nil {
+		return "", unknownBlobStorageTypeError(req.To)
+	}
+	if req.From == req.To {
+		return "", &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.SameBlobStorageMigration,
+			Message: exception.SameBlobStorageMigrationMsg,
+		}
+	}
+	kinds := req.Kinds
+	if len(kinds) == 0 {
+		kinds = view.BlobKinds
+	}
+	for _, kind := range kinds {
+		if !view.ValidBlobKind(kind) {
+			return "", &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.UnknownBlobKind,
+				Message: exception.UnknownBlobKindMsg,
+				Params:  map[string]interface{}{"kind": kind},
+			}
+		}
+	}
+	from, err := b.getStorage(req.From)
+	if err != nil {
+		return "", err
+	}
+	to, err := b.getStorage(req.To)
+	if err != nil {
+		return "", err
+	}
+
+	runId, err := b.startRun()
+	if err != nil {
+		return "", err
+	}
+	migration := b.addMigration(req, kinds)
+	utils.SafeAsync(func() {
+		b.finishRun(runId, b.runMigration(migration, from, to))
+	})
+	return migration.MigrationId, nil
+}
+
+func (b *blobStorageMigrationServiceImpl) addMigration(req view.BlobStorageMigrationReq, kinds []string) *view.BlobStorageMigration {
+	migration := &view.BlobStorageMigration{
+		MigrationId:      uuid.New().String(),
+		From:             req.From,
+		To:               req.To,
+		Kinds:            kinds,
+		DeleteFromSource: req.DeleteFromSource,
+		Status:           view.BlobStorageMigrationRunning,
+		Copied:           map[string]int{},
+		Failed:           map[string]int{},
+		StartedAt:        time.Now(),
+	}
+	b.migrationsMutex.Lock()
+	b.migrations[migration.MigrationId] = migration
+	b.migrationsMutex.Unlock()
+	return migration
+}
+
+func (b *blobStorageMigrationServiceImpl) runMigration(migration *view.BlobStorageMigration, from BlobStorage, to BlobStorage) error {
+	log.Infof("Blob storage migration %s from %s to %s has started for %v", migration.MigrationId, migration.From, migration.To, migration.Kinds)
+	err := b.migrate(migration, from, to)
+	b.migrationsMutex.Lock()
+	defer b.migrationsMutex.Unlock()
+	finishedAt := time.Now()
+	migration.FinishedAt = &finishedAt
+	if err != nil {
+		log.Errorf("Blob storage migration %s failed: %s", migration.MigrationId, err.Error())
+		migration.Status = view.BlobStorageMigrationError
+		migration.Details = err.Error()
+		return err
+	}
+	log.Infof("Blob storage migration %s has finished. Copied: %v, failed: %v", migration.MigrationId, migration.Copied, migration.Failed)
+	migration.Status = view.BlobStorageMigrationComplete
+	return nil
+}
+
+// the run of the crashed instance is considered as finished after this timeout
+const blobStorageMigrationRunTimeout = 24 * time.Hour
+
+// startRun registers the migration run, run id is used as a lock across instances,
+// so migrations started on startup and via API are not executed concurrently
+func (b *blobStorageMigrationServiceImpl) startRun() (int, error) {
+	inProgressErr := &exception.CustomError{
+		Status:  http.StatusConflict,
+		Code:    exception.BlobStorageMigrationInProgress,
+		Message: exception.BlobStorageMigrationInProgressMsg,
+	}
+	lastRun, err := b.blobRepository.GetLastMigrationRun()
+	if err != nil {
+		return 0, err
+	}
+	runId := 1
+	if lastRun != nil {
+		if lastRun.Status == string(view.BlobStorageMigrationRunning) && time.Since(lastRun.StartedAt) < blobStorageMigrationRunTimeout {
+			return 0, inProgressErr
+		}
+		runId = lastRun.RunId + 1
+	}
+	// the insert fails if another run has been started concurrently
+	err = b.blobRepository.StoreMigrationRun(entity.BlobStorageMigrationRunEntity{
+		RunId:     runId,
+		Status:    string(view.BlobStorageMigrationRunning),
+		StartedAt: time.Now(),
+	})
+	if err != nil {
+		inProgressErr.Debug = err.Error()
+		return 0, inProgressErr
+	}
+	return runId, nil
+}
+
+func (b *blobStorageMigrationServiceImpl) finishRun(runId int, migrationErr error) {
+	finishedAt := time.Now()
+	ent := entity.BlobStorageMigrationRunEntity{
+		RunId:      runId,
+		Status:     string(view.BlobStorageMigrationComplete),
+		FinishedAt: &finishedAt,
+	}
+	if migrationErr != nil {
+		ent.Status = string(view.BlobStorageMigrationError)
+	}
+	if err := b.blobRepository.UpdateMigrationRun(ent); err != nil {
+		log.Errorf("Failed to finish blob storage migration run %d: %s", runId, err.Error())
+	}
+}
+
+func (b *blobStorageMigrationServiceImpl) GetMigration(migrationId string) (*view.BlobStorageMigration, error) {
+	b.migrationsMutex.RLock()
+	defer b.migrationsMutex.RUnlock()
+	migration, exists := b.migrations[migrationId]
+	if !exists {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.BlobStorageMigrationNotFound,
+			Message: exception.BlobStorageMigrationNotFoundMsg,
+			Params:  map[string]interface{}{"migrationId": migrationId},
+		}
+	}
+	result := *migration
+	result.Copied = make(map[string]int, len(migration.Copied))
+	for kind, count := range migration.Copied {
+		result.Copied[kind] = count
+	}
+	result.Failed = make(map[string]int, len(migration.Failed))
+	for kind, count := range migration.Failed {
+		result.Failed[kind] = count
+	}
+	return &result, nil
+}
+
+// MigrateFromDatabase is executed on startup of each instance, but only one of them performs the migration.
+// Blobs are kept in the database unless BLOB_STORAGE_MIGRATION_DELETE_FROM_DB is set, so the configuration could be rolled back.
+func (b *blobStorageMigrationServiceImpl) MigrateFromDatabase() {
+	kindsByStorage := map[view.BlobStorageType][]string{}
+	for _, kind := range view.BlobKinds {
+		storageType := getBlobStorageTypeForKind(b.systemInfoService, kind)
+		if storageType != view.BlobStoragePostgres {
+			kindsByStorage[storageType] = append(kindsByStorage[storageType], kind)
+		}
+	}
+	if len(kindsByStorage) == 0 {
+		return
+	}
+	from, err := b.getStorage(view.BlobStoragePostgres)
+	if err != nil {
+		log.Errorf("Failed to start blob storage migration from database: %s", err.Error())
+		return
+	}
+	storages := make(map[view.BlobStorageType]BlobStorage, len(kindsByStorage))
+	for storageType := range kindsByStorage {
+		storages[storageType], err = b.getStorage(storageType)
+		if err != nil {
+			log.Errorf("Failed to start blob storage migration from database to %s: %s", storageType, err.Error())
+			return
+		}
+	}
+	runId, err := b.startRun()
+	if err != nil {
+		log.Infof("Blob storage migration from database is skipped: %s", err.Error())
+		return
+	}
+	migrations := make([]*view.BlobStorageMigration, 0, len(kindsByStorage))
+	for storageType, kinds := range kindsByStorage {
+		migrations = append(migrations, b.addMigration(view.BlobStorageMigrationReq{
+			From:             view.BlobStoragePostgres,
+			To:               storageType,
+			Kinds:            kinds,
+			DeleteFromSource: b.systemInfoService.IsBlobStorageMigrationDeleteFromDb(),
+		}, kinds))
+	}
+	utils.SafeAsync(func() {
+		var runErr error
+		for _, migration := range migrations {
+			if err := b.runMigration(migration, from, storages[migration.To]); err != nil {
+				runErr = err
+			}
+		}
+		b.finishRun(runId, runErr)
+	})
+}
+
+func (b *blobStorageMigrationServiceImpl) getStorage(storageType view.BlobStorageType) (BlobStorage, error) {
+	b.storagesMutex.Lock()
+	defer b.storagesMutex.Unlock()
+	if storage, exists := b.storages[storageType]; exists {
+		return storage, nil
+	}
+	storage, err := NewBlobStorageBackend(storageType, b.systemInfoService, b.blobRepository)
+	if err != nil {
+		return nil, err
+	}
+	b.storages[storageType] = storage
+	return storage, nil
+}
+
+func (b *blobStorageMigrationServiceImpl) migrate(migration *view.BlobStorageMigration, from BlobStorage, to BlobStorage) error {
+	ctx := context.Background()
+	for _, kind := range migration.Kinds {
+		copiedKeys := make([]string, 0)
+		deleted := 0
+		deleteCopied := func() error {
+			if len(copiedKeys) == 0 {
+				return nil
+			}
+			if err := from.Delete(ctx, kind, copiedKeys); err != nil {
+				return fmt.Errorf("failed to delete %s blobs from %s: %w", kind, from.Type(), err)
+			}
+			deleted += len(copiedKeys)
+			copiedKeys = copiedKeys[:0]
+			return nil
+		}
+		err := from.ListKeys(ctx, kind, func(key string) error {
+			if err := copyBlob(ctx, from, to, kind, key); err != nil {
+				log.Errorf("Blob storage migration %s: failed to copy %s blob %s: %s", migration.MigrationId, kind, key, err.Error())
+				b.incrementCounter(migration.Failed, kind)
+				return nil
+			}
+			b.incrementCounter(migration.Copied, kind)
+			if !migration.DeleteFromSource {
+				return nil
+			}
+			copiedKeys = append(copiedKeys, key)
+			if len(copiedKeys) < blobStorageMigrationDeleteBatchSize {
+				return nil
+			}
+			return deleteCopied()
+		})
+		if err != nil {
+			return fmt.Errorf("failed to migrate %s blobs: %w", kind, err)
+		}
+		if err = deleteCopied(); err != nil {
+			return err
+		}
+		if compactor, ok := from.(blobStorageCompactor); ok && deleted > 0 {
+			if err = compactor.Compact(ctx, kind); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *blobStorageMigrationServiceImpl) incrementCounter(counters map[string]int, kind string) {
+	b.migrationsMutex.Lock()
+	counters[kind]++
+	b.migrationsMutex.Unlock()
+}
+
+func copyBlob(ctx context.Context, from BlobStorage, to BlobStorage, kind string, key string) error {
+	content, err := from.Get(ctx, kind, key)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	return to.Put(ctx, kind, key, content, -1)
+}
+
+func unknownBlobStorageTypeError(storageType view.BlobStorageType) error {
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.UnknownBlobStorageType,
+		Message: exception.UnknownBlobStorageTypeMsg,
+		Params:  map[string]interface{}{"type": storageType},
+	}
+}
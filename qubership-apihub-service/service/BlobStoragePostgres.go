@@ -0,0 +1,91 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+const postgresBlobKeysPageSize = 100
+
+// NewPostgresBlobStorage creates the storage which keeps blobs in bytea columns.
+// Postgres driver can't stream bytea values, so blobs are fully loaded into memory on both read and write.
+func NewPostgresBlobStorage(blobRepository repository.BlobRepository) BlobStorage {
+	return &postgresBlobStorageImpl{blobRepository: blobRepository}
+}
+
+type postgresBlobStorageImpl struct {
+	blobRepository repository.BlobRepository
+}
+
+func (p postgresBlobStorageImpl) Type() view.BlobStorageType {
+	return view.BlobStoragePostgres
+}
+
+func (p postgresBlobStorageImpl) Put(ctx context.Context, kind string, key string, content io.Reader, size int64) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return fmt.Errorf("failed to read %s blob %s content: %w", kind, key, err)
+	}
+	return p.blobRepository.SaveBlob(kind, key, data)
+}
+
+func (p postgresBlobStorageImpl) Get(ctx context.Context, kind string, key string) (io.ReadCloser, error) {
+	data, err := p.blobRepository.GetBlob(kind, key)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrBlobNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (p postgresBlobStorageImpl) Delete(ctx context.Context, kind string, keys []string) error {
+	_, err := p.blobRepository.DeleteBlobs(kind, keys)
+	return err
+}
+
+func (p postgresBlobStorageImpl) ListKeys(ctx context.Context, kind string, handler func(key string) error) error {
+	afterKey := ""
+	for {
+		keys, err := p.blobRepository.GetBlobKeys(kind, afterKey, postgresBlobKeysPageSize)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+			if err = handler(key); err != nil {
+				return err
+			}
+		}
+		if len(keys) < postgresBlobKeysPageSize {
+			return nil
+		}
+		afterKey = keys[len(keys)-1]
+	}
+}
+
+func (p postgresBlobStorageImpl) Compact(ctx context.Context, kind string) error {
+	return p.blobRepository.VacuumBlobs(kind)
+}
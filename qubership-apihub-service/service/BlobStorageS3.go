@@ -0,0 +1,241 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	log "github.com/sirupsen/logrus"
+)
+
+const unknownSizeObjectPartSize = 16 * 1024 * 1024
+
+// NewS3BlobStorage creates the storage which keeps blobs in S3 compatible storage (minio) as <kind>/<key>.zip objects
+func NewS3BlobStorage(creds *view.MinioStorageCreds) (BlobStorage, error) {
+	client := createMinioClient(creds)
+	if client.error != nil {
+		return nil, fmt.Errorf("failed to create minio client: %w", client.error)
+	}
+	s := &s3BlobStorageImpl{
+		minioClient: client,
+		creds:       creds,
+	}
+	utils.SafeAsync(func() {
+		err := s.createBucketIfNotExists(context.Background())
+		if err != nil {
+			log.Errorf("MINIO error - %s", err.Error())
+		}
+	})
+	return s, nil
+}
+
+type s3BlobStorageImpl struct {
+	minioClient *minioClient
+	creds       *view.MinioStorageCreds
+}
+
+type minioClient struct {
+	client *minio.Client
+	error  error
+}
+
+func (m s3BlobStorageImpl) Type() view.BlobStorageType {
+	return view.BlobStorageS3
+}
+
+func (m s3BlobStorageImpl) createBucketIfNotExists(ctx context.Context) error {
+	exists, err := bucketExists(ctx, m.minioClient.client, m.creds.BucketName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Infof(fmt.Sprintf("Minio bucket - %s exists", m.creds.BucketName))
+	} else {
+		err = m.minioClient.client.MakeBucket(ctx, m.creds.BucketName, minio.MakeBucketOptions{})
+		if err != nil {
+			return err
+		}
+		exists, err = bucketExists(ctx, m.minioClient.client, m.creds.BucketName)
+		if err != nil {
+			return err
+		}
+		if exists {
+			log.Infof(fmt.Sprintf("Minio bucket - %s was created", m.creds.BucketName))
+		}
+	}
+	return nil
+}
+
+func createMinioClient(creds *view.MinioStorageCreds) *minioClient {
+	client := new(minioClient)
+	var err error
+	tr, err := minio.DefaultTransport(true)
+	if err != nil {
+		log.Warnf("error creating the minio connection: error creating the default transport layer: %v", err)
+		client.error = err
+		return client
+	}
+	crt, err := os.CreateTemp("", "minio.cert")
+	if err != nil {
+		log.Warn(err.Error())
+		client.error = err
+		return client
+	}
+	decodeSamlCert, err := base64.StdEncoding.DecodeString(creds.Crt)
+	if err != nil {
+		log.Warn(err.Error())
+		client.error = err
+		return client
+	}
+
+	_, err = crt.WriteString(string(decodeSamlCert))
+	rootCAs := mustGetSystemCertPool()
+	data, err := os.ReadFile(crt.Name())
+	if err == nil {
+		rootCAs.AppendCertsFromPEM(data)
+	}
+	tr.TLSClientConfig.RootCAs = rootCAs
+
+	minioClient, err := minio.New(creds.Endpoint, &minio.Options{
+		Creds:     credentials.NewStaticV4(creds.AccessKeyId, creds.SecretAccessKey, ""),
+		Secure:    true,
+		Transport: tr,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "endpoint") {
+			err = errors.New("invalid storage URL")
+		}
+		log.Warn(err.Error())
+		client.error = err
+		return client
+	}
+	log.Infof("MINIO instance initialized")
+	client.client = minioClient
+	return client
+}
+
+func (m s3BlobStorageImpl) Put(ctx context.Context, kind string, key string, content io.Reader, size int64) error {
+	opts := minio.PutObjectOptions{}
+	if size < 0 {
+		// minio client buffers the whole part in memory, default part size for unknown object size is too big
+		opts.PartSize = unknownSizeObjectPartSize
+	}
+	start := time.Now()
+	_, err := m.minioClient.client.PutObject(ctx, m.creds.BucketName, buildFileName(kind, key), content, size, opts)
+	utils.PerfLog(time.Since(start).Milliseconds(), 500, "Put: upload file to Minio")
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m s3BlobStorageImpl) Get(ctx context.Context, kind string, key string) (io.ReadCloser, error) {
+	minioObject, err := m.minioClient.client.GetObject(ctx, m.creds.BucketName, buildFileName(kind, key), minio.GetObjectOptions{})
+	if err != nil {
+		log.Warn(err)
+		return nil, err
+	}
+	// GetObject is lazy, so check the object existence explicitly
+	_, err = minioObject.Stat()
+	if err != nil {
+		minioObject.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, ErrBlobNotFound
+		}
+		return nil, err
+	}
+	return minioObject, nil
+}
+
+func (m s3BlobStorageImpl) Delete(ctx context.Context, kind string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	minioObjectsChan := make(chan minio.ObjectInfo, len(keys))
+	utils.SafeAsync(func() {
+		for _, key := range keys {
+			minioObjectsChan <- minio.ObjectInfo{Key: buildFileName(kind, key)}
+		}
+		defer close(minioObjectsChan)
+	})
+	errMsg := make([]string, 0)
+	errChan := m.minioClient.client.RemoveObjects(ctx, m.creds.BucketName, minioObjectsChan, minio.RemoveObjectsOptions{})
+	for removeError := range errChan {
+		errMsg = append(errMsg, removeError.Err.Error())
+	}
+	if len(errMsg) > 0 {
+		return errors.New(strings.Join(errMsg, ". "))
+	}
+	return nil
+}
+
+func (m s3BlobStorageImpl) ListKeys(ctx context.Context, kind string, handler func(key string) error) error {
+	listCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	folderName := fmt.Sprintf("%s/", kind)
+	for object := range m.minioClient.client.ListObjects(listCtx, m.creds.BucketName, minio.ListObjectsOptions{Prefix: folderName, Recursive: true}) {
+		if object.Err != nil {
+			return object.Err
+		}
+		key := getEntityId(folderName, object.Key)
+		if key == "" {
+			log.Errorf("unsupported file key format. folder - '%s', file - '%s'", folderName, object.Key)
+			continue
+		}
+		if err := handler(key); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func bucketExists(ctx context.Context, minioClient *minio.Client, bucketName string) (bool, error) {
+	exists, err := minioClient.BucketExists(ctx, bucketName)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+func mustGetSystemCertPool() *x509.CertPool {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return x509.NewCertPool()
+	}
+	return pool
+}
+
+func buildFileName(tableName, entityId string) string {
+	return fmt.Sprintf("%s/%s.zip", tableName, entityId)
+}
+
+func getEntityId(folderName string, fileName string) string {
+	if strings.Contains(fileName, folderName) && strings.Contains(fileName, ".zip") {
+		entityIdDotZip := strings.ReplaceAll(fileName, folderName, "")
+		return strings.ReplaceAll(entityIdDotZip, ".zip", "")
+	}
+	return ""
+}
@@ -0,0 +1,277 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilesystemBlobStorage(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewFilesystemBlobStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	data, err := GetBlob(ctx, storage, view.BUILD_RESULT_TABLE, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	assert.NoError(t, PutBlob(ctx, storage, view.BUILD_RESULT_TABLE, "build1", []byte("v1")))
+	assert.NoError(t, PutBlob(ctx, storage, view.BUILD_RESULT_TABLE, "build1", []byte("v2")))
+	data, err = GetBlob(ctx, storage, view.BUILD_RESULT_TABLE, "build1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v2"), data)
+
+	// the same key of another kind is a different blob
+	data, err = GetBlob(ctx, storage, view.EXPORT_RESULT_TABLE, "build1")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	assert.NoError(t, storage.Delete(ctx, view.BUILD_RESULT_TABLE, []string{"build1", "missing"}))
+	data, err = GetBlob(ctx, storage, view.BUILD_RESULT_TABLE, "build1")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestFilesystemBlobStorageRejectsInvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewFilesystemBlobStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "../outside", "a/b", `a\b`, ".tmp-1"} {
+		assert.Error(t, PutBlob(ctx, storage, view.BUILD_RESULT_TABLE, key, []byte("data")), "key %q", key)
+	}
+	assert.Error(t, PutBlob(ctx, storage, "unknown", "key", []byte("data")))
+	assert.Error(t, storage.ListKeys(ctx, "unknown", func(key string) error { return nil }))
+}
+
+func TestFilesystemBlobStorageListKeys(t *testing.T) {
+	ctx := context.Background()
+	rootPath := t.TempDir()
+	storage, err := NewFilesystemBlobStorage(rootPath)
+	assert.NoError(t, err)
+
+	keys, err := listBlobKeys(storage, view.EXPORT_RESULT_TABLE)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	for _, key := range []string{"b", "a", "c"} {
+		assert.NoError(t, PutBlob(ctx, storage, view.EXPORT_RESULT_TABLE, key, []byte(key)))
+	}
+	// unfinished writes must not be listed
+	assert.NoError(t, os.WriteFile(filepath.Join(rootPath, view.EXPORT_RESULT_TABLE, filesystemBlobTmpPrefix+"x"), []byte("x"), 0640))
+
+	keys, err = listBlobKeys(storage, view.EXPORT_RESULT_TABLE)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, keys)
+}
+
+func TestPostgresBlobStorage(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockBlobRepository()
+	storage := NewPostgresBlobStorage(repo)
+
+	_, err := storage.Get(ctx, view.BUILD_RESULT_TABLE, "missing")
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+
+	expectedKeys := make([]string, 0)
+	for i := 0; i < postgresBlobKeysPageSize*2+5; i++ {
+		key := fmt.Sprintf("key%03d", i)
+		expectedKeys = append(expectedKeys, key)
+		assert.NoError(t, PutBlob(ctx, storage, view.BUILD_RESULT_TABLE, key, []byte(key)))
+	}
+	data, err := GetBlob(ctx, storage, view.BUILD_RESULT_TABLE, "key042")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("key042"), data)
+
+	keys, err := listBlobKeys(storage, view.BUILD_RESULT_TABLE)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedKeys, keys)
+}
+
+func TestS3BlobFileName(t *testing.T) {
+	fileName := buildFileName(view.BUILD_RESULT_TABLE, "build1")
+	assert.Equal(t, "build_result/build1.zip", fileName)
+	assert.Equal(t, "build1", getEntityId("build_result/", fileName))
+	assert.Equal(t, "", getEntityId("build_result/", "export_result/build1.txt"))
+}
+
+func TestBlobStorageMigration(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockBlobRepository()
+	postgres := NewPostgresBlobStorage(repo)
+	filesystem, err := NewFilesystemBlobStorage(t.TempDir())
+	assert.NoError(t, err)
+	service := &blobStorageMigrationServiceImpl{migrations: map[string]*view.BlobStorageMigration{}}
+
+	for _, key := range []string{"a", "b"} {
+		assert.NoError(t, PutBlob(ctx, postgres, view.BUILD_RESULT_TABLE, key, []byte(key)))
+		assert.NoError(t, PutBlob(ctx, postgres, view.EXPORT_RESULT_TABLE, key, []byte(key)))
+	}
+
+	migration := service.addMigration(view.BlobStorageMigrationReq{From: view.BlobStoragePostgres, To: view.BlobStorageFilesystem, DeleteFromSource: true},
+		[]string{view.BUILD_RESULT_TABLE})
+	assert.NoError(t, service.runMigration(migration, postgres, filesystem))
+	assert.Equal(t, view.BlobStorageMigrationComplete, migration.Status)
+	assert.Equal(t, 2, migration.Copied[view.BUILD_RESULT_TABLE])
+	assert.Equal(t, 0, migration.Failed[view.BUILD_RESULT_TABLE])
+
+	keys, err := listBlobKeys(filesystem, view.BUILD_RESULT_TABLE)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+	keys, err = listBlobKeys(postgres, view.BUILD_RESULT_TABLE)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+	assert.Equal(t, 1, repo.vacuumed[view.BUILD_RESULT_TABLE])
+
+	// not migrated kinds are kept in the source storage
+	keys, err = listBlobKeys(postgres, view.EXPORT_RESULT_TABLE)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	migration = service.addMigration(view.BlobStorageMigrationReq{From: view.BlobStorageFilesystem, To: view.BlobStoragePostgres},
+		[]string{view.BUILD_RESULT_TABLE})
+	assert.NoError(t, service.runMigration(migration, filesystem, postgres))
+	keys, err = listBlobKeys(filesystem, view.BUILD_RESULT_TABLE)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+	data, err := GetBlob(ctx, postgres, view.BUILD_RESULT_TABLE, "b")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("b"), data)
+}
+
+func TestBlobStorageMigrationRunLock(t *testing.T) {
+	repo := newMockBlobRepository()
+	service := &blobStorageMigrationServiceImpl{blobRepository: repo}
+
+	runId, err := service.startRun()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, runId)
+
+	_, err = service.startRun()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), exception.BlobStorageMigrationInProgressMsg)
+
+	service.finishRun(runId, fmt.Errorf("failed"))
+	assert.Equal(t, string(view.BlobStorageMigrationError), repo.runs[0].Status)
+	runId, err = service.startRun()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, runId)
+
+	// the run of the crashed instance doesn't block new migrations forever
+	repo.runs[1].StartedAt = time.Now().Add(-blobStorageMigrationRunTimeout)
+	runId, err = service.startRun()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, runId)
+}
+
+func listBlobKeys(storage BlobStorage, kind string) ([]string, error) {
+	keys := make([]string, 0)
+	err := storage.ListKeys(context.Background(), kind, func(key string) error {
+		keys = append(keys, key)
+		return nil
+	})
+	sort.Strings(keys)
+	return keys, err
+}
+
+type mockBlobRepository struct {
+	blobs    map[string]map[string][]byte
+	vacuumed map[string]int
+	runs     []entity.BlobStorageMigrationRunEntity
+}
+
+func newMockBlobRepository() *mockBlobRepository {
+	return &mockBlobRepository{blobs: map[string]map[string][]byte{}, vacuumed: map[string]int{}}
+}
+
+func (m *mockBlobRepository) SaveBlob(kind string, key string, data []byte) error {
+	if m.blobs[kind] == nil {
+		m.blobs[kind] = map[string][]byte{}
+	}
+	m.blobs[kind][key] = data
+	return nil
+}
+
+func (m *mockBlobRepository) GetBlob(kind string, key string) ([]byte, error) {
+	return m.blobs[kind][key], nil
+}
+
+func (m *mockBlobRepository) DeleteBlobs(kind string, keys []string) (int, error) {
+	deleted := 0
+	for _, key := range keys {
+		if _, exists := m.blobs[kind][key]; exists {
+			delete(m.blobs[kind], key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *mockBlobRepository) GetBlobKeys(kind string, afterKey string, limit int) ([]string, error) {
+	keys := make([]string, 0)
+	for key := range m.blobs[kind] {
+		if key > afterKey {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+	return keys, nil
+}
+
+func (m *mockBlobRepository) VacuumBlobs(kind string) error {
+	m.vacuumed[kind]++
+	return nil
+}
+
+func (m *mockBlobRepository) GetLastMigrationRun() (*entity.BlobStorageMigrationRunEntity, error) {
+	if len(m.runs) == 0 {
+		return nil, nil
+	}
+	return &m.runs[len(m.runs)-1], nil
+}
+
+func (m *mockBlobRepository) StoreMigrationRun(ent entity.BlobStorageMigrationRunEntity) error {
+	for _, run := range m.runs {
+		if run.RunId == ent.RunId {
+			return fmt.Errorf("duplicate run id %d", ent.RunId)
+		}
+	}
+	m.runs = append(m.runs, ent)
+	return nil
+}
+
+func (m *mockBlobRepository) UpdateMigrationRun(ent entity.BlobStorageMigrationRunEntity) error {
+	for i := range m.runs {
+		if m.runs[i].RunId == ent.RunId {
+			m.runs[i].Status = ent.Status
+			m.runs[i].FinishedAt = ent.FinishedAt
+		}
+	}
+	return nil
+}
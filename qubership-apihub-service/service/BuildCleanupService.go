@@ -40,7 +40,7 @@ type DBCleanupService interface {
 
 func NewDBCleanupService(cleanUpRepository repository.BuildCleanupRepository,
 	migrationRepository mRepository.MigrationRunRepository,
-	minioStorageService MinioStorageService,
+	blobStorage BlobStorage,
 	infoService SystemInfoService) DBCleanupService {
 	return &dbCleanupServiceImpl{
 		cleanUpRepository:            cleanUpRepository,
@@ -49,7 +49,7 @@ func NewDBCleanupService(cleanUpRepository repository.BuildCleanupRepository,
 		rmMigrationBuildDataRes:      map[string]interface{}{},
 		rmMigrationBuildDataResMutex: sync.RWMutex{},
 		systemInfoService:            infoService,
-		minioStorageService:          minioStorageService,
+		blobStorage:                  blobStorage,
 	}
 }
 
@@ -60,7 +60,7 @@ type dbCleanupServiceImpl struct {
 	cron                         *cron.Cron
 	rmMigrationBuildDataRes      map[string]interface{}
 	rmMigrationBuildDataResMutex sync.RWMutex
-	minioStorageService          MinioStorageService
+	blobStorage                  BlobStorage
 	systemInfoService            SystemInfoService
 }
 
@@ -68,7 +68,7 @@ func (c *dbCleanupServiceImpl) CreateCleanupJob(schedule string) error {
 	job := BuildCleanupJob{
 		schedule:               schedule,
 		buildCleanupRepository: c.cleanUpRepository,
-		minioStorageService:    c.minioStorageService,
+		blobStorage:            c.blobStorage,
 		systemInfoService:      c.systemInfoService,
 		migrationRepository:    c.migrationRepository,
 	}
@@ -95,7 +95,7 @@ func (c *dbCleanupServiceImpl) CreateCleanupJob(schedule string) error {
 type BuildCleanupJob struct {
 	schedule               string
 	buildCleanupRepository repository.BuildCleanupRepository
-	minioStorageService    MinioStorageService
+	blobStorage            BlobStorage
 	systemInfoService      SystemInfoService
 	migrationRepository    mRepository.MigrationRunRepository
 }
@@ -146,16 +146,16 @@ func (j BuildCleanupJob) Run() {
 			log.Errorf("Failed to store cleanup entity: %v", err)
 			return
 		}
-		if j.systemInfoService.IsMinioStorageActive() {
+		if j.blobStorage.Type() != view.BlobStoragePostgres {
 			ctx := context.Background()
 			ids, err := j.buildCleanupRepository.GetRemoveCandidateOldBuildEntitiesIds()
 			if err != nil {
 				log.Errorf("Failed to get up remove candidate old build ids: %v", err)
 				return
 			}
-			err = j.minioStorageService.RemoveFiles(ctx, view.BUILD_RESULT_TABLE, ids)
+			err = j.blobStorage.Delete(ctx, view.BUILD_RESULT_TABLE, ids)
 			if err != nil {
-				log.Errorf("Failed to remove old build results from minio storage: %v", err)
+				log.Errorf("Failed to remove old build results from %s blob storage: %v", j.blobStorage.Type(), err)
 				return
 			}
 
@@ -202,13 +202,13 @@ func (c *dbCleanupServiceImpl) StartMigrationBuildDataCleanup() (string, error)
 	utils.SafeAsync(func() {
 		var err error
 		var removedRowsCount int
-		if c.systemInfoService.IsMinioStorageActive() {
+		if c.blobStorage.Type() != view.BlobStoragePostgres {
 			ctx := context.Background()
 			ids, err := c.cleanUpRepository.GetRemoveMigrationBuildIds()
 			if err != nil {
 				c.saveErrorInfo(result, err, id, removedRowsCount)
 			}
-			err = c.minioStorageService.RemoveFiles(ctx, view.BUILD_RESULT_TABLE, ids)
+			err = c.blobStorage.Delete(ctx, view.BUILD_RESULT_TABLE, ids)
 			if err != nil {
 				c.saveErrorInfo(result, err, id, removedRowsCount)
 			}
@@ -15,6 +15,7 @@
 package service
 
 import (
+	goctx "context"
 	"fmt"
 	"time"
 
@@ -25,14 +26,19 @@ import (
 )
 
 type BuildProcessorService interface {
-	GetFreeBuild(builderId string) (*view.BuildConfig, []byte, error)
+	GetFreeBuild(builderId string, capabilities view.BuilderCapabilities) (*view.BuildConfig, []byte, error)
+	AwaitFreeBuild(ctx goctx.Context, builderId string, capabilities view.BuilderCapabilities, timeout time.Duration) (*view.BuildConfig, []byte, error)
 }
 
-func NewBuildProcessorService(buildRepository repository.BuildRepository, refResolverService RefResolverService) BuildProcessorService {
+func NewBuildProcessorService(buildRepository repository.BuildRepository, refResolverService RefResolverService, buildQueueNotifier BuildQueueNotifier, buildStatusNotifier BuildStatusNotifier, systemInfoService SystemInfoService) BuildProcessorService {
 	bp := &buildProcessorServiceImpl{
 		buildRepository: buildRepository,
 
-		refResolverService: refResolverService,
+		refResolverService:  refResolverService,
+		buildQueueNotifier:  buildQueueNotifier,
+		buildStatusNotifier: buildStatusNotifier,
+		retryPolicies:       systemInfoService.GetBuildRetryPolicies(),
+		fairSharePolicy:     systemInfoService.GetBuildFairSharePolicy(),
 	}
 
 	return bp
@@ -41,11 +47,19 @@ func NewBuildProcessorService(buildRepository repository.BuildRepository, refRes
 type buildProcessorServiceImpl struct {
 	buildRepository repository.BuildRepository
 
-	refResolverService RefResolverService
+	refResolverService  RefResolverService
+	buildQueueNotifier  BuildQueueNotifier
+	buildStatusNotifier BuildStatusNotifier
+	retryPolicies       view.BuildRetryPolicies
+	fairSharePolicy     view.BuildFairSharePolicy
 }
 
-func (b *buildProcessorServiceImpl) GetFreeBuild(builderId string) (*view.BuildConfig, []byte, error) {
-	buildSrc, err := b.findFreeBuild(builderId) // find not started build
+// builds could become free without any notification (e.g. stale running builds or builds added by migration),
+// so waiting builders re-check the queue with this interval anyway
+const freeBuildRecheckInterval = 15 * time.Second
+
+func (b *buildProcessorServiceImpl) GetFreeBuild(builderId string, capabilities view.BuilderCapabilities) (*view.BuildConfig, []byte, error) {
+	buildSrc, err := b.findFreeBuild(builderId, capabilities) // find not started build
 	if err != nil {
 		return nil, nil, err
 	}
@@ -60,14 +74,47 @@ func (b *buildProcessorServiceImpl) GetFreeBuild(builderId string) (*view.BuildC
 	return config, buildSrc.Source, nil
 }
 
-func (b *buildProcessorServiceImpl) findFreeBuild(builderId string) (*entity.BuildSourceEntity, error) {
+func (b *buildProcessorServiceImpl) AwaitFreeBuild(ctx goctx.Context, builderId string, capabilities view.BuilderCapabilities, timeout time.Duration) (*view.BuildConfig, []byte, error) {
+	// subscribe before the first check to not miss builds added in between
+	buildQueueEvents, unsubscribe := b.buildQueueNotifier.Subscribe()
+	defer unsubscribe()
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	recheckTicker := time.NewTicker(freeBuildRecheckInterval)
+	defer recheckTicker.Stop()
+
+	signalled := false
+	for {
+		config, src, err := b.GetFreeBuild(builderId, capabilities)
+		if signalled && config == nil {
+			// the build which caused the signal doesn't match this builder or was already taken, let other builders check it
+			b.buildQueueNotifier.PassOn(buildQueueEvents)
+		}
+		if err != nil || config != nil {
+			return config, src, err
+		}
+		signalled = false
+		select {
+		case <-buildQueueEvents:
+			signalled = true
+		case <-recheckTicker.C:
+		case <-deadline.C:
+			return nil, nil, nil
+		case <-ctx.Done():
+			return nil, nil, nil
+		}
+	}
+}
+
+func (b *buildProcessorServiceImpl) findFreeBuild(builderId string, capabilities view.BuilderCapabilities) (*entity.BuildSourceEntity, error) {
 	var buildSrc *entity.BuildSourceEntity
 	var build *entity.BuildEntity
 	var err error
 
 	for {
 		start := time.Now()
-		build, err = b.buildRepository.FindAndTakeFreeBuild(builderId)
+		build, err = b.buildRepository.FindAndTakeFreeBuild(builderId, capabilities, b.retryPolicies, b.fairSharePolicy)
 		utils.PerfLog(time.Since(start).Milliseconds(), 250, "findFreeBuild: FindAndTakeFreeBuild")
 		if err != nil {
 			return nil, err
@@ -75,6 +122,7 @@ func (b *buildProcessorServiceImpl) findFreeBuild(builderId string) (*entity.Bui
 		if build == nil {
 			break
 		}
+		b.buildStatusNotifier.NotifyBuildStatusChanged(build.BuildId)
 
 		start = time.Now()
 		src, err := b.buildRepository.GetBuildSrc(build.BuildId)
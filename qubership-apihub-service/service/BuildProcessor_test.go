@@ -0,0 +1,166 @@
+package service
+
+import (
+	goctx "context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwaitFreeBuildPassesSignalToMatchingBuilder(t *testing.T) {
+	buildRepository := &mockBuildRepository{}
+	notifier := &buildQueueNotifierImpl{}
+	processor := &buildProcessorServiceImpl{
+		buildRepository:     buildRepository,
+		buildQueueNotifier:  notifier,
+		buildStatusNotifier: mockBuildStatusNotifier{},
+	}
+
+	type result struct {
+		builderId string
+		config    *view.BuildConfig
+		err       error
+	}
+	results := make(chan result, 2)
+	awaitBuild := func(builderId string, apiType string) {
+		capabilities := view.BuilderCapabilities{ApiTypes: []string{apiType}}
+		config, _, err := processor.AwaitFreeBuild(goctx.Background(), builderId, capabilities, 3*time.Second)
+		results <- result{builderId: builderId, config: config, err: err}
+	}
+
+	// rest builder subscribes first, so it is the one which receives the signal
+	go awaitBuild("rest-builder", "rest")
+	waitForSubscribers(t, notifier, 1)
+	go awaitBuild("graphql-builder", "graphql")
+	waitForSubscribers(t, notifier, 2)
+
+	buildRepository.addFreeBuild("graphql-build", "graphql")
+	notifier.NotifyBuildAvailable("graphql-build")
+
+	first := <-results
+	assert.Equal(t, "graphql-builder", first.builderId, "graphql build must be taken by the graphql builder")
+	assert.NoError(t, first.err)
+	if assert.NotNil(t, first.config, "graphql builder must receive the build before the recheck interval") {
+		assert.Equal(t, "graphql-build", first.config.PublishId)
+	}
+
+	second := <-results
+	assert.Equal(t, "rest-builder", second.builderId)
+	assert.Nil(t, second.config, "rest builder must not receive graphql build")
+}
+
+func TestBuildQueueNotifierPassOn(t *testing.T) {
+	tests := []struct {
+		name             string
+		subscribersCount int
+		pendingSignals   []int
+		passOnFrom       int
+		expectedSignals  []int
+	}{
+		{
+			name:             "next subscriber is signalled",
+			subscribersCount: 3,
+			passOnFrom:       0,
+			expectedSignals:  []int{1},
+		},
+		{
+			name:             "subscribers with pending signal are skipped",
+			subscribersCount: 3,
+			pendingSignals:   []int{1},
+			passOnFrom:       0,
+			expectedSignals:  []int{1, 2},
+		},
+		{
+			name:             "signal is not passed back to the first subscriber",
+			subscribersCount: 3,
+			passOnFrom:       2,
+			expectedSignals:  []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &buildQueueNotifierImpl{}
+			channels := make([]<-chan struct{}, 0, tt.subscribersCount)
+			for i := 0; i < tt.subscribersCount; i++ {
+				ch, unsubscribe := notifier.Subscribe()
+				defer unsubscribe()
+				channels = append(channels, ch)
+			}
+			for _, i := range tt.pendingSignals {
+				notifier.subscribers[i].ch <- struct{}{}
+			}
+
+			notifier.PassOn(channels[tt.passOnFrom])
+
+			signalled := make([]int, 0)
+			for i, ch := range channels {
+				select {
+				case <-ch:
+					signalled = append(signalled, i)
+				default:
+				}
+			}
+			assert.Equal(t, tt.expectedSignals, signalled, tt.name)
+		})
+	}
+}
+
+func waitForSubscribers(t *testing.T, notifier *buildQueueNotifierImpl, count int) {
+	for i := 0; i < 100; i++ {
+		notifier.subscribersMutex.Lock()
+		subscribersCount := len(notifier.subscribers)
+		notifier.subscribersMutex.Unlock()
+		if subscribersCount >= count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected %d build queue subscribers", count)
+}
+
+type mockBuildRepository struct {
+	repository.BuildRepository
+
+	mutex      sync.Mutex
+	freeBuilds map[string]string // build id -> api type
+}
+
+func (m *mockBuildRepository) addFreeBuild(buildId string, apiType string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.freeBuilds == nil {
+		m.freeBuilds = map[string]string{}
+	}
+	m.freeBuilds[buildId] = apiType
+}
+
+func (m *mockBuildRepository) FindAndTakeFreeBuild(builderId string, capabilities view.BuilderCapabilities, retryPolicies view.BuildRetryPolicies, fairSharePolicy view.BuildFairSharePolicy) (*entity.BuildEntity, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for buildId, apiType := range m.freeBuilds {
+		for _, builderApiType := range capabilities.ApiTypes {
+			if apiType == builderApiType {
+				delete(m.freeBuilds, buildId)
+				return &entity.BuildEntity{BuildId: buildId, BuilderId: builderId}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockBuildRepository) GetBuildSrc(buildId string) (*entity.BuildSourceEntity, error) {
+	return &entity.BuildSourceEntity{BuildId: buildId, Config: map[string]interface{}{}}, nil
+}
+
+type mockBuildStatusNotifier struct{}
+
+func (m mockBuildStatusNotifier) NotifyBuildStatusChanged(buildId string) {}
+
+func (m mockBuildStatusNotifier) Subscribe() (<-chan string, func()) {
+	return make(chan string), func() {}
+}
@@ -0,0 +1,148 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"sync"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/cache"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/buraksezer/olric"
+	log "github.com/sirupsen/logrus"
+)
+
+const BuildQueueTopicName = "build-queue-events"
+
+// BuildQueueNotifier delivers "build queue changed" signals to all backend replicas,
+// so builders waiting in long-poll mode can re-check the queue without polling the DB.
+// Each signal wakes up only one waiting builder per replica, the one which waits the longest.
+// A woken builder which is not able to take any build must pass the signal on, so builders with other capabilities get a chance.
+type BuildQueueNotifier interface {
+	NotifyBuildAvailable(buildId string)
+	Subscribe() (<-chan struct{}, func())
+	PassOn(ch <-chan struct{})
+}
+
+func NewBuildQueueNotifier(op cache.OlricProvider) BuildQueueNotifier {
+	n := &buildQueueNotifierImpl{
+		op: op,
+	}
+	utils.SafeAsync(func() {
+		n.initDTopic()
+	})
+	return n
+}
+
+type buildQueueNotifierImpl struct {
+	op         cache.OlricProvider
+	topic      *olric.DTopic
+	topicMutex sync.RWMutex
+
+	subscribers      []buildQueueSubscriber // ordered by subscription time
+	subscribersMutex sync.Mutex
+	nextSubscriberId uint64
+}
+
+type buildQueueSubscriber struct {
+	id uint64
+	ch chan struct{}
+}
+
+func (n *buildQueueNotifierImpl) initDTopic() {
+	topic, err := n.op.Get().NewDTopic(BuildQueueTopicName, 10000, 1)
+	if err != nil {
+		log.Errorf("Failed to create DTopic: %s", err.Error())
+		return
+	}
+	_, err = topic.AddListener(func(msg olric.DTopicMessage) {
+		n.wakeUpSubscriber()
+	})
+	if err != nil {
+		log.Errorf("Failed to add listener for %s DTopic: %s", BuildQueueTopicName, err.Error())
+		return
+	}
+	n.topicMutex.Lock()
+	n.topic = topic
+	n.topicMutex.Unlock()
+}
+
+func (n *buildQueueNotifierImpl) NotifyBuildAvailable(buildId string) {
+	n.topicMutex.RLock()
+	topic := n.topic
+	n.topicMutex.RUnlock()
+	if topic == nil {
+		// cluster is not ready yet, at least wake up local builders
+		n.wakeUpSubscriber()
+		return
+	}
+	err := topic.Publish(buildId)
+	if err != nil {
+		log.Errorf("Failed to publish build queue event for build %s: %s", buildId, err.Error())
+		n.wakeUpSubscriber()
+	}
+}
+
+// Subscribe returns a channel which receives a signal every time the build queue could contain a new free build
+// and a function which must be called to release the subscription.
+func (n *buildQueueNotifierImpl) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	n.subscribersMutex.Lock()
+	id := n.nextSubscriberId
+	n.nextSubscriberId++
+	n.subscribers = append(n.subscribers, buildQueueSubscriber{id: id, ch: ch})
+	n.subscribersMutex.Unlock()
+
+	return ch, func() {
+		n.subscribersMutex.Lock()
+		for i, subscriber := range n.subscribers {
+			if subscriber.id == id {
+				n.subscribers = append(n.subscribers[:i], n.subscribers[i+1:]...)
+				break
+			}
+		}
+		n.subscribersMutex.Unlock()
+	}
+}
+
+// PassOn forwards the signal received via ch to the next subscriber which waits after the ch owner.
+// The signal is never passed back to the beginning of the queue, so it stops after the last subscriber.
+func (n *buildQueueNotifierImpl) PassOn(ch <-chan struct{}) {
+	n.subscribersMutex.Lock()
+	defer n.subscribersMutex.Unlock()
+	for i, subscriber := range n.subscribers {
+		if subscriber.ch == ch {
+			n.signalFirstAvailable(n.subscribers[i+1:])
+			return
+		}
+	}
+}
+
+// wakeUpSubscriber signals one subscriber per queued build to avoid all waiting builders racing for the same build.
+// Subscribers which already have a pending signal are skipped since they are going to re-check the queue anyway.
+func (n *buildQueueNotifierImpl) wakeUpSubscriber() {
+	n.subscribersMutex.Lock()
+	defer n.subscribersMutex.Unlock()
+	n.signalFirstAvailable(n.subscribers)
+}
+
+func (n *buildQueueNotifierImpl) signalFirstAvailable(subscribers []buildQueueSubscriber) {
+	for _, subscriber := range subscribers {
+		select {
+		case subscriber.ch <- struct{}{}:
+			return
+		default: // subscriber already has a pending signal
+		}
+	}
+}
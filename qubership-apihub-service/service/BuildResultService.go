@@ -24,6 +24,7 @@ import (
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/service/validation"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
 	log "github.com/sirupsen/logrus"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -35,71 +36,78 @@ import (
 
 type BuildResultService interface {
 	StoreBuildResult(buildId string, result []byte) error
-	GetBuildResult(buildId string) ([]byte, error)
+	// GetBuildResult returns ErrBlobNotFound if there is no result, returned reader must be closed by the caller
+	GetBuildResult(buildId string) (io.ReadCloser, error)
 
 	SaveBuildResult_deprecated(packageId string, archiveData []byte, publishId string, availableVersionStatuses []string) error
 	SaveBuildResult(packageId string, data []byte, fileName string, publishId string, availableVersionStatuses []string) error
+	GetDryRunResult(packageId string, publishId string) (*view.DryRunPublishResult, error)
 }
 
 func NewBuildResultService(buildResultRepository repository.BuildResultRepository, buildRepository repository.BuildRepository,
-	publishedRepository repository.PublishedRepository, systemInfoService SystemInfoService, minioStorageService MinioStorageService,
-	publishService PublishedService, exportService ExportService) BuildResultService {
+	publishedRepository repository.PublishedRepository, systemInfoService SystemInfoService, blobStorage BlobStorage,
+	publishService PublishedService, exportService ExportService, buildQueueNotifier BuildQueueNotifier, buildStatusNotifier BuildStatusNotifier,
+	publishGateService PublishGateService, versionLifecycleService VersionLifecycleService, versionPromotionService VersionPromotionService,
+	versionInferenceService VersionInferenceService, versionLockService VersionLockService, savedSearchService SavedSearchService) BuildResultService {
 	return &buildResultServiceImpl{
-		buildResultRepository: buildResultRepository,
-		buildRepository:       buildRepository,
-		publishedRepository:   publishedRepository,
-		minioStorageService:   minioStorageService,
-		systemInfoService:     systemInfoService,
-		publishService:        publishService,
-		exportService:         exportService,
-		publishedValidator:    validation.NewPublishedValidator(publishedRepository),
+		buildResultRepository:   buildResultRepository,
+		buildRepository:         buildRepository,
+		publishedRepository:     publishedRepository,
+		blobStorage:             blobStorage,
+		systemInfoService:       systemInfoService,
+		publishService:          publishService,
+		exportService:           exportService,
+		buildQueueNotifier:      buildQueueNotifier,
+		buildStatusNotifier:     buildStatusNotifier,
+		publishGateService:      publishGateService,
+		versionLifecycleService: versionLifecycleService,
+		versionPromotionService: versionPromotionService,
+		versionInferenceService: versionInferenceService,
+		versionLockService:      versionLockService,
+		savedSearchService:      savedSearchService,
+		publishedValidator:      validation.NewPublishedValidator(publishedRepository, versionLifecycleService.ValidateStatus),
 	}
 }
 
 type buildResultServiceImpl struct {
-	buildResultRepository repository.BuildResultRepository
-	buildRepository       repository.BuildRepository
-	publishedRepository   repository.PublishedRepository
-	minioStorageService   MinioStorageService
-	systemInfoService     SystemInfoService
-	publishService        PublishedService
-	exportService         ExportService
+	buildResultRepository   repository.BuildResultRepository
+	buildRepository         repository.BuildRepository
+	publishedRepository     repository.PublishedRepository
+	blobStorage             BlobStorage
+	systemInfoService       SystemInfoService
+	publishService          PublishedService
+	exportService           ExportService
+	buildQueueNotifier      BuildQueueNotifier
+	buildStatusNotifier     BuildStatusNotifier
+	publishGateService      PublishGateService
+	versionLifecycleService VersionLifecycleService
+	versionPromotionService VersionPromotionService
+	versionInferenceService VersionInferenceService
+	versionLockService      VersionLockService
+	savedSearchService      SavedSearchService
 
 	publishedValidator validation.PublishedValidator
 }
 
 func (b buildResultServiceImpl) StoreBuildResult(buildId string, result []byte) error {
-	if b.systemInfoService.IsMinioStorageActive() {
-		ctx := context.Background()
-		err := b.minioStorageService.UploadFile(ctx, view.BUILD_RESULT_TABLE, buildId, result)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-	return b.buildResultRepository.StoreBuildResult(entity.BuildResultEntity{
-		BuildId: buildId,
-		Data:    result,
-	})
+	return PutBlob(context.Background(), b.blobStorage, view.BUILD_RESULT_TABLE, buildId, result)
 }
 
-func (b buildResultServiceImpl) GetBuildResult(buildId string) ([]byte, error) {
-	if b.systemInfoService.IsMinioStorageActive() {
-		ctx := context.Background()
-		content, err := b.minioStorageService.GetFile(ctx, view.BUILD_RESULT_TABLE, buildId)
-		if err != nil {
-			return nil, err
-		}
-		return content, nil
-	}
-	res, err := b.buildResultRepository.GetBuildResult(buildId)
-	if err != nil {
-		return nil, err
-	}
-	return res.Data, nil
+func (b buildResultServiceImpl) GetBuildResult(buildId string) (io.ReadCloser, error) {
+	return b.blobStorage.Get(context.Background(), view.BUILD_RESULT_TABLE, buildId)
 }
 
 func (p buildResultServiceImpl) SaveBuildResult_deprecated(packageId string, data []byte, publishId string, availableVersionStatuses []string) error {
+	err := p.saveBuildResult_deprecated(packageId, data, publishId, availableVersionStatuses)
+	if err == nil {
+		// builds which depend on the finished one might become free
+		p.buildQueueNotifier.NotifyBuildAvailable(publishId)
+		p.buildStatusNotifier.NotifyBuildStatusChanged(publishId)
+	}
+	return err
+}
+
+func (p buildResultServiceImpl) saveBuildResult_deprecated(packageId string, data []byte, publishId string, availableVersionStatuses []string) error {
 	// Update last active time to make sure that the build won't be restarted. Assuming that publication will take < 30 seconds!
 	// TODO: another option could be different status like "result_processing" for such builds
 	err := p.buildRepository.UpdateBuildStatus(publishId, view.StatusRunning, "")
@@ -191,8 +199,33 @@ func (p buildResultServiceImpl) SaveBuildResult_deprecated(packageId string, dat
 				Message: exception.InsufficientPrivilegesMsg,
 			}
 		}
+		if !buildArc.PackageInfo.MigrationBuild {
+			if err = p.versionInferenceService.InferVersion(buildArc, buildConfig); err != nil {
+				return err
+			}
+			// the version status could have been changed while the build was running
+			_, err = p.versionLifecycleService.GetPublishPermission(buildArc.PackageInfo.PackageId, buildArc.PackageInfo.Version, buildArc.PackageInfo.Status)
+			if err != nil {
+				return err
+			}
+			err = p.versionPromotionService.CheckPublish(buildArc.PackageInfo.PackageId, buildArc.PackageInfo.Version, buildArc.PackageInfo.Status)
+			if err != nil {
+				return err
+			}
+			err = p.versionLockService.CheckPublish(buildArc.PackageInfo.PackageId, buildArc.PackageInfo.Version)
+			if err != nil {
+				return err
+			}
+		}
 
-		return p.publishService.PublishPackage(buildArc, buildSrcEnt, buildConfig, existingPackage)
+		// dry run reports the gate result as well, but doesn't create approval requests
+		if err := p.publishGateService.CheckPublish(buildArc, buildConfig); err != nil {
+			return err
+		}
+		if buildConfig.DryRun {
+			return p.saveDryRunResult(buildArc, buildSrcEnt, buildConfig)
+		}
+		return p.publishPackage(buildArc, buildSrcEnt, buildConfig, existingPackage)
 		//support view.ReducedSourceSpecificationsType_deprecated type because of node-service that is not yet ready for v3 publish
 		//we need view.ReducedSourceSpecificationsType_deprecated build on node-service for operation group publication
 	case view.DocumentGroupType_deprecated, view.ReducedSourceSpecificationsType_deprecated:
@@ -209,7 +242,59 @@ func (p buildResultServiceImpl) SaveBuildResult_deprecated(packageId string, dat
 	}
 }
 
+// publishPackage publishes the package version and notifies subscribers of saved searches about the new operations
+func (p buildResultServiceImpl) publishPackage(buildArc *archive.BuildResultArchive, buildSrcEnt *entity.BuildSourceEntity, buildConfig *view.BuildConfig, existingPackage *entity.PackageEntity) error {
+	err := p.publishService.PublishPackage(buildArc, buildSrcEnt, buildConfig, existingPackage)
+	if err != nil {
+		return err
+	}
+	if !buildArc.PackageInfo.MigrationBuild {
+		p.savedSearchService.NotifyVersionPublished(buildArc.PackageInfo.PackageId, buildArc.PackageInfo.Version, buildArc.PackageInfo.Revision)
+	}
+	return nil
+}
+
+func (p buildResultServiceImpl) saveDryRunResult(buildArc *archive.BuildResultArchive, buildSrcEnt *entity.BuildSourceEntity, buildConfig *view.BuildConfig) error {
+	result, err := p.publishService.DryRunPublishPackage(buildArc, buildSrcEnt, buildConfig)
+	if err != nil {
+		return err
+	}
+	return p.buildResultRepository.SaveDryRunResult(entity.DryRunResultEntity{
+		BuildId:   buildSrcEnt.BuildId,
+		PackageId: result.PackageId,
+		Version:   result.Version,
+		CreatedAt: time.Now(),
+		Result:    *result,
+	})
+}
+
+func (p buildResultServiceImpl) GetDryRunResult(packageId string, publishId string) (*view.DryRunPublishResult, error) {
+	ent, err := p.buildResultRepository.GetDryRunResult(publishId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil || ent.PackageId != packageId {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.DryRunResultNotFound,
+			Message: exception.DryRunResultNotFoundMsg,
+			Params:  map[string]interface{}{"publishId": publishId},
+		}
+	}
+	return &ent.Result, nil
+}
+
 func (p buildResultServiceImpl) SaveBuildResult(packageId string, data []byte, fileName string, publishId string, availableVersionStatuses []string) error {
+	err := p.saveBuildResult(packageId, data, fileName, publishId, availableVersionStatuses)
+	if err == nil {
+		// builds which depend on the finished one might become free
+		p.buildQueueNotifier.NotifyBuildAvailable(publishId)
+		p.buildStatusNotifier.NotifyBuildStatusChanged(publishId)
+	}
+	return err
+}
+
+func (p buildResultServiceImpl) saveBuildResult(packageId string, data []byte, fileName string, publishId string, availableVersionStatuses []string) error {
 	utils.SafeAsync(func() {
 		err := p.StoreBuildResult(publishId, data)
 		if err != nil {
@@ -322,7 +407,32 @@ func (p buildResultServiceImpl) SaveBuildResult(packageId string, data []byte, f
 				Message: exception.InsufficientPrivilegesMsg,
 			}
 		}
-		return p.publishService.PublishPackage(buildArc, buildSrcEnt, buildConfig, existingPackage)
+		if !buildArc.PackageInfo.MigrationBuild {
+			if err = p.versionInferenceService.InferVersion(buildArc, buildConfig); err != nil {
+				return err
+			}
+			// the version status could have been changed while the build was running
+			_, err = p.versionLifecycleService.GetPublishPermission(buildArc.PackageInfo.PackageId, buildArc.PackageInfo.Version, buildArc.PackageInfo.Status)
+			if err != nil {
+				return err
+			}
+			err = p.versionPromotionService.CheckPublish(buildArc.PackageInfo.PackageId, buildArc.PackageInfo.Version, buildArc.PackageInfo.Status)
+			if err != nil {
+				return err
+			}
+			err = p.versionLockService.CheckPublish(buildArc.PackageInfo.PackageId, buildArc.PackageInfo.Version)
+			if err != nil {
+				return err
+			}
+		}
+		// dry run reports the gate result as well, but doesn't create approval requests
+		if err := p.publishGateService.CheckPublish(buildArc, buildConfig); err != nil {
+			return err
+		}
+		if buildConfig.DryRun {
+			return p.saveDryRunResult(buildArc, buildSrcEnt, buildConfig)
+		}
+		return p.publishPackage(buildArc, buildSrcEnt, buildConfig, existingPackage)
 	case view.ChangelogType:
 		return p.publishService.PublishChanges(buildArc, publishId)
 	case view.ReducedSourceSpecificationsType_deprecated, view.MergedSpecificationType_deprecated:
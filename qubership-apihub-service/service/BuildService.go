@@ -17,6 +17,7 @@ package service
 import (
 	"archive/zip"
 	"bytes"
+	goctx "context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -39,18 +40,27 @@ import (
 type BuildService interface {
 	PublishVersion(ctx context.SecurityContext, config view.BuildConfig, src []byte, clientBuild bool, builderId string, dependencies []string, resolveRefs bool, resolveConflicts bool) (*view.PublishV2Response, error)
 	GetStatus(buildId string) (string, string, error)
+	GetQueuePosition(buildId string) (int, error)
 	GetStatuses(buildIds []string) ([]view.PublishStatusResponse, error)
+	WatchStatuses(ctx goctx.Context, securityCtx context.SecurityContext, packageId string, buildIds []string, onCheck func(events []view.PublishStatusEvent) error) error
 	UpdateBuildStatus(buildId string, status view.BuildStatusEnum, details string) error
-	GetFreeBuild(builderId string) ([]byte, error)
+	CancelBuilds(ctx context.SecurityContext, packageId string, buildIds []string) (*view.CancelBuildsResponse, error)
+	GetFreeBuild(builderId string, capabilities view.BuilderCapabilities) ([]byte, error)
+	AwaitFreeBuild(ctx goctx.Context, builderId string, capabilities view.BuilderCapabilities, timeout time.Duration) ([]byte, error)
 	CreateChangelogBuild(config view.BuildConfig, isExternal bool, builderId string) (string, view.BuildConfig, error) //deprecated
 	GetBuildViewByChangelogSearchQuery(searchRequest view.ChangelogBuildSearchRequest) (*view.BuildView, error)
 	GetBuildViewByDocumentGroupSearchQuery(searchRequest view.DocumentGroupBuildSearchRequest) (*view.BuildView, error)
 	ValidateBuildOwnership(buildId string, builderId string) error
 
 	CreateBuildWithoutDependencies(config view.BuildConfig, isExternal bool, builderId string) (string, view.BuildConfig, error)
+	CopyBuild(ctx context.SecurityContext, buildId string, newBuildId string) error
 	AwaitBuildCompletion(buildId string) error
 
 	GetBuild(buildId string) (*view.BuildView, error)
+
+	GetDeadLetterBuilds(req view.DeadLetterBuildsListReq) (*view.DeadLetterBuilds, error)
+	GetDeadLetterBuild(buildId string) (*view.DeadLetterBuildDetails, error)
+	RequeueDeadLetterBuild(ctx context.SecurityContext, buildId string) error
 }
 
 func NewBuildService(
@@ -59,33 +69,89 @@ func NewBuildService(
 	publishService PublishedService,
 	systemInfoService SystemInfoService,
 	packageService PackageService,
-	refResolverService RefResolverService) BuildService {
+	refResolverService RefResolverService,
+	buildQueueNotifier BuildQueueNotifier,
+	buildStatusNotifier BuildStatusNotifier,
+	versionInferenceService VersionInferenceService,
+	versionLockService VersionLockService,
+	roleService RoleService,
+	builderRegistryService BuilderRegistryService) BuildService {
 	return &buildServiceImpl{
-		buildRepository:    buildRepository,
-		buildProcessor:     buildProcessor,
-		publishService:     publishService,
-		systemInfoService:  systemInfoService,
-		packageService:     packageService,
-		refResolverService: refResolverService,
+		buildRepository:         buildRepository,
+		buildProcessor:          buildProcessor,
+		publishService:          publishService,
+		systemInfoService:       systemInfoService,
+		packageService:          packageService,
+		refResolverService:      refResolverService,
+		buildQueueNotifier:      buildQueueNotifier,
+		buildStatusNotifier:     buildStatusNotifier,
+		versionInferenceService: versionInferenceService,
+		versionLockService:      versionLockService,
+		roleService:             roleService,
+		builderRegistryService:  builderRegistryService,
 	}
 }
 
 type buildServiceImpl struct {
-	buildRepository    repository.BuildRepository
-	buildProcessor     BuildProcessorService
-	publishService     PublishedService
-	systemInfoService  SystemInfoService
-	packageService     PackageService
-	refResolverService RefResolverService
+	buildRepository     repository.BuildRepository
+	buildProcessor      BuildProcessorService
+	publishService      PublishedService
+	systemInfoService   SystemInfoService
+	packageService      PackageService
+	refResolverService  RefResolverService
+	buildQueueNotifier  BuildQueueNotifier
+	buildStatusNotifier BuildStatusNotifier
+
+	versionInferenceService VersionInferenceService
+	versionLockService      VersionLockService
+	roleService             RoleService
+	builderRegistryService  BuilderRegistryService
 }
 
 func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config view.BuildConfig, src []byte, clientBuild bool, builderId string, dependencies []string, resolveRefs bool, resolveConflicts bool) (*view.PublishV2Response, error) {
-	exists, err := b.packageService.PackageExists(config.PackageId)
+	var err error
+	if config.VersionMode != "" {
+		if err = b.prepareAutoVersionMode(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = b.validatePublishConfig(ctx, config, src); err != nil {
+		return nil, err
+	}
+
+	//defer refs calculation if build has dependencies
+	if len(dependencies) > 0 {
+		config.UnresolvedRefs = true
+		config.ResolveConflicts = resolveConflicts
+		config.ResolveRefs = resolveRefs
+	} else {
+		config.Refs, err = b.refResolverService.CalculateBuildConfigRefs(config.Refs, resolveRefs, resolveConflicts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	publishId, config, err := b.addBuild(ctx, config, src, clientBuild, builderId, dependencies)
 	if err != nil {
 		return nil, err
 	}
+
+	if clientBuild && len(dependencies) == 0 {
+		return &view.PublishV2Response{PublishId: publishId, Config: &config}, nil
+	} else {
+		return &view.PublishV2Response{PublishId: publishId}, nil
+	}
+}
+
+// validatePublishConfig checks the publish config and sources, it's used for builds started by publish API and for their copies
+func (b *buildServiceImpl) validatePublishConfig(ctx context.SecurityContext, config view.BuildConfig, src []byte) error {
+	exists, err := b.packageService.PackageExists(config.PackageId)
+	if err != nil {
+		return err
+	}
 	if !exists {
-		return nil, &exception.CustomError{
+		return &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.PackageNotFound,
 			Message: exception.PackageNotFoundMsg,
@@ -95,11 +161,17 @@ func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config vi
 
 	versionNameValidationError := ValidateVersionName(config.Version)
 	if versionNameValidationError != nil {
-		return nil, versionNameValidationError
+		return versionNameValidationError
+	}
+
+	if config.BuildType == view.PublishType {
+		if err = b.versionLockService.CheckPublish(config.PackageId, config.Version); err != nil {
+			return err
+		}
 	}
 
 	if config.MigrationBuild == true || config.NoChangelog == true || !config.PublishedAt.IsZero() {
-		return nil, &exception.CustomError{
+		return &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Code:    exception.ForbiddenDefaultMigrationBuildParameters,
 			Message: exception.ForbiddenDefaultMigrationBuildParametersMsg,
@@ -107,9 +179,18 @@ func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config vi
 		}
 	}
 
+	if config.DryRun && config.BuildType != view.PublishType {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.DryRunNotSupportedForBuildType,
+			Message: exception.DryRunNotSupportedForBuildTypeMsg,
+			Params:  map[string]interface{}{"buildType": config.BuildType},
+		}
+	}
+
 	if config.BuildType != view.ChangelogType {
 		if strings.Contains(config.PreviousVersion, "@") {
-			return nil, &exception.CustomError{
+			return &exception.CustomError{
 				Status:  http.StatusBadRequest,
 				Code:    exception.PreviousVersionNameNotAllowed,
 				Message: exception.PreviousVersionNameNotAllowedMsg,
@@ -118,10 +199,11 @@ func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config vi
 		}
 	}
 
-	if config.Status == string(view.Release) {
+	// in auto version mode release version pattern is checked when the version is inferred
+	if config.Status == string(view.Release) && config.VersionMode != view.VersionModeAuto {
 		packEnt, err := b.packageService.GetPackage(ctx, config.PackageId, false)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		var pattern string
 		if packEnt.ReleaseVersionPattern != "" {
@@ -131,12 +213,12 @@ func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config vi
 		}
 		err = ReleaseVersionMatchesPattern(config.Version, pattern)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if config.PreviousVersionPackageId == config.PackageId {
-		return nil, &exception.CustomError{
+		return &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Code:    exception.InvalidPreviousVersionPackage,
 			Message: exception.InvalidPreviousVersionPackageMsg,
@@ -146,7 +228,7 @@ func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config vi
 
 	if config.Version == config.PreviousVersion {
 		if config.PreviousVersionPackageId == "" {
-			return nil, &exception.CustomError{
+			return &exception.CustomError{
 				Status:  http.StatusBadRequest,
 				Code:    exception.VersionIsEqualToPreviousVersion,
 				Message: exception.VersionIsEqualToPreviousVersionMsg,
@@ -162,10 +244,10 @@ func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config vi
 		}
 		previousVersionExists, err := b.publishService.VersionPublished(previousVersionPackageId, config.PreviousVersion)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if !previousVersionExists {
-			return nil, &exception.CustomError{
+			return &exception.CustomError{
 				Status:  http.StatusBadRequest,
 				Code:    exception.PublishedPackageVersionNotFound,
 				Message: exception.PublishedPackageVersionNotFoundMsg,
@@ -177,7 +259,7 @@ func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config vi
 	if len(src) > 0 {
 		zipReader, err := zip.NewReader(bytes.NewReader(src), int64(len(src)))
 		if err != nil {
-			return nil, &exception.CustomError{
+			return &exception.CustomError{
 				Status:  http.StatusBadRequest,
 				Code:    exception.InvalidPackageArchive,
 				Message: exception.InvalidPackageArchiveMsg,
@@ -185,41 +267,53 @@ func (b *buildServiceImpl) PublishVersion(ctx context.SecurityContext, config vi
 			}
 		}
 		if err = validation.ValidatePublishSources(archive.NewSourcesArchive(zipReader, &config)); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if config.Metadata.RepositoryUrl != "" && !utils.IsUrl(config.Metadata.RepositoryUrl) {
-		return nil, &exception.CustomError{
+		return &exception.CustomError{
 			Status:  http.StatusBadRequest,
 			Code:    exception.IncorrectMetadataField,
 			Message: exception.IncorrectMetadataFieldMsg,
 			Params:  map[string]interface{}{"field": "repositoryUrl", "description": "not valid URL"},
 		}
 	}
+	return nil
+}
 
-	//defer refs calculation if build has dependencies
-	if len(dependencies) > 0 {
-		config.UnresolvedRefs = true
-		config.ResolveConflicts = resolveConflicts
-		config.ResolveRefs = resolveRefs
-	} else {
-		config.Refs, err = b.refResolverService.CalculateBuildConfigRefs(config.Refs, resolveRefs, resolveConflicts)
-		if err != nil {
-			return nil, err
+// prepareAutoVersionMode sets placeholder version name which is replaced with the version inferred from the changelog on build result processing
+func (b *buildServiceImpl) prepareAutoVersionMode(config *view.BuildConfig) error {
+	if config.VersionMode != view.VersionModeAuto || config.BuildType != view.PublishType {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidVersionMode,
+			Message: exception.InvalidVersionModeMsg,
+			Params:  map[string]interface{}{"mode": config.VersionMode, "buildType": config.BuildType},
 		}
 	}
-
-	publishId, config, err := b.addBuild(ctx, config, src, clientBuild, builderId, dependencies)
-	if err != nil {
-		return nil, err
+	if config.Version != "" {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.VersionNotAllowedInAutoMode,
+			Message: exception.VersionNotAllowedInAutoModeMsg,
+			Params:  map[string]interface{}{"version": config.Version},
+		}
 	}
-
-	if clientBuild && len(dependencies) == 0 {
-		return &view.PublishV2Response{PublishId: publishId, Config: &config}, nil
-	} else {
-		return &view.PublishV2Response{PublishId: publishId}, nil
+	if config.PreviousVersion == "" && config.PreviousVersionPackageId == "" {
+		latestRelease, err := b.versionInferenceService.GetLatestReleaseVersion(config.PackageId)
+		if err != nil {
+			return err
+		}
+		config.PreviousVersion = latestRelease
+	}
+	if config.PreviousVersion != "" {
+		if _, err := parseSemanticVersion(config.PreviousVersion); err != nil {
+			return err
+		}
 	}
+	config.Version = view.VersionModeAuto + "-" + uuid.New().String()
+	return nil
 }
 
 func (b *buildServiceImpl) setValidationRulesSeverity(config view.BuildConfig) view.BuildConfig {
@@ -260,8 +354,9 @@ func (b *buildServiceImpl) CreateChangelogBuild(config view.BuildConfig, isExter
 		RestartCount: 0,
 
 		BuilderId: builderId,
-		Priority:  0,
+		Priority:  view.DefaultBuildPriority,
 	}
+	buildEnt.SetRequirements(view.MakeBuildRequirements(config, nil))
 
 	confAsMap, err := view.BuildConfigToMap(config)
 	if err != nil {
@@ -277,6 +372,9 @@ func (b *buildServiceImpl) CreateChangelogBuild(config view.BuildConfig, isExter
 	if err != nil {
 		return "", config, err
 	}
+	if status == view.StatusNotStarted {
+		b.buildQueueNotifier.NotifyBuildAvailable(buildEnt.BuildId)
+	}
 	return buildEnt.BuildId, config, nil
 }
 
@@ -308,8 +406,9 @@ func (b *buildServiceImpl) CreateBuildWithoutDependencies(config view.BuildConfi
 		RestartCount: 0,
 
 		BuilderId: builderId,
-		Priority:  0,
+		Priority:  view.DefaultBuildPriority,
 	}
+	buildEnt.SetRequirements(view.MakeBuildRequirements(config, nil))
 
 	confAsMap, err := view.BuildConfigToMap(config)
 	if err != nil {
@@ -325,6 +424,9 @@ func (b *buildServiceImpl) CreateBuildWithoutDependencies(config view.BuildConfi
 	if err != nil {
 		return "", config, err
 	}
+	if status == view.StatusNotStarted {
+		b.buildQueueNotifier.NotifyBuildAvailable(buildEnt.BuildId)
+	}
 	return buildEnt.BuildId, config, nil
 }
 
@@ -341,6 +443,12 @@ func (b *buildServiceImpl) addBuild(ctx context.SecurityContext, config view.Bui
 		buildId = uuid.New().String()
 	}
 
+	// interactive publishes take precedence over migration builds
+	priority := view.InteractiveBuildPriority
+	if config.MigrationBuild {
+		priority = view.MigrationBuildPriority
+	}
+
 	timeNow := time.Now()
 	buildEnt := entity.BuildEntity{
 		BuildId:     buildId,
@@ -357,8 +465,9 @@ func (b *buildServiceImpl) addBuild(ctx context.SecurityContext, config view.Bui
 		RestartCount: 0,
 
 		BuilderId: builderId,
-		Priority:  1,
+		Priority:  priority,
 	}
+	buildEnt.SetRequirements(view.MakeBuildRequirements(config, src))
 
 	confAsMap, err := view.BuildConfigToMap(config)
 	if err != nil {
@@ -383,6 +492,7 @@ func (b *buildServiceImpl) addBuild(ctx context.SecurityContext, config view.Bui
 
 	if !clientBuild {
 		log.Infof("Build %s added as internal", buildEnt.BuildId)
+		b.buildQueueNotifier.NotifyBuildAvailable(buildEnt.BuildId)
 	} else {
 		log.Infof("Build %s added as external", buildEnt.BuildId)
 	}
@@ -390,6 +500,33 @@ func (b *buildServiceImpl) addBuild(ctx context.SecurityContext, config view.Bui
 	return buildEnt.BuildId, config, nil
 }
 
+// CopyBuild adds a new build to the queue with the same config and sources as the existing one
+func (b *buildServiceImpl) CopyBuild(ctx context.SecurityContext, buildId string, newBuildId string) error {
+	src, err := b.buildRepository.GetBuildSrc(buildId)
+	if err != nil {
+		return err
+	}
+	if src == nil {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.BuildSourcesNotFound,
+			Message: exception.BuildSourcesNotFoundMsg,
+			Params:  map[string]interface{}{"publishId": buildId},
+		}
+	}
+	config, err := view.BuildConfigFromMap(src.Config, buildId)
+	if err != nil {
+		return err
+	}
+	// the copy is validated the same way as the original publish, since the package or versions could have been changed since then
+	if err = b.validatePublishConfig(ctx, *config, src.Source); err != nil {
+		return err
+	}
+	config.PublishId = newBuildId
+	_, _, err = b.addBuild(ctx, *config, src.Source, false, "", nil)
+	return err
+}
+
 func (b *buildServiceImpl) GetStatus(buildId string) (string, string, error) {
 	ent, err := b.buildRepository.GetBuild(buildId)
 	if err != nil {
@@ -401,6 +538,58 @@ func (b *buildServiceImpl) GetStatus(buildId string) (string, string, error) {
 	return ent.Status, ent.Details, nil
 }
 
+// GetQueuePosition returns an estimated position of the not started build in the queue, fair share order is applied.
+// Only builds which could be taken by the same active builders as the requested build are counted, since other builds don't compete with it.
+// Build which is not in the queue yet (waiting for dependencies or retry backoff) is placed after all queued builds.
+func (b *buildServiceImpl) GetQueuePosition(buildId string) (int, error) {
+	queue, err := b.buildRepository.GetBuildQueue(b.systemInfoService.GetBuildFairSharePolicy())
+	if err != nil {
+		return 0, err
+	}
+	var build *entity.BuildEntity
+	ahead := queue
+	for i := range queue {
+		if queue[i].BuildId == buildId {
+			build = &queue[i]
+			ahead = queue[:i]
+			break
+		}
+	}
+	if build == nil {
+		build, err = b.buildRepository.GetBuild(buildId)
+		if err != nil {
+			return 0, err
+		}
+		if build == nil {
+			return 0, nil
+		}
+	}
+	buildersCapabilities, err := b.builderRegistryService.GetActiveBuildersCapabilities()
+	if err != nil {
+		return 0, err
+	}
+	matchingBuilders := make([]view.BuilderCapabilities, 0)
+	for _, capabilities := range buildersCapabilities {
+		if capabilities.Accepts(build.GetRequirements()) {
+			matchingBuilders = append(matchingBuilders, capabilities)
+		}
+	}
+	position := 1
+	for _, queued := range ahead {
+		if len(matchingBuilders) == 0 {
+			position++
+			continue
+		}
+		for _, capabilities := range matchingBuilders {
+			if capabilities.Accepts(queued.GetRequirements()) {
+				position++
+				break
+			}
+		}
+	}
+	return position, nil
+}
+
 func (b *buildServiceImpl) GetStatuses(buildIds []string) ([]view.PublishStatusResponse, error) {
 	ents, err := b.buildRepository.GetBuilds(buildIds)
 	if err != nil {
@@ -412,28 +601,278 @@ func (b *buildServiceImpl) GetStatuses(buildIds []string) ([]view.PublishStatusR
 			PublishId: ent.BuildId,
 			Status:    ent.Status,
 			Message:   ent.Details,
+			Version:   ent.Version,
 		})
 	}
 	return result, nil
 }
 
+// statuses are re-checked with this interval anyway since some transitions (e.g. stale builds moved to dead letter) are not notified
+const buildStatusRecheckInterval = 5 * time.Second
+
+// WatchStatuses calls onCheck with status changes of the given builds and their related builds: dependency/dependent builds
+// and builds triggered by them (e.g. changelog recalculation on publish). Related builds of other packages are watched
+// only if the user has read permission for them.
+// The first call contains current statuses of all builds, next calls contain only changed ones (or nothing if the check was triggered by timer).
+// Returns when all builds are finished, ctx is done or onCheck returns an error.
+func (b *buildServiceImpl) WatchStatuses(ctx goctx.Context, securityCtx context.SecurityContext, packageId string, buildIds []string, onCheck func(events []view.PublishStatusEvent) error) error {
+	statusEvents, unsubscribe := b.buildStatusNotifier.Subscribe()
+	defer unsubscribe()
+
+	readablePackages := map[string]bool{packageId: true}
+	lastEvents := make(map[string]view.PublishStatusEvent)
+	check := func() (bool, error) {
+		ents, relatedTo, err := b.getWatchedBuilds(securityCtx, packageId, buildIds, readablePackages)
+		if err != nil {
+			return false, err
+		}
+		var events []view.PublishStatusEvent
+		finished := true
+		for _, ent := range ents {
+			event := view.PublishStatusEvent{
+				PublishId: ent.BuildId,
+				PackageId: ent.PackageId,
+				Status:    ent.Status,
+				Message:   ent.Details,
+				BuildType: ent.BuildType,
+				RelatedTo: relatedTo[ent.BuildId],
+			}
+			if lastEvents[ent.BuildId] != event {
+				lastEvents[ent.BuildId] = event
+				events = append(events, event)
+			}
+			if ent.Status == string(view.StatusNotStarted) || ent.Status == string(view.StatusRunning) {
+				finished = false
+			}
+		}
+		return finished, onCheck(events)
+	}
+
+	finished, err := check()
+	if err != nil || finished {
+		return err
+	}
+	recheckTicker := time.NewTicker(buildStatusRecheckInterval)
+	defer recheckTicker.Stop()
+	for {
+		select {
+		case buildId := <-statusEvents:
+			if _, watched := lastEvents[buildId]; !watched {
+				continue
+			}
+		case <-recheckTicker.C:
+		case <-ctx.Done():
+			return nil
+		}
+		finished, err = check()
+		if err != nil || finished {
+			return err
+		}
+	}
+}
+
+// getWatchedBuilds returns the requested builds (which must belong to the package) and their related builds.
+// readablePackages caches permission checks for packages of related builds between calls.
+func (b *buildServiceImpl) getWatchedBuilds(ctx context.SecurityContext, packageId string, buildIds []string, readablePackages map[string]bool) ([]entity.BuildEntity, map[string]string, error) {
+	links, err := b.buildRepository.GetBuildDependencyLinks(buildIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	childBuilds, err := b.buildRepository.GetChildBuilds(buildIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	requested := make(map[string]bool, len(buildIds))
+	for _, buildId := range buildIds {
+		requested[buildId] = true
+	}
+	relatedTo := make(map[string]string)
+	allBuildIds := append([]string{}, buildIds...)
+	addRelated := func(relatedBuildId string, requestedBuildId string) {
+		if requested[relatedBuildId] {
+			return
+		}
+		if _, exists := relatedTo[relatedBuildId]; !exists {
+			relatedTo[relatedBuildId] = requestedBuildId
+			allBuildIds = append(allBuildIds, relatedBuildId)
+		}
+	}
+	for _, link := range links {
+		if requested[link.BuildId] {
+			addRelated(link.DependId, link.BuildId)
+		} else {
+			addRelated(link.BuildId, link.DependId)
+		}
+	}
+	for _, child := range childBuilds {
+		addRelated(child.BuildId, child.ParentBuildId)
+	}
+
+	ents, err := b.buildRepository.GetBuilds(allBuildIds)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := make([]entity.BuildEntity, 0, len(ents))
+	found := make(map[string]bool, len(ents))
+	for _, ent := range ents {
+		if requested[ent.BuildId] {
+			if ent.PackageId != packageId {
+				continue
+			}
+		} else {
+			readable, checked := readablePackages[ent.PackageId]
+			if !checked {
+				readable, err = b.roleService.HasRequiredPermissions(ctx, ent.PackageId, view.ReadPermission)
+				if err != nil {
+					return nil, nil, err
+				}
+				readablePackages[ent.PackageId] = readable
+			}
+			if !readable {
+				continue
+			}
+		}
+		found[ent.BuildId] = true
+		result = append(result, ent)
+	}
+	for _, buildId := range buildIds {
+		if !found[buildId] {
+			return nil, nil, &exception.CustomError{
+				Status:  http.StatusNotFound,
+				Code:    exception.BuildNotFound,
+				Message: exception.BuildNotFoundMsg,
+				Params:  map[string]interface{}{"buildId": buildId},
+			}
+		}
+	}
+	return result, relatedTo, nil
+}
+
 func (b *buildServiceImpl) UpdateBuildStatus(buildId string, status view.BuildStatusEnum, details string) error {
+	if status == view.StatusError {
+		return b.failBuild(buildId, details)
+	}
 	err := b.buildRepository.UpdateBuildStatus(buildId, status, details)
 	if err != nil {
 		return err
 	}
+	if status != view.StatusRunning {
+		// running status is sent by builders as keepalive, the transition to running is notified when the build is taken
+		b.buildStatusNotifier.NotifyBuildStatusChanged(buildId)
+	}
+	if status == view.StatusComplete || status == view.StatusError {
+		// dependent builds might become free
+		b.buildQueueNotifier.NotifyBuildAvailable(buildId)
+	}
+
+	return nil
+}
 
+// failBuild handles the error reported by the builder, the build is either retried or moved to dead letter according to the retry policy
+func (b *buildServiceImpl) failBuild(buildId string, details string) error {
+	requeued, err := b.buildRepository.FailBuild(buildId, details, b.systemInfoService.GetBuildRetryPolicies())
+	if err != nil {
+		return err
+	}
+	b.buildStatusNotifier.NotifyBuildStatusChanged(buildId)
+	if requeued {
+		log.Infof("Build %s failed and is returned to the queue according to the retry policy: %s", buildId, details)
+		return nil
+	}
+	// dependent builds might become free
+	b.buildQueueNotifier.NotifyBuildAvailable(buildId)
 	return nil
 }
 
-func (b *buildServiceImpl) GetFreeBuild(builderId string) ([]byte, error) {
-	config, src, err := b.buildProcessor.GetFreeBuild(builderId)
+func (b *buildServiceImpl) CancelBuilds(ctx context.SecurityContext, packageId string, buildIds []string) (*view.CancelBuildsResponse, error) {
+	ents, err := b.buildRepository.GetBuilds(buildIds)
+	if err != nil {
+		return nil, err
+	}
+	packageBuilds := make(map[string]bool, len(ents))
+	for _, ent := range ents {
+		if ent.PackageId == packageId {
+			packageBuilds[ent.BuildId] = true
+		}
+	}
+	for _, buildId := range buildIds {
+		if !packageBuilds[buildId] {
+			return nil, &exception.CustomError{
+				Status:  http.StatusNotFound,
+				Code:    exception.BuildNotFound,
+				Message: exception.BuildNotFoundMsg,
+				Params:  map[string]interface{}{"buildId": buildId},
+			}
+		}
+	}
+
+	// dependent builds could belong to other packages and publish versions in other statuses,
+	// so the user must be allowed to publish the version of each cancelled build
+	checkBuildsToCancel := func(buildsToCancel []entity.BuildToCancelEntity) error {
+		checkedVersions := map[entity.BuildToCancelEntity]bool{}
+		for _, build := range buildsToCancel {
+			checkedVersion := entity.BuildToCancelEntity{PackageId: build.PackageId, Version: build.Version, VersionStatus: build.VersionStatus}
+			if checkedVersions[checkedVersion] {
+				continue
+			}
+			sufficientPrivileges, err := b.hasCancelBuildPermission(ctx, build)
+			if err != nil {
+				return err
+			}
+			if !sufficientPrivileges {
+				return &exception.CustomError{
+					Status:  http.StatusForbidden,
+					Code:    exception.InsufficientPrivileges,
+					Message: exception.InsufficientPrivilegesMsg,
+					Debug:   fmt.Sprintf("build %s of package %s would be cancelled", build.BuildId, build.PackageId),
+				}
+			}
+			checkedVersions[checkedVersion] = true
+		}
+		return nil
+	}
+	cancelledBuildIds, err := b.buildRepository.CancelBuilds(buildIds, fmt.Sprintf("Cancelled by %s", ctx.GetUserId()), checkBuildsToCancel)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Builds %v cancelled by %s", cancelledBuildIds, ctx.GetUserId())
+	for _, buildId := range cancelledBuildIds {
+		b.buildStatusNotifier.NotifyBuildStatusChanged(buildId)
+	}
+	return &view.CancelBuildsResponse{CancelledPublishIds: cancelledBuildIds}, nil
+}
+
+// builds which don't publish a version (e.g. changelog calculation) require the draft management permission only
+func (b *buildServiceImpl) hasCancelBuildPermission(ctx context.SecurityContext, build entity.BuildToCancelEntity) (bool, error) {
+	if build.VersionStatus == "" {
+		return b.roleService.HasRequiredPermissions(ctx, build.PackageId, view.ManageDraftVersionPermission)
+	}
+	return b.roleService.HasPublishVersionPermission(ctx, build.PackageId, build.Version, build.VersionStatus)
+}
+
+func (b *buildServiceImpl) GetFreeBuild(builderId string, capabilities view.BuilderCapabilities) ([]byte, error) {
+	config, src, err := b.buildProcessor.GetFreeBuild(builderId, capabilities)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil && src == nil {
+		return nil, nil
+	}
+	return makeBuildTaskArchive(config, src)
+}
+
+func (b *buildServiceImpl) AwaitFreeBuild(ctx goctx.Context, builderId string, capabilities view.BuilderCapabilities, timeout time.Duration) ([]byte, error) {
+	config, src, err := b.buildProcessor.AwaitFreeBuild(ctx, builderId, capabilities, timeout)
 	if err != nil {
 		return nil, err
 	}
 	if config == nil && src == nil {
 		return nil, nil
 	}
+	return makeBuildTaskArchive(config, src)
+}
+
+func makeBuildTaskArchive(config *view.BuildConfig, src []byte) ([]byte, error) {
 	result := bytes.Buffer{}
 	zw := zip.NewWriter(&result)
 	if src != nil {
@@ -566,6 +1005,9 @@ func (b *buildServiceImpl) AwaitBuildCompletion(buildId string) error {
 		if build.Status == string(view.StatusError) {
 			return fmt.Errorf("build failed with error: %v", build.Details)
 		}
+		if build.Status == string(view.StatusCancelled) {
+			return fmt.Errorf("build was cancelled: %v", build.Details)
+		}
 		if build.Status == string(view.StatusComplete) {
 			return nil
 		}
@@ -587,3 +1029,58 @@ func (b *buildServiceImpl) GetBuild(buildId string) (*view.BuildView, error) {
 	result := entity.MakeBuildView(build)
 	return result, nil
 }
+
+func (b *buildServiceImpl) GetDeadLetterBuilds(req view.DeadLetterBuildsListReq) (*view.DeadLetterBuilds, error) {
+	ents, err := b.buildRepository.GetDeadLetterBuilds(req)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.DeadLetterBuilds{Builds: make([]view.DeadLetterBuild, 0, len(ents))}
+	for _, ent := range ents {
+		result.Builds = append(result.Builds, entity.MakeDeadLetterBuildView(ent))
+	}
+	return result, nil
+}
+
+func (b *buildServiceImpl) GetDeadLetterBuild(buildId string) (*view.DeadLetterBuildDetails, error) {
+	ent, err := b.buildRepository.GetDeadLetterBuild(buildId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.BuildNotFound,
+			Message: exception.BuildNotFoundMsg,
+			Params:  map[string]interface{}{"buildId": buildId},
+		}
+	}
+	dependsOn, err := b.buildRepository.GetBuildDependencies(buildId)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.DeadLetterBuildDetails{
+		DeadLetterBuild: entity.MakeDeadLetterBuildView(*ent),
+		Status:          ent.Status,
+		DependsOn:       dependsOn,
+	}
+	src, err := b.buildRepository.GetBuildSrc(buildId)
+	if err != nil {
+		return nil, err
+	}
+	if src != nil {
+		result.Config = src.Config
+	}
+	return result, nil
+}
+
+func (b *buildServiceImpl) RequeueDeadLetterBuild(ctx context.SecurityContext, buildId string) error {
+	err := b.buildRepository.RequeueDeadLetterBuild(buildId)
+	if err != nil {
+		return err
+	}
+	log.Infof("Dead letter build %s was requeued by %s", buildId, ctx.GetUserId())
+	b.buildQueueNotifier.NotifyBuildAvailable(buildId)
+	b.buildStatusNotifier.NotifyBuildStatusChanged(buildId)
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"sync"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/cache"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/buraksezer/olric"
+	log "github.com/sirupsen/logrus"
+)
+
+const BuildStatusTopicName = "build-status-events"
+
+// BuildStatusNotifier delivers ids of builds with changed status to all backend replicas,
+// so publish status streams could be updated without polling the DB.
+type BuildStatusNotifier interface {
+	NotifyBuildStatusChanged(buildId string)
+	Subscribe() (<-chan string, func())
+}
+
+func NewBuildStatusNotifier(op cache.OlricProvider) BuildStatusNotifier {
+	n := &buildStatusNotifierImpl{
+		op:          op,
+		subscribers: make(map[uint64]chan string),
+	}
+	utils.SafeAsync(func() {
+		n.initDTopic()
+	})
+	return n
+}
+
+type buildStatusNotifierImpl struct {
+	op         cache.OlricProvider
+	topic      *olric.DTopic
+	topicMutex sync.RWMutex
+
+	subscribers      map[uint64]chan string
+	subscribersMutex sync.Mutex
+	nextSubscriberId uint64
+}
+
+// buffer for events of all builds in the cluster, subscriber re-checks statuses periodically, so overflow is not critical
+const buildStatusSubscriberBufferSize = 100
+
+func (n *buildStatusNotifierImpl) initDTopic() {
+	topic, err := n.op.Get().NewDTopic(BuildStatusTopicName, 10000, 1)
+	if err != nil {
+		log.Errorf("Failed to create DTopic: %s", err.Error())
+		return
+	}
+	_, err = topic.AddListener(func(msg olric.DTopicMessage) {
+		if buildId, ok := msg.Message.(string); ok {
+			n.sendToSubscribers(buildId)
+		}
+	})
+	if err != nil {
+		log.Errorf("Failed to add listener for %s DTopic: %s", BuildStatusTopicName, err.Error())
+		return
+	}
+	n.topicMutex.Lock()
+	n.topic = topic
+	n.topicMutex.Unlock()
+}
+
+func (n *buildStatusNotifierImpl) NotifyBuildStatusChanged(buildId string) {
+	n.topicMutex.RLock()
+	topic := n.topic
+	n.topicMutex.RUnlock()
+	if topic == nil {
+		// cluster is not ready yet, at least notify local subscribers
+		n.sendToSubscribers(buildId)
+		return
+	}
+	err := topic.Publish(buildId)
+	if err != nil {
+		log.Errorf("Failed to publish build status event for build %s: %s", buildId, err.Error())
+		n.sendToSubscribers(buildId)
+	}
+}
+
+// Subscribe returns a channel which receives ids of builds with changed status
+// and a function which must be called to release the subscription.
+func (n *buildStatusNotifierImpl) Subscribe() (<-chan string, func()) {
+	ch := make(chan string, buildStatusSubscriberBufferSize)
+	n.subscribersMutex.Lock()
+	id := n.nextSubscriberId
+	n.nextSubscriberId++
+	n.subscribers[id] = ch
+	n.subscribersMutex.Unlock()
+
+	return ch, func() {
+		n.subscribersMutex.Lock()
+		delete(n.subscribers, id)
+		n.subscribersMutex.Unlock()
+	}
+}
+
+func (n *buildStatusNotifierImpl) sendToSubscribers(buildId string) {
+	n.subscribersMutex.Lock()
+	defer n.subscribersMutex.Unlock()
+	for _, ch := range n.subscribers {
+		select {
+		case ch <- buildId:
+		default: // subscriber is busy, the event will be caught up by periodic re-check
+		}
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	log "github.com/sirupsen/logrus"
+)
+
+type BuilderRegistryService interface {
+	ProcessBuilderHeartbeat(builderId string, heartbeat view.BuilderHeartbeat) error
+	ListBuilders(onlyActive bool) ([]view.BuilderInstance, error)
+	GetBuilder(builderId string) (*view.BuilderInstance, error)
+	GetActiveBuildersCapabilities() ([]view.BuilderCapabilities, error)
+	StartStaleBuildersReclaimJob()
+}
+
+func NewBuilderRegistryService(builderRepository repository.BuilderRepository, buildRepository repository.BuildRepository, systemInfoService SystemInfoService, buildQueueNotifier BuildQueueNotifier, buildStatusNotifier BuildStatusNotifier) BuilderRegistryService {
+	return &builderRegistryServiceImpl{
+		builderRepository:   builderRepository,
+		buildRepository:     buildRepository,
+		buildQueueNotifier:  buildQueueNotifier,
+		buildStatusNotifier: buildStatusNotifier,
+		heartbeatTimeout:    time.Duration(systemInfoService.GetBuilderHeartbeatTimeoutSec()) * time.Second,
+		unmatchedTimeout:    time.Duration(systemInfoService.GetBuildUnmatchedTimeoutSec()) * time.Second,
+		retryPolicies:       systemInfoService.GetBuildRetryPolicies(),
+	}
+}
+
+type builderRegistryServiceImpl struct {
+	builderRepository   repository.BuilderRepository
+	buildRepository     repository.BuildRepository
+	buildQueueNotifier  BuildQueueNotifier
+	buildStatusNotifier BuildStatusNotifier
+	heartbeatTimeout    time.Duration
+	unmatchedTimeout    time.Duration
+	retryPolicies       view.BuildRetryPolicies
+}
+
+const builderStatsWindow = time.Hour
+const inactiveBuilderRetention = time.Hour * 24 * 7
+
+func (b builderRegistryServiceImpl) ProcessBuilderHeartbeat(builderId string, heartbeat view.BuilderHeartbeat) error {
+	capacity := heartbeat.Capacity
+	if capacity <= 0 {
+		capacity = 1
+	}
+	now := time.Now()
+	return b.builderRepository.SaveBuilderHeartbeat(entity.BuilderEntity{
+		BuilderId:     builderId,
+		Version:       heartbeat.Version,
+		Capacity:      capacity,
+		Capabilities:  heartbeat.Capabilities,
+		RegisteredAt:  now,
+		LastHeartbeat: now,
+	})
+}
+
+func (b builderRegistryServiceImpl) ListBuilders(onlyActive bool) ([]view.BuilderInstance, error) {
+	var activeSince *time.Time
+	if onlyActive {
+		t := time.Now().Add(-b.heartbeatTimeout)
+		activeSince = &t
+	}
+	ents, err := b.builderRepository.ListBuilders(activeSince)
+	if err != nil {
+		return nil, err
+	}
+	builderIds := make([]string, 0, len(ents))
+	for _, ent := range ents {
+		builderIds = append(builderIds, ent.BuilderId)
+	}
+	statsEnts, err := b.builderRepository.GetBuildersStats(builderIds, time.Now().Add(-builderStatsWindow))
+	if err != nil {
+		return nil, err
+	}
+	stats := make(map[string]*entity.BuilderStatsEntity, len(statsEnts))
+	for i := range statsEnts {
+		stats[statsEnts[i].BuilderId] = &statsEnts[i]
+	}
+
+	result := make([]view.BuilderInstance, 0, len(ents))
+	for _, ent := range ents {
+		result = append(result, entity.MakeBuilderView(ent, stats[ent.BuilderId], b.heartbeatTimeout))
+	}
+	return result, nil
+}
+
+func (b builderRegistryServiceImpl) GetBuilder(builderId string) (*view.BuilderInstance, error) {
+	ent, err := b.builderRepository.GetBuilder(builderId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, nil
+	}
+	statsEnts, err := b.builderRepository.GetBuildersStats([]string{builderId}, time.Now().Add(-builderStatsWindow))
+	if err != nil {
+		return nil, err
+	}
+	var stats *entity.BuilderStatsEntity
+	if len(statsEnts) > 0 {
+		stats = &statsEnts[0]
+	}
+	result := entity.MakeBuilderView(*ent, stats, b.heartbeatTimeout)
+	return &result, nil
+}
+
+func (b builderRegistryServiceImpl) GetActiveBuildersCapabilities() ([]view.BuilderCapabilities, error) {
+	activeSince := time.Now().Add(-b.heartbeatTimeout)
+	ents, err := b.builderRepository.ListBuilders(&activeSince)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]view.BuilderCapabilities, 0, len(ents))
+	for _, ent := range ents {
+		result = append(result, ent.Capabilities)
+	}
+	return result, nil
+}
+
+// StartStaleBuildersReclaimJob returns running builds of builders which stopped sending heartbeats to the queue.
+// Builders which never sent a heartbeat are not tracked, their builds are reclaimed by build keepalive timeout only.
+// The job is started on each instance, but only one of them performs the reclaim per interval.
+func (b builderRegistryServiceImpl) StartStaleBuildersReclaimJob() {
+	interval := b.heartbeatTimeout / 2
+	ticker := time.NewTicker(interval)
+	for range ticker.C {
+		if b.startReclaimRun(interval) {
+			b.reclaimStaleBuilds()
+		}
+	}
+}
+
+// startReclaimRun returns false if the reclaim has been recently performed by another instance.
+// Instances tick independently, so the run is skipped if the last one was less than half of the interval ago.
+func (b builderRegistryServiceImpl) startReclaimRun(interval time.Duration) bool {
+	scheduledAt := time.Now().Round(time.Second)
+	lastRun, err := b.builderRepository.GetLastReclaimRun()
+	if err != nil {
+		log.Warnf("Failed to get last stale builds reclaim run: %s", err.Error())
+		return false
+	}
+	runId := 1
+	if lastRun != nil {
+		if lastRun.ScheduledAt.After(scheduledAt.Add(-interval / 2)) {
+			return false
+		}
+		runId = lastRun.RunId + 1
+	}
+	// run id is used as a lock, so the insert fails if another instance has started the run concurrently
+	err = b.builderRepository.StoreReclaimRun(entity.BuilderReclaimRunEntity{
+		RunId:       runId,
+		ScheduledAt: scheduledAt,
+	})
+	if err != nil {
+		log.Debugf("Stale builds reclaim run %d was not started: %s", runId, err.Error())
+		return false
+	}
+	return true
+}
+
+func (b builderRegistryServiceImpl) reclaimStaleBuilds() {
+	staleBuilderIds, err := b.builderRepository.GetStaleBuilderIds(time.Now().Add(-b.heartbeatTimeout))
+	if err != nil {
+		log.Warnf("Failed to get stale builders: %s", err.Error())
+		return
+	}
+	if len(staleBuilderIds) > 0 {
+		requeuedBuildIds, err := b.buildRepository.RequeueBuildsOfBuilders(staleBuilderIds, b.retryPolicies)
+		if err != nil {
+			log.Warnf("Failed to reclaim builds of stale builders: %s", err.Error())
+			return
+		}
+		log.Infof("Builders %v stopped sending heartbeats, builds %v were returned to the queue", staleBuilderIds, requeuedBuildIds)
+		for _, buildId := range requeuedBuildIds {
+			b.buildQueueNotifier.NotifyBuildAvailable(buildId)
+			b.buildStatusNotifier.NotifyBuildStatusChanged(buildId)
+		}
+	}
+
+	b.failUnmatchedBuilds()
+
+	err = b.builderRepository.DeleteReclaimRuns(time.Now().Add(-inactiveBuilderRetention))
+	if err != nil {
+		log.Warnf("Failed to delete old stale builds reclaim runs: %s", err.Error())
+	}
+
+	deleted, err := b.builderRepository.DeleteInactiveBuilders(time.Now().Add(-inactiveBuilderRetention))
+	if err != nil {
+		log.Warnf("Failed to delete inactive builders: %s", err.Error())
+		return
+	}
+	if deleted > 0 {
+		log.Infof("%d builders were removed from the registry due to inactivity", deleted)
+	}
+}
+
+const unmatchedBuildDetails = "No active builder matches the build requirements"
+
+// failUnmatchedBuilds moves builds which wait in the queue too long and don't match capabilities of any active builder to dead letter,
+// otherwise such builds would wait forever. Builds are not failed if there are no active registered builders or if some builder
+// which doesn't send heartbeats is active, since capabilities of such builders are unknown.
+func (b builderRegistryServiceImpl) failUnmatchedBuilds() {
+	activeSince := time.Now().Add(-b.heartbeatTimeout)
+	activeBuilders, err := b.builderRepository.ListBuilders(&activeSince)
+	if err != nil {
+		log.Warnf("Failed to get active builders: %s", err.Error())
+		return
+	}
+	if len(activeBuilders) == 0 {
+		return
+	}
+	unregisteredBuildersActive, err := b.builderRepository.HasActiveUnregisteredBuilders(activeSince)
+	if err != nil {
+		log.Warnf("Failed to check active unregistered builders: %s", err.Error())
+		return
+	}
+	if unregisteredBuildersActive {
+		return
+	}
+	waitingBuilds, err := b.buildRepository.GetWaitingBuilds(time.Now().Add(-b.unmatchedTimeout))
+	if err != nil {
+		log.Warnf("Failed to get waiting builds: %s", err.Error())
+		return
+	}
+	unmatchedBuildIds := make([]string, 0)
+	for _, build := range waitingBuilds {
+		requirements := build.GetRequirements()
+		matched := false
+		for _, builder := range activeBuilders {
+			if builder.Capabilities.Accepts(requirements) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatchedBuildIds = append(unmatchedBuildIds, build.BuildId)
+		}
+	}
+	if len(unmatchedBuildIds) == 0 {
+		return
+	}
+	failedBuildIds, err := b.buildRepository.MoveBuildsToDeadLetter(unmatchedBuildIds, unmatchedBuildDetails)
+	if err != nil {
+		log.Warnf("Failed to move unmatched builds to dead letter: %s", err.Error())
+		return
+	}
+	log.Infof("Builds %v were moved to dead letter since no active builder matches their requirements", failedBuildIds)
+	for _, buildId := range failedBuildIds {
+		b.buildStatusNotifier.NotifyBuildStatusChanged(buildId)
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type BulkVersionOperationService interface {
+	StartOperation(ctx context.SecurityContext, packageId string, req view.BulkVersionOperationReq) (string, error)
+	GetOperationStatus(packageId string, operationId string) (*view.BulkVersionOperationStatus, error)
+	GetOperationReport(packageId string, operationId string) ([]byte, error)
+}
+
+func NewBulkVersionOperationService(repo repository.BulkVersionOperationRepository,
+	publishedRepo repository.PublishedRepository,
+	roleService RoleService,
+	versionService VersionService,
+	versionPromotionService VersionPromotionService) BulkVersionOperationService {
+	return &bulkVersionOperationServiceImpl{
+		repo:                    repo,
+		publishedRepo:           publishedRepo,
+		roleService:             roleService,
+		versionService:          versionService,
+		versionPromotionService: versionPromotionService,
+	}
+}
+
+type bulkVersionOperationServiceImpl struct {
+	repo                    repository.BulkVersionOperationRepository
+	publishedRepo           repository.PublishedRepository
+	roleService             RoleService
+	versionService          VersionService
+	versionPromotionService VersionPromotionService
+}
+
+const bulkVersionResultOk = "ok"
+
+func (b bulkVersionOperationServiceImpl) StartOperation(ctx context.SecurityContext, packageId string, req view.BulkVersionOperationReq) (string, error) {
+	if err := validateBulkVersionOperationReq(packageId, req); err != nil {
+		return "", err
+	}
+	pkg, err := b.publishedRepo.GetPackage(packageId)
+	if err != nil {
+		return "", err
+	}
+	if pkg == nil {
+		return "", &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+
+	operationEnt := &entity.BulkVersionOperationEntity{
+		OperationId: uuid.NewString(),
+		PackageId:   packageId,
+		Action:      string(req.Action),
+		Status:      string(view.StatusRunning),
+		Message:     "",
+		Report:      []byte{},
+		CreatedBy:   ctx.GetUserId(),
+		CreatedAt:   time.Now(),
+	}
+	err = b.repo.StoreOperation(operationEnt)
+	if err != nil {
+		return "", err
+	}
+
+	utils.SafeAsync(func() {
+		b.runOperation(ctx, packageId, req, operationEnt)
+	})
+	return operationEnt.OperationId, nil
+}
+
+func validateBulkVersionOperationReq(packageId string, req view.BulkVersionOperationReq) error {
+	invalidReq := func(details string) error {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidBulkVersionOperation,
+			Message: exception.InvalidBulkVersionOperationMsg,
+			Params:  map[string]interface{}{"details": details},
+		}
+	}
+	switch req.Action {
+	case view.BulkVersionActionArchive, view.BulkVersionActionDelete:
+	case view.BulkVersionActionAddLabel, view.BulkVersionActionRemoveLabel:
+		if req.Label == "" {
+			return invalidReq(fmt.Sprintf("label is required for '%v' action", req.Action))
+		}
+	default:
+		return invalidReq(fmt.Sprintf("unknown action '%v'", req.Action))
+	}
+	if len(req.Versions) == 0 && req.Filter == nil {
+		return invalidReq("either versions or filter is required")
+	}
+	if len(req.Versions) != 0 && req.Filter != nil {
+		return invalidReq("versions and filter cannot be used together")
+	}
+	for _, ref := range req.Versions {
+		if ref.PackageId == "" || ref.Version == "" {
+			return invalidReq("packageId and version are required for each version")
+		}
+		if ref.PackageId != packageId && !strings.HasPrefix(ref.PackageId, packageId+".") {
+			return invalidReq(fmt.Sprintf("package '%v' is not a descendant of '%v'", ref.PackageId, packageId))
+		}
+	}
+	if req.Filter != nil {
+		if req.Filter.PublishedFrom != nil && req.Filter.PublishedTo != nil &&
+			req.Filter.PublishedFrom.After(*req.Filter.PublishedTo) {
+			return invalidReq("publishedFrom cannot be after publishedTo")
+		}
+		if req.Filter.All && !req.Filter.IsEmpty() {
+			return invalidReq("all cannot be used together with other filter fields")
+		}
+		if req.Filter.IsEmpty() && !req.Filter.All &&
+			(req.Action == view.BulkVersionActionArchive || req.Action == view.BulkVersionActionDelete) {
+			return invalidReq(fmt.Sprintf("empty filter matches all versions, set all=true to apply '%v' action to all versions", req.Action))
+		}
+	}
+	return nil
+}
+
+func (b bulkVersionOperationServiceImpl) runOperation(ctx context.SecurityContext, packageId string, req view.BulkVersionOperationReq, operationEnt *entity.BulkVersionOperationEntity) {
+	// the operation must not stay in running status forever if processing panics
+	defer func() {
+		if err := recover(); err != nil {
+			log.Errorf("Bulk version operation %v failed with panic: %v", operationEnt.OperationId, err)
+			log.Tracef("Stacktrace: %v", string(debug.Stack()))
+			b.updateOperation(operationEnt, string(view.StatusError), fmt.Sprintf("internal server error: %v", err))
+		}
+	}()
+	report := [][]string{{"packageId", "version", "status", "result"}}
+	processed, skipped, failed := 0, 0, 0
+	addResult := func(packageId string, version string, status string, result string, err error) {
+		switch {
+		case err != nil:
+			failed++
+			result = err.Error()
+		case result == bulkVersionResultOk:
+			processed++
+		default:
+			skipped++
+		}
+		report = append(report, []string{packageId, version, status, result})
+	}
+
+	if req.Filter != nil {
+		versionEnts, err := b.repo.GetVersions(packageId, *req.Filter)
+		if err != nil {
+			b.updateOperation(operationEnt, string(view.StatusError), fmt.Sprintf("failed to get versions: %v", err.Error()))
+			return
+		}
+		for _, versionEnt := range versionEnts {
+			result, err := b.processVersion(ctx, req, versionEnt)
+			addResult(versionEnt.PackageId, versionEnt.Version, versionEnt.Status, result, err)
+		}
+	} else {
+		for _, ref := range req.Versions {
+			versionEnt, err := b.publishedRepo.GetVersion(ref.PackageId, ref.Version)
+			if err != nil {
+				addResult(ref.PackageId, ref.Version, "", "", err)
+				continue
+			}
+			if versionEnt == nil {
+				addResult(ref.PackageId, ref.Version, "", "skipped: version not found", nil)
+				continue
+			}
+			result, err := b.processVersion(ctx, req, *versionEnt)
+			addResult(versionEnt.PackageId, versionEnt.Version, versionEnt.Status, result, err)
+		}
+	}
+
+	var err error
+	operationEnt.Report, err = csvToBytes(report, ',')
+	if err != nil {
+		b.updateOperation(operationEnt, string(view.StatusError), fmt.Sprintf("internal server error: failed to generate csv report: %v", err.Error()))
+		return
+	}
+	if len(report) == 1 {
+		b.updateOperation(operationEnt, string(view.StatusComplete), "no versions matched")
+		return
+	}
+	b.updateOperation(operationEnt, string(view.StatusComplete),
+		fmt.Sprintf("%v versions processed, %v skipped, %v failed", processed, skipped, failed))
+}
+
+// processVersion checks the user permissions for the particular version and applies the action to it.
+// Returns either bulkVersionResultOk or the reason why the version was skipped.
+func (b bulkVersionOperationServiceImpl) processVersion(ctx context.SecurityContext, req view.BulkVersionOperationReq, versionEnt entity.PublishedVersionEntity) (string, error) {
+	var sufficientPrivileges bool
+	var err error
+	if req.Action == view.BulkVersionActionArchive {
+		if versionEnt.Status == string(view.Archived) {
+			return "skipped: version is already archived", nil
+		}
+		sufficientPrivileges, err = b.roleService.HasVersionStatusTransitionPermission(ctx, versionEnt.PackageId, versionEnt.Status, string(view.Archived))
+	} else {
+		sufficientPrivileges, err = b.roleService.HasManageVersionPermission(ctx, versionEnt.PackageId, versionEnt.Status)
+	}
+	if err != nil {
+		return "", err
+	}
+	if !sufficientPrivileges {
+		return "skipped: insufficient privileges", nil
+	}
+
+	switch req.Action {
+	case view.BulkVersionActionArchive:
+		status := string(view.Archived)
+		promotionRequest, err := b.versionPromotionService.RequestPromotion(ctx, versionEnt.PackageId, versionEnt.Version, status)
+		if err != nil {
+			return "", err
+		}
+		if promotionRequest != nil {
+			return "skipped: promotion to archived status is requested", nil
+		}
+		_, err = b.versionService.PatchVersion(ctx, versionEnt.PackageId, versionEnt.Version, &status, nil)
+		if err != nil {
+			return "", err
+		}
+	case view.BulkVersionActionAddLabel:
+		if utils.SliceContains(versionEnt.Labels, req.Label) {
+			return "skipped: version already has the label", nil
+		}
+		labels := append(append(make([]string, 0, len(versionEnt.Labels)+1), versionEnt.Labels...), req.Label)
+		_, err = b.versionService.PatchVersion(ctx, versionEnt.PackageId, versionEnt.Version, nil, &labels)
+		if err != nil {
+			return "", err
+		}
+	case view.BulkVersionActionRemoveLabel:
+		if !utils.SliceContains(versionEnt.Labels, req.Label) {
+			return "skipped: version does not have the label", nil
+		}
+		labels := make([]string, 0, len(versionEnt.Labels))
+		for _, label := range versionEnt.Labels {
+			if label != req.Label {
+				labels = append(labels, label)
+			}
+		}
+		_, err = b.versionService.PatchVersion(ctx, versionEnt.PackageId, versionEnt.Version, nil, &labels)
+		if err != nil {
+			return "", err
+		}
+	case view.BulkVersionActionDelete:
+		err = b.versionService.DeleteVersion(ctx, versionEnt.PackageId, versionEnt.Version)
+		if err != nil {
+			return "", err
+		}
+	}
+	return bulkVersionResultOk, nil
+}
+
+func (b bulkVersionOperationServiceImpl) updateOperation(operationEnt *entity.BulkVersionOperationEntity, status string, message string) {
+	operationEnt.Status = status
+	operationEnt.Message = message
+	err := b.repo.UpdateOperation(operationEnt)
+	if err != nil {
+		log.Errorf("failed to update bulk version operation %v: %v", operationEnt.OperationId, err.Error())
+	}
+}
+
+func (b bulkVersionOperationServiceImpl) GetOperationStatus(packageId string, operationId string) (*view.BulkVersionOperationStatus, error) {
+	operationEnt, err := b.repo.GetOperation(packageId, operationId)
+	if err != nil {
+		return nil, err
+	}
+	if operationEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.BulkVersionOperationNotFound,
+			Message: exception.BulkVersionOperationNotFoundMsg,
+			Params:  map[string]interface{}{"operationId": operationId},
+		}
+	}
+	return entity.MakeBulkVersionOperationStatusView(*operationEnt), nil
+}
+
+func (b bulkVersionOperationServiceImpl) GetOperationReport(packageId string, operationId string) ([]byte, error) {
+	operationEnt, err := b.repo.GetOperationReport(packageId, operationId)
+	if err != nil {
+		return nil, err
+	}
+	if operationEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.BulkVersionOperationNotFound,
+			Message: exception.BulkVersionOperationNotFoundMsg,
+			Params:  map[string]interface{}{"operationId": operationId},
+		}
+	}
+	return operationEnt.Report, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkVersionOperationPanicFailsOperation(t *testing.T) {
+	repo := &mockBulkVersionOperationRepository{
+		versions: []entity.PublishedVersionEntity{{PackageId: "ws.pkg", Version: "2024.1", Status: "draft"}},
+	}
+	service := bulkVersionOperationServiceImpl{
+		repo: repo,
+		// HasManageVersionPermission is not implemented by the mock and panics
+		roleService: mockRoleService{},
+	}
+	operationEnt := &entity.BulkVersionOperationEntity{OperationId: "op", Status: string(view.StatusRunning)}
+	req := view.BulkVersionOperationReq{Action: view.BulkVersionActionDelete, Filter: &view.BulkVersionFilter{All: true}}
+
+	assert.NotPanics(t, func() {
+		service.runOperation(mockSecurityContext{userId: "user"}, "ws.pkg", req, operationEnt)
+	})
+	if assert.NotNil(t, repo.updated) {
+		assert.Equal(t, string(view.StatusError), repo.updated.Status)
+		assert.Contains(t, repo.updated.Message, "internal server error")
+	}
+}
+
+type mockBulkVersionOperationRepository struct {
+	repository.BulkVersionOperationRepository
+	versions []entity.PublishedVersionEntity
+	updated  *entity.BulkVersionOperationEntity
+}
+
+func (m *mockBulkVersionOperationRepository) GetVersions(rootPackageId string, filter view.BulkVersionFilter) ([]entity.PublishedVersionEntity, error) {
+	return m.versions, nil
+}
+
+func (m *mockBulkVersionOperationRepository) UpdateOperation(ent *entity.BulkVersionOperationEntity) error {
+	updated := *ent
+	m.updated = &updated
+	return nil
+}
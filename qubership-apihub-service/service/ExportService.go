@@ -1,6 +1,8 @@
 package service
 
 import (
+	goctx "context"
+	"errors"
 	"fmt"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/archive"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
@@ -27,9 +29,10 @@ type ExportService interface {
 	StoreExportResult(userId string, exportId string, buildResult []byte, fileName string, buildConfig view.BuildConfig) error
 }
 
-func NewExportService(exportRepository repository.ExportResultRepository, buildService BuildService, packageExportConfigService PackageExportConfigService) ExportService {
+func NewExportService(exportRepository repository.ExportResultRepository, buildService BuildService, packageExportConfigService PackageExportConfigService, blobStorage BlobStorage) ExportService {
 	return &exportServiceImpl{
 		exportRepository:           exportRepository,
+		blobStorage:                blobStorage,
 		packageExportConfigService: packageExportConfigService,
 		buildService:               buildService,
 	}
@@ -37,6 +40,7 @@ func NewExportService(exportRepository repository.ExportResultRepository, buildS
 
 type exportServiceImpl struct {
 	exportRepository repository.ExportResultRepository
+	blobStorage      BlobStorage
 
 	packageExportConfigService PackageExportConfigService
 	buildService               BuildService
@@ -48,10 +52,18 @@ func (e exportServiceImpl) StoreExportResult(userId string, exportId string, bui
 		Config:    buildConfig,
 		CreatedAt: time.Now(),
 		CreatedBy: userId,
-		Data:      buildResult,
 		Filename:  fileName,
 	}
-	err := e.exportRepository.SaveExportResult(ent)
+	err := PutBlob(goctx.Background(), e.blobStorage, view.EXPORT_RESULT_TABLE, exportId, buildResult)
+	if err != nil {
+		return fmt.Errorf("failed to store export result %s: %w", exportId, err)
+	}
+	err = e.exportRepository.SaveExportResult(ent)
+	if err != nil {
+		if deleteErr := e.blobStorage.Delete(goctx.Background(), view.EXPORT_RESULT_TABLE, []string{exportId}); deleteErr != nil {
+			log.Warnf("Failed to delete export result %s data: %s", exportId, deleteErr.Error())
+		}
+	}
 	return err
 }
 
@@ -200,7 +212,7 @@ func (e exportServiceImpl) GetAsyncExportStatus(exportId string) (*view.ExportSt
 		}, nil, build.PackageId, err
 	case view.StatusComplete:
 		break
-	case view.StatusError:
+	case view.StatusError, view.StatusCancelled:
 		return &view.ExportStatus{
 			Status:  build.Status,
 			Message: &build.Details,
@@ -218,8 +230,15 @@ func (e exportServiceImpl) GetAsyncExportStatus(exportId string) (*view.ExportSt
 		// most probably export result was already cleaned up
 		return nil, nil, "", nil
 	}
+	content, err := e.blobStorage.Get(goctx.Background(), view.EXPORT_RESULT_TABLE, exportId)
+	if err != nil {
+		if errors.Is(err, ErrBlobNotFound) {
+			return nil, nil, "", nil
+		}
+		return nil, nil, "", fmt.Errorf("failed to get export result %s data: %w", exportId, err)
+	}
 
-	return nil, &view.ExportResult{Data: resultEnt.Data, FileName: resultEnt.Filename}, build.PackageId, nil
+	return nil, &view.ExportResult{Content: content, FileName: resultEnt.Filename}, build.PackageId, nil
 }
 
 func (e exportServiceImpl) StartCleanupOldResultsJob() {
@@ -227,7 +246,10 @@ func (e exportServiceImpl) StartCleanupOldResultsJob() {
 
 	ticker := time.NewTicker(cleanupTime)
 	for range ticker.C {
-		err := e.exportRepository.CleanupExportResults(cleanupTime)
+		exportIds, err := e.exportRepository.CleanupExportResults(cleanupTime)
+		if err == nil {
+			err = e.blobStorage.Delete(goctx.Background(), view.EXPORT_RESULT_TABLE, exportIds)
+		}
 		if err != nil {
 			log.Warnf("Failed to run export result cleanup job: %s", err.Error())
 		} else {
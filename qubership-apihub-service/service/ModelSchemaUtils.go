@@ -0,0 +1,105 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"strings"
+)
+
+// modelContainsProperty checks if the model schema from the operation document contains the property path.
+// Model schema is looked up in the components sections of the document, local $refs are resolved while walking the path.
+func modelContainsProperty(document map[string]interface{}, modelName string, propertyPath []string) bool {
+	if document == nil {
+		return false
+	}
+	components, _ := document["components"].(map[string]interface{})
+	for _, section := range components {
+		sectionObj, ok := section.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if schema, ok := sectionObj[modelName].(map[string]interface{}); ok {
+			return schemaContainsProperty(document, schema, propertyPath, make(map[string]bool))
+		}
+	}
+	return false
+}
+
+func schemaContainsProperty(document map[string]interface{}, schema map[string]interface{}, propertyPath []string, visitedRefs map[string]bool) bool {
+	if len(propertyPath) == 0 {
+		return true
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		//the same ref may be valid on different levels of the path, so the level is a part of the key
+		refKey := ref + "|" + strings.Join(propertyPath, ".")
+		if visitedRefs[refKey] {
+			return false
+		}
+		visitedRefs[refKey] = true
+		refSchema := resolveLocalRef(document, ref)
+		if refSchema == nil {
+			return false
+		}
+		return schemaContainsProperty(document, refSchema, propertyPath, visitedRefs)
+	}
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		for propertyName, property := range properties {
+			if !strings.EqualFold(propertyName, propertyPath[0]) {
+				continue
+			}
+			if len(propertyPath) == 1 {
+				return true
+			}
+			if propertySchema, ok := property.(map[string]interface{}); ok &&
+				schemaContainsProperty(document, propertySchema, propertyPath[1:], visitedRefs) {
+				return true
+			}
+		}
+	}
+	for _, combiner := range []string{"allOf", "oneOf", "anyOf"} {
+		if subSchemas, ok := schema[combiner].([]interface{}); ok {
+			for _, subSchema := range subSchemas {
+				if subSchemaObj, ok := subSchema.(map[string]interface{}); ok &&
+					schemaContainsProperty(document, subSchemaObj, propertyPath, visitedRefs) {
+					return true
+				}
+			}
+		}
+	}
+	for _, nested := range []string{"items", "additionalProperties"} {
+		if nestedSchema, ok := schema[nested].(map[string]interface{}); ok &&
+			schemaContainsProperty(document, nestedSchema, propertyPath, visitedRefs) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveLocalRef returns object by local json pointer ref, e.g. "#/components/schemas/Customer"
+func resolveLocalRef(document map[string]interface{}, ref string) map[string]interface{} {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	current := document
+	for _, pathItem := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		pathItem = strings.ReplaceAll(strings.ReplaceAll(pathItem, "~1", "/"), "~0", "~")
+		next, ok := current[pathItem].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
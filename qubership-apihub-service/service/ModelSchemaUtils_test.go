@@ -0,0 +1,124 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const modelSchemaTestDocument = `{
+	"components": {
+		"schemas": {
+			"Customer": {
+				"type": "object",
+				"properties": {
+					"id": {"type": "string"},
+					"address": {"$ref": "#/components/schemas/Address"},
+					"contacts": {"type": "array", "items": {"$ref": "#/components/schemas/Contact"}},
+					"parent": {"$ref": "#/components/schemas/Customer"},
+					"attributes": {"type": "object", "additionalProperties": {"$ref": "#/components/schemas/Attribute"}}
+				}
+			},
+			"Address": {
+				"allOf": [
+					{"$ref": "#/components/schemas/Location"},
+					{"type": "object", "properties": {"zip": {"type": "string"}}}
+				]
+			},
+			"Location": {
+				"type": "object",
+				"properties": {"city": {"type": "string"}}
+			},
+			"Contact": {
+				"oneOf": [
+					{"type": "object", "properties": {"email": {"type": "string"}}},
+					{"type": "object", "properties": {"phone": {"type": "string"}}}
+				]
+			},
+			"Attribute": {
+				"type": "object",
+				"properties": {"value": {"type": "string"}}
+			},
+			"Node": {
+				"type": "object",
+				"properties": {"next": {"$ref": "#/components/schemas/Node"}}
+			},
+			"Broken": {
+				"type": "object",
+				"properties": {"remote": {"$ref": "other.json#/components/schemas/Remote"}, "missing": {"$ref": "#/components/schemas/Missing"}}
+			},
+			"a/b~c": {
+				"type": "object",
+				"properties": {"escaped": {"type": "string"}}
+			},
+			"Escaped": {"$ref": "#/components/schemas/a~1b~0c"}
+		}
+	}
+}`
+
+func TestModelContainsProperty(t *testing.T) {
+	var document map[string]interface{}
+	if err := json.Unmarshal([]byte(modelSchemaTestDocument), &document); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		model    string
+		path     string
+		expected bool
+	}{
+		{model: "Customer", path: "id", expected: true},
+		{model: "Customer", path: "ID", expected: true},
+		{model: "Customer", path: "name", expected: false},
+		{model: "Customer", path: "address.zip", expected: true},
+		{model: "Customer", path: "address.city", expected: true},
+		{model: "Customer", path: "address.street", expected: false},
+		{model: "Customer", path: "contacts.email", expected: true},
+		{model: "Customer", path: "contacts.phone", expected: true},
+		{model: "Customer", path: "attributes.value", expected: true},
+		{model: "Customer", path: "parent.parent.address.zip", expected: true},
+		{model: "Customer", path: "parent.parent.unknown", expected: false},
+		{model: "Node", path: "next.next.next", expected: true},
+		{model: "Node", path: "next.value", expected: false},
+		{model: "Broken", path: "remote.id", expected: false},
+		{model: "Broken", path: "missing.id", expected: false},
+		{model: "Escaped", path: "escaped", expected: true},
+		{model: "Unknown", path: "id", expected: false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, modelContainsProperty(document, test.model, strings.Split(test.path, ".")), test.model+"."+test.path)
+	}
+	assert.False(t, modelContainsProperty(nil, "Customer", []string{"id"}))
+}
+
+func TestSchemaContainsPropertyCycle(t *testing.T) {
+	var document map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"components": {
+			"schemas": {
+				"A": {"$ref": "#/components/schemas/B"},
+				"B": {"allOf": [{"$ref": "#/components/schemas/A"}]}
+			}
+		}
+	}`), &document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, modelContainsProperty(document, "A", []string{"id"}))
+	assert.True(t, schemaContainsProperty(document, map[string]interface{}{}, []string{}, make(map[string]bool)))
+}
@@ -48,13 +48,14 @@ type OperationGroupService interface {
 }
 
 func NewOperationGroupService(operationRepository repository.OperationRepository, publishedRepo repository.PublishedRepository, exportRepository repository.ExportResultRepository,
-	packageVersionEnrichmentService PackageVersionEnrichmentService, activityTrackingService ActivityTrackingService) OperationGroupService {
+	packageVersionEnrichmentService PackageVersionEnrichmentService, activityTrackingService ActivityTrackingService, versionLockService VersionLockService) OperationGroupService {
 	return &operationGroupServiceImpl{
 		operationRepo:                   operationRepository,
 		publishedRepo:                   publishedRepo,
 		exportRepository:                exportRepository,
 		packageVersionEnrichmentService: packageVersionEnrichmentService,
 		atService:                       activityTrackingService,
+		versionLockService:              versionLockService,
 	}
 }
 
@@ -64,6 +65,7 @@ type operationGroupServiceImpl struct {
 	exportRepository                repository.ExportResultRepository
 	packageVersionEnrichmentService PackageVersionEnrichmentService
 	atService                       ActivityTrackingService
+	versionLockService              VersionLockService
 	buildService                    BuildService
 }
 
@@ -90,19 +92,31 @@ func (o operationGroupServiceImpl) CheckOperationGroupExists(packageId string, v
 	}
 }
 
-func (o operationGroupServiceImpl) CreateOperationGroup_deprecated(packageId string, version string, apiType string, createReq view.CreateOperationGroupReq_deprecated) error {
+// getUnlockedVersion returns the version to change its operation groups, the version must exist and must not be locked
+func (o operationGroupServiceImpl) getUnlockedVersion(packageId string, version string) (*entity.PublishedVersionEntity, error) {
 	versionEnt, err := o.publishedRepo.GetVersion(packageId, version)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if versionEnt == nil {
-		return &exception.CustomError{
+		return nil, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.PublishedPackageVersionNotFound,
 			Message: exception.PublishedPackageVersionNotFoundMsg,
 			Params:  map[string]interface{}{"version": version, "packageId": packageId},
 		}
 	}
+	if err = o.versionLockService.CheckVersionNotLocked(versionEnt.PackageId, versionEnt.Version, versionEnt.Status); err != nil {
+		return nil, err
+	}
+	return versionEnt, nil
+}
+
+func (o operationGroupServiceImpl) CreateOperationGroup_deprecated(packageId string, version string, apiType string, createReq view.CreateOperationGroupReq_deprecated) error {
+	versionEnt, err := o.getUnlockedVersion(packageId, version)
+	if err != nil {
+		return err
+	}
 	if createReq.GroupName == "" {
 		return &exception.CustomError{
 			Status:  http.StatusBadRequest,
@@ -138,18 +152,10 @@ func (o operationGroupServiceImpl) CreateOperationGroup_deprecated(packageId str
 }
 
 func (o operationGroupServiceImpl) CreateOperationGroup(ctx context.SecurityContext, packageId string, version string, apiType string, createReq view.CreateOperationGroupReq) error {
-	versionEnt, err := o.publishedRepo.GetVersion(packageId, version)
+	versionEnt, err := o.getUnlockedVersion(packageId, version)
 	if err != nil {
 		return err
 	}
-	if versionEnt == nil {
-		return &exception.CustomError{
-			Status:  http.StatusNotFound,
-			Code:    exception.PublishedPackageVersionNotFound,
-			Message: exception.PublishedPackageVersionNotFoundMsg,
-			Params:  map[string]interface{}{"version": version, "packageId": packageId},
-		}
-	}
 	if createReq.GroupName == "" {
 		return &exception.CustomError{
 			Status:  http.StatusBadRequest,
@@ -213,18 +219,10 @@ func (o operationGroupServiceImpl) CreateOperationGroup(ctx context.SecurityCont
 }
 
 func (o operationGroupServiceImpl) ReplaceOperationGroup_deprecated(packageId string, version string, apiType string, groupName string, replaceReq view.ReplaceOperationGroupReq_deprecated) error {
-	versionEnt, err := o.publishedRepo.GetVersion(packageId, version)
+	versionEnt, err := o.getUnlockedVersion(packageId, version)
 	if err != nil {
 		return err
 	}
-	if versionEnt == nil {
-		return &exception.CustomError{
-			Status:  http.StatusNotFound,
-			Code:    exception.PublishedPackageVersionNotFound,
-			Message: exception.PublishedPackageVersionNotFoundMsg,
-			Params:  map[string]interface{}{"version": version, "packageId": packageId},
-		}
-	}
 	existingGroup, err := o.operationRepo.GetOperationGroup(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision, apiType, groupName)
 	if err != nil {
 		return err
@@ -349,18 +347,10 @@ func (o operationGroupServiceImpl) ReplaceOperationGroup_deprecated(packageId st
 }
 
 func (o operationGroupServiceImpl) ReplaceOperationGroup(ctx context.SecurityContext, packageId string, version string, apiType string, groupName string, replaceReq view.ReplaceOperationGroupReq) error {
-	versionEnt, err := o.publishedRepo.GetVersion(packageId, version)
+	versionEnt, err := o.getUnlockedVersion(packageId, version)
 	if err != nil {
 		return err
 	}
-	if versionEnt == nil {
-		return &exception.CustomError{
-			Status:  http.StatusNotFound,
-			Code:    exception.PublishedPackageVersionNotFound,
-			Message: exception.PublishedPackageVersionNotFoundMsg,
-			Params:  map[string]interface{}{"version": version, "packageId": packageId},
-		}
-	}
 	existingGroup, err := o.operationRepo.GetOperationGroup(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision, apiType, groupName)
 	if err != nil {
 		return err
@@ -550,18 +540,10 @@ func (o operationGroupServiceImpl) makeGroupedOperationEntities(versionEnt *enti
 }
 
 func (o operationGroupServiceImpl) UpdateOperationGroup_deprecated(packageId string, version string, apiType string, groupName string, updateReq view.UpdateOperationGroupReq_deprecated) error {
-	versionEnt, err := o.publishedRepo.GetVersion(packageId, version)
+	versionEnt, err := o.getUnlockedVersion(packageId, version)
 	if err != nil {
 		return err
 	}
-	if versionEnt == nil {
-		return &exception.CustomError{
-			Status:  http.StatusNotFound,
-			Code:    exception.PublishedPackageVersionNotFound,
-			Message: exception.PublishedPackageVersionNotFoundMsg,
-			Params:  map[string]interface{}{"version": version, "packageId": packageId},
-		}
-	}
 	existingGroup, err := o.operationRepo.GetOperationGroup(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision, apiType, groupName)
 	if err != nil {
 		return err
@@ -617,18 +599,10 @@ func (o operationGroupServiceImpl) UpdateOperationGroup_deprecated(packageId str
 }
 
 func (o operationGroupServiceImpl) UpdateOperationGroup(ctx context.SecurityContext, packageId string, version string, apiType string, groupName string, updateReq view.UpdateOperationGroupReq) error {
-	versionEnt, err := o.publishedRepo.GetVersion(packageId, version)
+	versionEnt, err := o.getUnlockedVersion(packageId, version)
 	if err != nil {
 		return err
 	}
-	if versionEnt == nil {
-		return &exception.CustomError{
-			Status:  http.StatusNotFound,
-			Code:    exception.PublishedPackageVersionNotFound,
-			Message: exception.PublishedPackageVersionNotFoundMsg,
-			Params:  map[string]interface{}{"version": version, "packageId": packageId},
-		}
-	}
 	existingGroup, err := o.operationRepo.GetOperationGroup(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision, apiType, groupName)
 	if err != nil {
 		return err
@@ -752,18 +726,10 @@ func (o operationGroupServiceImpl) UpdateOperationGroup(ctx context.SecurityCont
 }
 
 func (o operationGroupServiceImpl) DeleteOperationGroup(ctx context.SecurityContext, packageId string, version string, apiType string, groupName string) error {
-	versionEnt, err := o.publishedRepo.GetVersion(packageId, version)
+	versionEnt, err := o.getUnlockedVersion(packageId, version)
 	if err != nil {
 		return err
 	}
-	if versionEnt == nil {
-		return &exception.CustomError{
-			Status:  http.StatusNotFound,
-			Code:    exception.PublishedPackageVersionNotFound,
-			Message: exception.PublishedPackageVersionNotFoundMsg,
-			Params:  map[string]interface{}{"version": version, "packageId": packageId},
-		}
-	}
 	existingGroup, err := o.operationRepo.GetOperationGroup(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision, apiType, groupName)
 	if err != nil {
 		return err
@@ -15,8 +15,10 @@
 package service
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
@@ -25,6 +27,7 @@ import (
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
 	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	log "github.com/sirupsen/logrus"
 )
 
 type OperationService interface {
@@ -37,6 +40,7 @@ type OperationService interface {
 	GetVersionChanges(packageId string, version string, apiType string, searchReq view.VersionChangesReq) (*view.VersionChangesView, error)
 	SearchForOperations_deprecated(searchReq view.SearchQueryReq) (*view.SearchResult_deprecated, error)
 	SearchForOperations(searchReq view.SearchQueryReq) (*view.SearchResult, error)
+	SearchForModels(searchReq view.SearchQueryReq) (*view.SearchResult, error)
 	GetDeprecatedOperations(packageId string, version string, searchReq view.DeprecatedOperationListReq) (*view.Operations, error)
 	GetOperationDeprecatedItems(searchReq view.OperationBasicSearchReq) (*view.DeprecatedItems, error)
 	GetDeprecatedOperationsSummary(packageId string, version string) (*view.DeprecatedOperationsSummary, error)
@@ -840,6 +844,14 @@ func (o operationServiceImpl) SearchForOperations(searchReq view.SearchQueryReq)
 		VersionArchivedStatus:       string(view.Archived),
 		VersionArchivedStatusWeight: 0.1,
 	}
+	var facets *view.SearchFacets
+	if searchReq.Facets {
+		facetEntities, err := o.operationRepository.GetOperationSearchFacets(searchQuery, searchFacetLimit)
+		if err != nil {
+			return nil, err
+		}
+		facets = entity.MakeSearchFacetsView(facetEntities)
+	}
 	operationEntities, err := o.operationRepository.SearchForOperations(searchQuery)
 	if err != nil {
 		return nil, err
@@ -849,7 +861,113 @@ func (o operationServiceImpl) SearchForOperations(searchReq view.SearchQueryReq)
 		operations = append(operations, entity.MakeOperationSearchResultView(ent))
 	}
 
-	return &view.SearchResult{Operations: &operations}, nil
+	return &view.SearchResult{Operations: &operations, Facets: facets}, nil
+}
+
+const searchFacetLimit = 20
+
+func (o operationServiceImpl) SearchForModels(searchReq view.SearchQueryReq) (*view.SearchResult, error) {
+	searchQuery, err := entity.MakeModelSearchQueryEntity(&searchReq)
+	if err != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidSearchParameters,
+			Message: exception.InvalidSearchParametersMsg,
+			Params:  map[string]interface{}{"error": err.Error()},
+		}
+	}
+	modelEntities, err := o.operationRepository.SearchForModels(searchQuery)
+	if err != nil {
+		return nil, err
+	}
+	if len(searchQuery.PropertyPath) > 0 {
+		modelEntities, err = o.filterModelsByPropertyPath(modelEntities, searchQuery.PropertyPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	models := make([]view.ModelSearchResult, 0)
+	versionIndex := make(map[string]int)
+	for _, ent := range modelEntities {
+		versionKey := fmt.Sprintf("%v|%v|%v", ent.PackageId, ent.Version, ent.Revision)
+		idx, exists := versionIndex[versionKey]
+		if !exists {
+			idx = len(models)
+			versionIndex[versionKey] = idx
+			models = append(models, view.ModelSearchResult{
+				PackageId:      ent.PackageId,
+				PackageName:    ent.PackageName,
+				ParentPackages: ent.ParentNames,
+				Version:        view.MakeVersionRefKey(ent.Version, ent.Revision),
+				VersionStatus:  ent.VersionStatus,
+				Operations:     make([]view.ModelSearchOperation, 0),
+			})
+		}
+		modelNames := make([]string, 0, len(ent.Models))
+		for modelName := range ent.Models {
+			modelNames = append(modelNames, modelName)
+		}
+		sort.Strings(modelNames)
+		models[idx].Operations = append(models[idx].Operations, view.ModelSearchOperation{
+			OperationId: ent.OperationId,
+			Title:       ent.Title,
+			ApiType:     ent.ApiType,
+			ModelNames:  modelNames,
+		})
+	}
+	return &view.SearchResult{Models: &models}, nil
+}
+
+// filterModelsByPropertyPath keeps only the models which schema contains the property path.
+// Schema is checked once per model hash since operations with the same model hash share the same model schema.
+func (o operationServiceImpl) filterModelsByPropertyPath(modelEntities []entity.ModelSearchResult, propertyPath []string) ([]entity.ModelSearchResult, error) {
+	modelDataHashes := make(map[string]string)
+	for _, ent := range modelEntities {
+		for _, modelHash := range ent.Models {
+			if _, exists := modelDataHashes[modelHash]; !exists {
+				modelDataHashes[modelHash] = ent.DataHash
+			}
+		}
+	}
+	dataHashes := make([]string, 0)
+	for _, dataHash := range modelDataHashes {
+		dataHashes = append(dataHashes, dataHash)
+	}
+	operationsData, err := o.operationRepository.GetOperationsData(utils.UniqueSet(dataHashes))
+	if err != nil {
+		return nil, err
+	}
+	documents := make(map[string]map[string]interface{})
+	for _, operationData := range operationsData {
+		var document map[string]interface{}
+		if err := json.Unmarshal(operationData.Data, &document); err != nil {
+			log.Debugf("Failed to parse operation data %v: %v", operationData.DataHash, err)
+			continue
+		}
+		documents[operationData.DataHash] = document
+	}
+
+	filteredEntities := make([]entity.ModelSearchResult, 0)
+	modelMatches := make(map[string]bool)
+	for _, ent := range modelEntities {
+		matchedModels := make(map[string]string)
+		for modelName, modelHash := range ent.Models {
+			matches, checked := modelMatches[modelHash]
+			if !checked {
+				matches = modelContainsProperty(documents[modelDataHashes[modelHash]], modelName, propertyPath)
+				modelMatches[modelHash] = matches
+			}
+			if matches {
+				matchedModels[modelName] = modelHash
+			}
+		}
+		if len(matchedModels) > 0 {
+			ent.Models = matchedModels
+			filteredEntities = append(filteredEntities, ent)
+		}
+	}
+	return filteredEntities, nil
 }
 
 func setOperationSearchParams(operationParams *view.OperationSearchParams, searchQuery *entity.OperationSearchQuery) error {
@@ -862,6 +980,8 @@ func setOperationSearchParams(operationParams *view.OperationSearchParams, searc
 		return setRestOperationSearchParams(operationParams, searchQuery)
 	case string(view.GraphqlApiType):
 		return setGraphqlOperationSearchParams(operationParams, searchQuery)
+	case string(view.ProtobufApiType):
+		return setProtobufOperationSearchParams(operationParams, searchQuery)
 	default:
 		return &exception.CustomError{
 			Status:  http.StatusBadRequest,
@@ -964,6 +1084,45 @@ func setGraphqlOperationSearchParams(graphqlOperationParams *view.OperationSearc
 	return nil
 }
 
+func setProtobufOperationSearchParams(protobufOperationParams *view.OperationSearchParams, searchQuery *entity.OperationSearchQuery) error {
+	searchQuery.ApiType = protobufOperationParams.ApiType
+	for _, operationType := range protobufOperationParams.OperationTypes {
+		if !view.ValidProtobufOperationType(operationType) {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidSearchParameters,
+				Message: exception.InvalidSearchParametersMsg,
+				Params:  map[string]interface{}{"error": fmt.Sprintf("operation type %v is invalid for %v apiType", operationType, protobufOperationParams.ApiType)},
+			}
+		}
+	}
+	searchQuery.OperationTypes = append(searchQuery.OperationTypes, protobufOperationParams.OperationTypes...)
+	if len(protobufOperationParams.Scopes) == 0 {
+		searchQuery.FilterAll = true
+	} else {
+		for _, s := range protobufOperationParams.Scopes {
+			switch s {
+			case view.ProtobufScopeRequest:
+				searchQuery.FilterRequest = true
+			case view.ProtobufScopeResponse:
+				searchQuery.FilterResponse = true
+			case view.ProtobufScopeAnnotation:
+				searchQuery.FilterAnnotation = true
+			case view.ProtobufScopeField:
+				searchQuery.FilterField = true
+			default:
+				return &exception.CustomError{
+					Status:  http.StatusBadRequest,
+					Code:    exception.InvalidSearchParameters,
+					Message: exception.InvalidSearchParametersMsg,
+					Params:  map[string]interface{}{"error": fmt.Sprintf("scope %v is invalid for %v apiType", s, protobufOperationParams.ApiType)},
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (o operationServiceImpl) GetOperationModelUsages(packageId string, version string, apiType string, operationId string, modelName string) (*view.OperationModelUsages, error) {
 	versionEnt, err := o.publishedRepo.GetVersion(packageId, version)
 	if err != nil {
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchForOperationsFacets(t *testing.T) {
+	facetEnts := []entity.SearchFacetEntity{
+		{Facet: "total", Count: 42},
+		{Facet: "packagesCount", Count: 3},
+		{Facet: "package", Value: "ws.pkg", Name: "Package", Count: 30},
+		{Facet: "status", Value: "release", Count: 40},
+	}
+	tests := []struct {
+		name                 string
+		req                  view.SearchQueryReq
+		facetsErr            error
+		expectedFacetsCalled bool
+		expectedFacets       *view.SearchFacets
+		expectedErrorCode    string
+		expectedError        error
+	}{
+		{
+			name: "facets are not requested",
+			req:  view.SearchQueryReq{SearchString: "user", Limit: 10},
+		},
+		{
+			name:                 "facets are requested",
+			req:                  view.SearchQueryReq{SearchString: "user", Limit: 10, Facets: true},
+			expectedFacetsCalled: true,
+			expectedFacets:       entity.MakeSearchFacetsView(facetEnts),
+		},
+		{
+			name:                 "facets of structured query",
+			req:                  view.SearchQueryReq{SearchString: "method:get user", Limit: 10, Facets: true},
+			expectedFacetsCalled: true,
+			expectedFacets:       entity.MakeSearchFacetsView(facetEnts),
+		},
+		{
+			name:                 "facets error fails the search",
+			req:                  view.SearchQueryReq{SearchString: "user", Limit: 10, Facets: true},
+			facetsErr:            errors.New("db error"),
+			expectedFacetsCalled: true,
+			expectedError:        errors.New("db error"),
+		},
+		{
+			name:              "invalid structured query is rejected before facets",
+			req:               view.SearchQueryReq{SearchString: "deprecated:maybe", Limit: 10, Facets: true},
+			expectedErrorCode: exception.InvalidSearchParameters,
+		},
+		{
+			name:              "invalid operation params are rejected before facets",
+			req:               view.SearchQueryReq{SearchString: "user", Limit: 10, Facets: true, OperationSearchParams: &view.OperationSearchParams{ApiType: "soap"}},
+			expectedErrorCode: exception.InvalidSearchParameters,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockOperationRepository{facetEnts: facetEnts, facetsErr: tt.facetsErr}
+			service := operationServiceImpl{operationRepository: repo}
+			result, err := service.SearchForOperations(tt.req)
+			assert.Equal(t, tt.expectedFacetsCalled, repo.facetsQuery != nil)
+			if tt.expectedErrorCode != "" {
+				if assert.IsType(t, &exception.CustomError{}, err) {
+					assert.Equal(t, tt.expectedErrorCode, err.(*exception.CustomError).Code)
+					assert.Equal(t, http.StatusBadRequest, err.(*exception.CustomError).Status)
+				}
+				assert.Nil(t, repo.searchQuery)
+				return
+			}
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, repo.searchQuery, "operations must not be searched if facets failed")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedFacets, result.Facets)
+			if tt.expectedFacetsCalled {
+				assert.Equal(t, searchFacetLimit, repo.facetLimit)
+				assert.Same(t, repo.searchQuery, repo.facetsQuery, "facets must be calculated for the same query as operations")
+			}
+		})
+	}
+}
+
+func TestMakeSearchFacetsView(t *testing.T) {
+	empty := func() []view.SearchFacetValue { return make([]view.SearchFacetValue, 0) }
+	tests := []struct {
+		name     string
+		ents     []entity.SearchFacetEntity
+		expected *view.SearchFacets
+	}{
+		{
+			name: "no matches",
+			expected: &view.SearchFacets{
+				Packages: empty(), ParentGroups: empty(), Statuses: empty(), ApiTypes: empty(),
+				Methods: empty(), ApiKinds: empty(), Tags: empty(), PublicationMonths: empty(),
+			},
+		},
+		{
+			name: "values keep repository order, unknown facets are ignored",
+			ents: []entity.SearchFacetEntity{
+				{Facet: "total", Count: 5},
+				{Facet: "packagesCount", Count: 2},
+				{Facet: "package", Value: "ws.b", Name: "B", Count: 3},
+				{Facet: "package", Value: "ws.a", Name: "A", Count: 2},
+				{Facet: "parentGroup", Value: "ws", Name: "Workspace", Count: 5},
+				{Facet: "status", Value: "release", Count: 5},
+				{Facet: "apiType", Value: "rest", Count: 4},
+				{Facet: "apiType", Value: "graphql", Count: 1},
+				{Facet: "method", Value: "get", Count: 4},
+				{Facet: "apiKind", Value: "bwc", Count: 5},
+				{Facet: "tag", Value: "users", Count: 2},
+				{Facet: "publicationMonth", Value: "2024-05", Count: 5},
+				{Facet: "unknown", Value: "x", Count: 1},
+			},
+			expected: &view.SearchFacets{
+				TotalCount:    5,
+				PackagesCount: 2,
+				Packages: []view.SearchFacetValue{
+					{Value: "ws.b", Name: "B", Count: 3},
+					{Value: "ws.a", Name: "A", Count: 2},
+				},
+				ParentGroups:      []view.SearchFacetValue{{Value: "ws", Name: "Workspace", Count: 5}},
+				Statuses:          []view.SearchFacetValue{{Value: "release", Count: 5}},
+				ApiTypes:          []view.SearchFacetValue{{Value: "rest", Count: 4}, {Value: "graphql", Count: 1}},
+				Methods:           []view.SearchFacetValue{{Value: "get", Count: 4}},
+				ApiKinds:          []view.SearchFacetValue{{Value: "bwc", Count: 5}},
+				Tags:              []view.SearchFacetValue{{Value: "users", Count: 2}},
+				PublicationMonths: []view.SearchFacetValue{{Value: "2024-05", Count: 5}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, entity.MakeSearchFacetsView(tt.ents))
+		})
+	}
+}
+
+type mockOperationRepository struct {
+	repository.OperationRepository
+	facetEnts   []entity.SearchFacetEntity
+	facetsErr   error
+	facetsQuery *entity.OperationSearchQuery
+	facetLimit  int
+	searchQuery *entity.OperationSearchQuery
+}
+
+func (m *mockOperationRepository) GetOperationSearchFacets(searchQuery *entity.OperationSearchQuery, facetLimit int) ([]entity.SearchFacetEntity, error) {
+	m.facetsQuery = searchQuery
+	m.facetLimit = facetLimit
+	return m.facetEnts, m.facetsErr
+}
+
+func (m *mockOperationRepository) SearchForOperations(searchQuery *entity.OperationSearchQuery) ([]entity.OperationSearchResult, error) {
+	m.searchQuery = searchQuery
+	return nil, nil
+}
@@ -0,0 +1,313 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/archive"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type PublishGateService interface {
+	GetPolicy(packageId string) (*view.PublishGatePolicy, error)
+	SetPolicy(ctx context.SecurityContext, packageId string, req view.PublishGatePolicyUpdate) error
+	DeletePolicy(packageId string) error
+
+	CheckPublish(buildArc *archive.BuildResultArchive, buildConfig *view.BuildConfig) error
+
+	ListRequests(packageId string, state string) (*view.PublishGateRequests, error)
+	ApproveRequest(ctx context.SecurityContext, packageId string, requestId string) (*view.PublishGateApproveResponse, error)
+	RejectRequest(ctx context.SecurityContext, packageId string, requestId string) error
+}
+
+func NewPublishGateService(repo repository.PublishGateRepository, packageService PackageService, buildService BuildService) PublishGateService {
+	return &publishGateServiceImpl{
+		repo:           repo,
+		packageService: packageService,
+		buildService:   buildService,
+	}
+}
+
+type publishGateServiceImpl struct {
+	repo           repository.PublishGateRepository
+	packageService PackageService
+	buildService   BuildService
+}
+
+func (p publishGateServiceImpl) GetPolicy(packageId string) (*view.PublishGatePolicy, error) {
+	if err := p.checkPackageExistence(packageId); err != nil {
+		return nil, err
+	}
+	ent, err := p.repo.GetPolicyForHierarchy(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return &view.PublishGatePolicy{
+			Action:     view.PublishGateActionNone,
+			Severities: view.DefaultPublishGateSeverities,
+		}, nil
+	}
+	return entity.MakePublishGatePolicyView(*ent, packageId), nil
+}
+
+func (p publishGateServiceImpl) SetPolicy(ctx context.SecurityContext, packageId string, req view.PublishGatePolicyUpdate) error {
+	if err := p.checkPackageExistence(packageId); err != nil {
+		return err
+	}
+	if !view.ValidPublishGateAction(req.Action) {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidPublishGateAction,
+			Message: exception.InvalidPublishGateActionMsg,
+			Params:  map[string]interface{}{"action": req.Action},
+		}
+	}
+	if len(req.Severities) == 0 {
+		req.Severities = view.DefaultPublishGateSeverities
+	}
+	severities := make([]string, 0, len(req.Severities))
+	for _, severity := range req.Severities {
+		if severity != view.Breaking && severity != view.SemiBreaking {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidPublishGateSeverity,
+				Message: exception.InvalidPublishGateSeverityMsg,
+				Params:  map[string]interface{}{"severity": severity},
+			}
+		}
+		severities = append(severities, string(severity))
+	}
+	return p.repo.SetPolicy(entity.PublishGatePolicyEntity{
+		PackageId:  packageId,
+		Action:     string(req.Action),
+		Severities: severities,
+		UpdatedBy:  ctx.GetUserId(),
+		UpdatedAt:  time.Now(),
+	})
+}
+
+func (p publishGateServiceImpl) DeletePolicy(packageId string) error {
+	if err := p.checkPackageExistence(packageId); err != nil {
+		return err
+	}
+	return p.repo.DeletePolicy(packageId)
+}
+
+// CheckPublish applies publish gate policy of the package to the release version build result.
+// Must be called after the version is inferred, approval request is not created for dry run builds.
+func (p publishGateServiceImpl) CheckPublish(buildArc *archive.BuildResultArchive, buildConfig *view.BuildConfig) error {
+	if buildArc.PackageInfo.MigrationBuild || buildArc.PackageInfo.Status != string(view.Release) {
+		return nil
+	}
+	policyEnt, err := p.repo.GetPolicyForHierarchy(buildArc.PackageInfo.PackageId)
+	if err != nil {
+		return err
+	}
+	if policyEnt == nil || policyEnt.Action == string(view.PublishGateActionNone) {
+		return nil
+	}
+
+	if err = buildArc.ReadPackageComparisons(false); err != nil {
+		return err
+	}
+	breaking, semiBreaking := 0, 0
+	for _, comparison := range buildArc.PackageComparisons.Comparisons {
+		for _, operationType := range comparison.OperationTypes {
+			breaking += operationType.ChangesSummary.Breaking
+			semiBreaking += operationType.ChangesSummary.SemiBreaking
+		}
+	}
+	triggered := false
+	for _, severity := range policyEnt.Severities {
+		if (severity == string(view.Breaking) && breaking > 0) || (severity == string(view.SemiBreaking) && semiBreaking > 0) {
+			triggered = true
+		}
+	}
+	if !triggered {
+		return nil
+	}
+
+	params := map[string]interface{}{
+		"breaking":        breaking,
+		"semiBreaking":    semiBreaking,
+		"policyPackageId": policyEnt.PackageId,
+	}
+	switch view.PublishGateAction(policyEnt.Action) {
+	case view.PublishGateActionReject:
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.PublishGateRejected,
+			Message: exception.PublishGateRejectedMsg,
+			Params:  params,
+		}
+	case view.PublishGateActionRequireOverride:
+		if buildConfig.AllowBreakingChanges {
+			return nil
+		}
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.PublishGateOverrideRequired,
+			Message: exception.PublishGateOverrideRequiredMsg,
+			Params:  params,
+		}
+	case view.PublishGateActionRequireApproval:
+		approvedRequest, err := p.repo.GetRequestByApprovedBuildId(buildConfig.PublishId)
+		if err != nil {
+			return err
+		}
+		if approvedRequest != nil {
+			return nil
+		}
+		if buildConfig.DryRun {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.PublishGateApprovalRequired,
+				Message: exception.PublishGateApprovalRequiredMsg,
+				Params:  params,
+			}
+		}
+		err = p.repo.CreateRequest(entity.PublishGateRequestEntity{
+			RequestId:           buildConfig.PublishId,
+			PackageId:           buildArc.PackageInfo.PackageId,
+			Version:             buildArc.PackageInfo.Version,
+			BreakingChanges:     breaking,
+			SemiBreakingChanges: semiBreaking,
+			State:               string(view.PublishGateRequestPending),
+			CreatedBy:           buildConfig.CreatedBy,
+			CreatedAt:           time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		params["requestId"] = buildConfig.PublishId
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.PublishGateApprovalRequired,
+			Message: exception.PublishGateApprovalRequiredMsg,
+			Params:  params,
+		}
+	}
+	return nil
+}
+
+func (p publishGateServiceImpl) ListRequests(packageId string, state string) (*view.PublishGateRequests, error) {
+	if err := p.checkPackageExistence(packageId); err != nil {
+		return nil, err
+	}
+	ents, err := p.repo.ListRequests(packageId, state)
+	if err != nil {
+		return nil, err
+	}
+	result := view.PublishGateRequests{Requests: make([]view.PublishGateRequest, 0, len(ents))}
+	for _, ent := range ents {
+		result.Requests = append(result.Requests, entity.MakePublishGateRequestView(ent))
+	}
+	return &result, nil
+}
+
+// ApproveRequest approves the pending request and starts a new build with the same config and sources,
+// the result of the new build is published regardless of the policy.
+// The new build is validated the same way as a publish, if it can't be started the request is returned to pending state.
+func (p publishGateServiceImpl) ApproveRequest(ctx context.SecurityContext, packageId string, requestId string) (*view.PublishGateApproveResponse, error) {
+	if _, err := p.getPendingRequest(packageId, requestId); err != nil {
+		return nil, err
+	}
+	newBuildId := uuid.New().String()
+	if err := p.resolveRequest(ctx, requestId, view.PublishGateRequestApproved, newBuildId); err != nil {
+		return nil, err
+	}
+	err := p.buildService.CopyBuild(ctx, requestId, newBuildId)
+	if err != nil {
+		log.Errorf("Failed to start build for approved publish gate request %s: %s", requestId, err.Error())
+		if reopenErr := p.repo.ReopenRequest(requestId, newBuildId); reopenErr != nil {
+			log.Errorf("Failed to return publish gate request %s to pending state: %s", requestId, reopenErr.Error())
+		}
+		return nil, err
+	}
+	return &view.PublishGateApproveResponse{PublishId: newBuildId}, nil
+}
+
+func (p publishGateServiceImpl) RejectRequest(ctx context.SecurityContext, packageId string, requestId string) error {
+	if _, err := p.getPendingRequest(packageId, requestId); err != nil {
+		return err
+	}
+	return p.resolveRequest(ctx, requestId, view.PublishGateRequestRejected, "")
+}
+
+func (p publishGateServiceImpl) getPendingRequest(packageId string, requestId string) (*entity.PublishGateRequestEntity, error) {
+	ent, err := p.repo.GetRequest(requestId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil || ent.PackageId != packageId {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishGateRequestNotFound,
+			Message: exception.PublishGateRequestNotFoundMsg,
+			Params:  map[string]interface{}{"requestId": requestId},
+		}
+	}
+	if ent.State != string(view.PublishGateRequestPending) {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.PublishGateRequestAlreadyResolved,
+			Message: exception.PublishGateRequestAlreadyResolvedMsg,
+			Params:  map[string]interface{}{"requestId": requestId, "state": ent.State},
+		}
+	}
+	return ent, nil
+}
+
+func (p publishGateServiceImpl) resolveRequest(ctx context.SecurityContext, requestId string, state view.PublishGateRequestState, approvedBuildId string) error {
+	resolved, err := p.repo.ResolveRequest(requestId, string(state), ctx.GetUserId(), approvedBuildId)
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		// resolved concurrently
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.PublishGateRequestAlreadyResolved,
+			Message: exception.PublishGateRequestAlreadyResolvedMsg,
+			Params:  map[string]interface{}{"requestId": requestId, "state": "resolved"},
+		}
+	}
+	return nil
+}
+
+func (p publishGateServiceImpl) checkPackageExistence(packageId string) error {
+	exists, err := p.packageService.PackageExists(packageId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	return nil
+}
@@ -21,7 +21,9 @@ import (
 	"crypto/sha512"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"sort"
@@ -50,7 +52,7 @@ import (
 type PublishedService interface {
 	GetPackageVersions(packageId string) (*view.PublishedVersions, error)
 	GetVersion(packageId string, versionName string, importFiles bool, dependFiles bool) (*view.PublishedVersion, error)
-	GetVersionSources(packageId string, versionName string) ([]byte, error)
+	GetVersionSources(packageId string, versionName string) (io.ReadCloser, error)
 	GetPublishedVersionSourceDataConfig(packageId string, versionName string) (*view.PublishedVersionSourceDataConfig, error)
 	GetPublishedVersionBuildConfig(packageId string, versionName string) (*view.BuildConfig, error)
 	GetLatestContentData_to_delete(packageId string, versionName string, contentId string) (*view.PublishedContent, *view.ContentData, error)
@@ -68,6 +70,8 @@ type PublishedService interface {
 
 	PublishPackage(buildArc *archive.BuildResultArchive, buildSrcEnt *entity.BuildSourceEntity,
 		buildConfig *view.BuildConfig, existingPackage *entity.PackageEntity) error
+	DryRunPublishPackage(buildArc *archive.BuildResultArchive, buildSrcEnt *entity.BuildSourceEntity,
+		buildConfig *view.BuildConfig) (*view.DryRunPublishResult, error)
 	PublishChanges(buildArc *archive.BuildResultArchive, publishId string) error
 }
 
@@ -81,39 +85,43 @@ func NewPublishedService(branchService BranchService,
 	operationRepo repository.OperationRepository,
 	atService ActivityTrackingService,
 	monitoringService MonitoringService,
-	minioStorageService MinioStorageService,
-	systemInfoService SystemInfoService) PublishedService {
+	blobStorage BlobStorage,
+	systemInfoService SystemInfoService,
+	buildQueueNotifier BuildQueueNotifier,
+	versionLifecycleService VersionLifecycleService) PublishedService {
 	return &publishedServiceImpl{
-		branchService:       branchService,
-		publishedRepo:       versionRepo,
-		projectsRepo:        projectsRepo,
-		buildRepository:     buildRepository,
-		gitClientProvider:   gitClientProvider,
-		websocketService:    websocketService,
-		favoritesRepo:       favoritesRepo,
-		operationRepo:       operationRepo,
-		atService:           atService,
-		monitoringService:   monitoringService,
-		minioStorageService: minioStorageService,
-		systemInfoService:   systemInfoService,
-		publishedValidator:  validation.NewPublishedValidator(versionRepo),
+		branchService:      branchService,
+		publishedRepo:      versionRepo,
+		projectsRepo:       projectsRepo,
+		buildRepository:    buildRepository,
+		gitClientProvider:  gitClientProvider,
+		websocketService:   websocketService,
+		favoritesRepo:      favoritesRepo,
+		operationRepo:      operationRepo,
+		atService:          atService,
+		monitoringService:  monitoringService,
+		blobStorage:        blobStorage,
+		systemInfoService:  systemInfoService,
+		buildQueueNotifier: buildQueueNotifier,
+		publishedValidator: validation.NewPublishedValidator(versionRepo, versionLifecycleService.ValidateStatus),
 	}
 }
 
 type publishedServiceImpl struct {
-	branchService       BranchService
-	publishedRepo       repository.PublishedRepository
-	projectsRepo        repository.PrjGrpIntRepository
-	buildRepository     repository.BuildRepository
-	gitClientProvider   GitClientProvider
-	websocketService    WsBranchService
-	favoritesRepo       repository.FavoritesRepository
-	operationRepo       repository.OperationRepository
-	atService           ActivityTrackingService
-	monitoringService   MonitoringService
-	minioStorageService MinioStorageService
-	systemInfoService   SystemInfoService
-	publishedValidator  validation.PublishedValidator
+	branchService      BranchService
+	publishedRepo      repository.PublishedRepository
+	projectsRepo       repository.PrjGrpIntRepository
+	buildRepository    repository.BuildRepository
+	gitClientProvider  GitClientProvider
+	websocketService   WsBranchService
+	favoritesRepo      repository.FavoritesRepository
+	operationRepo      repository.OperationRepository
+	atService          ActivityTrackingService
+	monitoringService  MonitoringService
+	blobStorage        BlobStorage
+	systemInfoService  SystemInfoService
+	buildQueueNotifier BuildQueueNotifier
+	publishedValidator validation.PublishedValidator
 }
 
 func (p publishedServiceImpl) GetPackageVersions(packageId string) (*view.PublishedVersions, error) {
@@ -298,7 +306,8 @@ func (p publishedServiceImpl) createRefFileId(refFile entity.PublishedContentEnt
 	return fmt.Sprintf("%s@@%s@@%s@@%s", refFile.PackageId, refFile.Version, refFile.FileId, refFile.ReferenceId)
 }
 
-func (p publishedServiceImpl) GetVersionSources(packageId string, versionName string) ([]byte, error) {
+// GetVersionSources returns the sources archive of the version, returned reader must be closed by the caller
+func (p publishedServiceImpl) GetVersionSources(packageId string, versionName string) (io.ReadCloser, error) {
 	version, err := p.publishedRepo.GetVersion(packageId, versionName)
 	if err != nil {
 		return nil, err
@@ -311,44 +320,24 @@ func (p publishedServiceImpl) GetVersionSources(packageId string, versionName st
 			Params:  map[string]interface{}{"version": versionName},
 		}
 	}
-	var srcArchive []byte
-	if p.systemInfoService.IsMinioStorageActive() && !p.systemInfoService.IsMinioStoreOnlyBuildResult() {
-		publishedSrc, err := p.publishedRepo.GetPublishedSources(packageId, version.Version, version.Revision)
-		if err != nil {
-			return nil, err
-		}
-		if publishedSrc == nil {
-			return nil, &exception.CustomError{
-				Status:  http.StatusNotFound,
-				Code:    exception.PublishedSourcesDataNotFound,
-				Message: exception.PublishedSourcesDataNotFoundMsg,
-				Params:  map[string]interface{}{"packageId": packageId, "versionName": versionName},
-			}
-		}
-		if publishedSrc.ArchiveChecksum != "" {
-			file, err := p.minioStorageService.GetFile(ctx.Background(), view.PUBLISHED_SOURCES_ARCHIVES_TABLE, publishedSrc.ArchiveChecksum)
-			if err != nil {
-				return nil, err
-			}
-			srcArchive = file
+	publishedSrc, err := p.publishedRepo.GetPublishedSources(packageId, version.Version, version.Revision)
+	if err != nil {
+		return nil, err
+	}
+	if publishedSrc == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedSourcesDataNotFound,
+			Message: exception.PublishedSourcesDataNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "versionName": versionName},
 		}
-	} else {
-		srcData, err := p.publishedRepo.GetVersionSources(packageId, version.Version, version.Revision)
-		if err != nil {
+	}
+	var srcArchive io.ReadCloser
+	if publishedSrc.ArchiveChecksum != "" {
+		srcArchive, err = p.blobStorage.Get(ctx.Background(), view.PUBLISHED_SOURCES_ARCHIVES_TABLE, publishedSrc.ArchiveChecksum)
+		if err != nil && !errors.Is(err, ErrBlobNotFound) {
 			return nil, err
 		}
-		if srcData == nil {
-			return nil, &exception.CustomError{
-				Status:  http.StatusNotFound,
-				Code:    exception.PublishedSourcesDataNotFound,
-				Message: exception.PublishedSourcesDataNotFoundMsg,
-				Params:  map[string]interface{}{"packageId": packageId, "versionName": versionName},
-			}
-		}
-		if len(srcData.Data) <= 0 {
-			return nil, fmt.Errorf("failed to read sources archive for version: %v", version.Version)
-		}
-		srcArchive = srcData.Data
 	}
 	if srcArchive == nil {
 		return nil, &exception.CustomError{
@@ -374,45 +363,28 @@ func (p publishedServiceImpl) GetPublishedVersionSourceDataConfig(packageId stri
 			Params:  map[string]interface{}{"version": versionName},
 		}
 	}
-	srcData := new(entity.PublishedSrcDataConfigEntity)
-	if p.systemInfoService.IsMinioStorageActive() && !p.systemInfoService.IsMinioStoreOnlyBuildResult() {
-		publishedSrc, err := p.publishedRepo.GetPublishedSources(packageId, version.Version, version.Revision)
-		if err != nil {
-			return nil, err
-		}
-		if publishedSrc == nil {
-			return nil, &exception.CustomError{
-				Status:  http.StatusNotFound,
-				Code:    exception.PublishedSourcesDataNotFound,
-				Message: exception.PublishedSourcesDataNotFoundMsg,
-				Params:  map[string]interface{}{"packageId": packageId, "versionName": versionName},
-			}
-		}
-		srcData = &entity.PublishedSrcDataConfigEntity{
-			PackageId:       packageId,
-			ArchiveChecksum: publishedSrc.ArchiveChecksum,
-			Config:          publishedSrc.Config,
-		}
-		if publishedSrc.ArchiveChecksum != "" {
-			src, err := p.minioStorageService.GetFile(ctx.Background(), view.PUBLISHED_SOURCES_ARCHIVES_TABLE, publishedSrc.ArchiveChecksum)
-			if err != nil {
-				return nil, err
-			}
-			srcData.Data = src
+	publishedSrc, err := p.publishedRepo.GetPublishedSources(packageId, version.Version, version.Revision)
+	if err != nil {
+		return nil, err
+	}
+	if publishedSrc == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedSourcesDataNotFound,
+			Message: exception.PublishedSourcesDataNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId, "versionName": versionName},
 		}
-	} else {
-		srcData, err = p.publishedRepo.GetPublishedVersionSourceDataConfig(packageId, version.Version, version.Revision)
+	}
+	srcData := &entity.PublishedSrcDataConfigEntity{
+		PackageId:       packageId,
+		ArchiveChecksum: publishedSrc.ArchiveChecksum,
+		Config:          publishedSrc.Config,
+	}
+	if publishedSrc.ArchiveChecksum != "" {
+		srcData.Data, err = GetBlob(ctx.Background(), p.blobStorage, view.PUBLISHED_SOURCES_ARCHIVES_TABLE, publishedSrc.ArchiveChecksum)
 		if err != nil {
 			return nil, err
 		}
-		if srcData == nil {
-			return nil, &exception.CustomError{
-				Status:  http.StatusNotFound,
-				Code:    exception.PublishedSourcesDataNotFound,
-				Message: exception.PublishedSourcesDataNotFoundMsg,
-				Params:  map[string]interface{}{"packageId": packageId, "versionName": versionName},
-			}
-		}
 	}
 	if srcData.Data == nil {
 		return nil, &exception.CustomError{
@@ -889,43 +861,42 @@ func validatePublishSources(filesFromSourcesArchive map[string]struct{}, filesFr
 	return nil
 }
 
-func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchive, buildSrcEnt *entity.BuildSourceEntity,
-	buildConfig *view.BuildConfig, existingPackage *entity.PackageEntity) error {
-
-	publishStart := time.Now()
+// readAndValidatePublishPackage reads and validates the build result, resolves version revision and returns previous version revision
+func (p publishedServiceImpl) readAndValidatePublishPackage(buildArc *archive.BuildResultArchive, buildSrcEnt *entity.BuildSourceEntity,
+	buildConfig *view.BuildConfig) (int, error) {
 	start := time.Now()
 	err := buildArc.ReadPackageDocuments(false)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	err = buildArc.ReadPackageComparisons(false)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	err = buildArc.ReadPackageOperations(false)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	err = buildArc.ReadBuilderNotifications(false)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	utils.PerfLog(time.Since(start).Milliseconds(), 400, "publishPackage: zip files read")
 
 	start = time.Now()
 	if err = p.publishedValidator.ValidatePackage(buildArc, buildConfig); err != nil {
-		return err
+		return 0, err
 	}
 	log.Debugf("Publishing package with packageId: %v; version: %v", buildArc.PackageInfo.PackageId, buildArc.PackageInfo.Version)
 	if err = validation.ValidatePublishBuildResult(buildArc); err != nil {
-		return err
+		return 0, err
 	}
 
 	checksumMap := make(map[string]struct{}, 0)
 	if len(buildSrcEnt.Source) > 0 {
 		origReader, err := zip.NewReader(bytes.NewReader(buildSrcEnt.Source), int64(len(buildSrcEnt.Source)))
 		if err != nil {
-			return fmt.Errorf("failed to read src zip, err: %w", err)
+			return 0, fmt.Errorf("failed to read src zip, err: %w", err)
 		}
 		for _, fl := range origReader.File {
 			checksumMap[fl.Name] = struct{}{}
@@ -933,21 +904,20 @@ func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchiv
 	}
 	err = validatePublishSources(checksumMap, buildConfig.Files)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	utils.PerfLog(time.Since(start).Milliseconds(), 200, "publishPackage: validate publishing package")
 
-	start = time.Now()
 	buildArc.PackageInfo.Version, buildArc.PackageInfo.Revision, err = SplitVersionRevision(buildArc.PackageInfo.Version)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if buildArc.PackageInfo.Revision == 0 {
 		buildArc.PackageInfo.Revision = 1
 		storedVersion, err := p.publishedRepo.GetVersionIncludingDeleted(buildArc.PackageInfo.PackageId, buildArc.PackageInfo.Version)
 		if err != nil {
-			return err
+			return 0, err
 		}
 		if storedVersion != nil {
 			buildArc.PackageInfo.Revision = storedVersion.Revision + 1
@@ -956,7 +926,7 @@ func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchiv
 
 	buildArc.PackageInfo.PreviousVersion, buildArc.PackageInfo.PreviousVersionRevision, err = SplitVersionRevision(buildArc.PackageInfo.PreviousVersion)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	previousVersionRevision := buildArc.PackageInfo.PreviousVersionRevision
 	if previousVersionRevision == 0 {
@@ -967,10 +937,10 @@ func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchiv
 			}
 			previousVersionEnt, err := p.publishedRepo.GetVersionIncludingDeleted(previousVersionPackageId, buildArc.PackageInfo.PreviousVersion)
 			if err != nil {
-				return err
+				return 0, err
 			}
 			if previousVersionEnt == nil {
-				return &exception.CustomError{
+				return 0, &exception.CustomError{
 					Status:  http.StatusBadRequest,
 					Code:    exception.PublishedPackageVersionNotFound,
 					Message: exception.PublishedPackageVersionNotFoundMsg,
@@ -980,7 +950,74 @@ func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchiv
 			previousVersionRevision = previousVersionEnt.Revision
 		}
 	}
+	return previousVersionRevision, nil
+}
+
+// DryRunPublishPackage runs the same validations as PublishPackage and returns the content which would be published without storing it
+func (p publishedServiceImpl) DryRunPublishPackage(buildArc *archive.BuildResultArchive, buildSrcEnt *entity.BuildSourceEntity,
+	buildConfig *view.BuildConfig) (*view.DryRunPublishResult, error) {
+	previousVersionRevision, err := p.readAndValidatePublishPackage(buildArc, buildSrcEnt, buildConfig)
+	if err != nil {
+		return nil, err
+	}
+	buildArc.PackageInfo.PreviousVersionRevision = previousVersionRevision
+	if buildArc.PackageInfo.PreviousVersion != "" && len(buildArc.PackageComparisons.Comparisons) > 0 {
+		if err = p.publishedValidator.ValidateChanges(buildArc); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &view.DryRunPublishResult{
+		PublishId:                buildSrcEnt.BuildId,
+		PackageId:                buildArc.PackageInfo.PackageId,
+		Version:                  buildArc.PackageInfo.Version,
+		Revision:                 buildArc.PackageInfo.Revision,
+		PreviousVersionPackageId: buildArc.PackageInfo.PreviousVersionPackageId,
+		PreviousVersion:          buildArc.PackageInfo.PreviousVersion,
+		PreviousVersionRevision:  buildArc.PackageInfo.PreviousVersionRevision,
+		Documents:                make([]view.DryRunDocument, 0, len(buildArc.PackageDocuments.Documents)),
+		Operations:               make([]view.DryRunOperation, 0, len(buildArc.PackageOperations.Operations)),
+		Changes:                  make([]view.VersionComparison, 0, len(buildArc.PackageComparisons.Comparisons)),
+		Notifications:            make([]view.BuilderNotification, 0, len(buildArc.BuilderNotifications.Notifications)),
+	}
+	for _, document := range buildArc.PackageDocuments.Documents {
+		result.Documents = append(result.Documents, view.DryRunDocument{
+			FileId:          document.FileId,
+			Slug:            document.Slug,
+			Title:           document.Title,
+			Type:            document.Type,
+			Format:          document.Format,
+			Filename:        document.Filename,
+			OperationsCount: len(document.OperationIds),
+		})
+	}
+	for _, operation := range buildArc.PackageOperations.Operations {
+		result.Operations = append(result.Operations, view.DryRunOperation{
+			OperationId: operation.OperationId,
+			Title:       operation.Title,
+			ApiType:     operation.ApiType,
+			ApiKind:     operation.ApiKind,
+			ApiAudience: operation.ApiAudience,
+			Deprecated:  operation.Deprecated,
+			Tags:        operation.Tags,
+		})
+	}
+	result.Changes = append(result.Changes, buildArc.PackageComparisons.Comparisons...)
+	result.BreakingChanges = view.HasBreakingChanges(result.Changes)
+	result.Notifications = append(result.Notifications, buildArc.BuilderNotifications.Notifications...)
+
+	return result, nil
+}
+
+func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchive, buildSrcEnt *entity.BuildSourceEntity,
+	buildConfig *view.BuildConfig, existingPackage *entity.PackageEntity) error {
 
+	publishStart := time.Now()
+	if _, err := p.readAndValidatePublishPackage(buildArc, buildSrcEnt, buildConfig); err != nil {
+		return err
+	}
+
+	start := time.Now()
 	refEntities, err := p.makePublishedReferencesEntities(buildArc.PackageInfo, buildArc.PackageInfo.Refs)
 	if err != nil {
 		return err
@@ -1006,7 +1043,6 @@ func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchiv
 	builderNotificationsEntities := buildArcEntitiesReader.ReadBuilderNotificationsToEntities(buildSrcEnt.BuildId)
 
 	var publishedSrcEntity *entity.PublishedSrcEntity
-	var publishedSrcArchiveEntity *entity.PublishedSrcArchiveEntity
 
 	cfgBytes, err := json.Marshal(buildSrcEnt.Config)
 	if err != nil {
@@ -1036,19 +1072,13 @@ func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchiv
 		Config:          cfgBytes,
 		ArchiveChecksum: archiveCSStr,
 	}
-	if p.systemInfoService.IsMinioStorageActive() && !p.systemInfoService.IsMinioStoreOnlyBuildResult() {
-		minioUploadStart := time.Now()
-		err = p.minioStorageService.UploadFile(ctx.Background(), view.PUBLISHED_SOURCES_ARCHIVES_TABLE, archiveCSStr, buildSrcEnt.Source)
-		if err != nil {
-			return err
-		}
-		utils.PerfLog(time.Since(minioUploadStart).Milliseconds(), 100, "publishPackage: upload sources to minio")
-	} else {
-		publishedSrcArchiveEntity = &entity.PublishedSrcArchiveEntity{
-			Checksum: archiveCSStr,
-			Data:     buildSrcEnt.Source,
-		}
+	// sources archive is content addressed, so it's safe to store it before the version is created
+	srcUploadStart := time.Now()
+	err = PutBlob(ctx.Background(), p.blobStorage, view.PUBLISHED_SOURCES_ARCHIVES_TABLE, archiveCSStr, buildSrcEnt.Source)
+	if err != nil {
+		return err
 	}
+	utils.PerfLog(time.Since(srcUploadStart).Milliseconds(), 100, "publishPackage: store sources archive")
 
 	versionLabels := make([]string, 0)
 	versionMetadata := entity.Metadata{}
@@ -1138,7 +1168,6 @@ func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchiv
 		fileDataEntities,
 		refEntities,
 		publishedSrcEntity,
-		publishedSrcArchiveEntity,
 		operationEntities,
 		operationDataEntities,
 		changedOperationEntities,
@@ -1167,7 +1196,7 @@ func (p publishedServiceImpl) PublishPackage(buildArc *archive.BuildResultArchiv
 		if versionEnt.Status == string(view.Release) {
 			p.monitoringService.IncreaseBusinessMetricCounter(buildArc.PackageInfo.CreatedBy, metrics.ReleaseVersionsPublished, versionEnt.PackageId)
 		}
-		err = p.reCalculateChangelogs(buildArc.PackageInfo)
+		err = p.reCalculateChangelogs(buildArc.PackageInfo, buildSrcEnt.BuildId)
 		if err != nil {
 			return err
 		}
@@ -1258,7 +1287,9 @@ func makePublishedReferenceUniqueKey(entity *entity.PublishedReferenceEntity) st
 	return fmt.Sprintf(`%v|@@|%v|@@|%v|@@|%v|@@|%v|@@|%v`, entity.RefPackageId, entity.RefVersion, entity.RefRevision, entity.ParentRefPackageId, entity.ParentRefVersion, entity.ParentRefRevision)
 }
 
-func (p publishedServiceImpl) reCalculateChangelogs(packageInfo view.PackageInfoFile) error {
+// reCalculateChangelogs creates changelog builds for versions which have the published one as previous version,
+// the builds are linked to the publish build to be tracked along with it
+func (p publishedServiceImpl) reCalculateChangelogs(packageInfo view.PackageInfoFile, publishId string) error {
 	versions, err := p.publishedRepo.GetVersionsByPreviousVersion(packageInfo.PackageId, packageInfo.Version)
 	if err != nil {
 		return err
@@ -1274,7 +1305,7 @@ func (p publishedServiceImpl) reCalculateChangelogs(packageInfo view.PackageInfo
 			CreatedBy:                packageInfo.CreatedBy,
 			PublishedAt:              time.Now(),
 		}
-		err := p.createChangelogBuild(buildConfig)
+		err := p.createChangelogBuild(buildConfig, publishId)
 		if err != nil {
 			return err
 		}
@@ -1358,7 +1389,7 @@ func SplitVersionRevision(version string) (string, int, error) {
 	return versionName, versionRevision, nil
 }
 
-func (p publishedServiceImpl) createChangelogBuild(config view.BuildConfig) error { //todo folder refactoring is needed. Use buildService.CreateChangelogBuild() after it
+func (p publishedServiceImpl) createChangelogBuild(config view.BuildConfig, parentBuildId string) error { //todo folder refactoring is needed. Use buildService.CreateChangelogBuild() after it
 	status := view.StatusNotStarted
 
 	buildId := config.PublishId
@@ -1376,8 +1407,11 @@ func (p publishedServiceImpl) createChangelogBuild(config view.BuildConfig) erro
 
 		CreatedBy:    config.CreatedBy,
 		RestartCount: 0,
-		Priority:     -1,
+		Priority:     view.BackgroundBuildPriority,
+
+		ParentBuildId: parentBuildId,
 	}
+	buildEnt.SetRequirements(view.MakeBuildRequirements(config, nil))
 
 	confAsMap, err := view.BuildConfigToMap(config)
 	if err != nil {
@@ -1393,5 +1427,6 @@ func (p publishedServiceImpl) createChangelogBuild(config view.BuildConfig) erro
 	if err != nil {
 		return err
 	}
+	p.buildQueueNotifier.NotifyBuildAvailable(buildEnt.BuildId)
 	return nil
 }
@@ -0,0 +1,370 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	goctx "context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	mRepository "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+type RecycleBinService interface {
+	GetItems(req view.RecycleBinItemsReq) (*view.RecycleBinItems, error)
+	GetDeletedPackage(packageId string) (*view.RecycleBinItem, error)
+	GetDeletedVersion(packageId string, version string, revision int) (*view.RecycleBinItem, error)
+
+	RestorePackage(ctx context.SecurityContext, packageId string, restoreReferences bool) (*view.RecycleBinRestoreResult, error)
+	RestoreVersion(ctx context.SecurityContext, packageId string, version string, revision int, restoreReferences bool) (*view.RecycleBinRestoreResult, error)
+	PurgePackage(packageId string) error
+	PurgeVersion(packageId string, version string, revision int) error
+
+	CreatePurgeJob(schedule string) error
+}
+
+func NewRecycleBinService(repo repository.RecycleBinRepository,
+	publishedRepo repository.PublishedRepository,
+	migrationRepository mRepository.MigrationRunRepository,
+	atService ActivityTrackingService,
+	systemInfoService SystemInfoService,
+	blobStorage BlobStorage) RecycleBinService {
+	return &recycleBinServiceImpl{
+		repo:                repo,
+		publishedRepo:       publishedRepo,
+		migrationRepository: migrationRepository,
+		atService:           atService,
+		blobStorage:         blobStorage,
+		gracePeriodDays:     systemInfoService.GetRecycleBinGracePeriodDays(),
+		cron:                cron.New(),
+	}
+}
+
+type recycleBinServiceImpl struct {
+	repo                repository.RecycleBinRepository
+	publishedRepo       repository.PublishedRepository
+	migrationRepository mRepository.MigrationRunRepository
+	atService           ActivityTrackingService
+	blobStorage         BlobStorage
+	gracePeriodDays     int
+	cron                *cron.Cron
+}
+
+func (r *recycleBinServiceImpl) GetItems(req view.RecycleBinItemsReq) (*view.RecycleBinItems, error) {
+	if req.PackageId != "" {
+		if _, err := r.getLivePackage(req.PackageId); err != nil {
+			return nil, err
+		}
+	}
+	ents, err := r.repo.GetItems(req.PackageId, string(req.Kind), req.Limit, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.RecycleBinItems{Items: make([]view.RecycleBinItem, 0, len(ents))}
+	for _, ent := range ents {
+		result.Items = append(result.Items, entity.MakeRecycleBinItemView(ent, r.gracePeriodDays))
+	}
+	return result, nil
+}
+
+func (r *recycleBinServiceImpl) GetDeletedPackage(packageId string) (*view.RecycleBinItem, error) {
+	ent, err := r.publishedRepo.GetPackageIncludingDeleted(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil || ent.DeletedAt == nil {
+		return nil, recycleBinItemNotFoundError(fmt.Sprintf("Package '%s'", packageId))
+	}
+	item := entity.MakeRecycleBinItemView(entity.RecycleBinItemEntity{
+		Kind:        string(view.RecycleBinPackage),
+		PackageId:   ent.Id,
+		PackageName: ent.Name,
+		PackageKind: ent.Kind,
+		DeletedAt:   *ent.DeletedAt,
+		DeletedBy:   ent.DeletedBy,
+	}, r.gracePeriodDays)
+	return &item, nil
+}
+
+// GetDeletedVersion returns the deleted revision or, if revision is not set, the deleted version.
+// The version is considered deleted only if all its revisions are deleted.
+func (r *recycleBinServiceImpl) GetDeletedVersion(packageId string, version string, revision int) (*view.RecycleBinItem, error) {
+	packageEnt, err := r.getLivePackage(packageId)
+	if err != nil {
+		return nil, err
+	}
+	revisionEnts, err := r.repo.GetVersionRevisions(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	var versionEnt *entity.PublishedVersionEntity
+	if revision == 0 {
+		if len(revisionEnts) == 0 {
+			return nil, recycleBinItemNotFoundError(fmt.Sprintf("Version '%s' of package '%s'", version, packageId))
+		}
+		for _, ent := range revisionEnts {
+			if ent.DeletedAt == nil {
+				return nil, &exception.CustomError{
+					Status:  http.StatusConflict,
+					Code:    exception.RecycleBinVersionNotDeleted,
+					Message: exception.RecycleBinVersionNotDeletedMsg,
+					Params:  map[string]interface{}{"version": version, "packageId": packageId},
+				}
+			}
+		}
+		versionEnt = &revisionEnts[0]
+	} else {
+		for i, ent := range revisionEnts {
+			if ent.Revision == revision && ent.DeletedAt != nil {
+				versionEnt = &revisionEnts[i]
+				break
+			}
+		}
+		if versionEnt == nil {
+			return nil, recycleBinItemNotFoundError(fmt.Sprintf("Revision '%s@%d' of package '%s'", version, revision, packageId))
+		}
+	}
+	kind := view.RecycleBinVersion
+	if revision != 0 {
+		kind = view.RecycleBinRevision
+	}
+	item := entity.MakeRecycleBinItemView(entity.RecycleBinItemEntity{
+		Kind:        string(kind),
+		PackageId:   packageEnt.Id,
+		PackageName: packageEnt.Name,
+		PackageKind: packageEnt.Kind,
+		Version:     versionEnt.Version,
+		Revision:    versionEnt.Revision,
+		Status:      versionEnt.Status,
+		DeletedAt:   *versionEnt.DeletedAt,
+		DeletedBy:   versionEnt.DeletedBy,
+	}, r.gracePeriodDays)
+	return &item, nil
+}
+
+func (r *recycleBinServiceImpl) RestorePackage(ctx context.SecurityContext, packageId string, restoreReferences bool) (*view.RecycleBinRestoreResult, error) {
+	if _, err := r.GetDeletedPackage(packageId); err != nil {
+		return nil, err
+	}
+	packageEnt, err := r.publishedRepo.GetPackageIncludingDeleted(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if packageEnt.ParentId != "" {
+		if _, err = r.getLivePackage(packageEnt.ParentId); err != nil {
+			return nil, err
+		}
+	}
+	restoredEnt, err := r.repo.RestorePackage(packageId, restoreReferences)
+	if err != nil {
+		return nil, err
+	}
+	r.atService.TrackEvent(view.ActivityTrackingEvent{
+		Type:      view.ATETRestorePackage,
+		Data:      nil,
+		PackageId: packageId,
+		Date:      time.Now(),
+		UserId:    ctx.GetUserId(),
+	})
+	return entity.MakeRecycleBinRestoreResultView(*restoredEnt), nil
+}
+
+func (r *recycleBinServiceImpl) RestoreVersion(ctx context.SecurityContext, packageId string, version string, revision int, restoreReferences bool) (*view.RecycleBinRestoreResult, error) {
+	item, err := r.GetDeletedVersion(packageId, version, revision)
+	if err != nil {
+		return nil, err
+	}
+	var restoredEnt *entity.RecycleBinRestoredEntity
+	if revision == 0 {
+		restoredEnt, err = r.repo.RestoreVersion(packageId, version, restoreReferences)
+	} else {
+		restoredEnt, err = r.repo.RestoreRevision(packageId, version, revision, restoreReferences)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.atService.TrackEvent(view.ActivityTrackingEvent{
+		Type: view.ATETRestoreVersion,
+		Data: map[string]interface{}{
+			"version":  item.Version,
+			"revision": item.Revision,
+			"status":   item.Status,
+		},
+		PackageId: packageId,
+		Date:      time.Now(),
+		UserId:    ctx.GetUserId(),
+	})
+	return entity.MakeRecycleBinRestoreResultView(*restoredEnt), nil
+}
+
+func (r *recycleBinServiceImpl) PurgePackage(packageId string) error {
+	if _, err := r.GetDeletedPackage(packageId); err != nil {
+		return err
+	}
+	purgedEnt, err := r.repo.PurgePackage(packageId)
+	if err != nil {
+		return err
+	}
+	r.deletePurgedBlobs(purgedEnt)
+	return nil
+}
+
+func (r *recycleBinServiceImpl) PurgeVersion(packageId string, version string, revision int) error {
+	if _, err := r.GetDeletedVersion(packageId, version, revision); err != nil {
+		return err
+	}
+	var purgedEnt *entity.RecycleBinPurgedEntity
+	var err error
+	if revision == 0 {
+		purgedEnt, err = r.repo.PurgeVersion(packageId, version)
+	} else {
+		purgedEnt, err = r.repo.PurgeRevision(packageId, version, revision)
+	}
+	if err != nil {
+		return err
+	}
+	r.deletePurgedBlobs(purgedEnt)
+	return nil
+}
+
+// deletePurgedBlobs removes build results and sources archives which are not needed after the purge from the blob storage.
+// DB data is already deleted at this point, so failures are only logged.
+func (r *recycleBinServiceImpl) deletePurgedBlobs(purgedEnt *entity.RecycleBinPurgedEntity) {
+	ctx := goctx.Background()
+	if len(purgedEnt.BuildIds) != 0 {
+		if err := r.blobStorage.Delete(ctx, view.BUILD_RESULT_TABLE, purgedEnt.BuildIds); err != nil {
+			log.Errorf("Failed to delete results of %d purged builds from %s blob storage: %s", len(purgedEnt.BuildIds), r.blobStorage.Type(), err.Error())
+		}
+	}
+	if len(purgedEnt.ArchiveChecksums) != 0 {
+		if err := r.blobStorage.Delete(ctx, view.PUBLISHED_SOURCES_ARCHIVES_TABLE, purgedEnt.ArchiveChecksums); err != nil {
+			log.Errorf("Failed to delete %d purged sources archives from %s blob storage: %s", len(purgedEnt.ArchiveChecksums), r.blobStorage.Type(), err.Error())
+		}
+	}
+}
+
+func (r *recycleBinServiceImpl) CreatePurgeJob(schedule string) error {
+	job := RecycleBinPurgeJob{
+		service: r,
+	}
+	if len(r.cron.Entries()) == 0 {
+		location, err := time.LoadLocation("")
+		if err != nil {
+			return err
+		}
+		r.cron = cron.New(cron.WithLocation(location))
+		r.cron.Start()
+	}
+	_, err := r.cron.AddJob(schedule, &job)
+	if err != nil {
+		log.Warnf("[RecycleBinService] Job wasn't added for schedule - %s. With error - %s", schedule, err)
+		return err
+	}
+	log.Infof("[RecycleBinService] Job was created with schedule - %s", schedule)
+	return nil
+}
+
+// purgeExpiredItems permanently deletes packages and versions which are in the recycle bin longer than the grace period.
+// Every item is deleted in a separate transaction, so the job could run on several replicas at the same time.
+func (r *recycleBinServiceImpl) purgeExpiredItems() {
+	deletedBefore := time.Now().AddDate(0, 0, -r.gracePeriodDays)
+
+	packageIds, err := r.repo.GetExpiredPackages(deletedBefore)
+	if err != nil {
+		log.Errorf("Failed to get expired packages from the recycle bin: %s", err.Error())
+		return
+	}
+	for _, packageId := range packageIds {
+		purgedEnt, err := r.repo.PurgePackage(packageId)
+		if err != nil {
+			log.Errorf("Failed to purge package %s from the recycle bin: %s", packageId, err.Error())
+			continue
+		}
+		r.deletePurgedBlobs(purgedEnt)
+	}
+
+	versionEnts, err := r.repo.GetExpiredVersions(deletedBefore)
+	if err != nil {
+		log.Errorf("Failed to get expired versions from the recycle bin: %s", err.Error())
+		return
+	}
+	for _, ent := range versionEnts {
+		purgedEnt, err := r.repo.PurgeExpiredVersion(ent.PackageId, ent.Version, deletedBefore)
+		if err != nil {
+			log.Errorf("Failed to purge version %s of package %s from the recycle bin: %s", ent.Version, ent.PackageId, err.Error())
+			continue
+		}
+		r.deletePurgedBlobs(purgedEnt)
+	}
+	log.Infof("Recycle bin purge has finished: %d packages and %d versions deleted before %s were purged", len(packageIds), len(versionEnts), deletedBefore)
+}
+
+func (r *recycleBinServiceImpl) getLivePackage(packageId string) (*entity.PackageEntity, error) {
+	ent, err := r.publishedRepo.GetPackageIncludingDeleted(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	if ent.DeletedAt != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.RecycleBinParentPackageDeleted,
+			Message: exception.RecycleBinParentPackageDeletedMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	return ent, nil
+}
+
+func recycleBinItemNotFoundError(item string) error {
+	return &exception.CustomError{
+		Status:  http.StatusNotFound,
+		Code:    exception.RecycleBinItemNotFound,
+		Message: exception.RecycleBinItemNotFoundMsg,
+		Params:  map[string]interface{}{"item": item},
+	}
+}
+
+type RecycleBinPurgeJob struct {
+	service *recycleBinServiceImpl
+}
+
+func (j RecycleBinPurgeJob) Run() {
+	migrations, err := j.service.migrationRepository.GetRunningMigrations()
+	if err != nil {
+		log.Error("Failed to check for running migrations for recycle bin purge job")
+		return
+	}
+	if len(migrations) != 0 {
+		log.Infof("Recycle bin purge was skipped at %s due to migration run", time.Now().Round(time.Second))
+		return
+	}
+	j.service.purgeExpiredItems()
+}
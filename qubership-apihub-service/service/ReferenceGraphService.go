@@ -0,0 +1,198 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type ReferenceGraphService interface {
+	GetDependents(ctx context.SecurityContext, packageId string, version string, req view.VersionDependentsReq) (*view.VersionDependents, error)
+	GetReferenceGraph(packageId string, version string) (*view.ReferenceGraph, error)
+	GetImpact(ctx context.SecurityContext, packageId string, version string) (*view.VersionImpact, error)
+}
+
+func NewReferenceGraphService(repo repository.ReferenceGraphRepository,
+	publishedRepo repository.PublishedRepository,
+	roleService RoleService,
+	packageVersionEnrichmentService PackageVersionEnrichmentService) ReferenceGraphService {
+	return &referenceGraphServiceImpl{
+		repo:                            repo,
+		publishedRepo:                   publishedRepo,
+		roleService:                     roleService,
+		packageVersionEnrichmentService: packageVersionEnrichmentService,
+	}
+}
+
+type referenceGraphServiceImpl struct {
+	repo                            repository.ReferenceGraphRepository
+	publishedRepo                   repository.PublishedRepository
+	roleService                     RoleService
+	packageVersionEnrichmentService PackageVersionEnrichmentService
+}
+
+// GetDependents matches the particular revision if it is set in the version name, otherwise any revision of the version
+func (r referenceGraphServiceImpl) GetDependents(ctx context.SecurityContext, packageId string, version string, req view.VersionDependentsReq) (*view.VersionDependents, error) {
+	versionEnt, err := r.getVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	_, revision, err := repository.SplitVersionRevision(version)
+	if err != nil {
+		return nil, err
+	}
+	dependents, err := r.getReadableDependents(ctx, versionEnt.PackageId, versionEnt.Version, revision, req.Status, req.OnlyLatestRevision)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.VersionDependents{Dependents: make([]view.VersionDependent, 0)}
+	start := req.Limit * req.Page
+	if start >= len(dependents) {
+		return result, nil
+	}
+	end := start + req.Limit
+	if end > len(dependents) {
+		end = len(dependents)
+	}
+	result.Dependents = dependents[start:end]
+	return result, nil
+}
+
+// getReadableDependents skips dependents from the packages which the user is not allowed to read
+func (r referenceGraphServiceImpl) getReadableDependents(ctx context.SecurityContext, packageId string, version string, revision int, status string, onlyLatestRevision bool) ([]view.VersionDependent, error) {
+	ents, err := r.repo.GetDependents(packageId, version, revision, status, onlyLatestRevision)
+	if err != nil {
+		return nil, err
+	}
+	readable := make(map[string]bool)
+	result := make([]view.VersionDependent, 0)
+	for _, ent := range ents {
+		canRead, checked := readable[ent.PackageId]
+		if !checked {
+			canRead, err = r.roleService.HasRequiredPermissions(ctx, ent.PackageId, view.ReadPermission)
+			if err != nil {
+				return nil, err
+			}
+			readable[ent.PackageId] = canRead
+		}
+		if canRead {
+			result = append(result, entity.MakeVersionDependentView(ent))
+		}
+	}
+	return result, nil
+}
+
+func (r referenceGraphServiceImpl) GetReferenceGraph(packageId string, version string) (*view.ReferenceGraph, error) {
+	versionEnt, err := r.getVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	refEnts, err := r.publishedRepo.GetVersionRefsV3(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision)
+	if err != nil {
+		return nil, err
+	}
+	root := view.MakePackageRefKey(versionEnt.PackageId, versionEnt.Version, versionEnt.Revision)
+	edges := make([]view.ReferenceGraphEdge, 0, len(refEnts))
+	packageVersions := map[string][]string{
+		versionEnt.PackageId: {view.MakeVersionRefKey(versionEnt.Version, versionEnt.Revision)},
+	}
+	for _, refEnt := range refEnts {
+		from := view.MakePackageRefKey(refEnt.ParentRefPackageId, refEnt.ParentRefVersion, refEnt.ParentRefRevision)
+		if from == "" {
+			from = root
+		}
+		edges = append(edges, view.ReferenceGraphEdge{
+			From:     from,
+			To:       view.MakePackageRefKey(refEnt.RefPackageId, refEnt.RefVersion, refEnt.RefRevision),
+			Excluded: refEnt.Excluded,
+		})
+		packageVersions[refEnt.RefPackageId] = append(packageVersions[refEnt.RefPackageId], view.MakeVersionRefKey(refEnt.RefVersion, refEnt.RefRevision))
+	}
+	packages, err := r.packageVersionEnrichmentService.GetPackageVersionRefsMap(packageVersions)
+	if err != nil {
+		return nil, err
+	}
+	return &view.ReferenceGraph{Root: root, Edges: edges, Packages: packages}, nil
+}
+
+// GetImpact returns the changes of the version compared with its previous version and all release dashboards which reference any revision of the version
+func (r referenceGraphServiceImpl) GetImpact(ctx context.SecurityContext, packageId string, version string) (*view.VersionImpact, error) {
+	versionEnt, err := r.getVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.VersionImpact{
+		PackageId:      versionEnt.PackageId,
+		Version:        view.MakeVersionRefKey(versionEnt.Version, versionEnt.Revision),
+		OperationTypes: make([]view.OperationType, 0),
+	}
+	if versionEnt.PreviousVersion != "" {
+		previousVersionPackageId := versionEnt.PreviousVersionPackageId
+		if previousVersionPackageId == "" {
+			previousVersionPackageId = versionEnt.PackageId
+		}
+		result.PreviousVersion = versionEnt.PreviousVersion
+		result.PreviousVersionPackageId = previousVersionPackageId
+		previousVersionEnt, err := r.publishedRepo.GetVersion(previousVersionPackageId, versionEnt.PreviousVersion)
+		if err != nil {
+			return nil, err
+		}
+		if previousVersionEnt != nil {
+			comparisonId := view.MakeVersionComparisonId(
+				versionEnt.PackageId, versionEnt.Version, versionEnt.Revision,
+				previousVersionEnt.PackageId, previousVersionEnt.Version, previousVersionEnt.Revision,
+			)
+			comparisonEnt, err := r.publishedRepo.GetVersionComparison(comparisonId)
+			if err != nil {
+				return nil, err
+			}
+			if comparisonEnt != nil && !comparisonEnt.NoContent {
+				result.OperationTypes = comparisonEnt.OperationTypes
+				for _, operationType := range comparisonEnt.OperationTypes {
+					if operationType.ChangesSummary.Breaking > 0 {
+						result.Breaking = true
+					}
+				}
+			}
+		}
+	}
+	result.Dashboards, err = r.getReadableDependents(ctx, versionEnt.PackageId, versionEnt.Version, 0, string(view.Release), true)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r referenceGraphServiceImpl) getVersion(packageId string, version string) (*entity.PublishedVersionEntity, error) {
+	versionEnt, err := r.publishedRepo.GetVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	if versionEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedPackageVersionNotFound,
+			Message: exception.PublishedPackageVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": version, "packageId": packageId},
+		}
+	}
+	return versionEnt, nil
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferenceGraphGetDependents(t *testing.T) {
+	dependents := []entity.VersionDependentEntity{
+		{PackageId: "ws.dashboard1", Version: "2024.1", Revision: 2},
+		{PackageId: "ws.dashboard1", Version: "2024.1", Revision: 1},
+		{PackageId: "ws.private", Version: "2024.1", Revision: 1},
+		{PackageId: "ws.dashboard2", Version: "2024.2", Revision: 1},
+	}
+	tests := []struct {
+		name              string
+		version           string
+		versionEnt        *entity.PublishedVersionEntity
+		dependentsErr     error
+		permissionErr     error
+		req               view.VersionDependentsReq
+		expectedRevision  int
+		expectedVersions  []string
+		expectedErrorCode string
+		expectedError     error
+	}{
+		{
+			name:              "version not found",
+			version:           "2024.1",
+			req:               view.VersionDependentsReq{Limit: 10},
+			expectedErrorCode: exception.PublishedPackageVersionNotFound,
+		},
+		{
+			name:              "invalid revision",
+			version:           "2024.1@x",
+			versionEnt:        &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.1", Revision: 1},
+			req:               view.VersionDependentsReq{Limit: 10},
+			expectedErrorCode: exception.InvalidRevisionFormat,
+		},
+		{
+			name:          "repository error",
+			version:       "2024.1",
+			versionEnt:    &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.1", Revision: 1},
+			dependentsErr: errors.New("db error"),
+			req:           view.VersionDependentsReq{Limit: 10},
+			expectedError: errors.New("db error"),
+		},
+		{
+			name:          "permission check error",
+			version:       "2024.1",
+			versionEnt:    &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.1", Revision: 1},
+			permissionErr: errors.New("roles error"),
+			req:           view.VersionDependentsReq{Limit: 10},
+			expectedError: errors.New("roles error"),
+		},
+		{
+			name:             "any revision, not readable packages skipped",
+			version:          "2024.1",
+			versionEnt:       &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.1", Revision: 3},
+			req:              view.VersionDependentsReq{Limit: 10},
+			expectedVersions: []string{"ws.dashboard1@2024.1@2", "ws.dashboard1@2024.1@1", "ws.dashboard2@2024.2@1"},
+		},
+		{
+			name:             "particular revision",
+			version:          "2024.1@2",
+			versionEnt:       &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.1", Revision: 2},
+			req:              view.VersionDependentsReq{Limit: 10},
+			expectedRevision: 2,
+			expectedVersions: []string{"ws.dashboard1@2024.1@2", "ws.dashboard1@2024.1@1", "ws.dashboard2@2024.2@1"},
+		},
+		{
+			name:             "last page is partial",
+			version:          "2024.1",
+			versionEnt:       &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.1", Revision: 1},
+			req:              view.VersionDependentsReq{Limit: 2, Page: 1},
+			expectedVersions: []string{"ws.dashboard2@2024.2@1"},
+		},
+		{
+			name:             "page out of range",
+			version:          "2024.1",
+			versionEnt:       &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.1", Revision: 1},
+			req:              view.VersionDependentsReq{Limit: 2, Page: 2},
+			expectedVersions: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleService := &mockReadPermissionRoleService{notReadable: []string{"ws.private"}, err: tt.permissionErr}
+			service := referenceGraphServiceImpl{
+				repo: &mockReferenceGraphRepository{
+					GetDependentsFunc: func(packageId string, version string, revision int, status string, onlyLatestRevision bool) ([]entity.VersionDependentEntity, error) {
+						assert.Equal(t, "ws.pkg", packageId)
+						assert.Equal(t, "2024.1", version)
+						assert.Equal(t, tt.expectedRevision, revision)
+						return dependents, tt.dependentsErr
+					},
+				},
+				publishedRepo: &mockPublishedRepository{
+					GetVersionFunc: func(packageId string, versionName string) (*entity.PublishedVersionEntity, error) {
+						return tt.versionEnt, nil
+					},
+				},
+				roleService: roleService,
+			}
+			result, err := service.GetDependents(mockSecurityContext{userId: "user"}, "ws.pkg", tt.version, tt.req)
+			if tt.expectedErrorCode != "" {
+				if assert.IsType(t, &exception.CustomError{}, err) {
+					assert.Equal(t, tt.expectedErrorCode, err.(*exception.CustomError).Code)
+				}
+				return
+			}
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			versions := make([]string, 0)
+			for _, dependent := range result.Dependents {
+				versions = append(versions, dependent.PackageId+"@"+dependent.Version)
+			}
+			assert.Equal(t, tt.expectedVersions, versions)
+			assert.Equal(t, 1, roleService.checks["ws.dashboard1"], "permissions must be checked once per package")
+		})
+	}
+}
+
+func TestReferenceGraphGetImpact(t *testing.T) {
+	breakingChanges := []view.OperationType{{ApiType: "rest", ChangesSummary: view.ChangeSummary{Breaking: 1}}}
+	nonBreakingChanges := []view.OperationType{{ApiType: "rest", ChangesSummary: view.ChangeSummary{NonBreaking: 2}}}
+	tests := []struct {
+		name                     string
+		versionEnt               *entity.PublishedVersionEntity
+		previousVersionEnt       *entity.PublishedVersionEntity
+		comparison               *entity.VersionComparisonEntity
+		expectedPreviousPackage  string
+		expectedOperationTypes   []view.OperationType
+		expectedBreaking         bool
+		expectedComparisonLookup bool
+		expectedErrorCode        string
+	}{
+		{
+			name:              "version not found",
+			expectedErrorCode: exception.PublishedPackageVersionNotFound,
+		},
+		{
+			name:                   "no previous version",
+			versionEnt:             &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.2", Revision: 1},
+			expectedOperationTypes: []view.OperationType{},
+		},
+		{
+			name:                    "previous version was deleted",
+			versionEnt:              &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.2", Revision: 1, PreviousVersion: "2024.1"},
+			expectedPreviousPackage: "ws.pkg",
+			expectedOperationTypes:  []view.OperationType{},
+		},
+		{
+			name:                     "comparison is not calculated",
+			versionEnt:               &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.2", Revision: 1, PreviousVersion: "2024.1"},
+			previousVersionEnt:       &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.1", Revision: 3},
+			expectedPreviousPackage:  "ws.pkg",
+			expectedOperationTypes:   []view.OperationType{},
+			expectedComparisonLookup: true,
+		},
+		{
+			name:                     "comparison without content",
+			versionEnt:               &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.2", Revision: 1, PreviousVersion: "2024.1"},
+			previousVersionEnt:       &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.1", Revision: 3},
+			comparison:               &entity.VersionComparisonEntity{NoContent: true, OperationTypes: breakingChanges},
+			expectedPreviousPackage:  "ws.pkg",
+			expectedOperationTypes:   []view.OperationType{},
+			expectedComparisonLookup: true,
+		},
+		{
+			name:                     "non breaking changes",
+			versionEnt:               &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.2", Revision: 1, PreviousVersion: "2024.1"},
+			previousVersionEnt:       &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.1", Revision: 3},
+			comparison:               &entity.VersionComparisonEntity{OperationTypes: nonBreakingChanges},
+			expectedPreviousPackage:  "ws.pkg",
+			expectedOperationTypes:   nonBreakingChanges,
+			expectedComparisonLookup: true,
+		},
+		{
+			name:                     "breaking changes against version of other package",
+			versionEnt:               &entity.PublishedVersionEntity{PackageId: "ws.pkg", Version: "2024.2", Revision: 1, PreviousVersion: "2024.1", PreviousVersionPackageId: "ws.old"},
+			previousVersionEnt:       &entity.PublishedVersionEntity{PackageId: "ws.old", Version: "2024.1", Revision: 3},
+			comparison:               &entity.VersionComparisonEntity{OperationTypes: breakingChanges},
+			expectedPreviousPackage:  "ws.old",
+			expectedOperationTypes:   breakingChanges,
+			expectedBreaking:         true,
+			expectedComparisonLookup: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comparisonLookup := false
+			service := referenceGraphServiceImpl{
+				repo: &mockReferenceGraphRepository{
+					GetDependentsFunc: func(packageId string, version string, revision int, status string, onlyLatestRevision bool) ([]entity.VersionDependentEntity, error) {
+						assert.Equal(t, 0, revision, "dashboards must reference any revision of the version")
+						assert.Equal(t, string(view.Release), status)
+						assert.True(t, onlyLatestRevision)
+						return []entity.VersionDependentEntity{{PackageId: "ws.dashboard", Version: "2024.2", Revision: 1}}, nil
+					},
+				},
+				publishedRepo: &mockComparisonPublishedRepository{
+					mockPublishedRepository: mockPublishedRepository{
+						GetVersionFunc: func(packageId string, versionName string) (*entity.PublishedVersionEntity, error) {
+							if packageId == "ws.pkg" && versionName == "2024.2" {
+								return tt.versionEnt, nil
+							}
+							assert.Equal(t, tt.expectedPreviousPackage, packageId)
+							assert.Equal(t, "2024.1", versionName)
+							return tt.previousVersionEnt, nil
+						},
+					},
+					GetVersionComparisonFunc: func(comparisonId string) (*entity.VersionComparisonEntity, error) {
+						comparisonLookup = true
+						assert.Equal(t, view.MakeVersionComparisonId("ws.pkg", "2024.2", 1, tt.previousVersionEnt.PackageId, "2024.1", 3), comparisonId)
+						return tt.comparison, nil
+					},
+				},
+				roleService: &mockReadPermissionRoleService{},
+			}
+			result, err := service.GetImpact(mockSecurityContext{userId: "user"}, "ws.pkg", "2024.2")
+			if tt.expectedErrorCode != "" {
+				if assert.IsType(t, &exception.CustomError{}, err) {
+					assert.Equal(t, tt.expectedErrorCode, err.(*exception.CustomError).Code)
+					assert.Equal(t, http.StatusNotFound, err.(*exception.CustomError).Status)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "2024.2@1", result.Version)
+			assert.Equal(t, tt.expectedPreviousPackage, result.PreviousVersionPackageId)
+			assert.Equal(t, tt.expectedOperationTypes, result.OperationTypes)
+			assert.Equal(t, tt.expectedBreaking, result.Breaking)
+			assert.Equal(t, tt.expectedComparisonLookup, comparisonLookup)
+			assert.Len(t, result.Dashboards, 1)
+		})
+	}
+}
+
+type mockReferenceGraphRepository struct {
+	GetDependentsFunc func(packageId string, version string, revision int, status string, onlyLatestRevision bool) ([]entity.VersionDependentEntity, error)
+}
+
+func (m *mockReferenceGraphRepository) GetDependents(packageId string, version string, revision int, status string, onlyLatestRevision bool) ([]entity.VersionDependentEntity, error) {
+	return m.GetDependentsFunc(packageId, version, revision, status, onlyLatestRevision)
+}
+
+type mockComparisonPublishedRepository struct {
+	mockPublishedRepository
+	GetVersionComparisonFunc func(comparisonId string) (*entity.VersionComparisonEntity, error)
+}
+
+func (m *mockComparisonPublishedRepository) GetVersionComparison(comparisonId string) (*entity.VersionComparisonEntity, error) {
+	return m.GetVersionComparisonFunc(comparisonId)
+}
+
+type mockReadPermissionRoleService struct {
+	RoleService
+	notReadable []string
+	err         error
+	checks      map[string]int
+}
+
+func (m *mockReadPermissionRoleService) HasRequiredPermissions(ctx context.SecurityContext, packageId string, requiredPermissions ...view.RolePermission) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	if m.checks == nil {
+		m.checks = make(map[string]int)
+	}
+	m.checks[packageId]++
+	for _, id := range m.notReadable {
+		if id == packageId {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"net/http"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type RevisionComparisonService interface {
+	// CompareRevisions queues a changelog build for two revisions of the same version if the comparison is not calculated yet.
+	// Returns nil status if the comparison is ready. A build is not queued if there is already a pending one for the same revisions.
+	CompareRevisions(ctx context.SecurityContext, packageId string, version string, revision int, previousRevision int, reCalculate bool) (*view.CalculationProcessStatus, error)
+	// GetRevisionChanges returns operation changes between two revisions of the same version.
+	// If the comparison is not calculated yet, the status of its changelog build is returned instead of changes.
+	GetRevisionChanges(packageId string, version string, revision int, previousRevision int, apiType string, searchReq view.VersionChangesReq) (*view.VersionChangesView, *view.CalculationProcessStatus, error)
+}
+
+func NewRevisionComparisonService(publishedRepo repository.PublishedRepository,
+	comparisonService ComparisonService,
+	operationService OperationService,
+	buildService BuildService) RevisionComparisonService {
+	return &revisionComparisonServiceImpl{
+		publishedRepo:     publishedRepo,
+		comparisonService: comparisonService,
+		operationService:  operationService,
+		buildService:      buildService,
+	}
+}
+
+type revisionComparisonServiceImpl struct {
+	publishedRepo     repository.PublishedRepository
+	comparisonService ComparisonService
+	operationService  OperationService
+	buildService      BuildService
+}
+
+func (r revisionComparisonServiceImpl) CompareRevisions(ctx context.SecurityContext, packageId string, version string, revision int, previousRevision int, reCalculate bool) (*view.CalculationProcessStatus, error) {
+	version, err := r.validateRevisions(packageId, version, revision, previousRevision)
+	if err != nil {
+		return nil, err
+	}
+	buildView, err := r.getLatestChangelogBuild(packageId, version, revision, previousRevision)
+	if err != nil {
+		return nil, err
+	}
+	if buildView != nil && isBuildPending(buildView.Status) {
+		return &view.CalculationProcessStatus{
+			Status: string(view.StatusRunning),
+		}, nil
+	}
+	if !reCalculate {
+		exists, err := r.comparisonService.ValidComparisonResultExists(packageId, view.MakeVersionRefKey(version, revision), packageId, view.MakeVersionRefKey(version, previousRevision))
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, nil
+		}
+	}
+	_, _, err = r.buildService.CreateChangelogBuild(view.BuildConfig{
+		PackageId:                packageId,
+		Version:                  version,
+		PreviousVersionPackageId: packageId,
+		PreviousVersion:          version,
+		BuildType:                view.ChangelogType,
+		CreatedBy:                ctx.GetUserId(),
+
+		ComparisonRevision:     revision,
+		ComparisonPrevRevision: previousRevision,
+	}, false, "")
+	if err != nil {
+		return nil, err
+	}
+	return &view.CalculationProcessStatus{
+		Status: string(view.StatusRunning),
+	}, nil
+}
+
+func (r revisionComparisonServiceImpl) GetRevisionChanges(packageId string, version string, revision int, previousRevision int, apiType string, searchReq view.VersionChangesReq) (*view.VersionChangesView, *view.CalculationProcessStatus, error) {
+	version, err := r.validateRevisions(packageId, version, revision, previousRevision)
+	if err != nil {
+		return nil, nil, err
+	}
+	versionName := view.MakeVersionRefKey(version, revision)
+	previousVersionName := view.MakeVersionRefKey(version, previousRevision)
+
+	exists, err := r.comparisonService.ValidComparisonResultExists(packageId, versionName, packageId, previousVersionName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exists {
+		buildView, err := r.getLatestChangelogBuild(packageId, version, revision, previousRevision)
+		if err != nil {
+			return nil, nil, err
+		}
+		if buildView != nil {
+			switch {
+			case isBuildPending(buildView.Status):
+				return nil, &view.CalculationProcessStatus{
+					Status: string(view.StatusRunning),
+				}, nil
+			case buildView.Status == string(view.StatusError) || buildView.Status == string(view.StatusCancelled):
+				return nil, &view.CalculationProcessStatus{
+					Status:  string(view.StatusError),
+					Message: buildView.Details,
+				}, nil
+			}
+		}
+		return nil, nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.RevisionComparisonNotCalculated,
+			Message: exception.RevisionComparisonNotCalculatedMsg,
+			Params:  map[string]interface{}{"version": version, "revision": revision, "previousRevision": previousRevision},
+		}
+	}
+
+	searchReq.PreviousVersion = previousVersionName
+	searchReq.PreviousVersionPackageId = packageId
+	changes, err := r.operationService.GetVersionChanges(packageId, versionName, apiType, searchReq)
+	if err != nil {
+		return nil, nil, err
+	}
+	return changes, nil, nil
+}
+
+// validateRevisions checks that both revisions of the version exist and returns the version name without revision
+func (r revisionComparisonServiceImpl) validateRevisions(packageId string, version string, revision int, previousRevision int) (string, error) {
+	version, _, err := SplitVersionRevision(version)
+	if err != nil {
+		return "", err
+	}
+	if revision == previousRevision {
+		return "", &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.SameRevisionComparison,
+			Message: exception.SameRevisionComparisonMsg,
+			Params:  map[string]interface{}{"version": version, "revision": revision},
+		}
+	}
+	for _, rev := range []int{revision, previousRevision} {
+		versionEnt, err := r.publishedRepo.GetVersion(packageId, view.MakeVersionRefKey(version, rev))
+		if err != nil {
+			return "", err
+		}
+		if versionEnt == nil {
+			return "", &exception.CustomError{
+				Status:  http.StatusNotFound,
+				Code:    exception.PublishedVersionRevisionNotFound,
+				Message: exception.PublishedVersionRevisionNotFoundMsg,
+				Params:  map[string]interface{}{"version": version, "revision": rev, "packageId": packageId},
+			}
+		}
+	}
+	return version, nil
+}
+
+// getLatestChangelogBuild returns the latest changelog build for the revisions or nil if there is no such build
+func (r revisionComparisonServiceImpl) getLatestChangelogBuild(packageId string, version string, revision int, previousRevision int) (*view.BuildView, error) {
+	buildView, err := r.buildService.GetBuildViewByChangelogSearchQuery(view.ChangelogBuildSearchRequest{
+		PackageId:                packageId,
+		Version:                  version,
+		PreviousVersionPackageId: packageId,
+		PreviousVersion:          version,
+		BuildType:                view.ChangelogType,
+
+		ComparisonRevision:     revision,
+		ComparisonPrevRevision: previousRevision,
+	})
+	if err != nil {
+		if customError, ok := err.(*exception.CustomError); ok && customError.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return buildView, nil
+}
+
+func isBuildPending(status string) bool {
+	return status == string(view.StatusNotStarted) || status == string(view.StatusRunning)
+}
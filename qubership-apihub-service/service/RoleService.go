@@ -41,6 +41,8 @@ type RoleService interface {
 	GetAvailableVersionPublishStatuses(ctx context.SecurityContext, packageId string) ([]string, error)
 	HasRequiredPermissions(ctx context.SecurityContext, packageId string, requiredPermissions ...view.RolePermission) (bool, error)
 	HasManageVersionPermission(ctx context.SecurityContext, packageId string, versionStatuses ...string) (bool, error)
+	HasVersionStatusTransitionPermission(ctx context.SecurityContext, packageId string, fromStatus string, toStatus string) (bool, error)
+	HasPublishVersionPermission(ctx context.SecurityContext, packageId string, version string, status string) (bool, error)
 	ValidateDefaultRole(ctx context.SecurityContext, packageId string, roleId string) error
 	PackageRoleExists(roleId string) (bool, error)
 	CreateRole(role string, permissions []string) (*view.PackageRole, error)
@@ -58,15 +60,17 @@ type RoleService interface {
 	DeleteSystemAdministrator(userId string) error
 }
 
-func NewRoleService(roleRepository repository.RoleRepository, userService UserService, atService ActivityTrackingService, publishedRepo repository.PublishedRepository) RoleService {
-	return roleServiceImpl{roleRepository: roleRepository, userService: userService, atService: atService, publishedRepo: publishedRepo}
+func NewRoleService(roleRepository repository.RoleRepository, userService UserService, atService ActivityTrackingService, publishedRepo repository.PublishedRepository,
+	versionLifecycleService VersionLifecycleService) RoleService {
+	return roleServiceImpl{roleRepository: roleRepository, userService: userService, atService: atService, publishedRepo: publishedRepo, versionLifecycleService: versionLifecycleService}
 }
 
 type roleServiceImpl struct {
-	roleRepository repository.RoleRepository
-	userService    UserService
-	atService      ActivityTrackingService
-	publishedRepo  repository.PublishedRepository
+	roleRepository          repository.RoleRepository
+	userService             UserService
+	atService               ActivityTrackingService
+	publishedRepo           repository.PublishedRepository
+	versionLifecycleService VersionLifecycleService
 }
 
 func (r roleServiceImpl) AddPackageMembers(ctx context.SecurityContext, packageId string, emails []string, roleIds []string) (*view.PackageMembers, error) {
@@ -513,36 +517,36 @@ func (r roleServiceImpl) GetUserPackagePromoteStatuses(packageIds []string, user
 		return nil, err
 	}
 	sysadmUser := userSystemRole == view.SysadmRole
+	lifecycle, err := r.versionLifecycleService.GetLifecycle()
+	if err != nil {
+		return nil, err
+	}
 
 	result := make(view.AvailablePackagePromoteStatuses, 0)
 	for _, packageId := range packageIds {
 		if sysadmUser {
-			result[packageId] = []string{
-				string(view.Draft),
-				string(view.Release),
-				string(view.Archived),
+			allStatuses := make([]string, 0, len(lifecycle.Statuses))
+			for _, status := range lifecycle.Statuses {
+				allStatuses = append(allStatuses, status.Status)
 			}
+			result[packageId] = allStatuses
 			continue
 		}
 		userPermissions, err := r.getUserPermissionsForPackage(packageId, userId)
 		if err != nil {
 			return nil, err
 		}
-		result[packageId] = getAvailablePublishStatuses(userPermissions)
+		result[packageId] = getAvailablePublishStatuses(lifecycle, userPermissions)
 	}
 	return &result, nil
 }
 
-func getAvailablePublishStatuses(userPermissions []string) []string {
+func getAvailablePublishStatuses(lifecycle *view.VersionLifecycle, userPermissions []string) []string {
 	availablePublishStatuses := make([]string, 0)
-	if utils.SliceContains(userPermissions, string(view.ManageDraftVersionPermission)) {
-		availablePublishStatuses = append(availablePublishStatuses, string(view.Draft))
-	}
-	if utils.SliceContains(userPermissions, string(view.ManageReleaseVersionPermission)) {
-		availablePublishStatuses = append(availablePublishStatuses, string(view.Release))
-	}
-	if utils.SliceContains(userPermissions, string(view.ManageArchivedVersionPermission)) {
-		availablePublishStatuses = append(availablePublishStatuses, string(view.Archived))
+	for _, status := range lifecycle.Statuses {
+		if utils.SliceContains(userPermissions, string(status.Permission)) {
+			availablePublishStatuses = append(availablePublishStatuses, status.Status)
+		}
 	}
 	return availablePublishStatuses
 }
@@ -552,7 +556,11 @@ func (r roleServiceImpl) GetAvailableVersionPublishStatuses(ctx context.Security
 	if err != nil {
 		return nil, err
 	}
-	return getAvailablePublishStatuses(userPackagePermissions), nil
+	lifecycle, err := r.versionLifecycleService.GetLifecycle()
+	if err != nil {
+		return nil, err
+	}
+	return getAvailablePublishStatuses(lifecycle, userPackagePermissions), nil
 }
 
 func (r roleServiceImpl) GetPermissionsForPackage(ctx context.SecurityContext, packageId string) ([]string, error) {
@@ -638,12 +646,16 @@ func (r roleServiceImpl) HasRequiredPermissions(ctx context.SecurityContext, pac
 }
 
 func (r roleServiceImpl) HasManageVersionPermission(ctx context.SecurityContext, packageId string, versionStatuses ...string) (bool, error) {
-	if r.IsSysadm(ctx) {
-		return true, nil
-	}
 	requiredPermissions := make([]view.RolePermission, 0)
 	for _, status := range versionStatuses {
-		requiredPermissions = append(requiredPermissions, getRequiredPermissionForVersionStatus(status))
+		permission, err := r.versionLifecycleService.GetStatusPermission(status)
+		if err != nil {
+			return false, err
+		}
+		requiredPermissions = append(requiredPermissions, permission)
+	}
+	if r.IsSysadm(ctx) {
+		return true, nil
 	}
 	hasRequiredPermission, err := r.HasRequiredPermissions(ctx, packageId, requiredPermissions...)
 	if err != nil {
@@ -656,17 +668,20 @@ func (r roleServiceImpl) HasManageVersionPermission(ctx context.SecurityContext,
 	return false, nil
 }
 
-func getRequiredPermissionForVersionStatus(versionStatus string) view.RolePermission {
-	switch versionStatus {
-	case string(view.Draft):
-		return view.ManageDraftVersionPermission
-	case string(view.Release):
-		return view.ManageReleaseVersionPermission
-	case string(view.Archived):
-		return view.ManageArchivedVersionPermission
-	default:
-		return ""
+func (r roleServiceImpl) HasVersionStatusTransitionPermission(ctx context.SecurityContext, packageId string, fromStatus string, toStatus string) (bool, error) {
+	requiredPermission, err := r.versionLifecycleService.GetTransitionPermission(fromStatus, toStatus)
+	if err != nil {
+		return false, err
+	}
+	return r.HasRequiredPermissions(ctx, packageId, requiredPermission)
+}
+
+func (r roleServiceImpl) HasPublishVersionPermission(ctx context.SecurityContext, packageId string, version string, status string) (bool, error) {
+	requiredPermission, err := r.versionLifecycleService.GetPublishPermission(packageId, version, status)
+	if err != nil {
+		return false, err
 	}
+	return r.HasRequiredPermissions(ctx, packageId, requiredPermission)
 }
 
 // todo move this method to utils or context package?
@@ -0,0 +1,399 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const savedSearchesLimit = 100
+
+// limits of saved searches evaluated and webhooks sent at once, shared by all published versions
+const savedSearchEvaluationConcurrency = 5
+const savedSearchWebhookConcurrency = 10
+
+type SavedSearchService interface {
+	CreateSavedSearch(ctx context.SecurityContext, req view.SavedSearchReq) (*view.SavedSearch, error)
+	UpdateSavedSearch(ctx context.SecurityContext, savedSearchId string, req view.SavedSearchReq) (*view.SavedSearch, error)
+	DeleteSavedSearch(ctx context.SecurityContext, savedSearchId string) error
+	GetSavedSearches(ctx context.SecurityContext) (*view.SavedSearches, error)
+
+	GetNotifications(ctx context.SecurityContext, req view.SavedSearchNotificationListReq) (*view.SavedSearchNotifications, error)
+	MarkNotificationRead(ctx context.SecurityContext, notificationId string) error
+
+	// NotifyVersionPublished asynchronously evaluates subscribed saved searches against operations of the published revision
+	NotifyVersionPublished(packageId string, version string, revision int)
+}
+
+func NewSavedSearchService(repo repository.SavedSearchRepository,
+	operationRepository repository.OperationRepository,
+	roleService RoleService) SavedSearchService {
+	return &savedSearchServiceImpl{
+		repo:                repo,
+		operationRepository: operationRepository,
+		roleService:         roleService,
+		httpClient:          makeWebhookHttpClient(),
+		evaluationSlots:     make(chan struct{}, savedSearchEvaluationConcurrency),
+		webhookSlots:        make(chan struct{}, savedSearchWebhookConcurrency),
+	}
+}
+
+type savedSearchServiceImpl struct {
+	repo                repository.SavedSearchRepository
+	operationRepository repository.OperationRepository
+	roleService         RoleService
+	httpClient          *http.Client
+	evaluationSlots     chan struct{}
+	webhookSlots        chan struct{}
+}
+
+func (s savedSearchServiceImpl) CreateSavedSearch(ctx context.SecurityContext, req view.SavedSearchReq) (*view.SavedSearch, error) {
+	userId := ctx.GetUserId()
+	if err := s.validateSavedSearchReq(userId, "", req); err != nil {
+		return nil, err
+	}
+	count, err := s.repo.CountSavedSearches(userId)
+	if err != nil {
+		return nil, err
+	}
+	if count >= savedSearchesLimit {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.SavedSearchLimitExceeded,
+			Message: exception.SavedSearchLimitExceededMsg,
+			Params:  map[string]interface{}{"limit": savedSearchesLimit},
+		}
+	}
+	ent := entity.SavedSearchEntity{
+		SavedSearchId: uuid.New().String(),
+		UserId:        userId,
+		Name:          req.Name,
+		Query:         req.Query,
+		Subscribed:    req.Subscribed,
+		WebhookUrl:    req.WebhookUrl,
+		CreatedAt:     time.Now(),
+	}
+	if err = s.repo.CreateSavedSearch(&ent); err != nil {
+		return nil, err
+	}
+	result := entity.MakeSavedSearchView(ent)
+	return &result, nil
+}
+
+func (s savedSearchServiceImpl) UpdateSavedSearch(ctx context.SecurityContext, savedSearchId string, req view.SavedSearchReq) (*view.SavedSearch, error) {
+	userId := ctx.GetUserId()
+	ent, err := s.getSavedSearch(userId, savedSearchId)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.validateSavedSearchReq(userId, savedSearchId, req); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	ent.Name = req.Name
+	ent.Query = req.Query
+	ent.Subscribed = req.Subscribed
+	ent.WebhookUrl = req.WebhookUrl
+	ent.UpdatedAt = &now
+	if err = s.repo.UpdateSavedSearch(ent); err != nil {
+		return nil, err
+	}
+	result := entity.MakeSavedSearchView(*ent)
+	return &result, nil
+}
+
+func (s savedSearchServiceImpl) DeleteSavedSearch(ctx context.SecurityContext, savedSearchId string) error {
+	deleted, err := s.repo.DeleteSavedSearch(ctx.GetUserId(), savedSearchId)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.SavedSearchNotFound,
+			Message: exception.SavedSearchNotFoundMsg,
+			Params:  map[string]interface{}{"savedSearchId": savedSearchId},
+		}
+	}
+	return nil
+}
+
+func (s savedSearchServiceImpl) GetSavedSearches(ctx context.SecurityContext) (*view.SavedSearches, error) {
+	ents, err := s.repo.GetSavedSearches(ctx.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	result := &view.SavedSearches{SavedSearches: make([]view.SavedSearch, 0)}
+	for _, ent := range ents {
+		result.SavedSearches = append(result.SavedSearches, entity.MakeSavedSearchView(ent))
+	}
+	return result, nil
+}
+
+func (s savedSearchServiceImpl) GetNotifications(ctx context.SecurityContext, req view.SavedSearchNotificationListReq) (*view.SavedSearchNotifications, error) {
+	ents, err := s.repo.GetNotifications(ctx.GetUserId(), req)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.SavedSearchNotifications{Notifications: make([]view.SavedSearchNotification, 0)}
+	for _, ent := range ents {
+		result.Notifications = append(result.Notifications, entity.MakeSavedSearchNotificationView(ent.SavedSearchNotificationEntity, ent.SavedSearchName))
+	}
+	return result, nil
+}
+
+func (s savedSearchServiceImpl) MarkNotificationRead(ctx context.SecurityContext, notificationId string) error {
+	updated, err := s.repo.MarkNotificationRead(ctx.GetUserId(), notificationId)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.SavedSearchNotificationNotFound,
+			Message: exception.SavedSearchNotificationNotFoundMsg,
+			Params:  map[string]interface{}{"notificationId": notificationId},
+		}
+	}
+	return nil
+}
+
+func (s savedSearchServiceImpl) NotifyVersionPublished(packageId string, version string, revision int) {
+	utils.SafeAsync(func() {
+		savedSearches, err := s.repo.GetSubscribedSavedSearches(packageId)
+		if err != nil {
+			log.Errorf("Failed to get subscribed saved searches: %v", err)
+			return
+		}
+		webhooks := make([]savedSearchWebhook, 0)
+		var webhooksMutex sync.Mutex
+		var wg sync.WaitGroup
+		for _, savedSearch := range savedSearches {
+			s.evaluationSlots <- struct{}{}
+			wg.Add(1)
+			utils.SafeAsync(func() {
+				defer wg.Done()
+				defer func() { <-s.evaluationSlots }()
+				notificationEnt, err := s.evaluateSavedSearch(savedSearch, packageId, version, revision)
+				if err != nil {
+					log.Errorf("Failed to evaluate saved search %s for version %s@%d of package %s: %v",
+						savedSearch.SavedSearchId, version, revision, packageId, err)
+					return
+				}
+				if notificationEnt != nil && savedSearch.WebhookUrl != "" {
+					webhooksMutex.Lock()
+					webhooks = append(webhooks, savedSearchWebhook{
+						savedSearchId: savedSearch.SavedSearchId,
+						url:           savedSearch.WebhookUrl,
+						notification:  entity.MakeSavedSearchNotificationView(*notificationEnt, savedSearch.Name),
+					})
+					webhooksMutex.Unlock()
+				}
+			})
+		}
+		wg.Wait()
+		//webhooks are sent after all searches are evaluated and independently of each other, so a slow webhook doesn't delay other notifications
+		for _, webhook := range webhooks {
+			s.webhookSlots <- struct{}{}
+			utils.SafeAsync(func() {
+				defer func() { <-s.webhookSlots }()
+				if err := s.sendWebhook(webhook.url, webhook.notification); err != nil {
+					log.Warnf("Failed to send saved search %s notification to webhook: %v", webhook.savedSearchId, err)
+				}
+			})
+		}
+	})
+}
+
+type savedSearchWebhook struct {
+	savedSearchId string
+	url           string
+	notification  view.SavedSearchNotification
+}
+
+// evaluateSavedSearch notifies the saved search owner about operations of the revision which were not matched by the search before.
+// Returns the stored notification or nil if there are no new matches.
+func (s savedSearchServiceImpl) evaluateSavedSearch(savedSearch entity.SavedSearchEntity, packageId string, version string, revision int) (*entity.SavedSearchNotificationEntity, error) {
+	//system role is not stored in the saved search since it could be changed after the search was saved
+	systemRole, err := s.roleService.GetUserSystemRole(savedSearch.UserId)
+	if err != nil {
+		return nil, err
+	}
+	hasPermission, err := s.roleService.HasRequiredPermissions(context.CreateFromIdAndSystemRole(savedSearch.UserId, systemRole), packageId, view.ReadPermission)
+	if err != nil {
+		return nil, err
+	}
+	if !hasPermission {
+		return nil, nil
+	}
+	searchQuery, err := makeSavedSearchQuery(savedSearch.Query)
+	if err != nil {
+		return nil, err
+	}
+	matchedOperations, err := s.operationRepository.GetVersionOperationsMatchingSearch(searchQuery, packageId, version, revision)
+	if err != nil {
+		return nil, err
+	}
+	if len(matchedOperations) == 0 {
+		return nil, nil
+	}
+	operationIds := make([]string, 0, len(matchedOperations))
+	for _, operation := range matchedOperations {
+		operationIds = append(operationIds, operation.OperationId)
+	}
+	newOperationIds, err := s.repo.StoreMatches(savedSearch.SavedSearchId, packageId, operationIds)
+	if err != nil {
+		return nil, err
+	}
+	if len(newOperationIds) == 0 {
+		return nil, nil
+	}
+	newOperationIdsSet := make(map[string]bool, len(newOperationIds))
+	for _, operationId := range newOperationIds {
+		newOperationIdsSet[operationId] = true
+	}
+	newOperations := make([]view.SavedSearchMatchedOperation, 0, len(newOperationIds))
+	for _, operation := range matchedOperations {
+		if newOperationIdsSet[operation.OperationId] {
+			newOperations = append(newOperations, view.SavedSearchMatchedOperation{
+				OperationId: operation.OperationId,
+				Title:       operation.Title,
+				ApiType:     operation.ApiType,
+			})
+		}
+	}
+	notificationEnt := entity.SavedSearchNotificationEntity{
+		NotificationId: uuid.New().String(),
+		SavedSearchId:  savedSearch.SavedSearchId,
+		UserId:         savedSearch.UserId,
+		PackageId:      packageId,
+		Version:        version,
+		Revision:       revision,
+		Operations:     newOperations,
+		CreatedAt:      time.Now(),
+	}
+	if err = s.repo.StoreNotification(&notificationEnt); err != nil {
+		return nil, err
+	}
+	return &notificationEnt, nil
+}
+
+func (s savedSearchServiceImpl) sendWebhook(webhookUrl string, notification view.SavedSearchNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, webhookUrl, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create http request: %v", err.Error())
+	}
+	req.Header.Add("Content-Type", "application/json")
+	response, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded with status code %d", response.StatusCode)
+	}
+	return nil
+}
+
+func (s savedSearchServiceImpl) getSavedSearch(userId string, savedSearchId string) (*entity.SavedSearchEntity, error) {
+	ent, err := s.repo.GetSavedSearch(userId, savedSearchId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.SavedSearchNotFound,
+			Message: exception.SavedSearchNotFoundMsg,
+			Params:  map[string]interface{}{"savedSearchId": savedSearchId},
+		}
+	}
+	return ent, nil
+}
+
+func (s savedSearchServiceImpl) validateSavedSearchReq(userId string, savedSearchId string, req view.SavedSearchReq) error {
+	sameNameSearch, err := s.repo.GetSavedSearchByName(userId, req.Name)
+	if err != nil {
+		return err
+	}
+	if sameNameSearch != nil && sameNameSearch.SavedSearchId != savedSearchId {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.SavedSearchNameIsUsed,
+			Message: exception.SavedSearchNameIsUsedMsg,
+			Params:  map[string]interface{}{"name": req.Name},
+		}
+	}
+	if req.WebhookUrl != "" {
+		webhookUrl, err := url.Parse(req.WebhookUrl)
+		if err != nil || (webhookUrl.Scheme != "http" && webhookUrl.Scheme != "https") || webhookUrl.Host == "" {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.InvalidSavedSearchWebhookUrl,
+				Message: exception.InvalidSavedSearchWebhookUrlMsg,
+				Params:  map[string]interface{}{"url": req.WebhookUrl},
+			}
+		}
+		if err = checkWebhookHost(webhookUrl.Hostname()); err != nil {
+			return &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.SavedSearchWebhookUrlNotAllowed,
+				Message: exception.SavedSearchWebhookUrlNotAllowedMsg,
+				Params:  map[string]interface{}{"url": req.WebhookUrl, "reason": err.Error()},
+			}
+		}
+	}
+	_, err = makeSavedSearchQuery(req.Query)
+	return err
+}
+
+// makeSavedSearchQuery builds operation search query for the saved search.
+// Publication date interval is not applied since saved searches are evaluated against newly published versions only.
+func makeSavedSearchQuery(searchReq view.SearchQueryReq) (*entity.OperationSearchQuery, error) {
+	searchReq.PublicationDateInterval = view.PublicationDateInterval{}
+	searchQuery, err := entity.MakeOperationSearchQueryEntity(&searchReq)
+	if err != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidSearchParameters,
+			Message: exception.InvalidSearchParametersMsg,
+			Params:  map[string]interface{}{"error": err.Error()},
+		}
+	}
+	err = setOperationSearchParams(searchReq.OperationSearchParams, searchQuery)
+	if err != nil {
+		return nil, err
+	}
+	return searchQuery, nil
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateSavedSearchChecksPermissionWithSystemRole(t *testing.T) {
+	tests := []struct {
+		name            string
+		systemRole      string
+		expectedMatched bool
+	}{
+		{name: "sysadmin without package roles", systemRole: view.SysadmRole, expectedMatched: true},
+		{name: "user without package roles"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operationRepo := &mockSavedSearchOperationRepository{}
+			service := savedSearchServiceImpl{
+				operationRepository: operationRepo,
+				roleService:         mockSavedSearchRoleService{systemRoles: map[string]string{"user": tt.systemRole}},
+			}
+			savedSearch := entity.SavedSearchEntity{SavedSearchId: "search", UserId: "user", Query: view.SearchQueryReq{SearchString: "user"}}
+			notification, err := service.evaluateSavedSearch(savedSearch, "ws.pkg", "2024.1", 1)
+			assert.NoError(t, err)
+			assert.Nil(t, notification)
+			assert.Equal(t, tt.expectedMatched, operationRepo.called)
+		})
+	}
+}
+
+type mockSavedSearchRoleService struct {
+	RoleService
+	systemRoles map[string]string
+}
+
+func (m mockSavedSearchRoleService) GetUserSystemRole(userId string) (string, error) {
+	return m.systemRoles[userId], nil
+}
+
+// HasRequiredPermissions grants permissions to sysadmin only, like for a user without package roles
+func (m mockSavedSearchRoleService) HasRequiredPermissions(ctx context.SecurityContext, packageId string, requiredPermissions ...view.RolePermission) (bool, error) {
+	return ctx.GetUserSystemRole() == view.SysadmRole, nil
+}
+
+type mockSavedSearchOperationRepository struct {
+	mockOperationRepository
+	called bool
+}
+
+func (m *mockSavedSearchOperationRepository) GetVersionOperationsMatchingSearch(searchQuery *entity.OperationSearchQuery, packageId string, version string, revision int) ([]entity.SavedSearchMatchedOperationEntity, error) {
+	m.called = true
+	return nil, nil
+}
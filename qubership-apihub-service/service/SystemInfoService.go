@@ -80,6 +80,22 @@ const (
 	FAIL_BUILDS_ON_BROKEN_REFS             = "FAIL_BUILDS_ON_BROKEN_REFS"
 	GIT_BRANCH                             = "GIT_BRANCH"
 	GIT_HASH                               = "GIT_HASH"
+	BUILDS_LONG_POLL_TIMEOUT_SEC           = "BUILDS_LONG_POLL_TIMEOUT_SEC"
+	BUILD_RETRY_MAX_ATTEMPTS               = "BUILD_RETRY_MAX_ATTEMPTS"
+	BUILD_RETRY_INITIAL_BACKOFF_SEC        = "BUILD_RETRY_INITIAL_BACKOFF_SEC"
+	BUILD_RETRY_MAX_BACKOFF_SEC            = "BUILD_RETRY_MAX_BACKOFF_SEC"
+	BUILD_RETRY_POLICIES                   = "BUILD_RETRY_POLICIES"
+	BUILDS_FAIR_SHARE_WORKSPACE_WEIGHTS    = "BUILDS_FAIR_SHARE_WORKSPACE_WEIGHTS"
+	BUILDS_FAIR_SHARE_CREATED_BY_WEIGHTS   = "BUILDS_FAIR_SHARE_CREATED_BY_WEIGHTS"
+	BUILDS_FAIR_SHARE_POLICY               = "BUILDS_FAIR_SHARE_POLICY"
+	BUILDER_HEARTBEAT_TIMEOUT_SEC          = "BUILDER_HEARTBEAT_TIMEOUT_SEC"
+	BUILD_UNMATCHED_TIMEOUT_SEC            = "BUILD_UNMATCHED_TIMEOUT_SEC"
+	BLOB_STORAGE_TYPE                      = "BLOB_STORAGE_TYPE"
+	BLOB_STORAGE_FS_PATH                   = "BLOB_STORAGE_FS_PATH"
+	BLOB_STORAGE_MIGRATION_DELETE_FROM_DB  = "BLOB_STORAGE_MIGRATION_DELETE_FROM_DB"
+	VERSION_RETENTION_CLEANUP_SCHEDULE     = "VERSION_RETENTION_CLEANUP_SCHEDULE"
+	RECYCLE_BIN_PURGE_SCHEDULE             = "RECYCLE_BIN_PURGE_SCHEDULE"
+	RECYCLE_BIN_GRACE_PERIOD_DAYS          = "RECYCLE_BIN_GRACE_PERIOD_DAYS"
 
 	maxMB = 8796093022207 // 8796093022207 * 1048576 is safely below MaxInt64
 )
@@ -139,6 +155,17 @@ type SystemInfoService interface {
 	GetSystemApiKey() (string, error)
 	GetEditorDisabled() bool
 	FailBuildOnBrokenRefs() bool
+	GetBuildsLongPollTimeoutSec() int
+	GetBuildRetryPolicies() view.BuildRetryPolicies
+	GetBuildFairSharePolicy() view.BuildFairSharePolicy
+	GetBuilderHeartbeatTimeoutSec() int
+	GetBuildUnmatchedTimeoutSec() int
+	GetBlobStorageType() view.BlobStorageType
+	GetBlobStorageFsPath() string
+	IsBlobStorageMigrationDeleteFromDb() bool
+	GetVersionRetentionCleanupSchedule() string
+	GetRecycleBinPurgeSchedule() string
+	GetRecycleBinGracePeriodDays() int
 }
 
 func (g systemInfoServiceImpl) GetCredsFromEnv() *view.DbCredentials {
@@ -244,6 +271,17 @@ func (g systemInfoServiceImpl) Init() error {
 	g.setAllowedHosts()
 	g.setEditorDisabled()
 	g.setFailBuildOnBrokenRefs()
+	g.setBuildsLongPollTimeoutSec()
+	g.setBuildRetryPolicies()
+	g.setBuildFairSharePolicy()
+	g.setBuilderHeartbeatTimeoutSec()
+	g.setBuildUnmatchedTimeoutSec()
+	g.setBlobStorageType()
+	g.setBlobStorageFsPath()
+	g.setBlobStorageMigrationDeleteFromDb()
+	g.setVersionRetentionCleanupSchedule()
+	g.setRecycleBinPurgeSchedule()
+	g.setRecycleBinGracePeriodDays()
 
 	return nil
 }
@@ -646,6 +684,42 @@ func (g systemInfoServiceImpl) setBuildsCleanupSchedule() {
 	g.systemInfoMap[BUILDS_CLEANUP_SCHEDULE] = "0 1 * * 0" // at 01:00 AM on Sunday
 }
 
+func (g systemInfoServiceImpl) GetVersionRetentionCleanupSchedule() string {
+	return g.systemInfoMap[VERSION_RETENTION_CLEANUP_SCHEDULE].(string)
+}
+
+func (g systemInfoServiceImpl) setVersionRetentionCleanupSchedule() {
+	schedule := os.Getenv(VERSION_RETENTION_CLEANUP_SCHEDULE)
+	if schedule == "" {
+		schedule = "0 2 * * *" // at 02:00 AM every day
+	}
+	g.systemInfoMap[VERSION_RETENTION_CLEANUP_SCHEDULE] = schedule
+}
+
+func (g systemInfoServiceImpl) GetRecycleBinPurgeSchedule() string {
+	return g.systemInfoMap[RECYCLE_BIN_PURGE_SCHEDULE].(string)
+}
+
+func (g systemInfoServiceImpl) setRecycleBinPurgeSchedule() {
+	schedule := os.Getenv(RECYCLE_BIN_PURGE_SCHEDULE)
+	if schedule == "" {
+		schedule = "0 3 * * *" // at 03:00 AM every day
+	}
+	g.systemInfoMap[RECYCLE_BIN_PURGE_SCHEDULE] = schedule
+}
+
+func (g systemInfoServiceImpl) GetRecycleBinGracePeriodDays() int {
+	return g.systemInfoMap[RECYCLE_BIN_GRACE_PERIOD_DAYS].(int)
+}
+
+func (g systemInfoServiceImpl) setRecycleBinGracePeriodDays() {
+	days, err := strconv.Atoi(os.Getenv(RECYCLE_BIN_GRACE_PERIOD_DAYS))
+	if err != nil || days <= 0 {
+		days = 30
+	}
+	g.systemInfoMap[RECYCLE_BIN_GRACE_PERIOD_DAYS] = days
+}
+
 func (g systemInfoServiceImpl) setInsecureProxy() {
 	envVal := os.Getenv(INSECURE_PROXY)
 	insecureProxy, err := strconv.ParseBool(envVal)
@@ -853,3 +927,186 @@ func (g systemInfoServiceImpl) setFailBuildOnBrokenRefs() {
 func (g systemInfoServiceImpl) FailBuildOnBrokenRefs() bool {
 	return g.systemInfoMap[FAIL_BUILDS_ON_BROKEN_REFS].(bool)
 }
+
+func (g systemInfoServiceImpl) setBuildsLongPollTimeoutSec() {
+	timeout, err := strconv.Atoi(os.Getenv(BUILDS_LONG_POLL_TIMEOUT_SEC))
+	if err != nil || timeout <= 0 {
+		timeout = 30
+	}
+	// must be less than http server write timeout
+	if timeout > 240 {
+		timeout = 240
+		log.Warnf("%s value is too large, limiting to %d", BUILDS_LONG_POLL_TIMEOUT_SEC, timeout)
+	}
+	g.systemInfoMap[BUILDS_LONG_POLL_TIMEOUT_SEC] = timeout
+}
+
+func (g systemInfoServiceImpl) GetBuildsLongPollTimeoutSec() int {
+	return g.systemInfoMap[BUILDS_LONG_POLL_TIMEOUT_SEC].(int)
+}
+
+func (g systemInfoServiceImpl) setBuildRetryPolicies() {
+	maxAttempts, err := strconv.Atoi(os.Getenv(BUILD_RETRY_MAX_ATTEMPTS))
+	if err != nil || maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	initialBackoffSec, err := strconv.Atoi(os.Getenv(BUILD_RETRY_INITIAL_BACKOFF_SEC))
+	if err != nil || initialBackoffSec < 0 {
+		initialBackoffSec = 30
+	}
+	maxBackoffSec, err := strconv.Atoi(os.Getenv(BUILD_RETRY_MAX_BACKOFF_SEC))
+	if err != nil || maxBackoffSec <= 0 {
+		maxBackoffSec = 600
+	}
+	policies := view.BuildRetryPolicies{
+		Default: view.BuildRetryPolicy{
+			MaxAttempts:       maxAttempts,
+			InitialBackoffSec: initialBackoffSec,
+			MaxBackoffSec:     maxBackoffSec,
+		},
+		ByBuildType: map[view.BuildType]view.BuildRetryPolicy{},
+	}
+	// format: <buildType>:<maxAttempts>:<initialBackoffSec>,...
+	// e.g. "build:5:60,changelog:3:10"
+	policiesStr := os.Getenv(BUILD_RETRY_POLICIES)
+	if policiesStr != "" {
+		for _, policyStr := range strings.Split(policiesStr, ",") {
+			parts := strings.Split(strings.TrimSpace(policyStr), ":")
+			if len(parts) != 3 {
+				log.Warnf("%s: incorrect policy format '%s', expected <buildType>:<maxAttempts>:<initialBackoffSec>", BUILD_RETRY_POLICIES, policyStr)
+				continue
+			}
+			buildTypeMaxAttempts, err := strconv.Atoi(parts[1])
+			if err != nil || buildTypeMaxAttempts <= 0 {
+				log.Warnf("%s: incorrect max attempts value '%s' for build type %s", BUILD_RETRY_POLICIES, parts[1], parts[0])
+				continue
+			}
+			buildTypeInitialBackoffSec, err := strconv.Atoi(parts[2])
+			if err != nil || buildTypeInitialBackoffSec < 0 {
+				log.Warnf("%s: incorrect initial backoff value '%s' for build type %s", BUILD_RETRY_POLICIES, parts[2], parts[0])
+				continue
+			}
+			policies.ByBuildType[view.BuildType(parts[0])] = view.BuildRetryPolicy{
+				MaxAttempts:       buildTypeMaxAttempts,
+				InitialBackoffSec: buildTypeInitialBackoffSec,
+				MaxBackoffSec:     maxBackoffSec,
+			}
+		}
+	}
+	g.systemInfoMap[BUILD_RETRY_POLICIES] = policies
+}
+
+func (g systemInfoServiceImpl) GetBuildRetryPolicies() view.BuildRetryPolicies {
+	return g.systemInfoMap[BUILD_RETRY_POLICIES].(view.BuildRetryPolicies)
+}
+
+func (g systemInfoServiceImpl) setBuildFairSharePolicy() {
+	g.systemInfoMap[BUILDS_FAIR_SHARE_POLICY] = view.BuildFairSharePolicy{
+		WorkspaceWeights: parseBuildQueueWeights(BUILDS_FAIR_SHARE_WORKSPACE_WEIGHTS),
+		CreatedByWeights: parseBuildQueueWeights(BUILDS_FAIR_SHARE_CREATED_BY_WEIGHTS),
+	}
+}
+
+// format: <key>:<weight>,... e.g. "QS:2,db migration:0.5"
+func parseBuildQueueWeights(envName string) map[string]float64 {
+	weights := make(map[string]float64)
+	weightsStr := os.Getenv(envName)
+	if weightsStr == "" {
+		return weights
+	}
+	for _, weightStr := range strings.Split(weightsStr, ",") {
+		separatorIndex := strings.LastIndex(weightStr, ":")
+		if separatorIndex <= 0 {
+			log.Warnf("%s: incorrect weight format '%s', expected <key>:<weight>", envName, weightStr)
+			continue
+		}
+		key := strings.TrimSpace(weightStr[:separatorIndex])
+		weight, err := strconv.ParseFloat(strings.TrimSpace(weightStr[separatorIndex+1:]), 64)
+		if err != nil || weight <= 0 {
+			log.Warnf("%s: incorrect weight value for '%s', positive number expected", envName, key)
+			continue
+		}
+		weights[key] = weight
+	}
+	return weights
+}
+
+func (g systemInfoServiceImpl) GetBuildFairSharePolicy() view.BuildFairSharePolicy {
+	return g.systemInfoMap[BUILDS_FAIR_SHARE_POLICY].(view.BuildFairSharePolicy)
+}
+
+func (g systemInfoServiceImpl) setBuilderHeartbeatTimeoutSec() {
+	timeout, err := strconv.Atoi(os.Getenv(BUILDER_HEARTBEAT_TIMEOUT_SEC))
+	if err != nil || timeout <= 0 {
+		timeout = 60
+	}
+	g.systemInfoMap[BUILDER_HEARTBEAT_TIMEOUT_SEC] = timeout
+}
+
+func (g systemInfoServiceImpl) GetBuilderHeartbeatTimeoutSec() int {
+	return g.systemInfoMap[BUILDER_HEARTBEAT_TIMEOUT_SEC].(int)
+}
+
+func (g systemInfoServiceImpl) setBuildUnmatchedTimeoutSec() {
+	timeout, err := strconv.Atoi(os.Getenv(BUILD_UNMATCHED_TIMEOUT_SEC))
+	if err != nil || timeout <= 0 {
+		timeout = 3600
+	}
+	g.systemInfoMap[BUILD_UNMATCHED_TIMEOUT_SEC] = timeout
+}
+
+func (g systemInfoServiceImpl) GetBuildUnmatchedTimeoutSec() int {
+	return g.systemInfoMap[BUILD_UNMATCHED_TIMEOUT_SEC].(int)
+}
+
+func (g systemInfoServiceImpl) setBlobStorageType() {
+	// minio integration used to be the only alternative to the DB storage, so keep it as a default for compatibility
+	defaultType := view.BlobStoragePostgres
+	if g.IsMinioStorageActive() {
+		defaultType = view.BlobStorageS3
+	}
+	envVal := os.Getenv(BLOB_STORAGE_TYPE)
+	if envVal == "" {
+		g.systemInfoMap[BLOB_STORAGE_TYPE] = defaultType
+		return
+	}
+	storageType, err := view.ParseBlobStorageType(envVal)
+	if err != nil {
+		log.Errorf("failed to parse %v env value: %v. Value by default - %s", BLOB_STORAGE_TYPE, err.Error(), defaultType)
+		storageType = defaultType
+	}
+	g.systemInfoMap[BLOB_STORAGE_TYPE] = storageType
+}
+
+func (g systemInfoServiceImpl) GetBlobStorageType() view.BlobStorageType {
+	return g.systemInfoMap[BLOB_STORAGE_TYPE].(view.BlobStorageType)
+}
+
+func (g systemInfoServiceImpl) setBlobStorageFsPath() {
+	path := os.Getenv(BLOB_STORAGE_FS_PATH)
+	if path == "" {
+		path = "/data/blobs"
+	}
+	g.systemInfoMap[BLOB_STORAGE_FS_PATH] = path
+}
+
+func (g systemInfoServiceImpl) GetBlobStorageFsPath() string {
+	return g.systemInfoMap[BLOB_STORAGE_FS_PATH].(string)
+}
+
+func (g systemInfoServiceImpl) setBlobStorageMigrationDeleteFromDb() {
+	envVal := os.Getenv(BLOB_STORAGE_MIGRATION_DELETE_FROM_DB)
+	if envVal == "" {
+		envVal = "false"
+	}
+	val, err := strconv.ParseBool(envVal)
+	if err != nil {
+		log.Errorf("failed to parse %v env value: %v. Value by default - false", BLOB_STORAGE_MIGRATION_DELETE_FROM_DB, err.Error())
+		val = false
+	}
+	g.systemInfoMap[BLOB_STORAGE_MIGRATION_DELETE_FROM_DB] = val
+}
+
+func (g systemInfoServiceImpl) IsBlobStorageMigrationDeleteFromDb() bool {
+	return g.systemInfoMap[BLOB_STORAGE_MIGRATION_DELETE_FROM_DB].(bool)
+}
@@ -0,0 +1,273 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/archive"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionInferenceService interface {
+	GetNextVersion(packageId string, version string, previousVersion string) (*view.NextVersion, error)
+	GetLatestReleaseVersion(packageId string) (string, error)
+	InferVersion(buildArc *archive.BuildResultArchive, buildConfig *view.BuildConfig) error
+}
+
+func NewVersionInferenceService(publishedRepo repository.PublishedRepository, buildRepository repository.BuildRepository) VersionInferenceService {
+	return &versionInferenceServiceImpl{
+		publishedRepo:   publishedRepo,
+		buildRepository: buildRepository,
+	}
+}
+
+type versionInferenceServiceImpl struct {
+	publishedRepo   repository.PublishedRepository
+	buildRepository repository.BuildRepository
+}
+
+func (v versionInferenceServiceImpl) GetNextVersion(packageId string, version string, previousVersion string) (*view.NextVersion, error) {
+	packageEnt, err := v.publishedRepo.GetPackage(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if packageEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	if previousVersion == "" {
+		previousVersion, err = v.GetLatestReleaseVersion(packageId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := &view.NextVersion{
+		PreviousVersion: previousVersion,
+		Version:         version,
+		Candidates:      map[view.VersionBump]string{},
+	}
+
+	var previousVersionEnt *entity.PublishedVersionEntity
+	var previousSemVer *utils.SemanticVersion
+	if previousVersion != "" {
+		previousVersionEnt, err = v.getVersion(packageId, previousVersion)
+		if err != nil {
+			return nil, err
+		}
+		previousSemVer, err = parseSemanticVersion(previousVersion)
+		if err != nil {
+			return nil, err
+		}
+		result.Candidates[view.VersionBumpMajor] = previousSemVer.NextMajor().String()
+		result.Candidates[view.VersionBumpMinor] = previousSemVer.NextMinor().String()
+		result.Candidates[view.VersionBumpPatch] = previousSemVer.NextPatch().String()
+	}
+	if version == "" {
+		return result, nil
+	}
+
+	versionEnt, err := v.getVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	if previousVersionEnt == nil {
+		result.NextVersion = view.InitialSemanticVersion
+		return result, nil
+	}
+
+	comparisonId := view.MakeVersionComparisonId(
+		versionEnt.PackageId, versionEnt.Version, versionEnt.Revision,
+		previousVersionEnt.PackageId, previousVersionEnt.Version, previousVersionEnt.Revision,
+	)
+	versionComparison, err := v.publishedRepo.GetVersionComparison(comparisonId)
+	if err != nil {
+		return nil, err
+	}
+	if versionComparison == nil || versionComparison.NoContent {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.ComparisonNotFound,
+			Message: exception.ComparisonNotFoundMsg,
+			Params: map[string]interface{}{
+				"comparisonId":      comparisonId,
+				"packageId":         versionEnt.PackageId,
+				"version":           versionEnt.Version,
+				"revision":          versionEnt.Revision,
+				"previousPackageId": previousVersionEnt.PackageId,
+				"previousVersion":   previousVersionEnt.Version,
+				"previousRevision":  previousVersionEnt.Revision,
+			},
+		}
+	}
+	refsComparisons, err := v.publishedRepo.GetVersionRefsComparisons(comparisonId)
+	if err != nil {
+		return nil, err
+	}
+	summary := sumChangesSummary(append(refsComparisons, *versionComparison))
+	result.ChangesSummary = &summary
+	result.Bump = view.GetVersionBump(summary)
+	result.NextVersion = result.Candidates[result.Bump]
+	return result, nil
+}
+
+// GetLatestReleaseVersion returns the highest semantic version among release versions of the package
+func (v versionInferenceServiceImpl) GetLatestReleaseVersion(packageId string) (string, error) {
+	releaseVersions, err := v.publishedRepo.GetVersionsByStatus(packageId, string(view.Release))
+	if err != nil {
+		return "", err
+	}
+	semVers := make([]utils.SemanticVersion, 0)
+	for _, versionEnt := range releaseVersions {
+		if semVer, ok := utils.ParseSemanticVersion(versionEnt.Version); ok {
+			semVers = append(semVers, *semVer)
+		}
+	}
+	if len(semVers) == 0 {
+		return "", nil
+	}
+	sort.Slice(semVers, func(i, j int) bool {
+		return semVers[j].Less(semVers[i])
+	})
+	return semVers[0].String(), nil
+}
+
+// InferVersion replaces the placeholder version of the build in 'auto' version mode with the version inferred from the changelog
+func (v versionInferenceServiceImpl) InferVersion(buildArc *archive.BuildResultArchive, buildConfig *view.BuildConfig) error {
+	if buildConfig.VersionMode != view.VersionModeAuto {
+		return nil
+	}
+	nextVersion := view.InitialSemanticVersion
+	if buildConfig.PreviousVersion != "" {
+		previousSemVer, err := parseSemanticVersion(buildConfig.PreviousVersion)
+		if err != nil {
+			return err
+		}
+		if err = buildArc.ReadPackageComparisons(false); err != nil {
+			return err
+		}
+		summary := view.ChangeSummary{}
+		for _, comparison := range buildArc.PackageComparisons.Comparisons {
+			for _, operationType := range comparison.OperationTypes {
+				summary = summary.Add(operationType.ChangesSummary)
+			}
+		}
+		switch view.GetVersionBump(summary) {
+		case view.VersionBumpMajor:
+			nextVersion = previousSemVer.NextMajor().String()
+		case view.VersionBumpMinor:
+			nextVersion = previousSemVer.NextMinor().String()
+		default:
+			nextVersion = previousSemVer.NextPatch().String()
+		}
+	}
+
+	// concurrent builds could infer the same version, the reservation is checked before the version existence
+	// since the reservation is taken over only after the build which reserved it is finished.
+	// Dry run doesn't store the version, so it must not block real publications of the inferred version
+	if !buildConfig.DryRun {
+		reserved, err := v.buildRepository.ReserveInferredVersion(buildConfig.PackageId, nextVersion, buildConfig.PublishId)
+		if err != nil {
+			return err
+		}
+		if !reserved {
+			return &exception.CustomError{
+				Status:  http.StatusConflict,
+				Code:    exception.InferredVersionReserved,
+				Message: exception.InferredVersionReservedMsg,
+				Params:  map[string]interface{}{"version": nextVersion, "packageId": buildConfig.PackageId},
+			}
+		}
+	}
+	existingVersion, err := v.publishedRepo.GetVersion(buildConfig.PackageId, nextVersion)
+	if err != nil {
+		return err
+	}
+	if existingVersion != nil {
+		return &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.InferredVersionAlreadyExists,
+			Message: exception.InferredVersionAlreadyExistsMsg,
+			Params:  map[string]interface{}{"version": nextVersion, "packageId": buildConfig.PackageId},
+		}
+	}
+	if buildConfig.Status == string(view.Release) {
+		packageEnt, err := v.publishedRepo.GetPackage(buildConfig.PackageId)
+		if err != nil {
+			return err
+		}
+		if packageEnt != nil && packageEnt.ReleaseVersionPattern != "" {
+			if err = ReleaseVersionMatchesPattern(nextVersion, packageEnt.ReleaseVersionPattern); err != nil {
+				return err
+			}
+		}
+	}
+
+	buildArc.ReplaceVersion(nextVersion)
+	buildConfig.Version = nextVersion
+	configAsMap, err := view.BuildConfigToMap(*buildConfig)
+	if err != nil {
+		return err
+	}
+	return v.buildRepository.UpdateBuildVersion(buildConfig.PublishId, nextVersion, *configAsMap)
+}
+
+func (v versionInferenceServiceImpl) getVersion(packageId string, version string) (*entity.PublishedVersionEntity, error) {
+	versionEnt, err := v.publishedRepo.GetVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	if versionEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedPackageVersionNotFound,
+			Message: exception.PublishedPackageVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": version, "packageId": packageId},
+		}
+	}
+	return versionEnt, nil
+}
+
+func parseSemanticVersion(version string) (*utils.SemanticVersion, error) {
+	semVer, ok := utils.ParseSemanticVersion(version)
+	if !ok {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.NotSemanticVersion,
+			Message: exception.NotSemanticVersionMsg,
+			Params:  map[string]interface{}{"version": version},
+		}
+	}
+	return semVer, nil
+}
+
+func sumChangesSummary(comparisons []entity.VersionComparisonEntity) view.ChangeSummary {
+	summary := view.ChangeSummary{}
+	for _, comparison := range comparisons {
+		for _, operationType := range comparison.OperationTypes {
+			summary = summary.Add(operationType.ChangesSummary)
+		}
+	}
+	return summary
+}
@@ -0,0 +1,275 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+// VersionLifecycleService provides the admin configured set of version statuses and allowed transitions between them
+type VersionLifecycleService interface {
+	// GetLifecycle returns the cached lifecycle, the result must not be modified
+	GetLifecycle() (*view.VersionLifecycle, error)
+	UpdateLifecycle(ctx context.SecurityContext, lifecycle view.VersionLifecycle) (*view.VersionLifecycle, error)
+	ValidateStatus(status string) error
+	GetStatusPermission(status string) (view.RolePermission, error)
+	// GetTransitionPermission returns the permission required to move the version to toStatus, fromStatus is empty for new versions
+	GetTransitionPermission(fromStatus string, toStatus string) (view.RolePermission, error)
+	// GetPublishPermission checks that the version could be published in the status according to its current status
+	// and returns the permission required for that
+	GetPublishPermission(packageId string, version string, status string) (view.RolePermission, error)
+}
+
+func NewVersionLifecycleService(lifecycleRepository repository.VersionLifecycleRepository, publishedRepo repository.PublishedRepository) VersionLifecycleService {
+	return &versionLifecycleServiceImpl{
+		lifecycleRepository: lifecycleRepository,
+		publishedRepo:       publishedRepo,
+	}
+}
+
+type versionLifecycleServiceImpl struct {
+	lifecycleRepository repository.VersionLifecycleRepository
+	publishedRepo       repository.PublishedRepository
+
+	cacheMutex        sync.RWMutex
+	cachedLifecycle   *view.VersionLifecycle
+	lifecycleLoadedAt time.Time
+	// incremented on update, so the lifecycle loaded concurrently with the update is not cached
+	cacheGeneration uint64
+}
+
+// the cache is reset on update, other replicas pick up the updated lifecycle after the ttl
+const versionLifecycleCacheTtl = time.Minute
+
+var versionStatusNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+func (v *versionLifecycleServiceImpl) GetLifecycle() (*view.VersionLifecycle, error) {
+	v.cacheMutex.RLock()
+	lifecycle, loadedAt, generation := v.cachedLifecycle, v.lifecycleLoadedAt, v.cacheGeneration
+	v.cacheMutex.RUnlock()
+	if lifecycle != nil && time.Since(loadedAt) < versionLifecycleCacheTtl {
+		return lifecycle, nil
+	}
+	lifecycle, err := v.loadLifecycle()
+	if err != nil {
+		return nil, err
+	}
+	v.cacheMutex.Lock()
+	if v.cacheGeneration == generation {
+		v.cachedLifecycle, v.lifecycleLoadedAt = lifecycle, time.Now()
+	}
+	v.cacheMutex.Unlock()
+	return lifecycle, nil
+}
+
+func (v *versionLifecycleServiceImpl) invalidateLifecycleCache() {
+	v.cacheMutex.Lock()
+	v.cachedLifecycle = nil
+	v.cacheGeneration++
+	v.cacheMutex.Unlock()
+}
+
+func (v *versionLifecycleServiceImpl) loadLifecycle() (*view.VersionLifecycle, error) {
+	statusEnts, err := v.lifecycleRepository.GetStatuses()
+	if err != nil {
+		return nil, err
+	}
+	transitionEnts, err := v.lifecycleRepository.GetTransitions()
+	if err != nil {
+		return nil, err
+	}
+	return entity.MakeVersionLifecycleView(statusEnts, transitionEnts), nil
+}
+
+func (v *versionLifecycleServiceImpl) UpdateLifecycle(ctx context.SecurityContext, lifecycle view.VersionLifecycle) (*view.VersionLifecycle, error) {
+	err := validateVersionLifecycle(lifecycle)
+	if err != nil {
+		return nil, err
+	}
+	usedStatuses, err := v.lifecycleRepository.GetUsedVersionStatuses()
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range usedStatuses {
+		if lifecycle.GetStatus(status) == nil {
+			return nil, &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.VersionStatusInUse,
+				Message: exception.VersionStatusInUseMsg,
+				Params:  map[string]interface{}{"status": status},
+			}
+		}
+	}
+	err = v.lifecycleRepository.ReplaceLifecycle(
+		entity.MakeVersionStatusEntities(lifecycle, ctx.GetUserId(), time.Now()),
+		entity.MakeVersionStatusTransitionEntities(lifecycle))
+	v.invalidateLifecycleCache()
+	if err != nil {
+		return nil, err
+	}
+	return v.GetLifecycle()
+}
+
+func validateVersionLifecycle(lifecycle view.VersionLifecycle) error {
+	invalidLifecycleError := func(details string) error {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidVersionLifecycle,
+			Message: exception.InvalidVersionLifecycleMsg,
+			Params:  map[string]interface{}{"details": details},
+		}
+	}
+	statuses := map[string]bool{}
+	hasInitialStatus := false
+	for _, status := range lifecycle.Statuses {
+		if !versionStatusNameRegexp.MatchString(status.Status) {
+			return invalidLifecycleError(fmt.Sprintf("status '%s' must match %s", status.Status, versionStatusNameRegexp.String()))
+		}
+		if statuses[status.Status] {
+			return invalidLifecycleError(fmt.Sprintf("status '%s' is duplicated", status.Status))
+		}
+		statuses[status.Status] = true
+		if _, err := view.ParseRolePermission(string(status.Permission)); err != nil {
+			return invalidLifecycleError(fmt.Sprintf("permission '%s' of status '%s' is unknown", status.Permission, status.Status))
+		}
+		hasInitialStatus = hasInitialStatus || status.Initial
+	}
+	for _, builtInStatus := range view.BuiltInVersionStatuses {
+		if !statuses[string(builtInStatus)] {
+			return invalidLifecycleError(fmt.Sprintf("built-in status '%s' could not be removed", builtInStatus))
+		}
+	}
+	if !hasInitialStatus {
+		return invalidLifecycleError("at least one initial status is required")
+	}
+	transitions := map[string]bool{}
+	for _, transition := range lifecycle.Transitions {
+		if !statuses[transition.From] || !statuses[transition.To] {
+			return invalidLifecycleError(fmt.Sprintf("transition from '%s' to '%s' refers to unknown status", transition.From, transition.To))
+		}
+		if transition.From == transition.To {
+			return invalidLifecycleError(fmt.Sprintf("transition from '%s' to itself is not allowed", transition.From))
+		}
+		key := transition.From + "->" + transition.To
+		if transitions[key] {
+			return invalidLifecycleError(fmt.Sprintf("transition from '%s' to '%s' is duplicated", transition.From, transition.To))
+		}
+		transitions[key] = true
+		if transition.Permission == "" {
+			continue
+		}
+		if _, err := view.ParseRolePermission(string(transition.Permission)); err != nil {
+			return invalidLifecycleError(fmt.Sprintf("permission '%s' of transition from '%s' to '%s' is unknown", transition.Permission, transition.From, transition.To))
+		}
+	}
+	return nil
+}
+
+func (v *versionLifecycleServiceImpl) ValidateStatus(status string) error {
+	_, err := v.GetStatusPermission(status)
+	return err
+}
+
+func (v *versionLifecycleServiceImpl) GetStatusPermission(status string) (view.RolePermission, error) {
+	lifecycle, err := v.GetLifecycle()
+	if err != nil {
+		return "", err
+	}
+	lifecycleStatus := lifecycle.GetStatus(status)
+	if lifecycleStatus == nil {
+		return "", unknownVersionStatusError(status)
+	}
+	return lifecycleStatus.Permission, nil
+}
+
+func (v *versionLifecycleServiceImpl) GetTransitionPermission(fromStatus string, toStatus string) (view.RolePermission, error) {
+	lifecycle, err := v.GetLifecycle()
+	if err != nil {
+		return "", err
+	}
+	targetStatus := lifecycle.GetStatus(toStatus)
+	if targetStatus == nil {
+		return "", unknownVersionStatusError(toStatus)
+	}
+	if fromStatus == toStatus {
+		return targetStatus.Permission, nil
+	}
+	if fromStatus == "" {
+		if !targetStatus.Initial {
+			return "", &exception.CustomError{
+				Status:  http.StatusBadRequest,
+				Code:    exception.VersionStatusNotInitial,
+				Message: exception.VersionStatusNotInitialMsg,
+				Params:  map[string]interface{}{"status": toStatus},
+			}
+		}
+		return targetStatus.Permission, nil
+	}
+	transition := lifecycle.GetTransition(fromStatus, toStatus)
+	if transition == nil {
+		return "", &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.VersionStatusTransitionNotAllowed,
+			Message: exception.VersionStatusTransitionNotAllowedMsg,
+			Params:  map[string]interface{}{"from": fromStatus, "to": toStatus},
+		}
+	}
+	if transition.Permission != "" {
+		return transition.Permission, nil
+	}
+	return targetStatus.Permission, nil
+}
+
+func (v *versionLifecycleServiceImpl) GetPublishPermission(packageId string, version string, status string) (view.RolePermission, error) {
+	currentStatus, err := v.getCurrentVersionStatus(packageId, version)
+	if err != nil {
+		return "", err
+	}
+	return v.GetTransitionPermission(currentStatus, status)
+}
+
+func (v *versionLifecycleServiceImpl) getCurrentVersionStatus(packageId string, version string) (string, error) {
+	versionName, _, err := repository.SplitVersionRevision(version)
+	if err != nil {
+		return "", err
+	}
+	versionEnt, err := v.publishedRepo.GetVersion(packageId, versionName)
+	if err != nil {
+		return "", err
+	}
+	if versionEnt == nil {
+		return "", nil
+	}
+	return versionEnt.Status, nil
+}
+
+func unknownVersionStatusError(status string) error {
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.UnknownVersionStatus,
+		Message: exception.UnknownVersionStatusMsg,
+		Params:  map[string]interface{}{"status": status},
+	}
+}
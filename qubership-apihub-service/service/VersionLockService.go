@@ -0,0 +1,271 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+)
+
+type VersionLockService interface {
+	GetPolicy(packageId string) (*view.VersionLockPolicy, error)
+	SetPolicy(ctx context.SecurityContext, packageId string, req view.VersionLockPolicyUpdate) error
+	DeletePolicy(packageId string) error
+
+	GetLock(packageId string, version string) (*view.VersionLock, error)
+	LockVersion(ctx context.SecurityContext, packageId string, version string) (*view.VersionLock, error)
+	UnlockVersion(ctx context.SecurityContext, packageId string, version string) (*view.VersionLock, error)
+
+	// CheckVersionNotLocked returns an error if the existing version is locked explicitly or by the package policy
+	CheckVersionNotLocked(packageId string, version string, status string) error
+	// CheckPublish forbids publication of a new revision of the locked version
+	CheckPublish(packageId string, version string) error
+	IsVersionLocked(packageId string, version string, status string) (bool, error)
+}
+
+func NewVersionLockService(repo repository.VersionLockRepository,
+	publishedRepo repository.PublishedRepository,
+	atService ActivityTrackingService,
+	isSysadm func(context.SecurityContext) bool) VersionLockService {
+	return &versionLockServiceImpl{
+		repo:          repo,
+		publishedRepo: publishedRepo,
+		atService:     atService,
+		isSysadm:      isSysadm,
+	}
+}
+
+type versionLockServiceImpl struct {
+	repo          repository.VersionLockRepository
+	publishedRepo repository.PublishedRepository
+	atService     ActivityTrackingService
+	isSysadm      func(context.SecurityContext) bool
+}
+
+func (v versionLockServiceImpl) GetPolicy(packageId string) (*view.VersionLockPolicy, error) {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return nil, err
+	}
+	ent, err := v.repo.GetPolicyForHierarchy(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return &view.VersionLockPolicy{Statuses: make([]string, 0)}, nil
+	}
+	return entity.MakeVersionLockPolicyView(*ent, packageId), nil
+}
+
+// SetPolicy allows package managers to lock more statuses, but only sysadmin could remove statuses from the effective policy,
+// which is the package own policy or the policy inherited from the nearest parent
+func (v versionLockServiceImpl) SetPolicy(ctx context.SecurityContext, packageId string, req view.VersionLockPolicyUpdate) error {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return err
+	}
+	if len(req.Statuses) == 0 {
+		req.Statuses = view.DefaultVersionLockStatuses
+	}
+	if !v.isSysadm(ctx) {
+		currentPolicy, err := v.repo.GetPolicyForHierarchy(packageId)
+		if err != nil {
+			return err
+		}
+		if currentPolicy != nil {
+			for _, status := range currentPolicy.Statuses {
+				if !utils.SliceContains(req.Statuses, status) {
+					return &exception.CustomError{
+						Status:  http.StatusForbidden,
+						Code:    exception.VersionLockPolicyWeakened,
+						Message: exception.VersionLockPolicyWeakenedMsg,
+					}
+				}
+			}
+		}
+	}
+	return v.repo.SetPolicy(entity.VersionLockPolicyEntity{
+		PackageId: packageId,
+		Statuses:  req.Statuses,
+		UpdatedBy: ctx.GetUserId(),
+		UpdatedAt: time.Now(),
+	})
+}
+
+func (v versionLockServiceImpl) DeletePolicy(packageId string) error {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return err
+	}
+	return v.repo.DeletePolicy(packageId)
+}
+
+func (v versionLockServiceImpl) GetLock(packageId string, version string) (*view.VersionLock, error) {
+	versionEnt, err := v.getVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	return v.getLock(versionEnt.PackageId, versionEnt.Version, versionEnt.Status)
+}
+
+func (v versionLockServiceImpl) LockVersion(ctx context.SecurityContext, packageId string, version string) (*view.VersionLock, error) {
+	return v.setLock(ctx, packageId, version, true)
+}
+
+func (v versionLockServiceImpl) UnlockVersion(ctx context.SecurityContext, packageId string, version string) (*view.VersionLock, error) {
+	return v.setLock(ctx, packageId, version, false)
+}
+
+func (v versionLockServiceImpl) setLock(ctx context.SecurityContext, packageId string, version string, locked bool) (*view.VersionLock, error) {
+	versionEnt, err := v.getVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	err = v.repo.SetLock(entity.VersionLockEntity{
+		PackageId: versionEnt.PackageId,
+		Version:   versionEnt.Version,
+		Locked:    locked,
+		UpdatedBy: ctx.GetUserId(),
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	eventType := view.ATETLockVersion
+	if !locked {
+		eventType = view.ATETUnlockVersion
+	}
+	v.atService.TrackEvent(view.ActivityTrackingEvent{
+		Type: eventType,
+		Data: map[string]interface{}{
+			"version":  versionEnt.Version,
+			"revision": versionEnt.Revision,
+			"status":   versionEnt.Status,
+		},
+		PackageId: versionEnt.PackageId,
+		Date:      time.Now(),
+		UserId:    ctx.GetUserId(),
+	})
+	return v.getLock(versionEnt.PackageId, versionEnt.Version, versionEnt.Status)
+}
+
+func (v versionLockServiceImpl) CheckVersionNotLocked(packageId string, version string, status string) error {
+	locked, err := v.IsVersionLocked(packageId, version, status)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return &exception.CustomError{
+			Status:  http.StatusLocked,
+			Code:    exception.VersionLocked,
+			Message: exception.VersionLockedMsg,
+			Params:  map[string]interface{}{"version": version, "packageId": packageId},
+		}
+	}
+	return nil
+}
+
+func (v versionLockServiceImpl) CheckPublish(packageId string, version string) error {
+	versionName, _, err := repository.SplitVersionRevision(version)
+	if err != nil {
+		return err
+	}
+	versionEnt, err := v.publishedRepo.GetVersion(packageId, versionName)
+	if err != nil {
+		return err
+	}
+	if versionEnt == nil {
+		return nil
+	}
+	return v.CheckVersionNotLocked(versionEnt.PackageId, versionEnt.Version, versionEnt.Status)
+}
+
+func (v versionLockServiceImpl) IsVersionLocked(packageId string, version string, status string) (bool, error) {
+	lock, err := v.getLock(packageId, version, status)
+	if err != nil {
+		return false, err
+	}
+	return lock.Locked, nil
+}
+
+// getLock returns the explicit lock state of the version if it was locked or unlocked, otherwise the package policy is applied
+func (v versionLockServiceImpl) getLock(packageId string, version string, status string) (*view.VersionLock, error) {
+	lockEnt, err := v.repo.GetLock(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	if lockEnt != nil {
+		return &view.VersionLock{
+			Locked:    lockEnt.Locked,
+			Source:    view.VersionLockSourceVersion,
+			UpdatedBy: lockEnt.UpdatedBy,
+			UpdatedAt: &lockEnt.UpdatedAt,
+		}, nil
+	}
+	policyEnt, err := v.repo.GetPolicyForHierarchy(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if policyEnt == nil || !utils.SliceContains(policyEnt.Statuses, status) {
+		return &view.VersionLock{Locked: false}, nil
+	}
+	return &view.VersionLock{
+		Locked:          true,
+		Source:          view.VersionLockSourcePolicy,
+		PolicyPackageId: policyEnt.PackageId,
+		UpdatedBy:       policyEnt.UpdatedBy,
+		UpdatedAt:       &policyEnt.UpdatedAt,
+	}, nil
+}
+
+func (v versionLockServiceImpl) getVersion(packageId string, version string) (*entity.PublishedVersionEntity, error) {
+	versionName, _, err := repository.SplitVersionRevision(version)
+	if err != nil {
+		return nil, err
+	}
+	versionEnt, err := v.publishedRepo.GetVersion(packageId, versionName)
+	if err != nil {
+		return nil, err
+	}
+	if versionEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedPackageVersionNotFound,
+			Message: exception.PublishedPackageVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": version, "packageId": packageId},
+		}
+	}
+	return versionEnt, nil
+}
+
+func (v versionLockServiceImpl) checkPackageExistence(packageId string) error {
+	packageEnt, err := v.publishedRepo.GetPackage(packageId)
+	if err != nil {
+		return err
+	}
+	if packageEnt == nil {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	return nil
+}
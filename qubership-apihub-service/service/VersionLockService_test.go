@@ -0,0 +1,319 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionLockGetPolicyInheritance(t *testing.T) {
+	tests := []struct {
+		name              string
+		policies          map[string][]string
+		expectedStatuses  []string
+		expectedPackageId string
+		expectedInherited bool
+	}{
+		{
+			name:             "no policy in hierarchy",
+			policies:         map[string][]string{"other": {"release"}},
+			expectedStatuses: []string{},
+		},
+		{
+			name:              "own policy",
+			policies:          map[string][]string{"ws.group.pkg": {"release"}},
+			expectedStatuses:  []string{"release"},
+			expectedPackageId: "ws.group.pkg",
+		},
+		{
+			name:              "inherited from workspace",
+			policies:          map[string][]string{"ws": {"release", "archived"}},
+			expectedStatuses:  []string{"release", "archived"},
+			expectedPackageId: "ws",
+			expectedInherited: true,
+		},
+		{
+			name:              "nearest parent takes precedence",
+			policies:          map[string][]string{"ws": {"release", "archived"}, "ws.group": {"release"}},
+			expectedStatuses:  []string{"release"},
+			expectedPackageId: "ws.group",
+			expectedInherited: true,
+		},
+		{
+			name:              "own policy takes precedence over parents",
+			policies:          map[string][]string{"ws": {"release"}, "ws.group": {"release", "archived"}, "ws.group.pkg": {"draft"}},
+			expectedStatuses:  []string{"draft"},
+			expectedPackageId: "ws.group.pkg",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := makeTestVersionLockService(&mockVersionLockRepository{policies: tt.policies}, nil)
+			policy, err := service.GetPolicy("ws.group.pkg")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatuses, policy.Statuses)
+			assert.Equal(t, tt.expectedPackageId, policy.PackageId)
+			assert.Equal(t, tt.expectedInherited, policy.Inherited)
+		})
+	}
+}
+
+func TestVersionLockSetPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		policies         map[string][]string
+		sysadm           bool
+		statuses         []string
+		expectedStatuses []string
+		expectedError    string
+	}{
+		{
+			name:             "first policy in hierarchy",
+			statuses:         []string{"archived"},
+			expectedStatuses: []string{"archived"},
+		},
+		{
+			name:             "default statuses",
+			expectedStatuses: view.DefaultVersionLockStatuses,
+		},
+		{
+			name:             "inherited policy extended",
+			policies:         map[string][]string{"ws": {"release"}},
+			statuses:         []string{"release", "archived"},
+			expectedStatuses: []string{"release", "archived"},
+		},
+		{
+			name:          "inherited policy weakened",
+			policies:      map[string][]string{"ws": {"release", "archived"}},
+			statuses:      []string{"release"},
+			expectedError: exception.VersionLockPolicyWeakened,
+		},
+		{
+			name:          "own policy weakened",
+			policies:      map[string][]string{"ws.group.pkg": {"release", "archived"}},
+			statuses:      []string{"archived"},
+			expectedError: exception.VersionLockPolicyWeakened,
+		},
+		{
+			name:             "only nearest parent policy is compared",
+			policies:         map[string][]string{"ws": {"release", "archived"}, "ws.group": {"release"}},
+			statuses:         []string{"release"},
+			expectedStatuses: []string{"release"},
+		},
+		{
+			name:             "sysadmin weakens policy",
+			policies:         map[string][]string{"ws": {"release", "archived"}},
+			sysadm:           true,
+			statuses:         []string{"draft"},
+			expectedStatuses: []string{"draft"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockVersionLockRepository{policies: tt.policies}
+			service := makeTestVersionLockService(repo, nil)
+			ctx := mockSecurityContext{userId: "user"}
+			if tt.sysadm {
+				ctx.systemRole = view.SysadmRole
+			}
+			err := service.SetPolicy(ctx, "ws.group.pkg", view.VersionLockPolicyUpdate{Statuses: tt.statuses})
+			if tt.expectedError != "" {
+				if assert.IsType(t, &exception.CustomError{}, err) {
+					assert.Equal(t, tt.expectedError, err.(*exception.CustomError).Code)
+					assert.Equal(t, http.StatusForbidden, err.(*exception.CustomError).Status)
+				}
+				assert.Nil(t, repo.savedPolicy)
+				return
+			}
+			assert.NoError(t, err)
+			if assert.NotNil(t, repo.savedPolicy) {
+				assert.Equal(t, "ws.group.pkg", repo.savedPolicy.PackageId)
+				assert.Equal(t, tt.expectedStatuses, repo.savedPolicy.Statuses)
+				assert.Equal(t, "user", repo.savedPolicy.UpdatedBy)
+			}
+		})
+	}
+}
+
+func TestVersionLockIsVersionLocked(t *testing.T) {
+	tests := []struct {
+		name           string
+		policies       map[string][]string
+		locks          map[string]bool
+		status         string
+		expectedLocked bool
+		expectedSource view.VersionLockSource
+		expectedPolicy string
+	}{
+		{
+			name:   "no lock and no policy",
+			status: "release",
+		},
+		{
+			name:           "locked by inherited policy",
+			policies:       map[string][]string{"ws": {"release"}},
+			status:         "release",
+			expectedLocked: true,
+			expectedSource: view.VersionLockSourcePolicy,
+			expectedPolicy: "ws",
+		},
+		{
+			name:     "status is not in policy",
+			policies: map[string][]string{"ws": {"release"}},
+			status:   "draft",
+		},
+		{
+			name:     "nearest policy doesn't lock the status",
+			policies: map[string][]string{"ws": {"draft", "release"}, "ws.group": {"release"}},
+			status:   "draft",
+		},
+		{
+			name:           "nearest policy locks the status",
+			policies:       map[string][]string{"ws": {"release"}, "ws.group": {"draft"}},
+			status:         "draft",
+			expectedLocked: true,
+			expectedSource: view.VersionLockSourcePolicy,
+			expectedPolicy: "ws.group",
+		},
+		{
+			name:           "explicit lock without policy",
+			locks:          map[string]bool{"ws.group.pkg@2024.1": true},
+			status:         "draft",
+			expectedLocked: true,
+			expectedSource: view.VersionLockSourceVersion,
+		},
+		{
+			name:           "explicit unlock overrides policy",
+			policies:       map[string][]string{"ws.group.pkg": {"release"}},
+			locks:          map[string]bool{"ws.group.pkg@2024.1": false},
+			status:         "release",
+			expectedSource: view.VersionLockSourceVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := makeTestVersionLockService(&mockVersionLockRepository{policies: tt.policies, locks: tt.locks}, nil)
+			locked, err := service.IsVersionLocked("ws.group.pkg", "2024.1", tt.status)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLocked, locked)
+
+			lock, err := service.getLock("ws.group.pkg", "2024.1", tt.status)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSource, lock.Source)
+			assert.Equal(t, tt.expectedPolicy, lock.PolicyPackageId)
+		})
+	}
+}
+
+func TestVersionLockCheckPublish(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       *entity.PublishedVersionEntity
+		policies      map[string][]string
+		locks         map[string]bool
+		expectedError bool
+	}{
+		{
+			name:     "new version",
+			policies: map[string][]string{"ws": {"release"}},
+		},
+		{
+			name:          "version locked by policy",
+			version:       &entity.PublishedVersionEntity{PackageId: "ws.group.pkg", Version: "2024.1", Status: "release"},
+			policies:      map[string][]string{"ws": {"release"}},
+			expectedError: true,
+		},
+		{
+			name:     "version status is not locked",
+			version:  &entity.PublishedVersionEntity{PackageId: "ws.group.pkg", Version: "2024.1", Status: "draft"},
+			policies: map[string][]string{"ws": {"release"}},
+		},
+		{
+			name:     "version unlocked explicitly",
+			version:  &entity.PublishedVersionEntity{PackageId: "ws.group.pkg", Version: "2024.1", Status: "release"},
+			policies: map[string][]string{"ws": {"release"}},
+			locks:    map[string]bool{"ws.group.pkg@2024.1": false},
+		},
+		{
+			name:          "version locked explicitly",
+			version:       &entity.PublishedVersionEntity{PackageId: "ws.group.pkg", Version: "2024.1", Status: "draft"},
+			locks:         map[string]bool{"ws.group.pkg@2024.1": true},
+			expectedError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publishedRepo := &mockPublishedRepository{
+				GetVersionFunc: func(packageId string, versionName string) (*entity.PublishedVersionEntity, error) {
+					assert.Equal(t, "2024.1", versionName, "revision must be removed from the version")
+					return tt.version, nil
+				},
+			}
+			service := makeTestVersionLockService(&mockVersionLockRepository{policies: tt.policies, locks: tt.locks}, publishedRepo)
+			err := service.CheckPublish("ws.group.pkg", "2024.1@3")
+			if !tt.expectedError {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.IsType(t, &exception.CustomError{}, err) {
+				assert.Equal(t, exception.VersionLocked, err.(*exception.CustomError).Code)
+				assert.Equal(t, http.StatusLocked, err.(*exception.CustomError).Status)
+			}
+		})
+	}
+}
+
+func makeTestVersionLockService(repo repository.VersionLockRepository, publishedRepo *mockPublishedRepository) versionLockServiceImpl {
+	if publishedRepo == nil {
+		publishedRepo = &mockPublishedRepository{}
+	}
+	if publishedRepo.GetPackageFunc == nil {
+		publishedRepo.GetPackageFunc = func(id string) (*entity.PackageEntity, error) {
+			return &entity.PackageEntity{Id: id}, nil
+		}
+	}
+	return versionLockServiceImpl{
+		repo:          repo,
+		publishedRepo: publishedRepo,
+		isSysadm:      mockRoleService{}.IsSysadm,
+	}
+}
+
+type mockVersionLockRepository struct {
+	repository.VersionLockRepository
+	policies    map[string][]string
+	locks       map[string]bool
+	savedPolicy *entity.VersionLockPolicyEntity
+}
+
+func (m *mockVersionLockRepository) GetLock(packageId string, version string) (*entity.VersionLockEntity, error) {
+	locked, exists := m.locks[packageId+"@"+version]
+	if !exists {
+		return nil, nil
+	}
+	return &entity.VersionLockEntity{PackageId: packageId, Version: version, Locked: locked}, nil
+}
+
+// GetPolicyForHierarchy returns the policy of the package or of the closest parent like the repository does
+func (m *mockVersionLockRepository) GetPolicyForHierarchy(packageId string) (*entity.VersionLockPolicyExtEntity, error) {
+	hierarchy := utils.GetPackageHierarchy(packageId)
+	for i := len(hierarchy) - 1; i >= 0; i-- {
+		if statuses, exists := m.policies[hierarchy[i]]; exists {
+			return &entity.VersionLockPolicyExtEntity{
+				VersionLockPolicyEntity: entity.VersionLockPolicyEntity{PackageId: hierarchy[i], Statuses: statuses},
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockVersionLockRepository) SetPolicy(ent entity.VersionLockPolicyEntity) error {
+	m.savedPolicy = &ent
+	return nil
+}
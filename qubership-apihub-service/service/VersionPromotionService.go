@@ -0,0 +1,666 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type VersionPromotionService interface {
+	GetPolicy(packageId string) (*view.VersionPromotionPolicy, error)
+	SetPolicy(ctx context.SecurityContext, packageId string, req view.VersionPromotionPolicyUpdate) error
+	DeletePolicy(packageId string) error
+
+	// RequestPromotion creates promotion request if the status change requires approval according to the package policy,
+	// returns nil if the status could be changed right away
+	RequestPromotion(ctx context.SecurityContext, packageId string, version string, status string) (*view.VersionPromotionRequest, error)
+	// CheckPublish forbids changing status of the version via publication if the status change requires approval,
+	// publication of a new version is checked as a change from no status
+	CheckPublish(packageId string, version string, status string) error
+
+	GetRequest(packageId string, requestId string) (*view.VersionPromotionRequest, error)
+	ListRequests(packageId string, state string) (*view.VersionPromotionRequests, error)
+	ListRequestsAwaitingApproval(ctx context.SecurityContext) (*view.VersionPromotionRequests, error)
+	ApproveRequest(ctx context.SecurityContext, packageId string, requestId string, comment string) (*view.VersionPromotionRequest, error)
+	RejectRequest(ctx context.SecurityContext, packageId string, requestId string, comment string) (*view.VersionPromotionRequest, error)
+	CancelRequest(ctx context.SecurityContext, packageId string, requestId string) error
+
+	GetNotifications(ctx context.SecurityContext, req view.VersionPromotionNotificationListReq) (*view.VersionPromotionNotifications, error)
+	MarkNotificationRead(ctx context.SecurityContext, notificationId string) error
+}
+
+func NewVersionPromotionService(repo repository.VersionPromotionRepository,
+	roleRepository repository.RoleRepository,
+	publishedRepo repository.PublishedRepository,
+	roleService RoleService,
+	versionService VersionService,
+	packageService PackageService,
+	atService ActivityTrackingService) VersionPromotionService {
+	return &versionPromotionServiceImpl{
+		repo:           repo,
+		roleRepository: roleRepository,
+		publishedRepo:  publishedRepo,
+		roleService:    roleService,
+		versionService: versionService,
+		packageService: packageService,
+		atService:      atService,
+	}
+}
+
+type versionPromotionServiceImpl struct {
+	repo           repository.VersionPromotionRepository
+	roleRepository repository.RoleRepository
+	publishedRepo  repository.PublishedRepository
+	roleService    RoleService
+	versionService VersionService
+	packageService PackageService
+	atService      ActivityTrackingService
+}
+
+func (v versionPromotionServiceImpl) GetPolicy(packageId string) (*view.VersionPromotionPolicy, error) {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return nil, err
+	}
+	ent, err := v.repo.GetPolicyForHierarchy(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return &view.VersionPromotionPolicy{
+			RequiredApprovals: 0,
+			ApproverRoles:     make([]string, 0),
+			Statuses:          view.DefaultVersionPromotionStatuses,
+		}, nil
+	}
+	return entity.MakeVersionPromotionPolicyView(*ent, packageId), nil
+}
+
+func (v versionPromotionServiceImpl) SetPolicy(ctx context.SecurityContext, packageId string, req view.VersionPromotionPolicyUpdate) error {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return err
+	}
+	invalidPolicyError := func(details string) error {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.InvalidVersionPromotionPolicy,
+			Message: exception.InvalidVersionPromotionPolicyMsg,
+			Params:  map[string]interface{}{"details": details},
+		}
+	}
+	for _, roleId := range req.ApproverRoles {
+		role, err := v.roleRepository.GetRole(roleId)
+		if err != nil {
+			return err
+		}
+		if role == nil {
+			return invalidPolicyError(fmt.Sprintf("role '%s' doesn't exist", roleId))
+		}
+	}
+	if len(req.Statuses) == 0 {
+		req.Statuses = view.DefaultVersionPromotionStatuses
+	}
+	return v.repo.SetPolicy(entity.VersionPromotionPolicyEntity{
+		PackageId:         packageId,
+		RequiredApprovals: req.RequiredApprovals,
+		ApproverRoles:     req.ApproverRoles,
+		Statuses:          req.Statuses,
+		UpdatedBy:         ctx.GetUserId(),
+		UpdatedAt:         time.Now(),
+	})
+}
+
+func (v versionPromotionServiceImpl) DeletePolicy(packageId string) error {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return err
+	}
+	return v.repo.DeletePolicy(packageId)
+}
+
+func (v versionPromotionServiceImpl) RequestPromotion(ctx context.SecurityContext, packageId string, version string, status string) (*view.VersionPromotionRequest, error) {
+	versionEnt, err := v.getVersion(packageId, version)
+	if err != nil {
+		return nil, err
+	}
+	if versionEnt.Status == status {
+		return nil, nil
+	}
+	policyEnt, err := v.getPolicyForStatus(packageId, status)
+	if err != nil {
+		return nil, err
+	}
+	if policyEnt == nil {
+		return nil, nil
+	}
+	existingRequest, err := v.repo.GetPendingRequest(packageId, versionEnt.Version)
+	if err != nil {
+		return nil, err
+	}
+	if existingRequest != nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.VersionPromotionRequestAlreadyExists,
+			Message: exception.VersionPromotionRequestAlreadyExistsMsg,
+			Params:  map[string]interface{}{"version": versionEnt.Version, "requestId": existingRequest.RequestId},
+		}
+	}
+	ent := entity.VersionPromotionRequestEntity{
+		RequestId:         uuid.New().String(),
+		PackageId:         packageId,
+		Version:           versionEnt.Version,
+		FromStatus:        versionEnt.Status,
+		ToStatus:          status,
+		State:             string(view.VersionPromotionRequestPending),
+		RequiredApprovals: policyEnt.RequiredApprovals,
+		ApproverRoles:     policyEnt.ApproverRoles,
+		CreatedBy:         ctx.GetUserId(),
+		CreatedAt:         time.Now(),
+	}
+	err = v.repo.CreateRequest(ent)
+	if err != nil {
+		return nil, err
+	}
+	v.trackRequestEvent(ctx, view.ATETCreateVersionPromotionRequest, ent, map[string]interface{}{
+		"requiredApprovals": ent.RequiredApprovals,
+		"approverRoles":     ent.ApproverRoles,
+	})
+	if err = v.notifyApprovers(ent); err != nil {
+		log.Errorf("Failed to notify approvers of version promotion request %s: %s", ent.RequestId, err.Error())
+	}
+	result := entity.MakeVersionPromotionRequestView(ent, nil)
+	return &result, nil
+}
+
+func (v versionPromotionServiceImpl) CheckPublish(packageId string, version string, status string) error {
+	versionName, _, err := repository.SplitVersionRevision(version)
+	if err != nil {
+		return err
+	}
+	versionEnt, err := v.publishedRepo.GetVersion(packageId, versionName)
+	if err != nil {
+		return err
+	}
+	if versionEnt != nil && versionEnt.Status == status {
+		return nil
+	}
+	policyEnt, err := v.getPolicyForStatus(packageId, status)
+	if err != nil {
+		return err
+	}
+	if policyEnt == nil {
+		return nil
+	}
+	if versionEnt == nil {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.NewVersionPromotionApprovalRequired,
+			Message: exception.NewVersionPromotionApprovalRequiredMsg,
+			Params:  map[string]interface{}{"version": versionName, "status": status},
+		}
+	}
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.VersionPromotionApprovalRequired,
+		Message: exception.VersionPromotionApprovalRequiredMsg,
+		Params:  map[string]interface{}{"version": versionName, "status": status},
+	}
+}
+
+// getPolicyForStatus returns the package policy if it requires approval for the status
+func (v versionPromotionServiceImpl) getPolicyForStatus(packageId string, status string) (*entity.VersionPromotionPolicyExtEntity, error) {
+	policyEnt, err := v.repo.GetPolicyForHierarchy(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if policyEnt == nil || policyEnt.RequiredApprovals < 1 || !utils.SliceContains(policyEnt.Statuses, status) {
+		return nil, nil
+	}
+	return policyEnt, nil
+}
+
+func (v versionPromotionServiceImpl) GetRequest(packageId string, requestId string) (*view.VersionPromotionRequest, error) {
+	ent, err := v.repo.GetRequest(requestId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil || ent.PackageId != packageId {
+		return nil, promotionRequestNotFoundError(requestId)
+	}
+	return v.makeRequestView(*ent)
+}
+
+func (v versionPromotionServiceImpl) ListRequests(packageId string, state string) (*view.VersionPromotionRequests, error) {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return nil, err
+	}
+	ents, err := v.repo.ListRequests(packageId, state)
+	if err != nil {
+		return nil, err
+	}
+	return v.makeRequestsView(ents)
+}
+
+// ListRequestsAwaitingApproval returns pending requests which the user could approve and has not approved or rejected yet
+func (v versionPromotionServiceImpl) ListRequestsAwaitingApproval(ctx context.SecurityContext) (*view.VersionPromotionRequests, error) {
+	ents, err := v.repo.ListPendingRequests()
+	if err != nil {
+		return nil, err
+	}
+	requestIds := make([]string, 0, len(ents))
+	for _, ent := range ents {
+		requestIds = append(requestIds, ent.RequestId)
+	}
+	approvalEnts, err := v.repo.GetApprovals(requestIds)
+	if err != nil {
+		return nil, err
+	}
+	decided := map[string]bool{}
+	for _, approvalEnt := range approvalEnts {
+		if approvalEnt.UserId == ctx.GetUserId() {
+			decided[approvalEnt.RequestId] = true
+		}
+	}
+	awaitingEnts := make([]entity.VersionPromotionRequestEntity, 0)
+	for _, ent := range ents {
+		if decided[ent.RequestId] || ent.CreatedBy == ctx.GetUserId() {
+			continue
+		}
+		isApprover, err := v.isApprover(ctx, ent)
+		if err != nil {
+			return nil, err
+		}
+		if isApprover {
+			awaitingEnts = append(awaitingEnts, ent)
+		}
+	}
+	return v.makeRequestsView(awaitingEnts)
+}
+
+func (v versionPromotionServiceImpl) ApproveRequest(ctx context.SecurityContext, packageId string, requestId string, comment string) (*view.VersionPromotionRequest, error) {
+	ent, err := v.getPendingRequestForDecision(ctx, packageId, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if ent.CreatedBy == ctx.GetUserId() {
+		return nil, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.VersionPromotionSelfApproval,
+			Message: exception.VersionPromotionSelfApprovalMsg,
+		}
+	}
+	if err = v.addDecision(ctx, *ent, view.VersionPromotionApprove, comment); err != nil {
+		return nil, err
+	}
+	v.trackRequestEvent(ctx, view.ATETApproveVersionPromotionRequest, *ent, map[string]interface{}{"comment": comment})
+
+	approvalEnts, err := v.repo.GetApprovals([]string{requestId})
+	if err != nil {
+		return nil, err
+	}
+	approvals := 0
+	for _, approvalEnt := range approvalEnts {
+		if approvalEnt.Decision == string(view.VersionPromotionApprove) {
+			approvals++
+		}
+	}
+	if approvals >= ent.RequiredApprovals {
+		if err = v.applyRequest(ctx, *ent); err != nil {
+			return nil, err
+		}
+	}
+	return v.GetRequest(packageId, requestId)
+}
+
+// applyRequest resolves the request and changes the version status in one transaction,
+// so the status is changed only once even if the last approvals are made concurrently
+func (v versionPromotionServiceImpl) applyRequest(ctx context.SecurityContext, ent entity.VersionPromotionRequestEntity) error {
+	if err := v.versionService.CheckStatusChange(ent.PackageId, ent.Version, ent.ToStatus); err != nil {
+		return err
+	}
+	applied, err := v.repo.ApproveRequest(ent, ctx.GetUserId())
+	if err != nil {
+		return err
+	}
+	if !applied {
+		// resolved concurrently or the version status was changed, the check returns the actual reason
+		if _, err = v.getPendingRequestForDecision(ctx, ent.PackageId, ent.RequestId); err != nil {
+			return err
+		}
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.VersionPromotionRequestAlreadyResolved,
+			Message: exception.VersionPromotionRequestAlreadyResolvedMsg,
+			Params:  map[string]interface{}{"requestId": ent.RequestId, "state": "resolved"},
+		}
+	}
+	v.atService.TrackEvent(view.ActivityTrackingEvent{
+		Type: view.ATETPatchVersionMeta,
+		Data: map[string]interface{}{
+			"version":     ent.Version,
+			"versionMeta": []string{"status"},
+			"oldStatus":   ent.FromStatus,
+			"newStatus":   ent.ToStatus,
+		},
+		PackageId: ent.PackageId,
+		Date:      time.Now(),
+		UserId:    ctx.GetUserId(),
+	})
+	return nil
+}
+
+func (v versionPromotionServiceImpl) RejectRequest(ctx context.SecurityContext, packageId string, requestId string, comment string) (*view.VersionPromotionRequest, error) {
+	ent, err := v.getPendingRequestForDecision(ctx, packageId, requestId)
+	if err != nil {
+		return nil, err
+	}
+	if err = v.addDecision(ctx, *ent, view.VersionPromotionReject, comment); err != nil {
+		return nil, err
+	}
+	if err = v.resolveRequest(ctx, requestId, view.VersionPromotionRequestRejected); err != nil {
+		return nil, err
+	}
+	v.trackRequestEvent(ctx, view.ATETRejectVersionPromotionRequest, *ent, map[string]interface{}{"comment": comment})
+	return v.GetRequest(packageId, requestId)
+}
+
+// notifyApprovers stores notifications for the package hierarchy members with approver roles except the request author
+func (v versionPromotionServiceImpl) notifyApprovers(ent entity.VersionPromotionRequestEntity) error {
+	memberEnts, err := v.roleRepository.GetPackageHierarchyMembers(ent.PackageId)
+	if err != nil {
+		return err
+	}
+	notified := map[string]bool{ent.CreatedBy: true}
+	notificationEnts := make([]entity.VersionPromotionNotificationEntity, 0)
+	for _, memberEnt := range memberEnts {
+		if notified[memberEnt.UserId] || !utils.SliceContains(ent.ApproverRoles, memberEnt.RoleId) {
+			continue
+		}
+		notified[memberEnt.UserId] = true
+		notificationEnts = append(notificationEnts, entity.VersionPromotionNotificationEntity{
+			NotificationId: uuid.New().String(),
+			RequestId:      ent.RequestId,
+			UserId:         memberEnt.UserId,
+			CreatedAt:      ent.CreatedAt,
+		})
+	}
+	return v.repo.StoreNotifications(notificationEnts)
+}
+
+func (v versionPromotionServiceImpl) GetNotifications(ctx context.SecurityContext, req view.VersionPromotionNotificationListReq) (*view.VersionPromotionNotifications, error) {
+	ents, err := v.repo.GetNotifications(ctx.GetUserId(), req)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.VersionPromotionNotifications{Notifications: make([]view.VersionPromotionNotification, 0)}
+	for _, ent := range ents {
+		result.Notifications = append(result.Notifications, entity.MakeVersionPromotionNotificationView(ent))
+	}
+	return result, nil
+}
+
+func (v versionPromotionServiceImpl) MarkNotificationRead(ctx context.SecurityContext, notificationId string) error {
+	updated, err := v.repo.MarkNotificationRead(ctx.GetUserId(), notificationId)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.VersionPromotionNotificationNotFound,
+			Message: exception.VersionPromotionNotificationNotFoundMsg,
+			Params:  map[string]interface{}{"notificationId": notificationId},
+		}
+	}
+	return nil
+}
+
+func (v versionPromotionServiceImpl) CancelRequest(ctx context.SecurityContext, packageId string, requestId string) error {
+	ent, err := v.getPendingRequest(packageId, requestId)
+	if err != nil {
+		return err
+	}
+	if ent.CreatedBy != ctx.GetUserId() && !v.roleService.IsSysadm(ctx) {
+		return &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.InsufficientPrivileges,
+			Message: exception.InsufficientPrivilegesMsg,
+		}
+	}
+	if err = v.resolveRequest(ctx, requestId, view.VersionPromotionRequestCancelled); err != nil {
+		return err
+	}
+	v.trackRequestEvent(ctx, view.ATETCancelVersionPromotionRequest, *ent, nil)
+	return nil
+}
+
+// getPendingRequestForDecision checks that the user could approve or reject the request and the request is still actual
+func (v versionPromotionServiceImpl) getPendingRequestForDecision(ctx context.SecurityContext, packageId string, requestId string) (*entity.VersionPromotionRequestEntity, error) {
+	ent, err := v.getPendingRequest(packageId, requestId)
+	if err != nil {
+		return nil, err
+	}
+	isApprover, err := v.isApprover(ctx, *ent)
+	if err != nil {
+		return nil, err
+	}
+	if !isApprover {
+		return nil, &exception.CustomError{
+			Status:  http.StatusForbidden,
+			Code:    exception.NotVersionPromotionApprover,
+			Message: exception.NotVersionPromotionApproverMsg,
+			Params:  map[string]interface{}{"roles": ent.ApproverRoles},
+		}
+	}
+	versionEnt, err := v.getVersion(packageId, ent.Version)
+	if err != nil {
+		return nil, err
+	}
+	if versionEnt.Status != ent.FromStatus {
+		if _, err = v.repo.ResolveRequest(requestId, string(view.VersionPromotionRequestCancelled), ""); err != nil {
+			return nil, err
+		}
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.VersionPromotionRequestOutdated,
+			Message: exception.VersionPromotionRequestOutdatedMsg,
+			Params:  map[string]interface{}{"requestId": requestId, "status": versionEnt.Status},
+		}
+	}
+	return ent, nil
+}
+
+func (v versionPromotionServiceImpl) isApprover(ctx context.SecurityContext, ent entity.VersionPromotionRequestEntity) (bool, error) {
+	if v.roleService.IsSysadm(ctx) {
+		return true, nil
+	}
+	if ctx.GetApikeyPackageId() != "" {
+		for _, role := range ctx.GetApikeyRoles() {
+			if utils.SliceContains(ent.ApproverRoles, role) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	memberRoles, err := v.roleRepository.GetPackageRolesHierarchyForUser(ent.PackageId, ctx.GetUserId())
+	if err != nil {
+		return false, err
+	}
+	for _, memberRole := range memberRoles {
+		if utils.SliceContains(ent.ApproverRoles, memberRole.RoleId) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (v versionPromotionServiceImpl) addDecision(ctx context.SecurityContext, ent entity.VersionPromotionRequestEntity, decision view.VersionPromotionDecision, comment string) error {
+	added, err := v.repo.AddApproval(entity.VersionPromotionApprovalEntity{
+		RequestId: ent.RequestId,
+		UserId:    ctx.GetUserId(),
+		Decision:  string(decision),
+		Comment:   comment,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if !added {
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.VersionPromotionAlreadyDecided,
+			Message: exception.VersionPromotionAlreadyDecidedMsg,
+			Params:  map[string]interface{}{"requestId": ent.RequestId},
+		}
+	}
+	return nil
+}
+
+func (v versionPromotionServiceImpl) getPendingRequest(packageId string, requestId string) (*entity.VersionPromotionRequestEntity, error) {
+	ent, err := v.repo.GetRequest(requestId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil || ent.PackageId != packageId {
+		return nil, promotionRequestNotFoundError(requestId)
+	}
+	if ent.State != string(view.VersionPromotionRequestPending) {
+		return nil, &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.VersionPromotionRequestAlreadyResolved,
+			Message: exception.VersionPromotionRequestAlreadyResolvedMsg,
+			Params:  map[string]interface{}{"requestId": requestId, "state": ent.State},
+		}
+	}
+	return ent, nil
+}
+
+func (v versionPromotionServiceImpl) resolveRequest(ctx context.SecurityContext, requestId string, state view.VersionPromotionRequestState) error {
+	resolved, err := v.repo.ResolveRequest(requestId, string(state), ctx.GetUserId())
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		// resolved concurrently
+		return &exception.CustomError{
+			Status:  http.StatusBadRequest,
+			Code:    exception.VersionPromotionRequestAlreadyResolved,
+			Message: exception.VersionPromotionRequestAlreadyResolvedMsg,
+			Params:  map[string]interface{}{"requestId": requestId, "state": "resolved"},
+		}
+	}
+	return nil
+}
+
+func (v versionPromotionServiceImpl) getVersion(packageId string, version string) (*entity.PublishedVersionEntity, error) {
+	versionName, _, err := repository.SplitVersionRevision(version)
+	if err != nil {
+		return nil, err
+	}
+	versionEnt, err := v.publishedRepo.GetVersion(packageId, versionName)
+	if err != nil {
+		return nil, err
+	}
+	if versionEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedPackageVersionNotFound,
+			Message: exception.PublishedPackageVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": version, "packageId": packageId},
+		}
+	}
+	return versionEnt, nil
+}
+
+func (v versionPromotionServiceImpl) makeRequestView(ent entity.VersionPromotionRequestEntity) (*view.VersionPromotionRequest, error) {
+	approvalEnts, err := v.repo.GetApprovals([]string{ent.RequestId})
+	if err != nil {
+		return nil, err
+	}
+	result := entity.MakeVersionPromotionRequestView(ent, approvalEnts)
+	return &result, nil
+}
+
+func (v versionPromotionServiceImpl) makeRequestsView(ents []entity.VersionPromotionRequestEntity) (*view.VersionPromotionRequests, error) {
+	requestIds := make([]string, 0, len(ents))
+	for _, ent := range ents {
+		requestIds = append(requestIds, ent.RequestId)
+	}
+	approvalEnts, err := v.repo.GetApprovals(requestIds)
+	if err != nil {
+		return nil, err
+	}
+	approvalsByRequest := map[string][]entity.VersionPromotionApprovalEntity{}
+	for _, approvalEnt := range approvalEnts {
+		approvalsByRequest[approvalEnt.RequestId] = append(approvalsByRequest[approvalEnt.RequestId], approvalEnt)
+	}
+	result := view.VersionPromotionRequests{Requests: make([]view.VersionPromotionRequest, 0, len(ents))}
+	for _, ent := range ents {
+		result.Requests = append(result.Requests, entity.MakeVersionPromotionRequestView(ent, approvalsByRequest[ent.RequestId]))
+	}
+	return &result, nil
+}
+
+func (v versionPromotionServiceImpl) trackRequestEvent(ctx context.SecurityContext, eventType view.ATEventType, ent entity.VersionPromotionRequestEntity, data map[string]interface{}) {
+	dataMap := map[string]interface{}{}
+	for key, value := range data {
+		dataMap[key] = value
+	}
+	dataMap["requestId"] = ent.RequestId
+	dataMap["version"] = ent.Version
+	dataMap["fromStatus"] = ent.FromStatus
+	dataMap["toStatus"] = ent.ToStatus
+	v.atService.TrackEvent(view.ActivityTrackingEvent{
+		Type:      eventType,
+		Data:      dataMap,
+		PackageId: ent.PackageId,
+		Date:      time.Now(),
+		UserId:    ctx.GetUserId(),
+	})
+}
+
+func (v versionPromotionServiceImpl) checkPackageExistence(packageId string) error {
+	exists, err := v.packageService.PackageExists(packageId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	return nil
+}
+
+func promotionRequestNotFoundError(requestId string) error {
+	return &exception.CustomError{
+		Status:  http.StatusNotFound,
+		Code:    exception.VersionPromotionRequestNotFound,
+		Message: exception.VersionPromotionRequestNotFoundMsg,
+		Params:  map[string]interface{}{"requestId": requestId},
+	}
+}
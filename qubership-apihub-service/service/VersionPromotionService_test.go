@@ -0,0 +1,212 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionPromotionCheckPublish(t *testing.T) {
+	releasePolicy := &entity.VersionPromotionPolicyExtEntity{
+		VersionPromotionPolicyEntity: entity.VersionPromotionPolicyEntity{
+			PackageId:         "ws.group",
+			RequiredApprovals: 2,
+			ApproverRoles:     []string{"owner"},
+			Statuses:          []string{"release"},
+		},
+	}
+	tests := []struct {
+		name          string
+		version       *entity.PublishedVersionEntity
+		policy        *entity.VersionPromotionPolicyExtEntity
+		status        string
+		expectedError string
+	}{
+		{
+			name:    "no policy",
+			version: &entity.PublishedVersionEntity{Version: "2024.1", Status: "draft"},
+			status:  "release",
+		},
+		{
+			name:    "policy without required approvals",
+			version: &entity.PublishedVersionEntity{Version: "2024.1", Status: "draft"},
+			policy: &entity.VersionPromotionPolicyExtEntity{
+				VersionPromotionPolicyEntity: entity.VersionPromotionPolicyEntity{RequiredApprovals: 0, Statuses: []string{"release"}},
+			},
+			status: "release",
+		},
+		{
+			name:    "status doesn't require approval",
+			version: &entity.PublishedVersionEntity{Version: "2024.1", Status: "release"},
+			policy:  releasePolicy,
+			status:  "archived",
+		},
+		{
+			name:    "new revision in the same status",
+			version: &entity.PublishedVersionEntity{Version: "2024.1", Status: "release"},
+			policy:  releasePolicy,
+			status:  "release",
+		},
+		{
+			name:          "status change requires approval",
+			version:       &entity.PublishedVersionEntity{Version: "2024.1", Status: "draft"},
+			policy:        releasePolicy,
+			status:        "release",
+			expectedError: exception.VersionPromotionApprovalRequired,
+		},
+		{
+			name:          "new version requires approval",
+			policy:        releasePolicy,
+			status:        "release",
+			expectedError: exception.NewVersionPromotionApprovalRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := versionPromotionServiceImpl{
+				repo: &mockVersionPromotionRepository{policy: tt.policy},
+				publishedRepo: &mockPublishedRepository{
+					GetVersionFunc: func(packageId string, versionName string) (*entity.PublishedVersionEntity, error) {
+						assert.Equal(t, "2024.1", versionName, "revision must be removed from the version")
+						return tt.version, nil
+					},
+				},
+			}
+
+			err := service.CheckPublish("ws.group.pkg", "2024.1@3", tt.status)
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err, tt.name)
+				return
+			}
+			customError, ok := err.(*exception.CustomError)
+			if assert.True(t, ok, "expected custom error, got %v", err) {
+				assert.Equal(t, tt.expectedError, customError.Code, tt.name)
+			}
+		})
+	}
+}
+
+func TestVersionPromotionIsApprover(t *testing.T) {
+	request := entity.VersionPromotionRequestEntity{
+		PackageId:     "ws.group.pkg",
+		ApproverRoles: []string{"owner", "release-manager"},
+	}
+	tests := []struct {
+		name        string
+		ctx         mockSecurityContext
+		memberRoles []string
+		expected    bool
+	}{
+		{
+			name:     "sysadm",
+			ctx:      mockSecurityContext{userId: "admin", systemRole: view.SysadmRole},
+			expected: true,
+		},
+		{
+			name:        "member with approver role",
+			ctx:         mockSecurityContext{userId: "user"},
+			memberRoles: []string{"viewer", "release-manager"},
+			expected:    true,
+		},
+		{
+			name:        "member without approver role",
+			ctx:         mockSecurityContext{userId: "user"},
+			memberRoles: []string{"viewer", "editor"},
+			expected:    false,
+		},
+		{
+			name:     "api key with approver role",
+			ctx:      mockSecurityContext{userId: "key", apikeyPackageId: "ws", apikeyRoles: []string{"owner"}},
+			expected: true,
+		},
+		{
+			name:        "api key roles are not extended by member roles",
+			ctx:         mockSecurityContext{userId: "key", apikeyPackageId: "ws", apikeyRoles: []string{"viewer"}},
+			memberRoles: []string{"owner"},
+			expected:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := versionPromotionServiceImpl{
+				roleService:    mockRoleService{},
+				roleRepository: mockRoleRepository{memberRoles: tt.memberRoles},
+			}
+
+			isApprover, err := service.isApprover(tt.ctx, request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, isApprover, tt.name)
+		})
+	}
+}
+
+type mockVersionPromotionRepository struct {
+	repository.VersionPromotionRepository
+	policy *entity.VersionPromotionPolicyExtEntity
+}
+
+func (m *mockVersionPromotionRepository) GetPolicyForHierarchy(packageId string) (*entity.VersionPromotionPolicyExtEntity, error) {
+	return m.policy, nil
+}
+
+type mockPublishedRepository struct {
+	repository.PublishedRepository
+	GetVersionFunc          func(packageId string, versionName string) (*entity.PublishedVersionEntity, error)
+	GetVersionsByStatusFunc func(packageId string, status string) ([]entity.PublishedVersionEntity, error)
+	GetPackageFunc          func(id string) (*entity.PackageEntity, error)
+}
+
+func (m *mockPublishedRepository) GetVersion(packageId string, versionName string) (*entity.PublishedVersionEntity, error) {
+	return m.GetVersionFunc(packageId, versionName)
+}
+
+func (m *mockPublishedRepository) GetVersionsByStatus(packageId string, status string) ([]entity.PublishedVersionEntity, error) {
+	return m.GetVersionsByStatusFunc(packageId, status)
+}
+
+func (m *mockPublishedRepository) GetPackage(id string) (*entity.PackageEntity, error) {
+	return m.GetPackageFunc(id)
+}
+
+type mockRoleService struct {
+	RoleService
+}
+
+func (m mockRoleService) IsSysadm(ctx context.SecurityContext) bool {
+	return ctx.GetUserSystemRole() == view.SysadmRole
+}
+
+type mockRoleRepository struct {
+	repository.RoleRepository
+	memberRoles []string
+}
+
+func (m mockRoleRepository) GetPackageRolesHierarchyForUser(packageId string, userId string) ([]entity.PackageMemberRoleRichEntity, error) {
+	result := make([]entity.PackageMemberRoleRichEntity, 0)
+	for _, roleId := range m.memberRoles {
+		result = append(result, entity.PackageMemberRoleRichEntity{PackageId: packageId, UserId: userId, RoleId: roleId})
+	}
+	return result, nil
+}
+
+type mockSecurityContext struct {
+	userId          string
+	systemRole      string
+	apikeyPackageId string
+	apikeyRoles     []string
+}
+
+func (m mockSecurityContext) GetUserId() string          { return m.userId }
+func (m mockSecurityContext) GetUserSystemRole() string  { return m.systemRole }
+func (m mockSecurityContext) GetApikeyRoles() []string   { return m.apikeyRoles }
+func (m mockSecurityContext) GetApikeyPackageId() string { return m.apikeyPackageId }
+func (m mockSecurityContext) GetUserToken() string       { return "" }
+func (m mockSecurityContext) GetApiKey() string          { return "" }
+func (m mockSecurityContext) GetApiKeyId() string        { return "" }
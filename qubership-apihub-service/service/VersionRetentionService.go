@@ -0,0 +1,460 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	mRepository "github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/migration/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/utils"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+type VersionRetentionService interface {
+	GetPolicy(packageId string) (*view.VersionRetentionPolicy, error)
+	SetPolicy(ctx context.SecurityContext, packageId string, req view.VersionRetentionPolicyUpdate) error
+	DeletePolicy(packageId string) error
+
+	PreviewCleanup(packageId string) (*view.VersionRetentionPreview, error)
+	GetReport(packageId string, req view.VersionRetentionReportReq) (*view.VersionRetentionReport, error)
+
+	CreateCleanupJob(schedule string) error
+	StartCleanup(ctx context.SecurityContext) (int, error)
+	ListRuns(req view.VersionRetentionReportReq) (*view.VersionRetentionRuns, error)
+	GetRun(runId int, req view.VersionRetentionReportReq) (*view.VersionRetentionRunReport, error)
+}
+
+func NewVersionRetentionService(repo repository.VersionRetentionRepository,
+	publishedRepo repository.PublishedRepository,
+	migrationRepository mRepository.MigrationRunRepository,
+	packageService PackageService,
+	versionLifecycleService VersionLifecycleService,
+	versionLockService VersionLockService,
+	versionService VersionService) VersionRetentionService {
+	return &versionRetentionServiceImpl{
+		repo:                    repo,
+		publishedRepo:           publishedRepo,
+		migrationRepository:     migrationRepository,
+		packageService:          packageService,
+		versionLifecycleService: versionLifecycleService,
+		versionLockService:      versionLockService,
+		versionService:          versionService,
+		cron:                    cron.New(),
+	}
+}
+
+type versionRetentionServiceImpl struct {
+	repo                    repository.VersionRetentionRepository
+	publishedRepo           repository.PublishedRepository
+	migrationRepository     mRepository.MigrationRunRepository
+	packageService          PackageService
+	versionLifecycleService VersionLifecycleService
+	versionLockService      VersionLockService
+	versionService          VersionService
+	cron                    *cron.Cron
+}
+
+// a run which is not finished during this period is considered as failed (e.g. replica was restarted), so a new run could be started manually
+const versionRetentionRunTimeout = time.Hour
+
+func (v *versionRetentionServiceImpl) GetPolicy(packageId string) (*view.VersionRetentionPolicy, error) {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return nil, err
+	}
+	ent, err := v.repo.GetPolicyForHierarchy(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if ent == nil {
+		return &view.VersionRetentionPolicy{Rules: make([]view.VersionRetentionRule, 0)}, nil
+	}
+	return entity.MakeVersionRetentionPolicyView(*ent, packageId), nil
+}
+
+func (v *versionRetentionServiceImpl) SetPolicy(ctx context.SecurityContext, packageId string, req view.VersionRetentionPolicyUpdate) error {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return err
+	}
+	statuses := make(map[string]bool)
+	for _, rule := range req.Rules {
+		if err := v.versionLifecycleService.ValidateStatus(rule.Status); err != nil {
+			return err
+		}
+		if statuses[rule.Status] {
+			return invalidVersionRetentionPolicyError(fmt.Sprintf("status '%s' is used in more than one rule", rule.Status))
+		}
+		statuses[rule.Status] = true
+		if rule.KeepLast != nil && *rule.KeepLast < 0 {
+			return invalidVersionRetentionPolicyError("keepLast must not be negative")
+		}
+		if rule.MaxAgeDays != nil && *rule.MaxAgeDays < 1 {
+			return invalidVersionRetentionPolicyError("maxAgeDays must be positive")
+		}
+	}
+	return v.repo.SetPolicy(entity.VersionRetentionPolicyEntity{
+		PackageId: packageId,
+		Rules:     req.Rules,
+		UpdatedBy: ctx.GetUserId(),
+		UpdatedAt: time.Now(),
+	})
+}
+
+func (v *versionRetentionServiceImpl) DeletePolicy(packageId string) error {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return err
+	}
+	return v.repo.DeletePolicy(packageId)
+}
+
+// PreviewCleanup returns versions of the package and its descendants which would be deleted by the next cleanup run
+func (v *versionRetentionServiceImpl) PreviewCleanup(packageId string) (*view.VersionRetentionPreview, error) {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return nil, err
+	}
+	packageIds, err := v.repo.GetPackagesUnderPolicy(packageId)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.VersionRetentionPreview{Versions: make([]view.VersionRetentionCandidate, 0)}
+	now := time.Now()
+	for _, id := range packageIds {
+		candidates, err := v.getCandidates(id, now)
+		if err != nil {
+			return nil, err
+		}
+		result.Versions = append(result.Versions, candidates...)
+	}
+	return result, nil
+}
+
+func (v *versionRetentionServiceImpl) GetReport(packageId string, req view.VersionRetentionReportReq) (*view.VersionRetentionReport, error) {
+	if err := v.checkPackageExistence(packageId); err != nil {
+		return nil, err
+	}
+	ents, err := v.repo.GetDeletedVersions(packageId, 0, req.Limit, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.VersionRetentionReport{Versions: make([]view.VersionRetentionDeletedVersion, 0, len(ents))}
+	for _, ent := range ents {
+		result.Versions = append(result.Versions, entity.MakeVersionRetentionDeletedVersionView(ent))
+	}
+	return result, nil
+}
+
+func (v *versionRetentionServiceImpl) CreateCleanupJob(schedule string) error {
+	job := VersionRetentionJob{
+		schedule: schedule,
+		service:  v,
+	}
+	if len(v.cron.Entries()) == 0 {
+		location, err := time.LoadLocation("")
+		if err != nil {
+			return err
+		}
+		v.cron = cron.New(cron.WithLocation(location))
+		v.cron.Start()
+	}
+	_, err := v.cron.AddJob(schedule, &job)
+	if err != nil {
+		log.Warnf("[VersionRetentionService] Job wasn't added for schedule - %s. With error - %s", schedule, err)
+		return err
+	}
+	log.Infof("[VersionRetentionService] Job was created with schedule - %s", schedule)
+	return nil
+}
+
+func (v *versionRetentionServiceImpl) StartCleanup(ctx context.SecurityContext) (int, error) {
+	lastRun, err := v.repo.GetLastRun()
+	if err != nil {
+		return 0, err
+	}
+	runId := 1
+	if lastRun != nil {
+		if lastRun.Status == string(view.StatusRunning) && time.Since(lastRun.ScheduledAt) < versionRetentionRunTimeout {
+			return 0, &exception.CustomError{
+				Status:  http.StatusConflict,
+				Code:    exception.VersionRetentionRunInProgress,
+				Message: exception.VersionRetentionRunInProgressMsg,
+			}
+		}
+		runId = lastRun.RunId + 1
+	}
+	runEnt := entity.VersionRetentionRunEntity{
+		RunId:       runId,
+		ScheduledAt: time.Now().Round(time.Second),
+		TriggeredBy: ctx.GetUserId(),
+		Status:      string(view.StatusRunning),
+	}
+	// run id is used as a lock, so the insert fails if another run has been started concurrently
+	if err = v.repo.StoreRun(runEnt); err != nil {
+		return 0, &exception.CustomError{
+			Status:  http.StatusConflict,
+			Code:    exception.VersionRetentionRunInProgress,
+			Message: exception.VersionRetentionRunInProgressMsg,
+			Debug:   err.Error(),
+		}
+	}
+	utils.SafeAsync(func() {
+		v.runCleanup(runEnt)
+	})
+	return runId, nil
+}
+
+func (v *versionRetentionServiceImpl) ListRuns(req view.VersionRetentionReportReq) (*view.VersionRetentionRuns, error) {
+	ents, err := v.repo.ListRuns(req.Limit, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.VersionRetentionRuns{Runs: make([]view.VersionRetentionRun, 0, len(ents))}
+	for _, ent := range ents {
+		result.Runs = append(result.Runs, entity.MakeVersionRetentionRunView(ent))
+	}
+	return result, nil
+}
+
+func (v *versionRetentionServiceImpl) GetRun(runId int, req view.VersionRetentionReportReq) (*view.VersionRetentionRunReport, error) {
+	runEnt, err := v.repo.GetRun(runId)
+	if err != nil {
+		return nil, err
+	}
+	if runEnt == nil {
+		return nil, &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.VersionRetentionRunNotFound,
+			Message: exception.VersionRetentionRunNotFoundMsg,
+			Params:  map[string]interface{}{"runId": runId},
+		}
+	}
+	ents, err := v.repo.GetDeletedVersions("", runId, req.Limit, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	result := &view.VersionRetentionRunReport{
+		VersionRetentionRun: entity.MakeVersionRetentionRunView(*runEnt),
+		Versions:            make([]view.VersionRetentionDeletedVersion, 0, len(ents)),
+	}
+	for _, ent := range ents {
+		result.Versions = append(result.Versions, entity.MakeVersionRetentionDeletedVersionView(ent))
+	}
+	return result, nil
+}
+
+func (v *versionRetentionServiceImpl) runCleanup(runEnt entity.VersionRetentionRunEntity) {
+	log.Infof("Version retention cleanup %d has started", runEnt.RunId)
+	err := v.deleteVersions(&runEnt)
+	finishedAt := time.Now()
+	runEnt.FinishedAt = &finishedAt
+	if err != nil {
+		log.Errorf("Version retention cleanup %d failed: %s", runEnt.RunId, err.Error())
+		runEnt.Status = string(view.StatusError)
+		runEnt.Details = err.Error()
+	} else {
+		log.Infof("Version retention cleanup %d has deleted %d versions", runEnt.RunId, runEnt.DeletedItems)
+		runEnt.Status = string(view.StatusComplete)
+	}
+	if err = v.repo.UpdateRun(runEnt); err != nil {
+		log.Errorf("Failed to update version retention cleanup run %d: %s", runEnt.RunId, err.Error())
+	}
+}
+
+func (v *versionRetentionServiceImpl) deleteVersions(runEnt *entity.VersionRetentionRunEntity) error {
+	packageIds, err := v.repo.GetPackagesUnderPolicy("")
+	if err != nil {
+		return err
+	}
+	deletedBy := context.CreateFromId("version_retention_" + strconv.Itoa(runEnt.RunId))
+	for _, packageId := range packageIds {
+		candidates, err := v.getCandidates(packageId, runEnt.ScheduledAt)
+		if err != nil {
+			return err
+		}
+		for _, candidate := range candidates {
+			if err = v.versionService.DeleteVersion(deletedBy, candidate.PackageId, candidate.Version); err != nil {
+				if customError, ok := err.(*exception.CustomError); ok && (customError.Status == http.StatusNotFound || customError.Status == http.StatusLocked) {
+					// the version was deleted or locked after the candidates were selected
+					log.Warnf("Version retention cleanup %d skipped version %s of package %s: %s", runEnt.RunId, candidate.Version, candidate.PackageId, customError.Message)
+					continue
+				}
+				return fmt.Errorf("failed to delete version %s of package %s: %w", candidate.Version, candidate.PackageId, err)
+			}
+			runEnt.DeletedItems++
+			err = v.repo.StoreDeletedVersion(entity.VersionRetentionDeletedVersionEntity{
+				RunId:           runEnt.RunId,
+				PackageId:       candidate.PackageId,
+				Version:         candidate.Version,
+				Status:          candidate.Status,
+				PublishedAt:     candidate.PublishedAt,
+				PolicyPackageId: candidate.PolicyPackageId,
+				Reason:          string(candidate.Reason),
+				DeletedAt:       time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// getCandidates applies the effective retention policy of the package to its versions
+func (v *versionRetentionServiceImpl) getCandidates(packageId string, now time.Time) ([]view.VersionRetentionCandidate, error) {
+	result := make([]view.VersionRetentionCandidate, 0)
+	policyEnt, err := v.repo.GetPolicyForHierarchy(packageId)
+	if err != nil {
+		return nil, err
+	}
+	if policyEnt == nil {
+		return result, nil
+	}
+	packageEnt, err := v.publishedRepo.GetPackage(packageId)
+	if err != nil {
+		return nil, err
+	}
+	defaultReleaseVersion := ""
+	if packageEnt != nil {
+		defaultReleaseVersion = packageEnt.DefaultReleaseVersion
+	}
+	for _, rule := range policyEnt.Rules {
+		if rule.KeepLast == nil && rule.MaxAgeDays == nil {
+			continue
+		}
+		versionEnts, err := v.publishedRepo.GetVersionsByStatus(packageId, rule.Status)
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(versionEnts, func(i, j int) bool {
+			return versionEnts[i].PublishedAt.After(versionEnts[j].PublishedAt)
+		})
+		for i, versionEnt := range versionEnts {
+			if rule.KeepLast != nil && i < *rule.KeepLast {
+				continue
+			}
+			// locked versions and the default release version are kept, they still count for keepLast
+			if versionEnt.Version == defaultReleaseVersion {
+				continue
+			}
+			locked, err := v.versionLockService.IsVersionLocked(packageId, versionEnt.Version, versionEnt.Status)
+			if err != nil {
+				return nil, err
+			}
+			if locked {
+				continue
+			}
+			reason := view.VersionRetentionKeepLast
+			if rule.MaxAgeDays != nil {
+				if versionEnt.PublishedAt.After(now.AddDate(0, 0, -*rule.MaxAgeDays)) {
+					continue
+				}
+				reason = view.VersionRetentionMaxAge
+			}
+			result = append(result, view.VersionRetentionCandidate{
+				PackageId:       packageId,
+				Version:         versionEnt.Version,
+				Status:          versionEnt.Status,
+				PublishedAt:     versionEnt.PublishedAt,
+				PolicyPackageId: policyEnt.PackageId,
+				Reason:          reason,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (v *versionRetentionServiceImpl) checkPackageExistence(packageId string) error {
+	exists, err := v.packageService.PackageExists(packageId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PackageNotFound,
+			Message: exception.PackageNotFoundMsg,
+			Params:  map[string]interface{}{"packageId": packageId},
+		}
+	}
+	return nil
+}
+
+func invalidVersionRetentionPolicyError(details string) error {
+	return &exception.CustomError{
+		Status:  http.StatusBadRequest,
+		Code:    exception.InvalidVersionRetentionPolicy,
+		Message: exception.InvalidVersionRetentionPolicyMsg,
+		Params:  map[string]interface{}{"details": details},
+	}
+}
+
+type VersionRetentionJob struct {
+	schedule string
+	service  *versionRetentionServiceImpl
+}
+
+func (j VersionRetentionJob) Run() {
+	scheduledAt := time.Now().Round(time.Second)
+
+	migrations, err := j.service.migrationRepository.GetRunningMigrations()
+	if err != nil {
+		log.Error("Failed to check for running migrations for version retention cleanup job")
+		return
+	}
+	if len(migrations) != 0 {
+		log.Infof("Version retention cleanup was skipped at %s due to migration run", scheduledAt)
+		return
+	}
+
+	lastRun, err := j.service.repo.GetLastRun()
+	if err != nil {
+		log.Errorf("Failed to get last version retention cleanup run: %v", err)
+		return
+	}
+	runId := 1
+	if lastRun != nil {
+		schedule, err := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow).Parse(j.schedule)
+		if err != nil {
+			log.Errorf("Failed to parse schedule for version retention cleanup job: %v", err)
+			return
+		}
+		currentTime := time.Now()
+		interval := schedule.Next(currentTime).Sub(currentTime)
+		// the job is triggered on all replicas, only one of them should run the cleanup
+		if lastRun.ScheduledAt.After(currentTime.Add(-interval)) {
+			log.Infof("Version retention cleanup was skipped at %s", scheduledAt)
+			return
+		}
+		runId = lastRun.RunId + 1
+	}
+	runEnt := entity.VersionRetentionRunEntity{
+		RunId:       runId,
+		ScheduledAt: scheduledAt,
+		Status:      string(view.StatusRunning),
+	}
+	if err = j.service.repo.StoreRun(runEnt); err != nil {
+		log.Infof("Version retention cleanup was skipped at %s since it's started by another instance: %v", scheduledAt, err)
+		return
+	}
+	j.service.runCleanup(runEnt)
+}
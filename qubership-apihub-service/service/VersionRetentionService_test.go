@@ -0,0 +1,345 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/context"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/entity"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/exception"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/repository"
+	"github.com/Netcracker/qubership-apihub-backend/qubership-apihub-service/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionRetentionGetCandidates(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+	intPtr := func(i int) *int {
+		return &i
+	}
+	// not sorted by publication date on purpose
+	draftVersions := []entity.PublishedVersionEntity{
+		{Version: "v3", Status: "draft", PublishedAt: daysAgo(30)},
+		{Version: "v5", Status: "draft", PublishedAt: daysAgo(1)},
+		{Version: "v1", Status: "draft", PublishedAt: daysAgo(200)},
+		{Version: "v4", Status: "draft", PublishedAt: daysAgo(10)},
+		{Version: "v2", Status: "draft", PublishedAt: daysAgo(100)},
+	}
+	tests := []struct {
+		name               string
+		rule               view.VersionRetentionRule
+		lockedVersions     []string
+		defaultRelease     string
+		expectedCandidates []string
+		expectedReason     view.VersionRetentionReason
+	}{
+		{
+			name:               "keepLast keeps most recently published versions",
+			rule:               view.VersionRetentionRule{Status: "draft", KeepLast: intPtr(2)},
+			expectedCandidates: []string{"v3", "v2", "v1"},
+			expectedReason:     view.VersionRetentionKeepLast,
+		},
+		{
+			name:               "maxAgeDays without keepLast",
+			rule:               view.VersionRetentionRule{Status: "draft", MaxAgeDays: intPtr(20)},
+			expectedCandidates: []string{"v3", "v2", "v1"},
+			expectedReason:     view.VersionRetentionMaxAge,
+		},
+		{
+			name:               "maxAgeDays with keepLast",
+			rule:               view.VersionRetentionRule{Status: "draft", KeepLast: intPtr(3), MaxAgeDays: intPtr(20)},
+			expectedCandidates: []string{"v2", "v1"},
+			expectedReason:     view.VersionRetentionMaxAge,
+		},
+		{
+			name:               "locked versions are skipped but count for keepLast",
+			rule:               view.VersionRetentionRule{Status: "draft", KeepLast: intPtr(2)},
+			lockedVersions:     []string{"v4", "v2"},
+			expectedCandidates: []string{"v3", "v1"},
+			expectedReason:     view.VersionRetentionKeepLast,
+		},
+		{
+			name:               "default release version is skipped",
+			rule:               view.VersionRetentionRule{Status: "draft", KeepLast: intPtr(2)},
+			defaultRelease:     "v1",
+			expectedCandidates: []string{"v3", "v2"},
+			expectedReason:     view.VersionRetentionKeepLast,
+		},
+		{
+			name:               "rule without limits",
+			rule:               view.VersionRetentionRule{Status: "draft"},
+			expectedCandidates: []string{},
+		},
+		{
+			name:               "rule for other status",
+			rule:               view.VersionRetentionRule{Status: "archived", KeepLast: intPtr(0)},
+			expectedCandidates: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := versionRetentionServiceImpl{
+				repo: &mockVersionRetentionRepository{
+					policy: &entity.VersionRetentionPolicyExtEntity{
+						VersionRetentionPolicyEntity: entity.VersionRetentionPolicyEntity{PackageId: "ws", Rules: []view.VersionRetentionRule{tt.rule}},
+					},
+				},
+				publishedRepo: &mockPublishedRepository{
+					GetVersionsByStatusFunc: func(packageId string, status string) ([]entity.PublishedVersionEntity, error) {
+						if status != "draft" {
+							return nil, nil
+						}
+						versions := make([]entity.PublishedVersionEntity, len(draftVersions))
+						copy(versions, draftVersions)
+						return versions, nil
+					},
+					GetPackageFunc: func(id string) (*entity.PackageEntity, error) {
+						return &entity.PackageEntity{Id: id, DefaultReleaseVersion: tt.defaultRelease}, nil
+					},
+				},
+				versionLockService: mockVersionLockService{lockedVersions: tt.lockedVersions},
+			}
+
+			candidates, err := service.getCandidates("ws.pkg", now)
+
+			assert.NoError(t, err)
+			versions := make([]string, 0)
+			for _, candidate := range candidates {
+				versions = append(versions, candidate.Version)
+				assert.Equal(t, tt.expectedReason, candidate.Reason, tt.name)
+				assert.Equal(t, "ws", candidate.PolicyPackageId, tt.name)
+			}
+			assert.Equal(t, tt.expectedCandidates, versions, tt.name)
+		})
+	}
+}
+
+func TestVersionRetentionDeleteVersions(t *testing.T) {
+	tests := []struct {
+		name            string
+		deleteErrors    map[string]error
+		expectedDeleted []string
+		expectedError   bool
+	}{
+		{
+			name:            "all candidates are deleted",
+			expectedDeleted: []string{"v2", "v1"},
+		},
+		{
+			name: "version locked after candidates selection is skipped",
+			deleteErrors: map[string]error{
+				"v2": &exception.CustomError{Status: http.StatusLocked, Code: exception.VersionLocked, Message: exception.VersionLockedMsg},
+			},
+			expectedDeleted: []string{"v1"},
+		},
+		{
+			name: "version deleted after candidates selection is skipped",
+			deleteErrors: map[string]error{
+				"v2": &exception.CustomError{Status: http.StatusNotFound, Code: exception.PublishedPackageVersionNotFound, Message: exception.PublishedPackageVersionNotFoundMsg},
+			},
+			expectedDeleted: []string{"v1"},
+		},
+		{
+			name: "unexpected error fails the run",
+			deleteErrors: map[string]error{
+				"v2": fmt.Errorf("connection refused"),
+			},
+			expectedDeleted: []string{},
+			expectedError:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockVersionRetentionRepository{
+				policy: &entity.VersionRetentionPolicyExtEntity{
+					VersionRetentionPolicyEntity: entity.VersionRetentionPolicyEntity{
+						PackageId: "ws.pkg",
+						Rules:     []view.VersionRetentionRule{{Status: "draft", MaxAgeDays: new(int)}},
+					},
+				},
+				packageIds: []string{"ws.pkg"},
+			}
+			deletedBy := ""
+			service := versionRetentionServiceImpl{
+				repo: repo,
+				publishedRepo: &mockPublishedRepository{
+					GetVersionsByStatusFunc: func(packageId string, status string) ([]entity.PublishedVersionEntity, error) {
+						return []entity.PublishedVersionEntity{
+							{Version: "v1", Status: "draft", PublishedAt: time.Now().AddDate(0, 0, -2)},
+							{Version: "v2", Status: "draft", PublishedAt: time.Now().AddDate(0, 0, -1)},
+						}, nil
+					},
+					GetPackageFunc: func(id string) (*entity.PackageEntity, error) {
+						return &entity.PackageEntity{Id: id}, nil
+					},
+				},
+				versionLockService: mockVersionLockService{},
+				versionService: mockVersionService{
+					DeleteVersionFunc: func(ctx context.SecurityContext, packageId string, versionName string) error {
+						deletedBy = ctx.GetUserId()
+						return tt.deleteErrors[versionName]
+					},
+				},
+			}
+			runEnt := &entity.VersionRetentionRunEntity{RunId: 7, ScheduledAt: time.Now()}
+
+			err := service.deleteVersions(runEnt)
+
+			if tt.expectedError {
+				assert.Error(t, err, tt.name)
+			} else {
+				assert.NoError(t, err, tt.name)
+			}
+			deletedVersions := make([]string, 0)
+			for _, ent := range repo.deletedVersions {
+				deletedVersions = append(deletedVersions, ent.Version)
+				assert.Equal(t, 7, ent.RunId, tt.name)
+			}
+			assert.Equal(t, tt.expectedDeleted, deletedVersions, tt.name)
+			assert.Equal(t, len(tt.expectedDeleted), runEnt.DeletedItems, tt.name)
+			assert.Equal(t, "version_retention_7", deletedBy, tt.name)
+		})
+	}
+}
+
+func TestVersionRetentionSetPolicyValidation(t *testing.T) {
+	intPtr := func(i int) *int {
+		return &i
+	}
+	tests := []struct {
+		name          string
+		rules         []view.VersionRetentionRule
+		expectedError string
+	}{
+		{
+			name:  "valid policy",
+			rules: []view.VersionRetentionRule{{Status: "draft", KeepLast: intPtr(0), MaxAgeDays: intPtr(30)}, {Status: "archived", KeepLast: intPtr(5)}},
+		},
+		{
+			name:  "empty policy",
+			rules: []view.VersionRetentionRule{},
+		},
+		{
+			name:          "unknown status",
+			rules:         []view.VersionRetentionRule{{Status: "unknown", KeepLast: intPtr(1)}},
+			expectedError: exception.UnknownVersionStatus,
+		},
+		{
+			name:          "duplicated status",
+			rules:         []view.VersionRetentionRule{{Status: "draft", KeepLast: intPtr(1)}, {Status: "draft", MaxAgeDays: intPtr(1)}},
+			expectedError: exception.InvalidVersionRetentionPolicy,
+		},
+		{
+			name:          "negative keepLast",
+			rules:         []view.VersionRetentionRule{{Status: "draft", KeepLast: intPtr(-1)}},
+			expectedError: exception.InvalidVersionRetentionPolicy,
+		},
+		{
+			name:          "zero maxAgeDays",
+			rules:         []view.VersionRetentionRule{{Status: "draft", MaxAgeDays: intPtr(0)}},
+			expectedError: exception.InvalidVersionRetentionPolicy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockVersionRetentionRepository{}
+			service := versionRetentionServiceImpl{
+				repo:                    repo,
+				packageService:          mockPackageService{},
+				versionLifecycleService: mockVersionLifecycleService{statuses: []string{"draft", "release", "archived"}},
+			}
+
+			err := service.SetPolicy(context.CreateFromId("user"), "ws.pkg", view.VersionRetentionPolicyUpdate{Rules: tt.rules})
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err, tt.name)
+				if assert.NotNil(t, repo.storedPolicy, tt.name) {
+					assert.Equal(t, tt.rules, repo.storedPolicy.Rules, tt.name)
+					assert.Equal(t, "user", repo.storedPolicy.UpdatedBy, tt.name)
+				}
+				return
+			}
+			customError, ok := err.(*exception.CustomError)
+			if assert.True(t, ok, "expected custom error, got %v", err) {
+				assert.Equal(t, tt.expectedError, customError.Code, tt.name)
+			}
+			assert.Nil(t, repo.storedPolicy, "policy must not be stored")
+		})
+	}
+}
+
+type mockVersionRetentionRepository struct {
+	repository.VersionRetentionRepository
+	policy          *entity.VersionRetentionPolicyExtEntity
+	packageIds      []string
+	storedPolicy    *entity.VersionRetentionPolicyEntity
+	deletedVersions []entity.VersionRetentionDeletedVersionEntity
+}
+
+func (m *mockVersionRetentionRepository) GetPolicyForHierarchy(packageId string) (*entity.VersionRetentionPolicyExtEntity, error) {
+	return m.policy, nil
+}
+
+func (m *mockVersionRetentionRepository) GetPackagesUnderPolicy(rootPackageId string) ([]string, error) {
+	return m.packageIds, nil
+}
+
+func (m *mockVersionRetentionRepository) SetPolicy(ent entity.VersionRetentionPolicyEntity) error {
+	m.storedPolicy = &ent
+	return nil
+}
+
+func (m *mockVersionRetentionRepository) StoreDeletedVersion(ent entity.VersionRetentionDeletedVersionEntity) error {
+	m.deletedVersions = append(m.deletedVersions, ent)
+	return nil
+}
+
+type mockVersionLockService struct {
+	VersionLockService
+	lockedVersions []string
+}
+
+func (m mockVersionLockService) IsVersionLocked(packageId string, version string, status string) (bool, error) {
+	for _, lockedVersion := range m.lockedVersions {
+		if lockedVersion == version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type mockVersionService struct {
+	VersionService
+	DeleteVersionFunc func(ctx context.SecurityContext, packageId string, versionName string) error
+}
+
+func (m mockVersionService) DeleteVersion(ctx context.SecurityContext, packageId string, versionName string) error {
+	return m.DeleteVersionFunc(ctx, packageId, versionName)
+}
+
+type mockPackageService struct {
+	PackageService
+}
+
+func (m mockPackageService) PackageExists(packageId string) (bool, error) {
+	return true, nil
+}
+
+type mockVersionLifecycleService struct {
+	VersionLifecycleService
+	statuses []string
+}
+
+func (m mockVersionLifecycleService) ValidateStatus(status string) error {
+	for _, s := range m.statuses {
+		if s == status {
+			return nil
+		}
+	}
+	return unknownVersionStatusError(status)
+}
@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"net/http"
 	"path"
 	"regexp"
@@ -46,6 +47,8 @@ type VersionService interface {
 	GetPackageVersionsView(req view.VersionListReq) (*view.PublishedVersionsView, error)
 	DeleteVersion(ctx context.SecurityContext, packageId string, versionName string) error
 	PatchVersion(ctx context.SecurityContext, packageId string, versionName string, status *string, versionLabels *[]string) (*view.VersionContent, error)
+	// CheckStatusChange checks that the status of the existing version could be changed the same way as PatchVersion does
+	CheckStatusChange(packageId string, version string, status string) error
 	GetLatestContentDataBySlug(packageId string, versionName string, slug string) (*view.PublishedContent, *view.ContentData, error)
 	GetLatestDocumentBySlug_deprecated(packageId string, versionName string, slug string) (*view.PublishedDocument_deprecated, error)
 	GetLatestDocumentBySlug(packageId string, versionName string, slug string) (*view.PublishedDocument, error)
@@ -71,6 +74,7 @@ type VersionService interface {
 	CopyVersion(ctx context.SecurityContext, packageId string, version string, req view.CopyVersionReq) (string, error)
 	GetPublishedVersionsHistory(filter view.PublishedVersionHistoryFilter) ([]view.PublishedVersionHistoryView, error)
 	StartPublishFromCSV(ctx context.SecurityContext, req view.PublishFromCSVReq) (string, error)
+	StartPublishFromNamespace(ctx context.SecurityContext, req view.PublishFromNamespaceReq, serviceNames []string) (string, error)
 	GetCSVDashboardPublishStatus(publishId string) (*view.CSVDashboardPublishStatusResponse, error)
 	GetCSVDashboardPublishReport(publishId string) ([]byte, error)
 }
@@ -88,7 +92,9 @@ func NewVersionService(gitClientProvider GitClientProvider,
 	packageVersionEnrichmentService PackageVersionEnrichmentService,
 	portalService PortalService,
 	versionCleanupRepository repository.VersionCleanupRepository,
-	operationGroupService OperationGroupService) VersionService {
+	operationGroupService OperationGroupService,
+	versionLifecycleService VersionLifecycleService,
+	versionLockService VersionLockService) VersionService {
 	return &versionServiceImpl{
 		gitClientProvider:               gitClientProvider,
 		pRepo:                           repo,
@@ -104,6 +110,8 @@ func NewVersionService(gitClientProvider GitClientProvider,
 		portalService:                   portalService,
 		versionCleanupRepository:        versionCleanupRepository,
 		operationGroupService:           operationGroupService,
+		versionLifecycleService:         versionLifecycleService,
+		versionLockService:              versionLockService,
 	}
 }
 
@@ -123,6 +131,8 @@ type versionServiceImpl struct {
 	versionCleanupRepository        repository.VersionCleanupRepository
 	buildService                    BuildService
 	operationGroupService           OperationGroupService
+	versionLifecycleService         VersionLifecycleService
+	versionLockService              VersionLockService
 }
 
 func (v *versionServiceImpl) SetBuildService(buildService BuildService) {
@@ -532,6 +542,9 @@ func (v versionServiceImpl) DeleteVersion(ctx context.SecurityContext, packageId
 			Message: exception.UnableToDeleteOldRevisionMsg,
 		}
 	}
+	if err = v.versionLockService.CheckVersionNotLocked(versionEnt.PackageId, versionEnt.Version, versionEnt.Status); err != nil {
+		return err
+	}
 	err = v.publishedService.DeleteVersion(ctx, packageId, versionEnt.Version)
 	if err != nil {
 		return err
@@ -575,26 +588,16 @@ func (v versionServiceImpl) PatchVersion(ctx context.SecurityContext, packageId
 			Message: exception.UnableToChangeOldRevisionMsg,
 		}
 	}
+	if err = v.versionLockService.CheckVersionNotLocked(versionEnt.PackageId, versionEnt.Version, versionEnt.Status); err != nil {
+		return nil, err
+	}
 	dataMap := map[string]interface{}{}
 	versionMeta := make([]string, 0)
 
 	if status != nil {
 		newStatus := *status
-		if newStatus == string(view.Release) {
-			packEnt, err := v.publishedRepo.GetPackage(packageId)
-			if err != nil {
-				return nil, err
-			}
-			var pattern string
-			if packEnt.ReleaseVersionPattern != "" {
-				pattern = packEnt.ReleaseVersionPattern
-			} else {
-				pattern = ".*"
-			}
-			err = ReleaseVersionMatchesPattern(versionEnt.Version, pattern)
-			if err != nil {
-				return nil, err
-			}
+		if err = v.checkStatusChange(*versionEnt, newStatus); err != nil {
+			return nil, err
 		}
 
 		dataMap["oldStatus"] = versionEnt.Status
@@ -630,6 +633,44 @@ func (v versionServiceImpl) PatchVersion(ctx context.SecurityContext, packageId
 	return result, nil
 }
 
+func (v versionServiceImpl) CheckStatusChange(packageId string, version string, status string) error {
+	versionEnt, err := v.publishedRepo.GetVersion(packageId, version)
+	if err != nil {
+		return err
+	}
+	if versionEnt == nil {
+		return &exception.CustomError{
+			Status:  http.StatusNotFound,
+			Code:    exception.PublishedPackageVersionNotFound,
+			Message: exception.PublishedPackageVersionNotFoundMsg,
+			Params:  map[string]interface{}{"version": version, "packageId": packageId},
+		}
+	}
+	if err = v.versionLockService.CheckVersionNotLocked(versionEnt.PackageId, versionEnt.Version, versionEnt.Status); err != nil {
+		return err
+	}
+	return v.checkStatusChange(*versionEnt, status)
+}
+
+func (v versionServiceImpl) checkStatusChange(versionEnt entity.PublishedVersionEntity, newStatus string) error {
+	_, err := v.versionLifecycleService.GetTransitionPermission(versionEnt.Status, newStatus)
+	if err != nil {
+		return err
+	}
+	if newStatus != string(view.Release) {
+		return nil
+	}
+	packEnt, err := v.publishedRepo.GetPackage(versionEnt.PackageId)
+	if err != nil {
+		return err
+	}
+	pattern := ".*"
+	if packEnt.ReleaseVersionPattern != "" {
+		pattern = packEnt.ReleaseVersionPattern
+	}
+	return ReleaseVersionMatchesPattern(versionEnt.Version, pattern)
+}
+
 func (v versionServiceImpl) GetPackageVersionsView_deprecated(req view.VersionListReq) (*view.PublishedVersionsView_deprecated_v2, error) {
 	packageEnt, err := v.publishedRepo.GetPackage(req.PackageId)
 	if err != nil {
@@ -1951,10 +1992,16 @@ func (v versionServiceImpl) CopyVersion(ctx context.SecurityContext, packageId s
 	}
 	var versionSources []byte
 	if currentPackage.Kind == entity.KIND_PACKAGE {
-		versionSources, err = v.publishedService.GetVersionSources(packageId, version)
+		sourcesArchive, err := v.publishedService.GetVersionSources(packageId, version)
 		if err != nil {
 			return "", err
 		}
+		// sources are stored in the build_src table which is always in the database
+		versionSources, err = io.ReadAll(sourcesArchive)
+		sourcesArchive.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to read version %s sources: %w", version, err)
+		}
 	}
 	targetBuildConfig := view.BuildConfig{
 		PackageId:                req.TargetPackageId,
@@ -2025,89 +2072,97 @@ func (v versionServiceImpl) StartPublishFromCSV(ctx context.SecurityContext, req
 			Message: exception.EmptyCSVFileMsg,
 		}
 	}
-	pkg, err := v.publishedRepo.GetPackage(req.PackageId)
+	pkg, err := v.checkDashboardPublishTargets(req.PackageId, req.ServicesWorkspaceId, req.PreviousVersion, req.PreviousVersionPackageId)
 	if err != nil {
 		return "", err
 	}
+
+	publishEntity := &entity.CSVDashboardPublishEntity{
+		PublishId: uuid.NewString(),
+		Status:    string(view.StatusRunning),
+		Message:   "",
+		Report:    []byte{},
+	}
+
+	err = v.publishedRepo.StoreCSVDashboardPublishProcess(publishEntity)
+	if err != nil {
+		return "", err
+	}
+
+	utils.SafeAsync(func() {
+		v.publishFromCSV(ctx, pkg.Name, req, csvOriginal, publishEntity)
+	})
+	return publishEntity.PublishId, nil
+}
+
+// checkDashboardPublishTargets validates the dashboard, the workspace of the services and the previous release version
+func (v versionServiceImpl) checkDashboardPublishTargets(packageId string, servicesWorkspaceId string, previousVersion string, previousVersionPackageId string) (*entity.PackageEntity, error) {
+	pkg, err := v.publishedRepo.GetPackage(packageId)
+	if err != nil {
+		return nil, err
+	}
 	if pkg == nil {
-		return "", &exception.CustomError{
+		return nil, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.PackageNotFound,
 			Message: exception.PackageNotFoundMsg,
-			Params:  map[string]interface{}{"packageId": req.PackageId},
+			Params:  map[string]interface{}{"packageId": packageId},
 		}
 	}
 	if pkg.Kind != entity.KIND_DASHBOARD {
-		return "", &exception.CustomError{
+		return nil, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.InvalidPackageKind,
 			Message: exception.InvalidPackageKindMsg,
 			Params:  map[string]interface{}{"kind": pkg.Kind, "allowedKind": entity.KIND_DASHBOARD},
 		}
 	}
-	workspace, err := v.publishedRepo.GetPackage(req.ServicesWorkspaceId)
+	workspace, err := v.publishedRepo.GetPackage(servicesWorkspaceId)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if workspace == nil {
-		return "", &exception.CustomError{
+		return nil, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.PackageNotFound,
 			Message: exception.PackageNotFoundMsg,
-			Params:  map[string]interface{}{"packageId": req.ServicesWorkspaceId},
+			Params:  map[string]interface{}{"packageId": servicesWorkspaceId},
 		}
 	}
 	if workspace.Kind != entity.KIND_WORKSPACE {
-		return "", &exception.CustomError{
+		return nil, &exception.CustomError{
 			Status:  http.StatusNotFound,
 			Code:    exception.InvalidPackageKind,
 			Message: exception.InvalidPackageKindMsg,
 			Params:  map[string]interface{}{"kind": workspace.Kind, "allowedKind": entity.KIND_WORKSPACE},
 		}
 	}
-	if req.PreviousVersion != "" {
-		previousVersionPackageId := req.PreviousVersionPackageId
-		if req.PreviousVersionPackageId == "" {
-			previousVersionPackageId = req.PackageId
+	if previousVersion != "" {
+		if previousVersionPackageId == "" {
+			previousVersionPackageId = packageId
 		}
-		prevVersion, err := v.publishedRepo.GetVersion(previousVersionPackageId, req.PreviousVersion)
+		prevVersion, err := v.publishedRepo.GetVersion(previousVersionPackageId, previousVersion)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		if prevVersion == nil {
-			return "", &exception.CustomError{
+			return nil, &exception.CustomError{
 				Status:  http.StatusNotFound,
 				Code:    exception.PublishedPackageVersionNotFound,
 				Message: exception.PublishedPackageVersionNotFoundMsg,
-				Params:  map[string]interface{}{"packageId": previousVersionPackageId, "version": req.PreviousVersion},
+				Params:  map[string]interface{}{"packageId": previousVersionPackageId, "version": previousVersion},
 			}
 		}
 		if prevVersion.Status != string(view.Release) {
-			return "", &exception.CustomError{
+			return nil, &exception.CustomError{
 				Status:  http.StatusNotFound,
 				Code:    exception.PreviousPackageVersionNotRelease,
 				Message: exception.PreviousPackageVersionNotReleaseMsg,
-				Params:  map[string]interface{}{"packageId": previousVersionPackageId, "version": req.PreviousVersion},
+				Params:  map[string]interface{}{"packageId": previousVersionPackageId, "version": previousVersion},
 			}
 		}
 	}
-
-	publishEntity := &entity.CSVDashboardPublishEntity{
-		PublishId: uuid.NewString(),
-		Status:    string(view.StatusRunning),
-		Message:   "",
-		Report:    []byte{},
-	}
-
-	err = v.publishedRepo.StoreCSVDashboardPublishProcess(publishEntity)
-	if err != nil {
-		return "", err
-	}
-
-	utils.SafeAsync(func() {
-		v.publishFromCSV(ctx, pkg.Name, req, csvOriginal, publishEntity)
-	})
-	return publishEntity.PublishId, nil
+	return pkg, nil
 }
 
 func (v versionServiceImpl) publishFromCSV(ctx context.SecurityContext, dashboardName string, req view.PublishFromCSVReq, csvOriginal [][]string, publishEntity *entity.CSVDashboardPublishEntity) {
@@ -2353,6 +2408,123 @@ func (v versionServiceImpl) publishFromCSV(ctx context.SecurityContext, dashboar
 	v.updateDashboardPublishProcess(publishEntity, string(view.StatusComplete), summary)
 }
 
+func (v versionServiceImpl) StartPublishFromNamespace(ctx context.SecurityContext, req view.PublishFromNamespaceReq, serviceNames []string) (string, error) {
+	_, err := v.checkDashboardPublishTargets(req.PackageId, req.ServicesWorkspaceId, req.PreviousVersion, req.PreviousVersionPackageId)
+	if err != nil {
+		return "", err
+	}
+
+	publishEntity := &entity.CSVDashboardPublishEntity{
+		PublishId: uuid.NewString(),
+		Status:    string(view.StatusRunning),
+		Message:   "",
+		Report:    []byte{},
+	}
+
+	err = v.publishedRepo.StoreCSVDashboardPublishProcess(publishEntity)
+	if err != nil {
+		return "", err
+	}
+
+	utils.SafeAsync(func() {
+		v.publishFromNamespace(ctx, req, serviceNames, publishEntity)
+	})
+	return publishEntity.PublishId, nil
+}
+
+// publishFromNamespace resolves each discovered service to its package and version and publishes the dashboard version with them.
+// The report contains a row for each service with the matched version or the reason why the service is not included.
+func (v versionServiceImpl) publishFromNamespace(ctx context.SecurityContext, req view.PublishFromNamespaceReq, serviceNames []string, publishEntity *entity.CSVDashboardPublishEntity) {
+	report := [][]string{{"serviceName", "packageId", "version", "result"}}
+	dashboardRefs := make([]view.BCRef, 0)
+	notIncludedServicesCount := 0
+	for _, serviceName := range serviceNames {
+		servicePackageId, err := v.publishedRepo.GetServiceOwner(req.ServicesWorkspaceId, serviceName)
+		if err != nil {
+			report = append(report, []string{serviceName, "", "", fmt.Sprintf("failed to look up service package: %v", err.Error())})
+			notIncludedServicesCount++
+			continue
+		}
+		if servicePackageId == "" {
+			report = append(report, []string{serviceName, "", "", "service package doesn't exist"})
+			notIncludedServicesCount++
+			continue
+		}
+		var versionEnt *entity.PublishedVersionEntity
+		result := "ok: latest release version"
+		if len(req.ServiceVersionLabels) > 0 {
+			versionEnt, err = v.publishedRepo.GetLatestVersionWithLabels(servicePackageId, req.ServiceVersionLabels)
+			if err != nil {
+				report = append(report, []string{serviceName, servicePackageId, "", fmt.Sprintf("failed to look up service version: %v", err.Error())})
+				notIncludedServicesCount++
+				continue
+			}
+			if versionEnt != nil {
+				result = "ok: version with labels"
+			}
+		}
+		if versionEnt == nil {
+			versionEnt, err = v.publishedRepo.GetDefaultVersion(servicePackageId, string(view.Release))
+			if err != nil {
+				report = append(report, []string{serviceName, servicePackageId, "", fmt.Sprintf("failed to look up service version: %v", err.Error())})
+				notIncludedServicesCount++
+				continue
+			}
+		}
+		if versionEnt == nil {
+			report = append(report, []string{serviceName, servicePackageId, "", "service version doesn't exist"})
+			notIncludedServicesCount++
+			continue
+		}
+		dashboardRefs = append(dashboardRefs, view.BCRef{
+			RefId:   versionEnt.PackageId,
+			Version: view.MakeVersionRefKey(versionEnt.Version, versionEnt.Revision),
+		})
+		report = append(report, []string{serviceName, servicePackageId, view.MakeVersionRefKey(versionEnt.Version, versionEnt.Revision), result})
+	}
+
+	var err error
+	publishEntity.Report, err = csvToBytes(report, ',')
+	if err != nil {
+		v.updateDashboardPublishProcess(publishEntity, string(view.StatusError), fmt.Sprintf("internal server error: failed to generate csv report: %v", err.Error()))
+		return
+	}
+	if len(dashboardRefs) == 0 {
+		v.updateDashboardPublishProcess(publishEntity, string(view.StatusError), "no versions matched")
+		return
+	}
+
+	dashboardPublishBuildConfig := view.BuildConfig{
+		PackageId:                req.PackageId,
+		Version:                  req.Version,
+		BuildType:                view.PublishType,
+		PreviousVersion:          req.PreviousVersion,
+		PreviousVersionPackageId: req.PreviousVersionPackageId,
+		Status:                   req.Status,
+		Refs:                     dashboardRefs,
+		CreatedBy:                ctx.GetUserId(),
+		Metadata: view.BuildConfigMetadata{
+			VersionLabels: req.VersionLabels,
+		},
+	}
+	build, err := v.buildService.PublishVersion(ctx, dashboardPublishBuildConfig, nil, false, "", nil, false, false)
+	if err != nil {
+		v.updateDashboardPublishProcess(publishEntity, string(view.StatusError), fmt.Sprintf("failed to start dashboard publish: %v", err.Error()))
+		return
+	}
+	err = v.buildService.AwaitBuildCompletion(build.PublishId)
+	if err != nil {
+		v.updateDashboardPublishProcess(publishEntity, string(view.StatusError), fmt.Sprintf("failed to publish dashboard from namespace %v: %v", req.Namespace, err.Error()))
+		return
+	}
+
+	summary := ""
+	if notIncludedServicesCount > 0 {
+		summary = fmt.Sprintf(`%v services were not included into dashboard version`, notIncludedServicesCount)
+	}
+	v.updateDashboardPublishProcess(publishEntity, string(view.StatusComplete), summary)
+}
+
 func (v versionServiceImpl) updateDashboardPublishProcess(publishEntity *entity.CSVDashboardPublishEntity, status string, message string) {
 	publishEntity.Status = status
 	publishEntity.Message = message
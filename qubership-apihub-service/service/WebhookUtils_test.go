@@ -0,0 +1,114 @@
+package service
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicIp(t *testing.T) {
+	tests := []struct {
+		ip       string
+		expected bool
+	}{
+		{ip: "8.8.8.8", expected: true},
+		{ip: "2001:4860:4860::8888", expected: true},
+		{ip: "100.63.255.255", expected: true},
+		{ip: "100.128.0.0", expected: true},
+		{ip: "127.0.0.1"},
+		{ip: "127.1.2.3"},
+		{ip: "::1"},
+		{ip: "10.0.0.1"},
+		{ip: "172.16.0.1"},
+		{ip: "172.31.255.255"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "100.64.0.1"},
+		{ip: "100.127.255.255"},
+		{ip: "fc00::1"},
+		{ip: "fd12:3456:789a::1"},
+		{ip: "::ffff:127.0.0.1"},
+		{ip: "::ffff:10.0.0.1"},
+		{ip: "::ffff:169.254.169.254"},
+		{ip: "0.0.0.0"},
+		{ip: "::"},
+		{ip: "224.0.0.1"},
+		{ip: "ff02::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			ip := net.ParseIP(tt.ip)
+			if assert.NotNil(t, ip) {
+				assert.Equal(t, tt.expected, isPublicIp(ip))
+			}
+		})
+	}
+}
+
+func TestCheckWebhookHost(t *testing.T) {
+	tests := []struct {
+		host          string
+		expectedError bool
+	}{
+		{host: "8.8.8.8"},
+		{host: "localhost", expectedError: true},
+		{host: "127.0.0.1", expectedError: true},
+		{host: "::1", expectedError: true},
+		{host: "192.168.0.10", expectedError: true},
+		{host: "169.254.169.254", expectedError: true},
+		{host: "100.100.100.200", expectedError: true},
+		{host: "fc00::1", expectedError: true},
+		{host: "::ffff:127.0.0.1", expectedError: true},
+		{host: "unknown-host.invalid", expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			err := checkWebhookHost(tt.host)
+			if tt.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookHttpClientRefusesLoopbackConnection(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	_, err := makeWebhookHttpClient().Post(server.URL, "application/json", nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is not allowed")
+	}
+	assert.False(t, called)
+}
+
+func TestWebhookHttpClientDoesNotFollowRedirects(t *testing.T) {
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	client := makeWebhookHttpClient()
+	// test servers listen on loopback which is refused by the webhook transport
+	client.Transport = http.DefaultTransport
+	response, err := client.Post(server.URL, "application/json", nil)
+	if assert.NoError(t, err) {
+		response.Body.Close()
+		assert.Equal(t, http.StatusTemporaryRedirect, response.StatusCode)
+	}
+	assert.False(t, redirected)
+}
@@ -33,14 +33,19 @@ type PublishedValidator interface {
 	ValidateChanges(buildArc *archive.BuildResultArchive) error                                                 //TODO remove and merge logic with ValidatePackage
 }
 
-func NewPublishedValidator(publishedRepo repository.PublishedRepository) PublishedValidator {
+// VersionStatusValidator checks that the status is present in the configured version lifecycle
+type VersionStatusValidator func(status string) error
+
+func NewPublishedValidator(publishedRepo repository.PublishedRepository, validateVersionStatus VersionStatusValidator) PublishedValidator {
 	return &publishedValidatorImpl{
-		publishedRepo: publishedRepo,
+		publishedRepo:         publishedRepo,
+		validateVersionStatus: validateVersionStatus,
 	}
 }
 
 type publishedValidatorImpl struct {
-	publishedRepo repository.PublishedRepository
+	publishedRepo         repository.PublishedRepository
+	validateVersionStatus VersionStatusValidator
 }
 
 func (p publishedValidatorImpl) ValidatePackage(buildArc *archive.BuildResultArchive, buildConfig *view.BuildConfig) error {
@@ -312,13 +317,8 @@ func (p publishedValidatorImpl) validatePackageInfo(buildArc *archive.BuildResul
 		}
 	}
 	info := buildArc.PackageInfo
-	if _, err := view.ParseVersionStatus(buildArc.PackageInfo.Status); err != nil {
-		return &exception.CustomError{
-			Status:  http.StatusBadRequest,
-			Code:    exception.InvalidPackagedFile,
-			Message: exception.InvalidPackagedFileMsg,
-			Params:  map[string]interface{}{"file": "info", "error": err.Error()},
-		}
+	if err := p.validateVersionStatus(info.Status); err != nil {
+		return err
 	}
 	if info.PreviousVersionPackageId == info.PackageId {
 		return &exception.CustomError{
@@ -589,7 +589,19 @@ func (p publishedValidatorImpl) validatePackageOperations(buildArc *archive.Buil
 					},
 				}
 			}
-			//todo validate protobuf search scopes
+			for scope := range operation.SearchScopes {
+				if !view.ValidProtobufOperationScope(scope) {
+					return &exception.CustomError{
+						Status:  http.StatusBadRequest,
+						Code:    exception.InvalidPackagedFile,
+						Message: exception.InvalidPackagedFileMsg,
+						Params: map[string]interface{}{
+							"file":  "operations",
+							"error": fmt.Sprintf("object with operationId = %v is incorrect: search scope %v doesn't exist for %v api type", operation.OperationId, scope, apiType),
+						},
+					}
+				}
+			}
 		default:
 
 		}
@@ -0,0 +1,77 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var semanticVersionRegexp = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)$`)
+
+// SemanticVersion is major.minor.patch version name with optional 'v' prefix
+type SemanticVersion struct {
+	Prefix string
+	Major  int
+	Minor  int
+	Patch  int
+}
+
+func ParseSemanticVersion(version string) (*SemanticVersion, bool) {
+	match := semanticVersionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return nil, false
+	}
+	major, err := strconv.Atoi(match[2])
+	if err != nil {
+		return nil, false
+	}
+	minor, err := strconv.Atoi(match[3])
+	if err != nil {
+		return nil, false
+	}
+	patch, err := strconv.Atoi(match[4])
+	if err != nil {
+		return nil, false
+	}
+	return &SemanticVersion{Prefix: match[1], Major: major, Minor: minor, Patch: patch}, true
+}
+
+func (s SemanticVersion) String() string {
+	return fmt.Sprintf("%s%d.%d.%d", s.Prefix, s.Major, s.Minor, s.Patch)
+}
+
+func (s SemanticVersion) Less(other SemanticVersion) bool {
+	if s.Major != other.Major {
+		return s.Major < other.Major
+	}
+	if s.Minor != other.Minor {
+		return s.Minor < other.Minor
+	}
+	return s.Patch < other.Patch
+}
+
+func (s SemanticVersion) NextMajor() SemanticVersion {
+	return SemanticVersion{Prefix: s.Prefix, Major: s.Major + 1}
+}
+
+func (s SemanticVersion) NextMinor() SemanticVersion {
+	return SemanticVersion{Prefix: s.Prefix, Major: s.Major, Minor: s.Minor + 1}
+}
+
+func (s SemanticVersion) NextPatch() SemanticVersion {
+	return SemanticVersion{Prefix: s.Prefix, Major: s.Major, Minor: s.Minor, Patch: s.Patch + 1}
+}
@@ -0,0 +1,82 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSemanticVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected *SemanticVersion
+	}{
+		{version: "1.2.3", expected: &SemanticVersion{Major: 1, Minor: 2, Patch: 3}},
+		{version: "v1.2.3", expected: &SemanticVersion{Prefix: "v", Major: 1, Minor: 2, Patch: 3}},
+		{version: "0.0.0", expected: &SemanticVersion{}},
+		{version: "10.20.30", expected: &SemanticVersion{Major: 10, Minor: 20, Patch: 30}},
+		{version: "01.2.3"},
+		{version: "1.02.3"},
+		{version: "1.2"},
+		{version: "1.2.3.4"},
+		{version: "1.2.3-beta"},
+		{version: "V1.2.3"},
+		{version: "2024.4"},
+		{version: ""},
+		{version: "99999999999999999999.0.0"},
+	}
+	for _, test := range tests {
+		semVer, ok := ParseSemanticVersion(test.version)
+		if test.expected == nil {
+			assert.False(t, ok, "version %s", test.version)
+			assert.Nil(t, semVer, "version %s", test.version)
+			continue
+		}
+		assert.True(t, ok, "version %s", test.version)
+		assert.Equal(t, test.expected, semVer, "version %s", test.version)
+		assert.Equal(t, test.version, semVer.String())
+	}
+}
+
+func TestSemanticVersionNext(t *testing.T) {
+	semVer := SemanticVersion{Prefix: "v", Major: 1, Minor: 2, Patch: 3}
+	assert.Equal(t, "v2.0.0", semVer.NextMajor().String())
+	assert.Equal(t, "v1.3.0", semVer.NextMinor().String())
+	assert.Equal(t, "v1.2.4", semVer.NextPatch().String())
+	assert.Equal(t, "v1.2.3", semVer.String(), "original version must not be changed")
+}
+
+func TestSemanticVersionLess(t *testing.T) {
+	versions := []string{"1.10.0", "v2.0.0", "1.2.10", "0.9.9", "1.2.9", "1.9.0"}
+	semVers := make([]SemanticVersion, 0, len(versions))
+	for _, version := range versions {
+		semVer, ok := ParseSemanticVersion(version)
+		assert.True(t, ok, "version %s", version)
+		semVers = append(semVers, *semVer)
+	}
+	sort.Slice(semVers, func(i, j int) bool {
+		return semVers[i].Less(semVers[j])
+	})
+	sorted := make([]string, 0, len(semVers))
+	for _, semVer := range semVers {
+		sorted = append(sorted, semVer.String())
+	}
+	assert.Equal(t, []string{"0.9.9", "1.2.9", "1.2.10", "1.9.0", "1.10.0", "v2.0.0"}, sorted)
+
+	assert.False(t, SemanticVersion{Major: 1}.Less(SemanticVersion{Prefix: "v", Major: 1}), "prefix is not compared")
+}
@@ -72,6 +72,7 @@ const ATETRevokeApiKey ATEventType = "revoke_api_key"
 const ATETPatchPackageMeta ATEventType = "patch_package_meta"
 const ATETCreatePackage ATEventType = "create_package"
 const ATETDeletePackage ATEventType = "delete_package"
+const ATETRestorePackage ATEventType = "restore_package"
 
 // publish/versioning
 
@@ -79,6 +80,16 @@ const ATETPublishNewVersion ATEventType = "publish_new_version"
 const ATETPublishNewRevision ATEventType = "publish_new_revision"
 const ATETPatchVersionMeta ATEventType = "patch_version_meta"
 const ATETDeleteVersion ATEventType = "delete_version"
+const ATETRestoreVersion ATEventType = "restore_version"
+const ATETLockVersion ATEventType = "lock_version"
+const ATETUnlockVersion ATEventType = "unlock_version"
+
+// version promotion
+
+const ATETCreateVersionPromotionRequest ATEventType = "create_version_promotion_request"
+const ATETApproveVersionPromotionRequest ATEventType = "approve_version_promotion_request"
+const ATETRejectVersionPromotionRequest ATEventType = "reject_version_promotion_request"
+const ATETCancelVersionPromotionRequest ATEventType = "cancel_version_promotion_request"
 
 // manual groups
 
@@ -97,9 +108,13 @@ func ConvertEventTypes(input []string) []string {
 		case "new_version":
 			output = append(output, string(ATETPublishNewVersion))
 		case "package_version":
-			output = append(output, string(ATETPublishNewRevision), string(ATETPatchVersionMeta), string(ATETDeleteVersion))
+			output = append(output, string(ATETPublishNewRevision), string(ATETPatchVersionMeta), string(ATETDeleteVersion), string(ATETRestoreVersion),
+				string(ATETLockVersion), string(ATETUnlockVersion))
 		case "package_management":
-			output = append(output, string(ATETPatchPackageMeta), string(ATETCreatePackage), string(ATETDeletePackage))
+			output = append(output, string(ATETPatchPackageMeta), string(ATETCreatePackage), string(ATETDeletePackage), string(ATETRestorePackage))
+		case "version_promotion":
+			output = append(output, string(ATETCreateVersionPromotionRequest), string(ATETApproveVersionPromotionRequest),
+				string(ATETRejectVersionPromotionRequest), string(ATETCancelVersionPromotionRequest))
 		case "operations_group":
 			output = append(output, string(ATETCreateManualGroup), string(ATETDeleteManualGroup), string(ATETOperationsGroupParameters))
 		}
@@ -0,0 +1,75 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"fmt"
+	"time"
+)
+
+type BlobStorageType string
+
+const BlobStoragePostgres BlobStorageType = "postgres"
+const BlobStorageFilesystem BlobStorageType = "filesystem"
+const BlobStorageS3 BlobStorageType = "s3"
+
+func ParseBlobStorageType(s string) (BlobStorageType, error) {
+	switch BlobStorageType(s) {
+	case BlobStoragePostgres, BlobStorageFilesystem, BlobStorageS3:
+		return BlobStorageType(s), nil
+	}
+	return "", fmt.Errorf("unknown blob storage type: %s", s)
+}
+
+// blob kinds are named after the tables which used to store the data
+const EXPORT_RESULT_TABLE = "export_result"
+
+var BlobKinds = []string{BUILD_RESULT_TABLE, PUBLISHED_SOURCES_ARCHIVES_TABLE, EXPORT_RESULT_TABLE}
+
+func ValidBlobKind(kind string) bool {
+	for _, k := range BlobKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+type BlobStorageMigrationReq struct {
+	From             BlobStorageType `json:"from" validate:"required"`
+	To               BlobStorageType `json:"to" validate:"required"`
+	Kinds            []string        `json:"kinds"`
+	DeleteFromSource bool            `json:"deleteFromSource"`
+}
+
+type BlobStorageMigrationStatus string
+
+const BlobStorageMigrationRunning BlobStorageMigrationStatus = "running"
+const BlobStorageMigrationComplete BlobStorageMigrationStatus = "complete"
+const BlobStorageMigrationError BlobStorageMigrationStatus = "error"
+
+type BlobStorageMigration struct {
+	MigrationId      string                     `json:"migrationId"`
+	From             BlobStorageType            `json:"from"`
+	To               BlobStorageType            `json:"to"`
+	Kinds            []string                   `json:"kinds"`
+	DeleteFromSource bool                       `json:"deleteFromSource"`
+	Status           BlobStorageMigrationStatus `json:"status"`
+	Details          string                     `json:"details,omitempty"`
+	Copied           map[string]int             `json:"copied"`
+	Failed           map[string]int             `json:"failed"`
+	StartedAt        time.Time                  `json:"startedAt"`
+	FinishedAt       *time.Time                 `json:"finishedAt,omitempty"`
+}
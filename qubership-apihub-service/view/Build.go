@@ -53,6 +53,9 @@ type BuildConfig struct {
 	AllowedOasExtensions         *[]string               `json:"allowedOasExtensions,omitempty"`         // for export
 	DocumentId                   string                  `json:"documentId,omitempty"`                   // for export
 	OperationsSpecTransformation string                  `json:"operationsSpecTransformation,omitempty"` // for export
+	DryRun                       bool                    `json:"dryRun,omitempty"`
+	AllowBreakingChanges         bool                    `json:"allowBreakingChanges,omitempty"` // override for publish gate policy
+	VersionMode                  string                  `json:"versionMode,omitempty"`          // "auto" to infer version from changelog
 }
 
 type BuildConfigMetadata struct {
@@ -88,6 +91,7 @@ const StatusNotStarted BuildStatusEnum = "none"
 const StatusRunning BuildStatusEnum = "running"
 const StatusComplete BuildStatusEnum = "complete"
 const StatusError BuildStatusEnum = "error"
+const StatusCancelled BuildStatusEnum = "cancelled"
 
 type BuildType string
 
@@ -126,6 +130,8 @@ func BuildStatusFromString(str string) (BuildStatusEnum, error) {
 		return StatusComplete, nil
 	case "error":
 		return StatusError, nil
+	case "cancelled":
+		return StatusCancelled, nil
 	}
 	return StatusNotStarted, fmt.Errorf("unknown build status: %s", str)
 }
@@ -158,15 +164,35 @@ func BuildConfigFromMap(confAsMap map[string]interface{}, publishId string) (*Bu
 }
 
 type PublishStatusResponse struct {
+	PublishId     string `json:"publishId"`
+	Status        string `json:"status"`
+	Message       string `json:"message"`
+	QueuePosition *int   `json:"queuePosition,omitempty"`
+	Version       string `json:"version,omitempty"`
+}
+
+type PublishStatusEvent struct {
 	PublishId string `json:"publishId"`
+	PackageId string `json:"packageId"`
 	Status    string `json:"status"`
 	Message   string `json:"message"`
+	BuildType string `json:"buildType,omitempty"`
+	// id of the requested publish process if the event relates to its dependency or dependent build (e.g. changelog)
+	RelatedTo string `json:"relatedTo,omitempty"`
 }
 
 type BuildsStatusRequest struct {
 	PublishIds []string `json:"publishIds"`
 }
 
+type CancelBuildsRequest struct {
+	PublishIds []string `json:"publishIds"`
+}
+
+type CancelBuildsResponse struct {
+	CancelledPublishIds []string `json:"cancelledPublishIds"`
+}
+
 type ChangelogBuildSearchRequest struct {
 	PackageId                string    `json:"packageId"`
 	Version                  string    `json:"version"`
@@ -0,0 +1,29 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+// BuildFairSharePolicy defines weights of build queues per workspace and per build author (createdBy).
+// Among builds with the same priority, the build of the workspace/author with the lowest number of running builds per weight unit is taken first.
+// Default weight is 1.
+type BuildFairSharePolicy struct {
+	WorkspaceWeights map[string]float64 `json:"workspaceWeights"`
+	CreatedByWeights map[string]float64 `json:"createdByWeights"`
+}
+
+// build priorities, builds with higher priority are always taken first
+const InteractiveBuildPriority = 1
+const DefaultBuildPriority = 0
+const BackgroundBuildPriority = -1
+const MigrationBuildPriority = -100
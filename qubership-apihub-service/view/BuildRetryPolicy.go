@@ -0,0 +1,83 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import "time"
+
+// BuildRetryPolicy defines how many times a build is started if builder stops responding (crash, keepalive timeout)
+// and how long the build waits in the queue before the next attempt.
+type BuildRetryPolicy struct {
+	MaxAttempts       int `json:"maxAttempts"`
+	InitialBackoffSec int `json:"initialBackoffSec"`
+	MaxBackoffSec     int `json:"maxBackoffSec"`
+}
+
+// GetBackoff returns delay before the retry with the given number (starting from 1), the delay is doubled for each next retry
+func (p BuildRetryPolicy) GetBackoff(retryNumber int) time.Duration {
+	if retryNumber < 1 || p.InitialBackoffSec <= 0 {
+		return 0
+	}
+	backoffSec := p.InitialBackoffSec
+	for i := 1; i < retryNumber && (p.MaxBackoffSec <= 0 || backoffSec < p.MaxBackoffSec); i++ {
+		backoffSec *= 2
+	}
+	if p.MaxBackoffSec > 0 && backoffSec > p.MaxBackoffSec {
+		backoffSec = p.MaxBackoffSec
+	}
+	return time.Duration(backoffSec) * time.Second
+}
+
+type BuildRetryPolicies struct {
+	Default     BuildRetryPolicy               `json:"default"`
+	ByBuildType map[BuildType]BuildRetryPolicy `json:"byBuildType"`
+}
+
+func (p BuildRetryPolicies) Get(buildType BuildType) BuildRetryPolicy {
+	if policy, exists := p.ByBuildType[buildType]; exists {
+		return policy
+	}
+	return p.Default
+}
+
+type DeadLetterBuild struct {
+	BuildId      string     `json:"buildId"`
+	BuildType    BuildType  `json:"buildType"`
+	PackageId    string     `json:"packageId"`
+	Version      string     `json:"version"`
+	Details      string     `json:"details"`
+	CreatedAt    *time.Time `json:"createdAt,omitempty"`
+	CreatedBy    string     `json:"createdBy"`
+	LastActive   *time.Time `json:"lastActive,omitempty"`
+	RestartCount int        `json:"restartCount"`
+	BuilderId    string     `json:"builderId,omitempty"`
+}
+
+type DeadLetterBuilds struct {
+	Builds []DeadLetterBuild `json:"builds"`
+}
+
+type DeadLetterBuildDetails struct {
+	DeadLetterBuild
+	Status    string                 `json:"status"`
+	DependsOn []string               `json:"dependsOn"`
+	Config    map[string]interface{} `json:"config,omitempty"`
+}
+
+type DeadLetterBuildsListReq struct {
+	BuildType BuildType
+	PackageId string
+	Limit     int
+	Page      int
+}
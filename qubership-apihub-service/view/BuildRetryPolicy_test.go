@@ -0,0 +1,54 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBackoff(t *testing.T) {
+	policy := BuildRetryPolicy{MaxAttempts: 5, InitialBackoffSec: 30, MaxBackoffSec: 100}
+	tests := []struct {
+		retryNumber int
+		expected    time.Duration
+	}{
+		{retryNumber: -1, expected: 0},
+		{retryNumber: 0, expected: 0},
+		{retryNumber: 1, expected: 30 * time.Second},
+		{retryNumber: 2, expected: 60 * time.Second},
+		{retryNumber: 3, expected: 100 * time.Second},
+		{retryNumber: 50, expected: 100 * time.Second},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, policy.GetBackoff(test.retryNumber), "retry number %d", test.retryNumber)
+	}
+}
+
+func TestGetBackoffWithoutLimits(t *testing.T) {
+	assert.Equal(t, time.Duration(0), BuildRetryPolicy{InitialBackoffSec: 0, MaxBackoffSec: 100}.GetBackoff(3))
+	assert.Equal(t, 120*time.Second, BuildRetryPolicy{InitialBackoffSec: 30}.GetBackoff(3))
+}
+
+func TestBuildRetryPoliciesGet(t *testing.T) {
+	policies := BuildRetryPolicies{
+		Default:     BuildRetryPolicy{MaxAttempts: 3},
+		ByBuildType: map[BuildType]BuildRetryPolicy{ChangelogType: {MaxAttempts: 1}},
+	}
+	assert.Equal(t, 1, policies.Get(ChangelogType).MaxAttempts)
+	assert.Equal(t, 3, policies.Get(PublishType).MaxAttempts)
+}
@@ -0,0 +1,51 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import "time"
+
+type BuilderHeartbeat struct {
+	Version      string              `json:"version"`
+	Capacity     int                 `json:"capacity"`
+	Capabilities BuilderCapabilities `json:"capabilities"`
+}
+
+type BuilderStatus string
+
+const BuilderStatusActive BuilderStatus = "active"
+const BuilderStatusInactive BuilderStatus = "inactive"
+
+type BuilderInstance struct {
+	BuilderId     string              `json:"builderId"`
+	Version       string              `json:"version"`
+	Capacity      int                 `json:"capacity"`
+	Capabilities  BuilderCapabilities `json:"capabilities"`
+	Status        BuilderStatus       `json:"status"`
+	RegisteredAt  time.Time           `json:"registeredAt"`
+	LastHeartbeat time.Time           `json:"lastHeartbeat"`
+	CurrentBuilds []string            `json:"currentBuilds"`
+	Stats         BuilderStats        `json:"stats"`
+}
+
+// BuilderStats is calculated for builds finished by the builder during the last hour
+type BuilderStats struct {
+	CompletedBuilds int     `json:"completedBuilds"`
+	FailedBuilds    int     `json:"failedBuilds"`
+	ErrorRate       float64 `json:"errorRate"`
+}
+
+type Builders struct {
+	Builders []BuilderInstance `json:"builders"`
+}
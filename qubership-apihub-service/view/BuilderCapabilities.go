@@ -0,0 +1,74 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+// BuilderCapabilities are advertised by builder when it asks for a free build.
+// Empty value means that builder has no restriction.
+type BuilderCapabilities struct {
+	BuildTypes      []BuildType `json:"buildTypes,omitempty"`
+	ApiTypes        []string    `json:"apiTypes,omitempty"`
+	MaxSourceSizeMB int64       `json:"maxSourceSizeMB,omitempty"`
+}
+
+// BuildRequirements are derived from build config and sources, only builders with matching capabilities can take the build
+type BuildRequirements struct {
+	BuildType  BuildType
+	ApiType    string
+	SourceSize int64
+}
+
+func MakeBuildRequirements(config BuildConfig, src []byte) BuildRequirements {
+	apiType := config.ApiType
+	if apiType == "" && (config.BuildType == ExportRestDocument || config.BuildType == ExportRestOperationsGroup) {
+		apiType = string(RestApiType)
+	}
+	return BuildRequirements{
+		BuildType:  config.BuildType,
+		ApiType:    apiType,
+		SourceSize: int64(len(src)),
+	}
+}
+
+// Accepts checks the build requirements the same way as the build queue does, empty capability means no restriction
+func (c BuilderCapabilities) Accepts(requirements BuildRequirements) bool {
+	if len(c.BuildTypes) > 0 && requirements.BuildType != "" {
+		accepted := false
+		for _, buildType := range c.BuildTypes {
+			if buildType == requirements.BuildType {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return false
+		}
+	}
+	if len(c.ApiTypes) > 0 && requirements.ApiType != "" {
+		accepted := false
+		for _, apiType := range c.ApiTypes {
+			if apiType == requirements.ApiType {
+				accepted = true
+				break
+			}
+		}
+		if !accepted {
+			return false
+		}
+	}
+	if c.MaxSourceSizeMB > 0 && requirements.SourceSize > c.MaxSourceSizeMB*1024*1024 {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderCapabilitiesAccepts(t *testing.T) {
+	capabilities := BuilderCapabilities{
+		BuildTypes:      []BuildType{PublishType, ChangelogType},
+		ApiTypes:        []string{string(RestApiType)},
+		MaxSourceSizeMB: 1,
+	}
+	tests := []struct {
+		name         string
+		requirements BuildRequirements
+		expected     bool
+	}{
+		{name: "matching", requirements: BuildRequirements{BuildType: PublishType, ApiType: string(RestApiType), SourceSize: 1024}, expected: true},
+		{name: "no requirements", requirements: BuildRequirements{}, expected: true},
+		{name: "build type", requirements: BuildRequirements{BuildType: ExportRestDocument}, expected: false},
+		{name: "api type", requirements: BuildRequirements{BuildType: PublishType, ApiType: string(GraphqlApiType)}, expected: false},
+		{name: "source size", requirements: BuildRequirements{BuildType: PublishType, SourceSize: 2 * 1024 * 1024}, expected: false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, capabilities.Accepts(test.requirements), test.name)
+	}
+	assert.True(t, BuilderCapabilities{}.Accepts(BuildRequirements{BuildType: ExportRestDocument, ApiType: string(GraphqlApiType), SourceSize: 1 << 40}))
+}
@@ -0,0 +1,65 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import "time"
+
+type BulkVersionAction string
+
+const (
+	BulkVersionActionArchive     BulkVersionAction = "archive"
+	BulkVersionActionAddLabel    BulkVersionAction = "addLabel"
+	BulkVersionActionRemoveLabel BulkVersionAction = "removeLabel"
+	BulkVersionActionDelete      BulkVersionAction = "delete"
+)
+
+// BulkVersionOperationReq applies the action either to the listed versions or to the versions matched by the filter
+type BulkVersionOperationReq struct {
+	Action   BulkVersionAction  `json:"action" validate:"required"`
+	Label    string             `json:"label"` // required for addLabel and removeLabel actions
+	Versions []BulkVersionRef   `json:"versions"`
+	Filter   *BulkVersionFilter `json:"filter"`
+}
+
+type BulkVersionRef struct {
+	PackageId string `json:"packageId"`
+	Version   string `json:"version"`
+}
+
+// BulkVersionFilter matches the latest revisions of versions of the package and all its descendants
+type BulkVersionFilter struct {
+	Status        string     `json:"status"`
+	Label         string     `json:"label"`
+	PublishedFrom *time.Time `json:"publishedFrom"`
+	PublishedTo   *time.Time `json:"publishedTo"`
+	All           bool       `json:"all"` // confirms that the empty filter matches all versions, required for archive and delete actions
+}
+
+func (f BulkVersionFilter) IsEmpty() bool {
+	return f.Status == "" && f.Label == "" && f.PublishedFrom == nil && f.PublishedTo == nil
+}
+
+type BulkVersionOperationResp struct {
+	OperationId string `json:"operationId"`
+}
+
+type BulkVersionOperationStatus struct {
+	OperationId string            `json:"operationId"`
+	Action      BulkVersionAction `json:"action"`
+	Status      string            `json:"status"`
+	Message     string            `json:"message"`
+	CreatedBy   string            `json:"createdBy"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
@@ -0,0 +1,62 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+type DryRunPublishResult struct {
+	PublishId                string                `json:"publishId"`
+	PackageId                string                `json:"packageId"`
+	Version                  string                `json:"version"`
+	Revision                 int                   `json:"revision"`
+	PreviousVersionPackageId string                `json:"previousVersionPackageId,omitempty"`
+	PreviousVersion          string                `json:"previousVersion,omitempty"`
+	PreviousVersionRevision  int                   `json:"previousVersionRevision,omitempty"`
+	Documents                []DryRunDocument      `json:"documents"`
+	Operations               []DryRunOperation     `json:"operations"`
+	Changes                  []VersionComparison   `json:"changes"`
+	BreakingChanges          bool                  `json:"breakingChanges"`
+	Notifications            []BuilderNotification `json:"notifications"`
+}
+
+type DryRunDocument struct {
+	FileId          string `json:"fileId"`
+	Slug            string `json:"slug"`
+	Title           string `json:"title"`
+	Type            string `json:"type"`
+	Format          string `json:"format"`
+	Filename        string `json:"filename"`
+	OperationsCount int    `json:"operationsCount"`
+}
+
+type DryRunOperation struct {
+	OperationId string   `json:"operationId"`
+	Title       string   `json:"title"`
+	ApiType     string   `json:"apiType"`
+	ApiKind     string   `json:"apiKind"`
+	ApiAudience string   `json:"apiAudience"`
+	Deprecated  bool     `json:"deprecated"`
+	Tags        []string `json:"tags"`
+}
+
+// HasBreakingChanges returns true if at least one comparison contains breaking changes
+func HasBreakingChanges(comparisons []VersionComparison) bool {
+	for _, comparison := range comparisons {
+		for _, operationType := range comparison.OperationTypes {
+			if operationType.ChangesSummary.Breaking > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
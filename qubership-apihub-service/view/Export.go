@@ -14,6 +14,8 @@
 
 package view
 
+import "io"
+
 type ExportApiChangesRequestView struct {
 	PreviousVersion          string
 	PreviousVersionPackageId string
@@ -113,6 +115,6 @@ type ExportStatus struct {
 }
 
 type ExportResult struct {
-	Data     []byte
+	Content  io.ReadCloser
 	FileName string
 }
@@ -0,0 +1,83 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import "time"
+
+type PublishGateAction string
+
+const (
+	PublishGateActionNone            PublishGateAction = "none"
+	PublishGateActionReject          PublishGateAction = "reject"
+	PublishGateActionRequireOverride PublishGateAction = "requireOverride"
+	PublishGateActionRequireApproval PublishGateAction = "requireApproval"
+)
+
+func ValidPublishGateAction(action PublishGateAction) bool {
+	switch action {
+	case PublishGateActionNone, PublishGateActionReject, PublishGateActionRequireOverride, PublishGateActionRequireApproval:
+		return true
+	}
+	return false
+}
+
+type PublishGatePolicyUpdate struct {
+	Action     PublishGateAction `json:"action" validate:"required"`
+	Severities []Severity        `json:"severities"`
+}
+
+// PublishGatePolicy is the policy which is applied on release version publication if the changelog contains changes with the listed severities.
+// The policy is inherited from the closest parent package/group which has it.
+type PublishGatePolicy struct {
+	Action      PublishGateAction `json:"action"`
+	Severities  []Severity        `json:"severities"`
+	PackageId   string            `json:"packageId,omitempty"`
+	PackageName string            `json:"packageName,omitempty"`
+	PackageKind string            `json:"packageKind,omitempty"`
+	Inherited   bool              `json:"inherited"`
+}
+
+// DefaultPublishGateSeverities are used if the policy is set without severities
+var DefaultPublishGateSeverities = []Severity{Breaking}
+
+type PublishGateRequestState string
+
+const (
+	PublishGateRequestPending  PublishGateRequestState = "pending"
+	PublishGateRequestApproved PublishGateRequestState = "approved"
+	PublishGateRequestRejected PublishGateRequestState = "rejected"
+)
+
+type PublishGateRequest struct {
+	RequestId           string                  `json:"requestId"`
+	PackageId           string                  `json:"packageId"`
+	Version             string                  `json:"version"`
+	BreakingChanges     int                     `json:"breakingChanges"`
+	SemiBreakingChanges int                     `json:"semiBreakingChanges"`
+	State               PublishGateRequestState `json:"state"`
+	CreatedBy           string                  `json:"createdBy"`
+	CreatedAt           time.Time               `json:"createdAt"`
+	ResolvedBy          string                  `json:"resolvedBy,omitempty"`
+	ResolvedAt          *time.Time              `json:"resolvedAt,omitempty"`
+	ApprovedPublishId   string                  `json:"approvedPublishId,omitempty"`
+}
+
+type PublishGateRequests struct {
+	Requests []PublishGateRequest `json:"requests"`
+}
+
+type PublishGateApproveResponse struct {
+	PublishId string `json:"publishId"`
+}
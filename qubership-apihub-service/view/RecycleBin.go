@@ -0,0 +1,70 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import "time"
+
+type RecycleBinItemKind string
+
+const (
+	RecycleBinPackage  RecycleBinItemKind = "package"
+	RecycleBinVersion  RecycleBinItemKind = "version"
+	RecycleBinRevision RecycleBinItemKind = "revision"
+)
+
+func ParseRecycleBinItemKind(kind string) (RecycleBinItemKind, bool) {
+	switch RecycleBinItemKind(kind) {
+	case RecycleBinPackage, RecycleBinVersion, RecycleBinRevision:
+		return RecycleBinItemKind(kind), true
+	}
+	return "", false
+}
+
+// RecycleBinItem is a deleted package, version or revision which could be restored until PurgeAt.
+// Items deleted together with the parent package/version are not listed separately, they are restored with the parent.
+type RecycleBinItem struct {
+	Kind        RecycleBinItemKind `json:"kind"`
+	PackageId   string             `json:"packageId"`
+	PackageName string             `json:"packageName"`
+	PackageKind string             `json:"packageKind"`
+	Version     string             `json:"version,omitempty"`
+	Revision    int                `json:"revision,omitempty"`
+	Status      string             `json:"status,omitempty"`
+	DeletedAt   time.Time          `json:"deletedAt"`
+	DeletedBy   string             `json:"deletedBy,omitempty"`
+	PurgeAt     time.Time          `json:"purgeAt"`
+}
+
+type RecycleBinItems struct {
+	Items []RecycleBinItem `json:"items"`
+}
+
+type RecycleBinItemsReq struct {
+	PackageId string
+	Kind      RecycleBinItemKind
+	Limit     int
+	Page      int
+}
+
+type RecycleBinRevisionRef struct {
+	PackageId string `json:"packageId"`
+	Version   string `json:"version"`
+	Revision  int    `json:"revision"`
+}
+
+type RecycleBinRestoreResult struct {
+	Packages  []string                `json:"packages"`
+	Revisions []RecycleBinRevisionRef `json:"revisions"`
+}
@@ -0,0 +1,104 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// VersionDependent is a version (usually dashboard) which references the requested package version directly or through other dashboards
+type VersionDependent struct {
+	PackageId      string    `json:"packageId"`
+	PackageName    string    `json:"name"`
+	Kind           string    `json:"kind"`
+	Version        string    `json:"version"`
+	Status         string    `json:"status"`
+	PublishedAt    time.Time `json:"publishedAt"`
+	Direct         bool      `json:"direct"`
+	LatestRevision bool      `json:"latestRevision"`
+}
+
+type VersionDependents struct {
+	Dependents []VersionDependent `json:"dependents"`
+}
+
+type VersionDependentsReq struct {
+	Status             string
+	OnlyLatestRevision bool
+	Limit              int
+	Page               int
+}
+
+// ReferenceGraph is the transitive reference graph of the version. Nodes are package refs (packageId@version@revision).
+type ReferenceGraph struct {
+	Root     string                       `json:"root"`
+	Edges    []ReferenceGraphEdge         `json:"edges"`
+	Packages map[string]PackageVersionRef `json:"packages"`
+}
+
+type ReferenceGraphEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Excluded bool   `json:"excluded,omitempty"`
+}
+
+const ReferenceGraphFormatJson = "json"
+const ReferenceGraphFormatDot = "dot"
+
+// ToDot renders the graph in Graphviz DOT format, excluded references are drawn with dashed lines
+func (g ReferenceGraph) ToDot() []byte {
+	var sb strings.Builder
+	sb.WriteString("digraph references {\n")
+	nodes := make([]string, 0, len(g.Packages))
+	for node := range g.Packages {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		pkg := g.Packages[node]
+		label := fmt.Sprintf("%s\n%s (%s)", pkg.RefPackageName, pkg.RefPackageVersion, pkg.Status)
+		sb.WriteString(fmt.Sprintf("  %s [label=%s];\n", dotQuote(node), dotQuote(label)))
+	}
+	for _, edge := range g.Edges {
+		if edge.Excluded {
+			sb.WriteString(fmt.Sprintf("  %s -> %s [style=dashed];\n", dotQuote(edge.From), dotQuote(edge.To)))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s -> %s;\n", dotQuote(edge.From), dotQuote(edge.To)))
+		}
+	}
+	sb.WriteString("}\n")
+	return []byte(sb.String())
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// VersionImpact is the blast radius of the changes of the version: release dashboards which reference any its revision
+type VersionImpact struct {
+	PackageId                string             `json:"packageId"`
+	Version                  string             `json:"version"`
+	PreviousVersion          string             `json:"previousVersion,omitempty"`
+	PreviousVersionPackageId string             `json:"previousVersionPackageId,omitempty"`
+	OperationTypes           []OperationType    `json:"operationTypes"` // empty if there is no previous version or comparison is not calculated
+	Breaking                 bool               `json:"breaking"`
+	Dashboards               []VersionDependent `json:"dashboards"`
+}
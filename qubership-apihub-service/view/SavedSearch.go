@@ -0,0 +1,65 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import "time"
+
+type SavedSearchReq struct {
+	Name       string         `json:"name" validate:"required"`
+	Query      SearchQueryReq `json:"query"`
+	Subscribed bool           `json:"subscribed"`
+	WebhookUrl string         `json:"webhookUrl"`
+}
+
+type SavedSearch struct {
+	SavedSearchId string         `json:"savedSearchId"`
+	Name          string         `json:"name"`
+	Query         SearchQueryReq `json:"query"`
+	Subscribed    bool           `json:"subscribed"`
+	WebhookUrl    string         `json:"webhookUrl,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     *time.Time     `json:"updatedAt,omitempty"`
+}
+
+type SavedSearches struct {
+	SavedSearches []SavedSearch `json:"savedSearches"`
+}
+
+type SavedSearchNotification struct {
+	NotificationId  string                        `json:"notificationId"`
+	SavedSearchId   string                        `json:"savedSearchId"`
+	SavedSearchName string                        `json:"savedSearchName"`
+	PackageId       string                        `json:"packageId"`
+	Version         string                        `json:"version"`
+	Operations      []SavedSearchMatchedOperation `json:"operations"`
+	Read            bool                          `json:"read"`
+	CreatedAt       time.Time                     `json:"createdAt"`
+}
+
+type SavedSearchMatchedOperation struct {
+	OperationId string `json:"operationId"`
+	Title       string `json:"title"`
+	ApiType     string `json:"apiType"`
+}
+
+type SavedSearchNotifications struct {
+	Notifications []SavedSearchNotification `json:"notifications"`
+}
+
+type SavedSearchNotificationListReq struct {
+	UnreadOnly bool
+	Limit      int
+	Page       int
+}
@@ -19,6 +19,7 @@ import "time"
 const SearchLevelOperations = "operations"
 const SearchLevelPackages = "packages"
 const SearchLevelDocuments = "documents"
+const SearchLevelModels = "models"
 
 const ScopeAll = "all"
 
@@ -32,6 +33,11 @@ const GraphqlScopeAnnotation = "annotation"
 const GraphqlScopeArgument = "argument"
 const GraphqlScopeProperty = "property"
 
+const ProtobufScopeRequest = "request"
+const ProtobufScopeResponse = "response"
+const ProtobufScopeAnnotation = "annotation"
+const ProtobufScopeField = "field"
+
 func ValidRestOperationScope(scope string) bool {
 	switch scope {
 	case ScopeAll, RestScopeRequest, RestScopeResponse, RestScopeAnnotation, RestScopeExamples, RestScopeProperties:
@@ -48,6 +54,14 @@ func ValidGraphqlOperationScope(scope string) bool {
 	return false
 }
 
+func ValidProtobufOperationScope(scope string) bool {
+	switch scope {
+	case ScopeAll, ProtobufScopeRequest, ProtobufScopeResponse, ProtobufScopeAnnotation, ProtobufScopeField:
+		return true
+	}
+	return false
+}
+
 type PublicationDateInterval struct {
 	// TODO: probably user's timezone is required to handle dates properly
 	StartDate time.Time `json:"startDate"`
@@ -69,6 +83,7 @@ type SearchQueryReq struct {
 	Statuses                []string                `json:"statuses"`
 	PublicationDateInterval PublicationDateInterval `json:"creationDateInterval"`
 	OperationSearchParams   *OperationSearchParams  `json:"operationParams"`
+	Facets                  bool                    `json:"facets"`
 	Limit                   int                     `json:"-"`
 	Page                    int                     `json:"-"`
 }
@@ -84,6 +99,28 @@ type SearchResult struct {
 	Operations *[]interface{}          `json:"operations,omitempty"`
 	Packages   *[]PackageSearchResult  `json:"packages,omitempty"`
 	Documents  *[]DocumentSearchResult `json:"documents,omitempty"`
+	Models     *[]ModelSearchResult    `json:"models,omitempty"`
+	Facets     *SearchFacets           `json:"facets,omitempty"`
+}
+
+// SearchFacets contains counts of all search matches grouped by facet values, only top values are returned for each facet
+type SearchFacets struct {
+	TotalCount        int                `json:"totalCount"`
+	PackagesCount     int                `json:"packagesCount"`
+	Packages          []SearchFacetValue `json:"packages"`
+	ParentGroups      []SearchFacetValue `json:"parentGroups"`
+	Statuses          []SearchFacetValue `json:"statuses"`
+	ApiTypes          []SearchFacetValue `json:"apiTypes"`
+	Methods           []SearchFacetValue `json:"methods"`
+	ApiKinds          []SearchFacetValue `json:"apiKinds"`
+	Tags              []SearchFacetValue `json:"tags"`
+	PublicationMonths []SearchFacetValue `json:"publicationMonths"`
+}
+
+type SearchFacetValue struct {
+	Value string `json:"value"`
+	Name  string `json:"name,omitempty"`
+	Count int    `json:"count"`
 }
 
 type OperationSearchWeightsDebug struct {
@@ -134,6 +171,11 @@ type GraphQLOperationSearchResult struct {
 	CommonOperationSearchResult
 }
 
+type ProtobufOperationSearchResult struct {
+	ProtobufOperationView
+	CommonOperationSearchResult
+}
+
 type PackageSearchWeightsDebug struct {
 	PackageIdTf            float64 `json:"packageIdTf"`
 	PackageNameTf          float64 `json:"packageNameTf"`
@@ -188,3 +230,20 @@ type DocumentSearchResult struct {
 	//debug
 	Debug DocumentSearchWeightsDebug `json:"debug,omitempty"`
 }
+
+// ModelSearchResult groups operations of one package version which use the searched model
+type ModelSearchResult struct {
+	PackageId      string                 `json:"packageId"`
+	PackageName    string                 `json:"name"`
+	ParentPackages []string               `json:"parentPackages"`
+	Version        string                 `json:"version"`
+	VersionStatus  string                 `json:"status"`
+	Operations     []ModelSearchOperation `json:"operations"`
+}
+
+type ModelSearchOperation struct {
+	OperationId string   `json:"operationId"`
+	Title       string   `json:"title"`
+	ApiType     string   `json:"apiType"`
+	ModelNames  []string `json:"modelNames"`
+}
@@ -0,0 +1,321 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"fmt"
+	"strings"
+)
+
+const SearchQueryFieldPath = "path"
+const SearchQueryFieldMethod = "method"
+const SearchQueryFieldTag = "tag"
+const SearchQueryFieldKind = "kind"
+const SearchQueryFieldAudience = "audience"
+const SearchQueryFieldDeprecated = "deprecated"
+const SearchQueryFieldPackage = "package"
+
+func ValidSearchQueryField(field string) bool {
+	switch field {
+	case SearchQueryFieldPath, SearchQueryFieldMethod, SearchQueryFieldTag, SearchQueryFieldKind,
+		SearchQueryFieldAudience, SearchQueryFieldDeprecated, SearchQueryFieldPackage:
+		return true
+	}
+	return false
+}
+
+type SearchQueryNodeType string
+
+const (
+	SearchQueryAnd  SearchQueryNodeType = "and"
+	SearchQueryOr   SearchQueryNodeType = "or"
+	SearchQueryNot  SearchQueryNodeType = "not"
+	SearchQueryTerm SearchQueryNodeType = "term"
+)
+
+// SearchQueryNode is a node of the parsed operation search query.
+// Term nodes without Field are free text terms, Phrase is set for quoted values.
+type SearchQueryNode struct {
+	Type     SearchQueryNodeType
+	Children []SearchQueryNode
+	Field    string
+	Value    string
+	Phrase   bool
+}
+
+// FreeTextTerms returns free text terms of the query except the negated ones
+func (n SearchQueryNode) FreeTextTerms() []string {
+	terms := make([]string, 0)
+	switch n.Type {
+	case SearchQueryTerm:
+		if n.Field == "" {
+			terms = append(terms, n.Value)
+		}
+	case SearchQueryAnd, SearchQueryOr:
+		for _, child := range n.Children {
+			terms = append(terms, child.FreeTextTerms()...)
+		}
+	}
+	return terms
+}
+
+type searchQueryTokenType int
+
+const (
+	searchQueryTokenWord searchQueryTokenType = iota
+	searchQueryTokenField
+	searchQueryTokenAnd
+	searchQueryTokenOr
+	searchQueryTokenNot
+	searchQueryTokenLeftParen
+	searchQueryTokenRightParen
+)
+
+type searchQueryToken struct {
+	tokenType searchQueryTokenType
+	field     string
+	value     string
+	phrase    bool
+}
+
+// IsStructuredSearchQuery checks if search string uses query syntax (field qualifiers or quoted phrases).
+// Boolean operators alone don't make the query structured, so plain search strings containing AND, OR or NOT words keep their meaning.
+// Search strings which could not be tokenized (e.g. with a single quote) are processed as plain full text search strings as well.
+func IsStructuredSearchQuery(query string) bool {
+	tokens, err := tokenizeSearchQuery(query)
+	if err != nil {
+		return false
+	}
+	for _, token := range tokens {
+		if token.tokenType == searchQueryTokenField || (token.tokenType == searchQueryTokenWord && token.phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSearchQuery parses search string like `path:/users/* AND (method:delete OR NOT deprecated:true) "user id"`.
+// Terms without operator between them are joined with AND, NOT has the highest precedence and OR has the lowest one.
+func ParseSearchQuery(query string) (*SearchQueryNode, error) {
+	tokens, err := tokenizeSearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("search query is empty")
+	}
+	p := &searchQueryParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected '%v' in search query", p.tokens[p.pos].String())
+	}
+	return node, nil
+}
+
+func tokenizeSearchQuery(query string) ([]searchQueryToken, error) {
+	tokens := make([]searchQueryToken, 0)
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		switch {
+		case runes[i] == ' ' || runes[i] == '\t' || runes[i] == '\n':
+			i++
+		case runes[i] == '(':
+			tokens = append(tokens, searchQueryToken{tokenType: searchQueryTokenLeftParen})
+			i++
+		case runes[i] == ')':
+			tokens = append(tokens, searchQueryToken{tokenType: searchQueryTokenRightParen})
+			i++
+		case runes[i] == '"':
+			phrase, next, err := readSearchQueryPhrase(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			if phrase != "" {
+				tokens = append(tokens, searchQueryToken{tokenType: searchQueryTokenWord, value: phrase, phrase: true})
+			}
+			i = next
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n()\"", runes[i]) {
+				i++
+			}
+			word := string(runes[start:i])
+			switch word {
+			case "AND":
+				tokens = append(tokens, searchQueryToken{tokenType: searchQueryTokenAnd})
+				continue
+			case "OR":
+				tokens = append(tokens, searchQueryToken{tokenType: searchQueryTokenOr})
+				continue
+			case "NOT":
+				tokens = append(tokens, searchQueryToken{tokenType: searchQueryTokenNot})
+				continue
+			}
+			separatorIndex := strings.Index(word, ":")
+			if separatorIndex <= 0 || !ValidSearchQueryField(strings.ToLower(word[:separatorIndex])) {
+				tokens = append(tokens, searchQueryToken{tokenType: searchQueryTokenWord, value: word})
+				continue
+			}
+			token := searchQueryToken{
+				tokenType: searchQueryTokenField,
+				field:     strings.ToLower(word[:separatorIndex]),
+				value:     word[separatorIndex+1:],
+			}
+			if token.value == "" && i < len(runes) && runes[i] == '"' {
+				phrase, next, err := readSearchQueryPhrase(runes, i)
+				if err != nil {
+					return nil, err
+				}
+				token.value = phrase
+				token.phrase = true
+				i = next
+			}
+			if token.value == "" {
+				return nil, fmt.Errorf("value is missing for '%v' field", token.field)
+			}
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func readSearchQueryPhrase(runes []rune, start int) (string, int, error) {
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '"' {
+			return strings.TrimSpace(string(runes[start+1 : i])), i + 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("quoted phrase is not closed in search query")
+}
+
+func (t searchQueryToken) String() string {
+	switch t.tokenType {
+	case searchQueryTokenField:
+		return t.field + ":" + t.value
+	case searchQueryTokenAnd:
+		return "AND"
+	case searchQueryTokenOr:
+		return "OR"
+	case searchQueryTokenNot:
+		return "NOT"
+	case searchQueryTokenLeftParen:
+		return "("
+	case searchQueryTokenRightParen:
+		return ")"
+	}
+	return t.value
+}
+
+type searchQueryParser struct {
+	tokens []searchQueryToken
+	pos    int
+}
+
+func (p *searchQueryParser) peek() *searchQueryToken {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *searchQueryParser) parseOr() (*SearchQueryNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []SearchQueryNode{*node}
+	for token := p.peek(); token != nil && token.tokenType == searchQueryTokenOr; token = p.peek() {
+		p.pos++
+		node, err = p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, *node)
+	}
+	if len(children) == 1 {
+		return &children[0], nil
+	}
+	return &SearchQueryNode{Type: SearchQueryOr, Children: children}, nil
+}
+
+func (p *searchQueryParser) parseAnd() (*SearchQueryNode, error) {
+	node, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	children := []SearchQueryNode{*node}
+	for token := p.peek(); token != nil && token.tokenType != searchQueryTokenOr && token.tokenType != searchQueryTokenRightParen; token = p.peek() {
+		if token.tokenType == searchQueryTokenAnd {
+			p.pos++
+		}
+		node, err = p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, *node)
+	}
+	if len(children) == 1 {
+		return &children[0], nil
+	}
+	return &SearchQueryNode{Type: SearchQueryAnd, Children: children}, nil
+}
+
+func (p *searchQueryParser) parseNot() (*SearchQueryNode, error) {
+	token := p.peek()
+	if token != nil && token.tokenType == searchQueryTokenNot {
+		p.pos++
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &SearchQueryNode{Type: SearchQueryNot, Children: []SearchQueryNode{*node}}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *searchQueryParser) parsePrimary() (*SearchQueryNode, error) {
+	token := p.peek()
+	if token == nil {
+		return nil, fmt.Errorf("unexpected end of search query")
+	}
+	switch token.tokenType {
+	case searchQueryTokenLeftParen:
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing := p.peek()
+		if closing == nil || closing.tokenType != searchQueryTokenRightParen {
+			return nil, fmt.Errorf("closing parenthesis is missing in search query")
+		}
+		p.pos++
+		return node, nil
+	case searchQueryTokenField, searchQueryTokenWord:
+		p.pos++
+		value := token.value
+		if token.field == SearchQueryFieldDeprecated {
+			value = strings.ToLower(value)
+			if value != "true" && value != "false" {
+				return nil, fmt.Errorf("value '%v' is invalid for '%v' field, allowed values: true, false", token.value, token.field)
+			}
+		}
+		return &SearchQueryNode{Type: SearchQueryTerm, Field: token.field, Value: value, Phrase: token.phrase}, nil
+	}
+	return nil, fmt.Errorf("unexpected '%v' in search query", token.String())
+}
@@ -0,0 +1,145 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsStructuredSearchQuery(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{query: "get user", expected: false},
+		{query: "create OR update user", expected: false},
+		{query: "NOT found", expected: false},
+		{query: "user (deprecated)", expected: false},
+		{query: `user "id`, expected: false},
+		{query: "path:", expected: false},
+		{query: "unknown:value", expected: false},
+		{query: "path:/users", expected: true},
+		{query: "METHOD:get", expected: true},
+		{query: `"user id"`, expected: true},
+		{query: `tag:"billing api" OR tag:billing`, expected: true},
+		{query: "deprecated:maybe", expected: true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsStructuredSearchQuery(test.query), test.query)
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	term := func(field string, value string) SearchQueryNode {
+		return SearchQueryNode{Type: SearchQueryTerm, Field: field, Value: value}
+	}
+	phrase := func(field string, value string) SearchQueryNode {
+		return SearchQueryNode{Type: SearchQueryTerm, Field: field, Value: value, Phrase: true}
+	}
+	node := func(nodeType SearchQueryNodeType, children ...SearchQueryNode) SearchQueryNode {
+		return SearchQueryNode{Type: nodeType, Children: children}
+	}
+	tests := []struct {
+		query    string
+		expected SearchQueryNode
+	}{
+		{
+			query:    "path:/users/*",
+			expected: term(SearchQueryFieldPath, "/users/*"),
+		},
+		{
+			query:    "path:/users method:get",
+			expected: node(SearchQueryAnd, term(SearchQueryFieldPath, "/users"), term(SearchQueryFieldMethod, "get")),
+		},
+		{
+			query: "method:get OR method:put path:/users",
+			expected: node(SearchQueryOr,
+				term(SearchQueryFieldMethod, "get"),
+				node(SearchQueryAnd, term(SearchQueryFieldMethod, "put"), term(SearchQueryFieldPath, "/users"))),
+		},
+		{
+			query: "(method:get OR method:put) AND path:/users",
+			expected: node(SearchQueryAnd,
+				node(SearchQueryOr, term(SearchQueryFieldMethod, "get"), term(SearchQueryFieldMethod, "put")),
+				term(SearchQueryFieldPath, "/users")),
+		},
+		{
+			query: "NOT deprecated:true kind:bwc",
+			expected: node(SearchQueryAnd,
+				node(SearchQueryNot, term(SearchQueryFieldDeprecated, "true")),
+				term(SearchQueryFieldKind, "bwc")),
+		},
+		{
+			query:    "NOT NOT tag:billing",
+			expected: node(SearchQueryNot, node(SearchQueryNot, term(SearchQueryFieldTag, "billing"))),
+		},
+		{
+			query:    `tag:"billing api"`,
+			expected: phrase(SearchQueryFieldTag, "billing api"),
+		},
+		{
+			query:    `"  user id " package:QS.CP.*`,
+			expected: node(SearchQueryAnd, phrase("", "user id"), term(SearchQueryFieldPackage, "QS.CP.*")),
+		},
+		{
+			query:    `path:/a"b"`,
+			expected: node(SearchQueryAnd, term(SearchQueryFieldPath, "/a"), phrase("", "b")),
+		},
+		{
+			query:    "Deprecated:FALSE",
+			expected: term(SearchQueryFieldDeprecated, "false"),
+		},
+		{
+			query:    "user and path:/users",
+			expected: node(SearchQueryAnd, term("", "user"), term("", "and"), term(SearchQueryFieldPath, "/users")),
+		},
+	}
+	for _, test := range tests {
+		actual, err := ParseSearchQuery(test.query)
+		if assert.NoError(t, err, test.query) {
+			assert.Equal(t, test.expected, *actual, test.query)
+		}
+	}
+}
+
+func TestParseSearchQueryErrors(t *testing.T) {
+	queries := []string{
+		"",
+		"   ",
+		`path:"/users`,
+		"path:",
+		"deprecated:maybe",
+		"deprecated:1",
+		"(path:/users",
+		"path:/users)",
+		"path:/users AND",
+		"OR path:/users",
+		"NOT",
+		"()",
+	}
+	for _, query := range queries {
+		_, err := ParseSearchQuery(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestSearchQueryNodeFreeTextTerms(t *testing.T) {
+	queryNode, err := ParseSearchQuery(`user path:/users ("user id" OR NOT account)`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"user", "user id"}, queryNode.FreeTextTerms())
+	}
+}
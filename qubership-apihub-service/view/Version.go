@@ -259,6 +259,21 @@ type PublishFromCSVReq struct {
 	ServicesWorkspaceId      string   `json:"servicesWorkspaceId" validate:"required"` //workspace for matching packages by serviceNames
 }
 
+// PublishFromNamespaceReq publishes dashboard version which references services discovered by the agent in the namespace
+type PublishFromNamespaceReq struct {
+	PackageId                string   `json:"-"`
+	Version                  string   `json:"version" validate:"required"`
+	PreviousVersion          string   `json:"previousVersion"`
+	PreviousVersionPackageId string   `json:"previousVersionPackageId"`
+	Status                   string   `json:"status" validate:"required"`
+	VersionLabels            []string `json:"versionLabels"`
+	AgentId                  string   `json:"agentId" validate:"required"`
+	Namespace                string   `json:"namespace" validate:"required"`
+	ServicesWorkspaceId      string   `json:"servicesWorkspaceId" validate:"required"` //workspace for matching packages by serviceNames
+	// ServiceVersionLabels selects the latest service version which has all the labels, the latest release version is used if there is no such version
+	ServiceVersionLabels []string `json:"serviceVersionLabels"`
+}
+
 type PublishFromCSVResp struct {
 	PublishId string `json:"publishId"`
 }
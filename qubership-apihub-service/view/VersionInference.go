@@ -0,0 +1,61 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+type VersionBump string
+
+const (
+	VersionBumpMajor VersionBump = "major"
+	VersionBumpMinor VersionBump = "minor"
+	VersionBumpPatch VersionBump = "patch"
+)
+
+// VersionModeAuto makes the server infer the version name from the changelog against the previous release version
+const VersionModeAuto = "auto"
+
+// InitialSemanticVersion is used if the package doesn't have release versions yet
+const InitialSemanticVersion = "1.0.0"
+
+type NextVersion struct {
+	PreviousVersion string                 `json:"previousVersion,omitempty"`
+	Version         string                 `json:"version,omitempty"`
+	Bump            VersionBump            `json:"bump,omitempty"`
+	NextVersion     string                 `json:"nextVersion,omitempty"`
+	ChangesSummary  *ChangeSummary         `json:"changesSummary,omitempty"`
+	Candidates      map[VersionBump]string `json:"candidates"`
+}
+
+// GetVersionBump returns required version bump for the changes: breaking changes require major bump,
+// semi-breaking, deprecated and non-breaking changes require minor bump, any other changes require patch bump
+func GetVersionBump(summary ChangeSummary) VersionBump {
+	if summary.Breaking > 0 {
+		return VersionBumpMajor
+	}
+	if summary.SemiBreaking > 0 || summary.Deprecated > 0 || summary.NonBreaking > 0 {
+		return VersionBumpMinor
+	}
+	return VersionBumpPatch
+}
+
+func (c ChangeSummary) Add(other ChangeSummary) ChangeSummary {
+	return ChangeSummary{
+		Breaking:     c.Breaking + other.Breaking,
+		SemiBreaking: c.SemiBreaking + other.SemiBreaking,
+		Deprecated:   c.Deprecated + other.Deprecated,
+		NonBreaking:  c.NonBreaking + other.NonBreaking,
+		Annotation:   c.Annotation + other.Annotation,
+		Unclassified: c.Unclassified + other.Unclassified,
+	}
+}
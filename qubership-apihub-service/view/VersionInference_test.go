@@ -0,0 +1,46 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetVersionBump(t *testing.T) {
+	tests := []struct {
+		name     string
+		summary  ChangeSummary
+		expected VersionBump
+	}{
+		{name: "no changes", summary: ChangeSummary{}, expected: VersionBumpPatch},
+		{name: "annotation", summary: ChangeSummary{Annotation: 3}, expected: VersionBumpPatch},
+		{name: "unclassified", summary: ChangeSummary{Unclassified: 1}, expected: VersionBumpPatch},
+		{name: "non-breaking", summary: ChangeSummary{NonBreaking: 1, Annotation: 2}, expected: VersionBumpMinor},
+		{name: "deprecated", summary: ChangeSummary{Deprecated: 1}, expected: VersionBumpMinor},
+		{name: "semi-breaking", summary: ChangeSummary{SemiBreaking: 1}, expected: VersionBumpMinor},
+		{name: "breaking", summary: ChangeSummary{Breaking: 1}, expected: VersionBumpMajor},
+		{name: "breaking with others", summary: ChangeSummary{Breaking: 1, SemiBreaking: 2, NonBreaking: 5, Annotation: 1}, expected: VersionBumpMajor},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, GetVersionBump(test.summary), test.name)
+	}
+}
+
+func TestChangeSummaryAdd(t *testing.T) {
+	summary := ChangeSummary{Breaking: 1, Annotation: 2}.Add(ChangeSummary{Breaking: 2, SemiBreaking: 1, Deprecated: 3, NonBreaking: 4, Unclassified: 5})
+	assert.Equal(t, ChangeSummary{Breaking: 3, SemiBreaking: 1, Deprecated: 3, NonBreaking: 4, Annotation: 2, Unclassified: 5}, summary)
+}
@@ -0,0 +1,56 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+type VersionLifecycleStatus struct {
+	Status      string         `json:"status" validate:"required"`
+	Description string         `json:"description,omitempty"`
+	Permission  RolePermission `json:"permission" validate:"required"`
+	// new versions could be published right away in the initial statuses
+	Initial bool `json:"initial"`
+}
+
+type VersionLifecycleTransition struct {
+	From string `json:"from" validate:"required"`
+	To   string `json:"to" validate:"required"`
+	// permission of the target status is required if not set
+	Permission RolePermission `json:"permission,omitempty"`
+}
+
+type VersionLifecycle struct {
+	Statuses    []VersionLifecycleStatus     `json:"statuses" validate:"required,dive"`
+	Transitions []VersionLifecycleTransition `json:"transitions" validate:"dive"`
+}
+
+// BuiltInVersionStatuses could not be removed from the lifecycle since their behavior is hardcoded (e.g. release version pattern)
+var BuiltInVersionStatuses = []VersionStatus{Draft, Release, Archived}
+
+func (l VersionLifecycle) GetStatus(status string) *VersionLifecycleStatus {
+	for i := range l.Statuses {
+		if l.Statuses[i].Status == status {
+			return &l.Statuses[i]
+		}
+	}
+	return nil
+}
+
+func (l VersionLifecycle) GetTransition(from string, to string) *VersionLifecycleTransition {
+	for i := range l.Transitions {
+		if l.Transitions[i].From == from && l.Transitions[i].To == to {
+			return &l.Transitions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import "time"
+
+type VersionLockPolicyUpdate struct {
+	Statuses []string `json:"statuses"`
+}
+
+// VersionLockPolicy locks all versions of the package in the listed statuses.
+// The policy is inherited from the closest parent package/group which has it.
+type VersionLockPolicy struct {
+	Statuses    []string `json:"statuses"`
+	PackageId   string   `json:"packageId,omitempty"`
+	PackageName string   `json:"packageName,omitempty"`
+	PackageKind string   `json:"packageKind,omitempty"`
+	Inherited   bool     `json:"inherited"`
+}
+
+// DefaultVersionLockStatuses are used if the policy is set without statuses
+var DefaultVersionLockStatuses = []string{string(Release)}
+
+type VersionLockSource string
+
+const (
+	VersionLockSourceVersion VersionLockSource = "version"
+	VersionLockSourcePolicy  VersionLockSource = "policy"
+)
+
+type VersionLock struct {
+	Locked bool `json:"locked"`
+	// Source is empty if the version is neither locked nor unlocked explicitly and the lock policy is not applied to it
+	Source          VersionLockSource `json:"source,omitempty"`
+	PolicyPackageId string            `json:"policyPackageId,omitempty"`
+	UpdatedBy       string            `json:"updatedBy,omitempty"`
+	UpdatedAt       *time.Time        `json:"updatedAt,omitempty"`
+}
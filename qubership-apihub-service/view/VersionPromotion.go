@@ -0,0 +1,117 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import "time"
+
+type VersionPromotionPolicyUpdate struct {
+	RequiredApprovals int      `json:"requiredApprovals" validate:"required,min=1"`
+	ApproverRoles     []string `json:"approverRoles" validate:"required,min=1"`
+	Statuses          []string `json:"statuses"`
+}
+
+// VersionPromotionPolicy makes version status changes to the listed statuses wait for approvals of the package members with approver roles.
+// The policy is inherited from the closest parent package/group which has it.
+type VersionPromotionPolicy struct {
+	RequiredApprovals int      `json:"requiredApprovals"`
+	ApproverRoles     []string `json:"approverRoles"`
+	Statuses          []string `json:"statuses"`
+	PackageId         string   `json:"packageId,omitempty"`
+	PackageName       string   `json:"packageName,omitempty"`
+	PackageKind       string   `json:"packageKind,omitempty"`
+	Inherited         bool     `json:"inherited"`
+}
+
+// DefaultVersionPromotionStatuses are used if the policy is set without statuses
+var DefaultVersionPromotionStatuses = []string{string(Release)}
+
+type VersionPromotionRequestState string
+
+const (
+	VersionPromotionRequestPending   VersionPromotionRequestState = "pending"
+	VersionPromotionRequestApproved  VersionPromotionRequestState = "approved"
+	VersionPromotionRequestRejected  VersionPromotionRequestState = "rejected"
+	VersionPromotionRequestCancelled VersionPromotionRequestState = "cancelled"
+)
+
+func ValidVersionPromotionRequestState(state string) bool {
+	switch VersionPromotionRequestState(state) {
+	case VersionPromotionRequestPending, VersionPromotionRequestApproved, VersionPromotionRequestRejected, VersionPromotionRequestCancelled:
+		return true
+	}
+	return false
+}
+
+type VersionPromotionDecision string
+
+const (
+	VersionPromotionApprove VersionPromotionDecision = "approve"
+	VersionPromotionReject  VersionPromotionDecision = "reject"
+)
+
+type VersionPromotionApproval struct {
+	UserId    string                   `json:"userId"`
+	Decision  VersionPromotionDecision `json:"decision"`
+	Comment   string                   `json:"comment,omitempty"`
+	CreatedAt time.Time                `json:"createdAt"`
+}
+
+type VersionPromotionRequest struct {
+	RequestId         string                       `json:"requestId"`
+	PackageId         string                       `json:"packageId"`
+	Version           string                       `json:"version"`
+	FromStatus        string                       `json:"fromStatus"`
+	ToStatus          string                       `json:"toStatus"`
+	State             VersionPromotionRequestState `json:"state"`
+	RequiredApprovals int                          `json:"requiredApprovals"`
+	ApproverRoles     []string                     `json:"approverRoles"`
+	Approvals         []VersionPromotionApproval   `json:"approvals"`
+	CreatedBy         string                       `json:"createdBy"`
+	CreatedAt         time.Time                    `json:"createdAt"`
+	ResolvedBy        string                       `json:"resolvedBy,omitempty"`
+	ResolvedAt        *time.Time                   `json:"resolvedAt,omitempty"`
+}
+
+type VersionPromotionRequests struct {
+	Requests []VersionPromotionRequest `json:"requests"`
+}
+
+type VersionPromotionDecisionReq struct {
+	Comment string `json:"comment"`
+}
+
+// VersionPromotionNotification is sent to the package members with approver roles when the promotion request is created
+type VersionPromotionNotification struct {
+	NotificationId string                       `json:"notificationId"`
+	RequestId      string                       `json:"requestId"`
+	PackageId      string                       `json:"packageId"`
+	Version        string                       `json:"version"`
+	FromStatus     string                       `json:"fromStatus"`
+	ToStatus       string                       `json:"toStatus"`
+	State          VersionPromotionRequestState `json:"state"`
+	RequestedBy    string                       `json:"requestedBy"`
+	Read           bool                         `json:"read"`
+	CreatedAt      time.Time                    `json:"createdAt"`
+}
+
+type VersionPromotionNotifications struct {
+	Notifications []VersionPromotionNotification `json:"notifications"`
+}
+
+type VersionPromotionNotificationListReq struct {
+	UnreadOnly bool
+	Limit      int
+	Page       int
+}
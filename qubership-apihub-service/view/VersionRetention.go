@@ -0,0 +1,95 @@
+// Copyright 2024-2025 NetCracker Technology Corporation
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package view
+
+import "time"
+
+// VersionRetentionRule is applied to versions whose latest revision has the status.
+// Versions older than MaxAgeDays are deleted, except KeepLast most recently published ones.
+// Without MaxAgeDays all versions except KeepLast most recently published ones are deleted.
+// The rule without both KeepLast and MaxAgeDays keeps all versions, the same as the absence of the rule for the status.
+type VersionRetentionRule struct {
+	Status     string `json:"status" validate:"required"`
+	KeepLast   *int   `json:"keepLast,omitempty"`
+	MaxAgeDays *int   `json:"maxAgeDays,omitempty"`
+}
+
+type VersionRetentionPolicyUpdate struct {
+	Rules []VersionRetentionRule `json:"rules" validate:"required,dive"`
+}
+
+// VersionRetentionPolicy is applied to the versions of the package by scheduled cleanup job.
+// The policy is inherited from the closest parent package/group which has it.
+type VersionRetentionPolicy struct {
+	Rules       []VersionRetentionRule `json:"rules"`
+	PackageId   string                 `json:"packageId,omitempty"`
+	PackageName string                 `json:"packageName,omitempty"`
+	PackageKind string                 `json:"packageKind,omitempty"`
+	Inherited   bool                   `json:"inherited"`
+}
+
+type VersionRetentionReason string
+
+const (
+	VersionRetentionMaxAge   VersionRetentionReason = "maxAgeDays"
+	VersionRetentionKeepLast VersionRetentionReason = "keepLast"
+)
+
+type VersionRetentionCandidate struct {
+	PackageId       string                 `json:"packageId"`
+	Version         string                 `json:"version"`
+	Status          string                 `json:"status"`
+	PublishedAt     time.Time              `json:"publishedAt"`
+	PolicyPackageId string                 `json:"policyPackageId"`
+	Reason          VersionRetentionReason `json:"reason"`
+}
+
+type VersionRetentionPreview struct {
+	Versions []VersionRetentionCandidate `json:"versions"`
+}
+
+type VersionRetentionDeletedVersion struct {
+	VersionRetentionCandidate
+	RunId     int       `json:"runId"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type VersionRetentionReport struct {
+	Versions []VersionRetentionDeletedVersion `json:"versions"`
+}
+
+type VersionRetentionRun struct {
+	RunId        int        `json:"runId"`
+	ScheduledAt  time.Time  `json:"scheduledAt"`
+	FinishedAt   *time.Time `json:"finishedAt,omitempty"`
+	TriggeredBy  string     `json:"triggeredBy,omitempty"`
+	Status       string     `json:"status"`
+	Details      string     `json:"details,omitempty"`
+	DeletedItems int        `json:"deletedItems"`
+}
+
+type VersionRetentionRuns struct {
+	Runs []VersionRetentionRun `json:"runs"`
+}
+
+type VersionRetentionReportReq struct {
+	Limit int
+	Page  int
+}
+
+type VersionRetentionRunReport struct {
+	VersionRetentionRun
+	Versions []VersionRetentionDeletedVersion `json:"versions"`
+}
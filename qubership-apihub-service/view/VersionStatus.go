@@ -14,8 +14,6 @@
 
 package view
 
-import "fmt"
-
 type VersionStatus string
 
 const (
@@ -24,27 +22,7 @@ const (
 	Archived VersionStatus = "archived"
 )
 
+// VersionStatus values other than the built-in ones are configured in the version lifecycle
 func (v VersionStatus) String() string {
-	switch v {
-	case Draft:
-		return "draft"
-	case Release:
-		return "release"
-	case Archived:
-		return "archived"
-	default:
-		return ""
-	}
-}
-
-func ParseVersionStatus(s string) (VersionStatus, error) {
-	switch s {
-	case "draft":
-		return Draft, nil
-	case "release":
-		return Release, nil
-	case "archived":
-		return Archived, nil
-	}
-	return "", fmt.Errorf("unknown version status: %v", s)
+	return string(v)
 }